@@ -0,0 +1,342 @@
+// Package circuit describes a homomorphic computation symbolically as a DAG
+// of operations, analyzes it before any keys exist and executes it lazily
+// against an openfhe.Evaluator.
+//
+//	c := circuit.New()
+//	x := c.Input("x")
+//	x2 := c.Rescale(c.Mult(x, x))
+//	c.Output("y", c.Add(c.Rotate(x2, 1), c.Const([]float64{1})))
+//
+//	a, err := c.Analyze()     // depth 1, rotation keys [1]
+//	out, err := c.Execute(cc, map[string]*openfhe.Ciphertext{"x": ct})
+//
+// Execution evaluates independent nodes in parallel and closes every
+// intermediate ciphertext as soon as its last consumer has finished.
+package circuit
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/dozyio/openfhe-go/openfhe"
+)
+
+type op int
+
+const (
+	opInput op = iota
+	opConst
+	opAdd
+	opSub
+	opMult
+	opRotate
+	opRescale
+	opPoly
+	opBootstrap
+)
+
+var opNames = [...]string{"input", "const", "add", "sub", "mult", "rotate", "rescale", "poly", "bootstrap"}
+
+func (o op) String() string { return opNames[o] }
+
+// Node is one value in a Circuit: an encrypted input, a plaintext constant
+// or the result of an operation.
+type Node struct {
+	c     *Circuit
+	id    int
+	op    op
+	args  []*Node
+	name  string    // input
+	index int32     // rotate
+	coefs []float64 // poly
+	reals []float64 // const
+	ints  []int64   // const
+}
+
+// IsConst reports whether n is a plaintext constant.
+func (n *Node) IsConst() bool { return n.op == opConst }
+
+func (n *Node) String() string {
+	switch n.op {
+	case opInput:
+		return fmt.Sprintf("#%d input %q", n.id, n.name)
+	case opRotate:
+		return fmt.Sprintf("#%d rotate %d", n.id, n.index)
+	}
+	return fmt.Sprintf("#%d %v", n.id, n.op)
+}
+
+// Circuit is a computation under construction. Builder methods never fail;
+// the first misuse is recorded and reported by Analyze and Execute.
+type Circuit struct {
+	nodes   []*Node
+	inputs  map[string]*Node
+	outputs map[string]*Node
+	err     error
+}
+
+// New returns an empty Circuit.
+func New() *Circuit {
+	return &Circuit{
+		inputs:  make(map[string]*Node),
+		outputs: make(map[string]*Node),
+	}
+}
+
+// Err returns the first error recorded while building the circuit.
+func (c *Circuit) Err() error { return c.err }
+
+func (c *Circuit) fail(format string, args ...any) {
+	if c.err == nil {
+		c.err = fmt.Errorf("circuit: "+format, args...)
+	}
+}
+
+func (c *Circuit) add(n *Node) *Node {
+	n.c = c
+	n.id = len(c.nodes)
+	c.nodes = append(c.nodes, n)
+	return n
+}
+
+// operand checks that n belongs to c and, unless constOK, is encrypted.
+func (c *Circuit) operand(what string, n *Node, constOK bool) bool {
+	switch {
+	case n == nil:
+		c.fail("%s: nil operand", what)
+	case n.c != c:
+		c.fail("%s: operand %v belongs to another circuit", what, n)
+	case n.IsConst() && !constOK:
+		c.fail("%s: operand %v must be encrypted", what, n)
+	default:
+		return true
+	}
+	return false
+}
+
+// Input declares an encrypted input supplied to Execute under name.
+// Declaring the same name twice returns the same node.
+func (c *Circuit) Input(name string) *Node {
+	if n, ok := c.inputs[name]; ok {
+		return n
+	}
+	n := c.add(&Node{op: opInput, name: name})
+	c.inputs[name] = n
+	return n
+}
+
+// Const declares a CKKS plaintext constant. It is encoded at the level of
+// the ciphertext it is combined with; with FIXEDMANUAL scaling, rescale a
+// product before adding a constant to it.
+func (c *Circuit) Const(values []float64) *Node {
+	if len(values) == 0 {
+		c.fail("Const: empty vector")
+	}
+	return c.add(&Node{op: opConst, reals: append([]float64(nil), values...)})
+}
+
+// ConstInt declares a BFV/BGV plaintext constant.
+func (c *Circuit) ConstInt(values []int64) *Node {
+	if len(values) == 0 {
+		c.fail("ConstInt: empty vector")
+	}
+	return c.add(&Node{op: opConst, ints: append([]int64(nil), values...)})
+}
+
+func (c *Circuit) binary(o op, a, b *Node) *Node {
+	name := o.String()
+	if c.operand(name, a, true) && c.operand(name, b, true) {
+		switch {
+		case a.IsConst() && b.IsConst():
+			c.fail("%s: both operands are constants", name)
+		case o == opSub && a.IsConst():
+			c.fail("sub: constant minuend is not supported; negate the difference instead")
+		}
+	}
+	// Keep the encrypted operand first.
+	if a != nil && a.IsConst() {
+		a, b = b, a
+	}
+	return c.add(&Node{op: o, args: []*Node{a, b}})
+}
+
+// Add returns a + b. At most one operand may be a constant.
+func (c *Circuit) Add(a, b *Node) *Node { return c.binary(opAdd, a, b) }
+
+// Sub returns a - b. b may be a constant.
+func (c *Circuit) Sub(a, b *Node) *Node { return c.binary(opSub, a, b) }
+
+// Mult returns a * b. At most one operand may be a constant.
+func (c *Circuit) Mult(a, b *Node) *Node { return c.binary(opMult, a, b) }
+
+// Rotate returns a rotated left by index slots.
+func (c *Circuit) Rotate(a *Node, index int32) *Node {
+	c.operand("rotate", a, false)
+	return c.add(&Node{op: opRotate, args: []*Node{a}, index: index})
+}
+
+// Rescale returns a rescaled. It is only needed with FIXEDMANUAL scaling
+// and is a no-op otherwise.
+func (c *Circuit) Rescale(a *Node) *Node {
+	c.operand("rescale", a, false)
+	return c.add(&Node{op: opRescale, args: []*Node{a}})
+}
+
+// Poly evaluates the polynomial with the given coefficients (ascending
+// order) on a.
+func (c *Circuit) Poly(a *Node, coefficients []float64) *Node {
+	c.operand("poly", a, false)
+	if len(coefficients) == 0 {
+		c.fail("poly: no coefficients")
+	}
+	return c.add(&Node{op: opPoly, args: []*Node{a}, coefs: append([]float64(nil), coefficients...)})
+}
+
+// Bootstrap refreshes a. The evaluator passed to Execute must implement
+// EvalBootstrap, as *openfhe.CryptoContext does.
+func (c *Circuit) Bootstrap(a *Node) *Node {
+	c.operand("bootstrap", a, false)
+	return c.add(&Node{op: opBootstrap, args: []*Node{a}})
+}
+
+// Output marks n as a result returned by Execute under name.
+func (c *Circuit) Output(name string, n *Node) {
+	if !c.operand("output "+name, n, false) {
+		return
+	}
+	if _, dup := c.outputs[name]; dup {
+		c.fail("output %q declared twice", name)
+		return
+	}
+	c.outputs[name] = n
+}
+
+// --- analysis ---
+
+// Analysis summarizes the resources a circuit needs.
+type Analysis struct {
+	// Depth is the multiplicative depth, in CKKS levels, of the longest
+	// path between inputs or bootstraps and an output. Multiplication by a
+	// constant counts as a level.
+	Depth int
+	// Rotations are the rotation indices to pass to EvalRotateKeyGen.
+	Rotations []int32
+	// KeySwitches estimates the key-switching operations: one per
+	// ciphertext product and rotation, plus an estimate for each
+	// polynomial. Bootstraps are counted separately.
+	KeySwitches int
+	Bootstraps  int
+	// Nodes is the number of operations that will be executed.
+	Nodes int
+	// Inputs lists the input names the outputs depend on.
+	Inputs []string
+}
+
+// PolyDepth returns the levels consumed by evaluating a polynomial of the
+// given degree.
+func PolyDepth(degree int) int {
+	return openfhe.PolyDepth(degree)
+}
+
+// polyKeySwitches estimates the ciphertext products of a Paterson–Stockmeyer
+// evaluation: the baby-step powers x^2..x^m plus one Horner step per
+// further chunk of m coefficients. A leading chunk holding a single
+// coefficient is a scalar product and needs no key switch.
+func polyKeySwitches(degree int) int {
+	if degree < 2 {
+		return 0
+	}
+	m := int(math.Ceil(math.Sqrt(float64(degree))))
+	chunks := (degree + m) / m
+	n := (m - 1) + (chunks - 1)
+	if degree%m == 0 {
+		n--
+	}
+	return n
+}
+
+// live returns the nodes the outputs depend on, in topological order.
+func (c *Circuit) live() []*Node {
+	seen := make([]bool, len(c.nodes))
+	var visit func(n *Node)
+	visit = func(n *Node) {
+		if seen[n.id] {
+			return
+		}
+		seen[n.id] = true
+		for _, a := range n.args {
+			visit(a)
+		}
+	}
+	for _, n := range c.outputs {
+		visit(n)
+	}
+	var out []*Node
+	for _, n := range c.nodes {
+		if seen[n.id] {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+func (c *Circuit) validate() error {
+	if c.err != nil {
+		return c.err
+	}
+	if len(c.outputs) == 0 {
+		return errors.New("circuit: no outputs")
+	}
+	return nil
+}
+
+// Analyze computes the depth, rotation keys and key-switch count of the
+// part of the circuit the outputs depend on.
+func (c *Circuit) Analyze() (*Analysis, error) {
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	a := &Analysis{}
+	depth := make([]int, len(c.nodes))
+	rots := make(map[int32]struct{})
+	for _, n := range c.live() {
+		d := 0
+		for _, arg := range n.args {
+			d = max(d, depth[arg.id])
+		}
+		switch n.op {
+		case opInput:
+			a.Inputs = append(a.Inputs, n.name)
+			continue
+		case opConst:
+			continue
+		case opMult:
+			d++
+			if !n.args[1].IsConst() {
+				a.KeySwitches++
+			}
+		case opRotate:
+			if n.index != 0 {
+				rots[n.index] = struct{}{}
+				a.KeySwitches++
+			}
+		case opPoly:
+			d += PolyDepth(len(n.coefs) - 1)
+			a.KeySwitches += polyKeySwitches(len(n.coefs) - 1)
+		case opBootstrap:
+			d = 0
+			a.Bootstraps++
+		}
+		depth[n.id] = d
+		a.Depth = max(a.Depth, d)
+		a.Nodes++
+	}
+	for k := range rots {
+		a.Rotations = append(a.Rotations, k)
+	}
+	sort.Slice(a.Rotations, func(i, j int) bool { return a.Rotations[i] < a.Rotations[j] })
+	sort.Strings(a.Inputs)
+	return a, nil
+}
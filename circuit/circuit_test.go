@@ -0,0 +1,337 @@
+package circuit
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dozyio/openfhe-go/internal/testutil"
+	"github.com/dozyio/openfhe-go/openfhe"
+)
+
+// tracker records every ciphertext the circuit creates so tests can check
+// that intermediates are released.
+type tracker struct {
+	openfhe.Evaluator
+	mu   sync.Mutex
+	made []*openfhe.Ciphertext
+}
+
+func (tr *tracker) record(ct *openfhe.Ciphertext, err error) (*openfhe.Ciphertext, error) {
+	if err == nil {
+		tr.mu.Lock()
+		tr.made = append(tr.made, ct)
+		tr.mu.Unlock()
+	}
+	return ct, err
+}
+
+func (tr *tracker) EvalAdd(a, b *openfhe.Ciphertext) (*openfhe.Ciphertext, error) {
+	return tr.record(tr.Evaluator.EvalAdd(a, b))
+}
+
+func (tr *tracker) EvalSub(a, b *openfhe.Ciphertext) (*openfhe.Ciphertext, error) {
+	return tr.record(tr.Evaluator.EvalSub(a, b))
+}
+
+func (tr *tracker) EvalMult(a, b *openfhe.Ciphertext) (*openfhe.Ciphertext, error) {
+	return tr.record(tr.Evaluator.EvalMult(a, b))
+}
+
+func (tr *tracker) EvalAddPlain(ct *openfhe.Ciphertext, pt *openfhe.Plaintext) (*openfhe.Ciphertext, error) {
+	return tr.record(tr.Evaluator.EvalAddPlain(ct, pt))
+}
+
+func (tr *tracker) EvalMultPlain(ct *openfhe.Ciphertext, pt *openfhe.Plaintext) (*openfhe.Ciphertext, error) {
+	return tr.record(tr.Evaluator.EvalMultPlain(ct, pt))
+}
+
+func (tr *tracker) EvalRotate(ct *openfhe.Ciphertext, index int32) (*openfhe.Ciphertext, error) {
+	return tr.record(tr.Evaluator.EvalRotate(ct, index))
+}
+
+func (tr *tracker) EvalPoly(ct *openfhe.Ciphertext, coefficients []float64) (*openfhe.Ciphertext, error) {
+	return tr.record(tr.Evaluator.EvalPoly(ct, coefficients))
+}
+
+// open returns the recorded ciphertexts that have not been closed.
+func (tr *tracker) open() []*openfhe.Ciphertext {
+	var out []*openfhe.Ciphertext
+	for _, ct := range tr.made {
+		if _, ok := ct.GetLevel(); ok {
+			out = append(out, ct)
+		}
+	}
+	return out
+}
+
+// testCircuit computes
+//
+//	y = rotate(x*x, 1) + 1
+//	z = x*w + p(x)       with p(t) = 1 + t^2/2
+//
+// and contains an unused branch.
+func testCircuit() *Circuit {
+	c := New()
+	x := c.Input("x")
+	w := c.Input("w")
+	c.Input("unused")
+	sq := c.Rescale(c.Mult(x, x))
+	c.Output("y", c.Add(c.Rotate(sq, 1), c.Const([]float64{1, 1, 1, 1})))
+	c.Output("z", c.Add(c.Mult(x, w), c.Poly(x, []float64{1, 0, 0.5})))
+	c.Rotate(c.Mult(w, w), -3) // dead
+	return c
+}
+
+func TestAnalyze(t *testing.T) {
+	a, err := testCircuit().Analyze()
+	testutil.MustT(t, err, "Analyze")
+	if a.Depth != 2 {
+		t.Errorf("Depth = %d, expected 2", a.Depth)
+	}
+	if len(a.Rotations) != 1 || a.Rotations[0] != 1 {
+		t.Errorf("Rotations = %v, expected [1]", a.Rotations)
+	}
+	// x*x, rotate, x*w and one product in the degree-2 polynomial.
+	if a.KeySwitches != 4 {
+		t.Errorf("KeySwitches = %d, expected 4", a.KeySwitches)
+	}
+	if a.Nodes != 7 {
+		t.Errorf("Nodes = %d, expected 7", a.Nodes)
+	}
+	if strings.Join(a.Inputs, ",") != "w,x" {
+		t.Errorf("Inputs = %v, expected [w x]", a.Inputs)
+	}
+
+	c := New()
+	x := c.Input("x")
+	b := c.Bootstrap(c.Mult(c.Mult(x, x), x))
+	c.Output("out", c.Mult(b, c.Const([]float64{2})))
+	a, err = c.Analyze()
+	testutil.MustT(t, err, "Analyze bootstrap")
+	if a.Depth != 2 || a.Bootstraps != 1 {
+		t.Errorf("Depth, Bootstraps = %d, %d, expected 2, 1", a.Depth, a.Bootstraps)
+	}
+}
+
+func TestBuilderErrors(t *testing.T) {
+	other := New().Input("x")
+	cases := []struct {
+		name  string
+		build func(c *Circuit)
+		want  string
+	}{
+		{"no outputs", func(c *Circuit) { c.Input("x") }, "no outputs"},
+		{"two constants", func(c *Circuit) {
+			c.Output("y", c.Add(c.Const([]float64{1}), c.Const([]float64{2})))
+		}, "both operands are constants"},
+		{"constant minuend", func(c *Circuit) {
+			c.Output("y", c.Sub(c.Const([]float64{1}), c.Input("x")))
+		}, "constant minuend"},
+		{"foreign node", func(c *Circuit) {
+			c.Output("y", c.Add(c.Input("x"), other))
+		}, "another circuit"},
+		{"duplicate output", func(c *Circuit) {
+			x := c.Input("x")
+			c.Output("y", x)
+			c.Output("y", x)
+		}, "declared twice"},
+	}
+	for _, tc := range cases {
+		c := New()
+		tc.build(c)
+		if _, err := c.Analyze(); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: Analyze error = %v, expected %q", tc.name, err, tc.want)
+		}
+	}
+}
+
+func TestExecuteSimulator(t *testing.T) {
+	sim, keys := testutil.NewSimulator(t, openfhe.SimParams{
+		Scheme:              openfhe.SimCKKS,
+		RingDimension:       64,
+		BatchSize:           4,
+		MultiplicativeDepth: 3,
+		ScalingTechnique:    openfhe.FLEXIBLEAUTO,
+	}, 1)
+	defer sim.Close()
+	defer keys.Close()
+
+	xs := []float64{0.5, -1, 2, 0.25}
+	ws := []float64{3, 1, -0.5, 4}
+	x := testutil.EncryptReals(t, sim, keys, xs)
+	defer x.Close()
+	w := testutil.EncryptReals(t, sim, keys, ws)
+	defer w.Close()
+
+	wantY := make([]float64, 4)
+	wantZ := make([]float64, 4)
+	for i := range xs {
+		next := xs[(i+1)%4]
+		wantY[i] = next*next + 1
+		wantZ[i] = xs[i]*ws[i] + 1 + xs[i]*xs[i]/2
+	}
+
+	c := testCircuit()
+	for _, workers := range []int{1, 4} {
+		tr := &tracker{Evaluator: sim}
+		out, err := c.ExecuteWorkers(tr, map[string]*openfhe.Ciphertext{"x": x, "w": w}, workers)
+		testutil.MustT(t, err, "ExecuteWorkers")
+		if len(out) != 2 {
+			t.Fatalf("workers=%d: %d outputs, expected 2", workers, len(out))
+		}
+		if got := testutil.DecryptReals(t, sim, keys, out["y"], 4); !testutil.ApproxEqual(got, wantY, 1e-6) {
+			t.Errorf("workers=%d: y = %v, expected %v", workers, got, wantY)
+		}
+		if got := testutil.DecryptReals(t, sim, keys, out["z"], 4); !testutil.ApproxEqual(got, wantZ, 1e-6) {
+			t.Errorf("workers=%d: z = %v, expected %v", workers, got, wantZ)
+		}
+		if open := tr.open(); len(open) != 2 {
+			t.Errorf("workers=%d: %d ciphertexts left open, expected only the 2 outputs", workers, len(open))
+		}
+		for _, ct := range out {
+			ct.Close()
+		}
+		if _, ok := x.GetLevel(); !ok {
+			t.Fatalf("workers=%d: input was closed", workers)
+		}
+	}
+}
+
+func TestExecuteBFV(t *testing.T) {
+	sim, keys := testutil.NewSimulator(t, openfhe.SimParams{
+		Scheme:              openfhe.SimBFV,
+		RingDimension:       32,
+		PlaintextModulus:    65537,
+		MultiplicativeDepth: 2,
+	}, -1)
+	defer sim.Close()
+	defer keys.Close()
+
+	pt, err := sim.MakePackedPlaintext([]int64{1, 2, 3, 4})
+	testutil.MustT(t, err, "MakePackedPlaintext")
+	defer pt.Close()
+	a, err := sim.Encrypt(keys, pt)
+	testutil.MustT(t, err, "Encrypt")
+	defer a.Close()
+
+	// (a*3 - a) rotated right by one slot, also returned as "again".
+	c := New()
+	in := c.Input("a")
+	r := c.Rotate(c.Sub(c.Mult(in, c.ConstInt([]int64{3, 3, 3, 3})), in), -1)
+	c.Output("r", r)
+	c.Output("again", r)
+	c.Output("a", in)
+
+	out, err := c.Execute(sim, map[string]*openfhe.Ciphertext{"a": a})
+	testutil.MustT(t, err, "Execute")
+	for name, want := range map[string][]int64{
+		"r":     {0, 2, 4, 6},
+		"again": {0, 2, 4, 6},
+		"a":     {1, 2, 3, 4},
+	} {
+		dec, err := sim.Decrypt(keys, out[name])
+		testutil.MustT(t, err, "Decrypt "+name)
+		testutil.MustT(t, dec.SetLength(4), "SetLength")
+		got, err := dec.GetPackedValue()
+		testutil.MustT(t, err, "GetPackedValue")
+		dec.Close()
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("%s = %v, expected %v", name, got[:4], want)
+				break
+			}
+		}
+	}
+	// Every output must be independently closable.
+	for _, ct := range out {
+		ct.Close()
+	}
+	if _, ok := a.GetLevel(); !ok {
+		t.Error("input was closed")
+	}
+}
+
+func TestExecuteErrors(t *testing.T) {
+	sim, keys := testutil.NewSimulator(t, openfhe.SimParams{
+		Scheme:              openfhe.SimCKKS,
+		RingDimension:       64,
+		BatchSize:           4,
+		MultiplicativeDepth: 2,
+		ScalingTechnique:    openfhe.FLEXIBLEAUTO,
+	})
+	defer sim.Close()
+	defer keys.Close()
+
+	x := testutil.EncryptReals(t, sim, keys, []float64{1, 2, 3, 4})
+	defer x.Close()
+
+	c := New()
+	in := c.Input("x")
+	c.Output("y", c.Rotate(c.Mult(in, in), 2))
+
+	if _, err := c.Execute(sim, map[string]*openfhe.Ciphertext{}); err == nil || !strings.Contains(err.Error(), `missing input "x"`) {
+		t.Errorf("missing input error = %v", err)
+	}
+
+	// No rotation key for index 2.
+	tr := &tracker{Evaluator: sim}
+	if _, err := c.Execute(tr, map[string]*openfhe.Ciphertext{"x": x}); err == nil || !strings.Contains(err.Error(), "rotate 2") {
+		t.Errorf("missing rotation key error = %v", err)
+	}
+	if open := tr.open(); len(open) != 0 {
+		t.Errorf("%d ciphertexts left open after a failed execution", len(open))
+	}
+
+	b := New()
+	b.Output("y", b.Bootstrap(b.Input("x")))
+	if _, err := b.Execute(sim, map[string]*openfhe.Ciphertext{"x": x}); err == nil || !strings.Contains(err.Error(), "bootstrapping") {
+		t.Errorf("bootstrap on simulator error = %v", err)
+	}
+}
+
+func TestExecuteCryptoContext(t *testing.T) {
+	params, err := openfhe.NewParamsCKKSRNS()
+	testutil.MustT(t, err, "NewParamsCKKSRNS")
+	defer params.Close()
+	testutil.MustT(t, params.SetMultiplicativeDepth(3), "SetMultiplicativeDepth")
+	testutil.MustT(t, params.SetScalingModSize(50), "SetScalingModSize")
+	testutil.MustT(t, params.SetBatchSize(4), "SetBatchSize")
+	testutil.MustT(t, params.SetScalingTechnique(openfhe.FIXEDMANUAL), "SetScalingTechnique")
+
+	cc, err := openfhe.NewCryptoContextCKKS(params)
+	testutil.MustT(t, err, "NewCryptoContextCKKS")
+	defer cc.Close()
+	for _, f := range []int{openfhe.PKE, openfhe.KEYSWITCH, openfhe.LEVELEDSHE, openfhe.ADVANCEDSHE} {
+		testutil.MustT(t, cc.Enable(f), "Enable")
+	}
+	keys, err := cc.KeyGen()
+	testutil.MustT(t, err, "KeyGen")
+	defer keys.Close()
+
+	c := New()
+	x := c.Input("x")
+	w := c.Input("w")
+	c.Output("y", c.Add(c.Rotate(c.Rescale(c.Mult(x, w)), 1), c.Const([]float64{1, 1, 1, 1})))
+
+	a, err := c.Analyze()
+	testutil.MustT(t, err, "Analyze")
+	testutil.MustT(t, cc.EvalMultKeyGen(keys), "EvalMultKeyGen")
+	testutil.MustT(t, cc.EvalRotateKeyGen(keys, a.Rotations), "EvalRotateKeyGen")
+
+	xs := []float64{1, 2, 3, 4}
+	ws := []float64{0.5, 0.25, -1, 2}
+	xct := testutil.EncryptReals(t, cc, keys, xs)
+	defer xct.Close()
+	wct := testutil.EncryptReals(t, cc, keys, ws)
+	defer wct.Close()
+
+	out, err := c.Execute(cc, map[string]*openfhe.Ciphertext{"x": xct, "w": wct})
+	testutil.MustT(t, err, "Execute")
+	defer out["y"].Close()
+
+	want := []float64{1 + 2*0.25, 1 + 3*-1, 1 + 4*2, 1 + 1*0.5}
+	if got := testutil.DecryptReals(t, cc, keys, out["y"], 4); !testutil.ApproxEqual(got, want, 1e-3) {
+		t.Errorf("y = %v, expected %v", got, want)
+	}
+}
@@ -0,0 +1,254 @@
+package circuit
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+
+	"github.com/dozyio/openfhe-go/openfhe"
+)
+
+// Bootstrapper is implemented by evaluators that can refresh ciphertexts,
+// such as *openfhe.CryptoContext after EvalBootstrapSetup and
+// EvalBootstrapKeyGen.
+type Bootstrapper interface {
+	EvalBootstrap(ct *openfhe.Ciphertext) (*openfhe.Ciphertext, error)
+}
+
+// Execute runs the circuit on ev with up to GOMAXPROCS operations in
+// flight. See ExecuteWorkers.
+func (c *Circuit) Execute(ev openfhe.Evaluator, inputs map[string]*openfhe.Ciphertext) (map[string]*openfhe.Ciphertext, error) {
+	return c.ExecuteWorkers(ev, inputs, runtime.GOMAXPROCS(0))
+}
+
+// ExecuteWorkers runs the part of the circuit the outputs depend on and
+// returns one ciphertext per output, owned by the caller. Nodes whose
+// operands are ready run concurrently, at most workers at a time.
+// Intermediate ciphertexts are closed once their last consumer finishes;
+// the inputs remain owned by the caller. On error every intermediate result
+// is closed.
+func (c *Circuit) ExecuteWorkers(ev openfhe.Evaluator, inputs map[string]*openfhe.Ciphertext, workers int) (map[string]*openfhe.Ciphertext, error) {
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	if ev == nil {
+		return nil, errors.New("circuit: nil evaluator")
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	live := c.live()
+	for _, n := range live {
+		if n.op != opInput {
+			continue
+		}
+		if ct, ok := inputs[n.name]; !ok || ct == nil {
+			return nil, fmt.Errorf("circuit: missing input %q", n.name)
+		}
+	}
+	return newRun(c, ev, inputs, live).execute(workers)
+}
+
+type result struct {
+	n   *Node
+	ct  *openfhe.Ciphertext
+	err error
+}
+
+// run holds the scheduling state of one execution. It is only touched by
+// the goroutine calling execute; workers receive operands by value and
+// report back on done.
+type run struct {
+	c      *Circuit
+	ev     openfhe.Evaluator
+	inputs map[string]*openfhe.Ciphertext
+	live   []*Node
+
+	values    map[int]*openfhe.Ciphertext
+	waiting   map[int]int // encrypted operands not yet computed
+	uses      map[int]int // consumer edges not yet finished
+	consumers map[int][]*Node
+	isOutput  map[int]bool
+}
+
+func newRun(c *Circuit, ev openfhe.Evaluator, inputs map[string]*openfhe.Ciphertext, live []*Node) *run {
+	r := &run{
+		c:         c,
+		ev:        ev,
+		inputs:    inputs,
+		live:      live,
+		values:    make(map[int]*openfhe.Ciphertext),
+		waiting:   make(map[int]int),
+		uses:      make(map[int]int),
+		consumers: make(map[int][]*Node),
+		isOutput:  make(map[int]bool),
+	}
+	for _, n := range live {
+		for _, a := range n.args {
+			if a.IsConst() {
+				continue
+			}
+			r.waiting[n.id]++
+			r.uses[a.id]++
+			r.consumers[a.id] = append(r.consumers[a.id], n)
+		}
+	}
+	for _, n := range c.outputs {
+		r.isOutput[n.id] = true
+	}
+	return r
+}
+
+func (r *run) execute(workers int) (map[string]*openfhe.Ciphertext, error) {
+	// Every operation has an encrypted operand, so inputs start the run.
+	var ready []*Node
+	for _, n := range r.live {
+		if n.op == opInput {
+			r.values[n.id] = r.inputs[n.name]
+			ready = append(ready, r.finish(n)...)
+		}
+	}
+
+	done := make(chan result)
+	inFlight := 0
+	var firstErr error
+	for len(ready) > 0 || inFlight > 0 {
+		for firstErr == nil && len(ready) > 0 && inFlight < workers {
+			n := ready[0]
+			ready = ready[1:]
+			args := make([]*openfhe.Ciphertext, len(n.args))
+			for i, a := range n.args {
+				args[i] = r.values[a.id]
+			}
+			inFlight++
+			go func() {
+				ct, err := r.eval(n, args)
+				done <- result{n, ct, err}
+			}()
+		}
+		if inFlight == 0 {
+			break
+		}
+		res := <-done
+		inFlight--
+		if res.err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("circuit: %v: %w", res.n, res.err)
+			}
+			continue
+		}
+		r.values[res.n.id] = res.ct
+		if firstErr == nil {
+			ready = append(ready, r.finish(res.n)...)
+		}
+	}
+
+	if firstErr != nil {
+		for id, ct := range r.values {
+			if r.c.nodes[id].op != opInput {
+				ct.Close()
+			}
+		}
+		return nil, firstErr
+	}
+	return r.collect()
+}
+
+// finish releases the operands of n that have no consumer left and returns
+// the consumers of n that became ready.
+func (r *run) finish(n *Node) []*Node {
+	for _, a := range n.args {
+		if a.IsConst() {
+			continue
+		}
+		r.uses[a.id]--
+		if r.uses[a.id] == 0 && a.op != opInput && !r.isOutput[a.id] {
+			r.values[a.id].Close()
+			delete(r.values, a.id)
+		}
+	}
+	var ready []*Node
+	for _, m := range r.consumers[n.id] {
+		r.waiting[m.id]--
+		if r.waiting[m.id] == 0 {
+			ready = append(ready, m)
+		}
+	}
+	return ready
+}
+
+// collect hands the output ciphertexts to the caller. Inputs, and nodes
+// returned under more than one name, are cloned so that every returned
+// ciphertext can be closed independently.
+func (r *run) collect() (map[string]*openfhe.Ciphertext, error) {
+	out := make(map[string]*openfhe.Ciphertext, len(r.c.outputs))
+	handed := make(map[int]bool)
+	for name, n := range r.c.outputs {
+		ct := r.values[n.id]
+		if n.op == opInput || handed[n.id] {
+			clone, err := ct.Clone()
+			if err != nil {
+				for _, o := range out {
+					o.Close()
+				}
+				return nil, err
+			}
+			ct = clone
+		}
+		handed[n.id] = true
+		out[name] = ct
+	}
+	return out, nil
+}
+
+func (r *run) eval(n *Node, args []*openfhe.Ciphertext) (*openfhe.Ciphertext, error) {
+	ev := r.ev
+	switch n.op {
+	case opAdd, opSub, opMult:
+		if k := n.args[1]; k.IsConst() {
+			return r.evalPlain(n.op, args[0], k)
+		}
+		switch n.op {
+		case opAdd:
+			return ev.EvalAdd(args[0], args[1])
+		case opSub:
+			return ev.EvalSub(args[0], args[1])
+		}
+		return ev.EvalMult(args[0], args[1])
+	case opRotate:
+		return ev.EvalRotate(args[0], n.index)
+	case opRescale:
+		return ev.Rescale(args[0])
+	case opPoly:
+		return ev.EvalPoly(args[0], n.coefs)
+	case opBootstrap:
+		b, ok := ev.(Bootstrapper)
+		if !ok {
+			return nil, errors.New("evaluator does not support bootstrapping")
+		}
+		return b.EvalBootstrap(args[0])
+	}
+	return nil, fmt.Errorf("unexpected operation %v", n.op)
+}
+
+func (r *run) evalPlain(o op, ct *openfhe.Ciphertext, k *Node) (*openfhe.Ciphertext, error) {
+	var pt *openfhe.Plaintext
+	var err error
+	if k.ints != nil {
+		pt, err = r.ev.MakePackedPlaintext(k.ints)
+	} else {
+		level, _ := ct.GetLevel()
+		pt, err = r.ev.MakeCKKSPackedPlaintextWithParams(k.reals, 1, uint32(max(level, 0)), 0)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer pt.Close()
+	switch o {
+	case opAdd:
+		return r.ev.EvalAddPlain(ct, pt)
+	case opSub:
+		return r.ev.EvalSubPlain(ct, pt)
+	}
+	return r.ev.EvalMultPlain(ct, pt)
+}
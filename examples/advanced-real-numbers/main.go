@@ -12,7 +12,7 @@ import (
 )
 
 // Helper function to print vectors and check results (using float64)
-func printAndCheck(label string, ptxt openfhe.Plaintext, expected []float64, tolerance float64, numSlots int) {
+func printAndCheck(label string, ptxt *openfhe.Plaintext, expected []float64, tolerance float64, numSlots int) {
 	// API Correction: Use GetRealPackedValue for CKKS
 	result, err := ptxt.GetRealPackedValue()
 	if err != nil {
@@ -248,7 +248,7 @@ func main() {
 	}
 
 	tolerance := 0.001
-	printAndCheck("Decrypted x^3", *decryptedPtxt, expectedX3, tolerance, batchSize)
+	printAndCheck("Decrypted x^3", decryptedPtxt, expectedX3, tolerance, batchSize)
 
 	fmt.Println("Execution finished.")
 }
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+
+	"github.com/dozyio/openfhe-go/openfhe"
+)
+
+func must(err error, what string) {
+	if err != nil {
+		log.Fatalf("%s: %v", what, err)
+	}
+}
+
+// approximationError returns the precision in bits of got versus want, using
+// the infinity norm.
+func approximationError(got, want []float64) float64 {
+	var maxErr float64
+	for i := range want {
+		maxErr = math.Max(maxErr, math.Abs(got[i]-want[i]))
+	}
+	return math.Abs(math.Log2(maxErr))
+}
+
+func main() {
+	fmt.Println("--- Go iterative-ckks-bootstrapping ---")
+
+	// === 1) Parameters ===
+	params, err := openfhe.NewParamsCKKSRNS()
+	must(err, "NewParamsCKKSRNS")
+	defer params.Close()
+
+	secretKeyDist := openfhe.SecretKeyUniformTernary
+	must(params.SetSecretKeyDist(secretKeyDist), "SetSecretKeyDist")
+	must(params.SetSecurityLevel(openfhe.HEStdNotSet), "SetSecurityLevel") // important with small N
+	must(params.SetRingDim(uint64(1<<12)), "SetRingDim")
+
+	if openfhe.GetNativeInt() == 128 {
+		must(params.SetScalingTechnique(openfhe.FIXEDAUTO), "SetScalingTechnique")
+		must(params.SetScalingModSize(78), "SetScalingModSize")
+		must(params.SetFirstModSize(89), "SetFirstModSize")
+	} else {
+		must(params.SetScalingTechnique(openfhe.FLEXIBLEAUTO), "SetScalingTechnique")
+		must(params.SetScalingModSize(59), "SetScalingModSize")
+		must(params.SetFirstModSize(60), "SetFirstModSize")
+	}
+
+	// Each extra iteration of bootstrapping consumes one more level.
+	levelBudget := []uint32{3, 3}
+	bsgsDim := []uint32{0, 0}
+	levelsAfter := uint32(10)
+	numIterations := uint32(2)
+	depth := levelsAfter + openfhe.GetBootstrapDepth(levelBudget, secretKeyDist) + (numIterations - 1)
+	must(params.SetMultiplicativeDepth(int(depth)), "SetMultiplicativeDepth")
+
+	// === 2) Context & enable ===
+	cc, err := openfhe.NewCryptoContextCKKS(params)
+	must(err, "NewCryptoContextCKKS")
+	defer cc.Close()
+
+	must(cc.Enable(openfhe.PKE), "Enable PKE")
+	must(cc.Enable(openfhe.KEYSWITCH), "Enable KEYSWITCH")
+	must(cc.Enable(openfhe.LEVELEDSHE), "Enable LEVELEDSHE")
+	must(cc.Enable(openfhe.ADVANCEDSHE), "Enable ADVANCEDSHE")
+	must(cc.Enable(openfhe.FHE), "Enable FHE")
+
+	ringDim := cc.GetRingDimension()
+	// Full packing; use a smaller power of two here for sparse bootstrapping.
+	numSlots := uint32(ringDim / 2)
+	fmt.Printf("CKKS scheme is using ring dimension %d\n\n", ringDim)
+
+	// === 3) Bootstrapping setup & keys ===
+	must(cc.EvalBootstrapSetup(levelBudget, bsgsDim, numSlots, 0, true), "EvalBootstrapSetup")
+
+	kp, err := cc.KeyGen()
+	must(err, "KeyGen")
+	defer kp.Close()
+	must(cc.EvalMultKeyGen(kp), "EvalMultKeyGen")
+	must(cc.EvalBootstrapKeyGen(kp, numSlots), "EvalBootstrapKeyGen")
+
+	// === 4) Encode at the last level & encrypt ===
+	x := make([]float64, numSlots)
+	for i := range x {
+		x[i] = rand.Float64()
+	}
+	pt, err := cc.MakeCKKSPackedPlaintextWithParams(x, 1, depth-1, numSlots)
+	must(err, "MakeCKKSPackedPlaintextWithParams")
+	defer pt.Close()
+	must(pt.SetLength(int(numSlots)), "SetLength")
+
+	ct, err := cc.Encrypt(kp, pt)
+	must(err, "Encrypt")
+	defer ct.Close()
+
+	decrypt := func(c *openfhe.Ciphertext) []float64 {
+		out, err := cc.Decrypt(kp, c)
+		must(err, "Decrypt")
+		defer out.Close()
+		must(out.SetLength(int(numSlots)), "SetLength")
+		vals, err := out.GetRealPackedValue()
+		must(err, "GetRealPackedValue")
+		return vals
+	}
+
+	// === 5) Measure the precision of a single bootstrap ===
+	ctOnce, err := cc.EvalBootstrap(ct)
+	must(err, "EvalBootstrap")
+	defer ctOnce.Close()
+	precision := approximationError(decrypt(ctOnce), x)
+	fmt.Printf("Bootstrapping precision after 1 iteration: %.2f bits\n\n", precision)
+
+	// === 6) Iterative bootstrapping ===
+	// Use the precision measured empirically over many runs, not the value above.
+	precisionBits := uint32(17)
+	fmt.Printf("Precision input to algorithm: %d bits\n\n", precisionBits)
+
+	ctTwice, err := cc.EvalBootstrapWithIterations(ct, numIterations, precisionBits)
+	must(err, "EvalBootstrapWithIterations")
+	defer ctTwice.Close()
+
+	result := decrypt(ctTwice)
+	fmt.Printf("Output after two iterations of bootstrapping: %v\n\n", result[:8])
+	precisionMulti := approximationError(result, x)
+	fmt.Printf("Bootstrapping precision after %d iterations: %.2f bits\n", numIterations, precisionMulti)
+	fmt.Printf("Number of bits gained: %.2f\n", precisionMulti-precision)
+}
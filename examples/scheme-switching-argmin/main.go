@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/dozyio/openfhe-go/openfhe"
+)
+
+func must(err error, what string) {
+	if err != nil {
+		log.Fatalf("%s: %v", what, err)
+	}
+}
+
+func main() {
+	fmt.Println("--- Go argmin/argmax via scheme switching ---")
+
+	x := []float64{-1.125, 4.5, 2.0, 3.75, 0.0, -0.5, 1.25, 3.0}
+	numValues := uint32(len(x))
+
+	// === 1) CKKS context ===
+	params, err := openfhe.NewParamsCKKSRNS()
+	must(err, "NewParamsCKKSRNS")
+	defer params.Close()
+
+	// 9 levels for FHEW->CKKS, 3 for CKKS->FHEW, 1 per tournament round and one spare.
+	multDepth := 9 + 3 + 1 + 3 // log2(8) rounds
+	must(params.SetMultiplicativeDepth(multDepth), "SetMultiplicativeDepth")
+	must(params.SetFirstModSize(60), "SetFirstModSize")
+	must(params.SetScalingModSize(50), "SetScalingModSize")
+	must(params.SetScalingTechnique(openfhe.FLEXIBLEAUTO), "SetScalingTechnique")
+	must(params.SetSecurityLevel(openfhe.HEStdNotSet), "SetSecurityLevel")
+	must(params.SetRingDim(8192), "SetRingDim")
+	must(params.SetBatchSize(int(numValues)), "SetBatchSize")
+
+	cc, err := openfhe.NewCryptoContextCKKS(params)
+	must(err, "NewCryptoContextCKKS")
+	defer cc.Close()
+
+	must(cc.Enable(openfhe.PKE), "Enable PKE")
+	must(cc.Enable(openfhe.KEYSWITCH), "Enable KEYSWITCH")
+	must(cc.Enable(openfhe.LEVELEDSHE), "Enable LEVELEDSHE")
+	must(cc.Enable(openfhe.ADVANCEDSHE), "Enable ADVANCEDSHE")
+	must(cc.Enable(openfhe.SCHEMESWITCH), "Enable SCHEMESWITCH")
+
+	keys, err := cc.KeyGen()
+	must(err, "KeyGen")
+	defer keys.Close()
+
+	// === 2) Scheme switching with argmin and one-hot output ===
+	swParams, err := openfhe.NewSchSwchParams()
+	must(err, "NewSchSwchParams")
+	defer swParams.Close()
+
+	must(swParams.SetSecurityLevelCKKS(openfhe.HEStdNotSet), "SetSecurityLevelCKKS")
+	must(swParams.SetSecurityLevelFHEW(openfhe.BinFHETOY), "SetSecurityLevelFHEW")
+	must(swParams.SetCtxtModSizeFHEWLargePrec(25), "SetCtxtModSizeFHEWLargePrec")
+	must(swParams.SetNumSlotsCKKS(numValues), "SetNumSlotsCKKS")
+	must(swParams.SetNumValues(numValues), "SetNumValues")
+	must(swParams.SetComputeArgmin(true), "SetComputeArgmin")
+	must(swParams.SetOneHotEncoding(true), "SetOneHotEncoding")
+
+	lwesk, err := cc.EvalSchemeSwitchingSetup(swParams)
+	must(err, "EvalSchemeSwitchingSetup")
+	defer lwesk.Close()
+	must(cc.EvalSchemeSwitchingKeyGen(keys, lwesk), "EvalSchemeSwitchingKeyGen")
+
+	ccLWE, err := cc.GetBinCCForSchemeSwitch()
+	must(err, "GetBinCCForSchemeSwitch")
+	pLWE, err := ccLWE.GetMaxPlaintextSpace()
+	must(err, "GetMaxPlaintextSpace")
+
+	// Scale differences up so they are not rounded away in FHEW.
+	scaleSign := 512.0
+	must(cc.EvalCompareSwitchPrecompute(pLWE, scaleSign), "EvalCompareSwitchPrecompute")
+
+	// === 3) Encrypt and evaluate ===
+	pt, err := cc.MakeCKKSPackedPlaintext(x)
+	must(err, "MakeCKKSPackedPlaintext")
+	defer pt.Close()
+	ct, err := cc.Encrypt(keys, pt)
+	must(err, "Encrypt")
+	defer ct.Close()
+
+	fmt.Printf("Input: %v\n", x)
+
+	decrypt := func(c *openfhe.Ciphertext, n int) []float64 {
+		out, err := cc.Decrypt(keys, c)
+		must(err, "Decrypt")
+		defer out.Close()
+		must(out.SetLength(n), "SetLength")
+		v, err := out.GetRealPackedValue()
+		must(err, "GetRealPackedValue")
+		return v[:n]
+	}
+
+	minVal, argmin, err := cc.EvalMinSchemeSwitching(ct, keys, numValues, numValues, pLWE, scaleSign)
+	must(err, "EvalMinSchemeSwitching")
+	defer minVal.Close()
+	defer argmin.Close()
+	fmt.Printf("Min:    %.4f\n", decrypt(minVal, 1)[0])
+	fmt.Printf("Argmin: %.2f\n", decrypt(argmin, int(numValues)))
+
+	maxVal, argmax, err := cc.EvalMaxSchemeSwitching(ct, keys, numValues, numValues, pLWE, scaleSign)
+	must(err, "EvalMaxSchemeSwitching")
+	defer maxVal.Close()
+	defer argmax.Close()
+	fmt.Printf("Max:    %.4f\n", decrypt(maxVal, 1)[0])
+	fmt.Printf("Argmax: %.2f\n", decrypt(argmax, int(numValues)))
+}
@@ -0,0 +1,76 @@
+// Package testutil holds the helpers shared by the tests of the packages
+// built on openfhe.
+package testutil
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dozyio/openfhe-go/openfhe"
+)
+
+// MustT fails the test when err is not nil.
+func MustT(t *testing.T, err error, where string) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %v", where, err)
+	}
+}
+
+// ApproxEqual reports whether a and b have the same length and differ by at
+// most tolerance in every element.
+func ApproxEqual(a, b []float64, tolerance float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, v := range a {
+		if math.Abs(v-b[i]) > tolerance {
+			return false
+		}
+	}
+	return true
+}
+
+// NewSimulator creates a Simulator with every feature enabled and a key
+// pair with multiplication, summation and the given rotation keys.
+func NewSimulator(t *testing.T, p openfhe.SimParams, rotations ...int32) (*openfhe.Simulator, *openfhe.KeyPair) {
+	t.Helper()
+	sim, err := openfhe.NewSimulator(p)
+	MustT(t, err, "NewSimulator")
+	for _, f := range []int{openfhe.PKE, openfhe.KEYSWITCH, openfhe.LEVELEDSHE, openfhe.ADVANCEDSHE} {
+		MustT(t, sim.Enable(f), "Enable")
+	}
+	keys, err := sim.KeyGen()
+	MustT(t, err, "KeyGen")
+	MustT(t, sim.EvalMultKeyGen(keys), "EvalMultKeyGen")
+	MustT(t, sim.EvalSumKeyGen(keys), "EvalSumKeyGen")
+	if len(rotations) > 0 {
+		MustT(t, sim.EvalRotateKeyGen(keys, rotations), "EvalRotateKeyGen")
+	}
+	return sim, keys
+}
+
+// EncryptReals packs vals into a CKKS plaintext and encrypts it.
+func EncryptReals(t *testing.T, ev openfhe.Evaluator, keys *openfhe.KeyPair, vals []float64) *openfhe.Ciphertext {
+	t.Helper()
+	pt, err := ev.MakeCKKSPackedPlaintext(vals)
+	MustT(t, err, "MakeCKKSPackedPlaintext")
+	defer pt.Close()
+	ct, err := ev.Encrypt(keys, pt)
+	MustT(t, err, "Encrypt")
+	return ct
+}
+
+// DecryptReals decrypts ct and returns its first n real slots.
+func DecryptReals(t *testing.T, ev openfhe.Evaluator, keys *openfhe.KeyPair, ct *openfhe.Ciphertext, n int) []float64 {
+	t.Helper()
+	pt, err := ev.Decrypt(keys, ct)
+	MustT(t, err, "Decrypt")
+	defer pt.Close()
+	vals, err := pt.GetRealPackedValue()
+	MustT(t, err, "GetRealPackedValue")
+	if len(vals) < n {
+		t.Fatalf("decrypted %d slots, expected at least %d", len(vals), n)
+	}
+	return vals[:n]
+}
@@ -0,0 +1,205 @@
+package matrix
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/dozyio/openfhe-go/openfhe"
+)
+
+// diagonals is a sparse n×n matrix in diagonal form: diags[k][j] is the
+// entry in row j, column (j+k) mod n. Applying it to a vector v packed with
+// period n computes sum_k diags[k] ⊙ rot(v, k).
+type diagonals[T openfhe.Slot] struct {
+	n     int
+	diags map[int][]T
+}
+
+// fromDense converts a rows×cols matrix, zero-padded to n×n, to diagonal
+// form. All-zero diagonals are dropped.
+func fromDense[T openfhe.Slot](m [][]T, n int) *diagonals[T] {
+	d := &diagonals[T]{n: n, diags: make(map[int][]T)}
+	var zero T
+	for k := 0; k < n; k++ {
+		diag := make([]T, n)
+		nonzero := false
+		for j := 0; j < n && j < len(m); j++ {
+			col := (j + k) % n
+			if col < len(m[j]) && m[j][col] != zero {
+				diag[j] = m[j][col]
+				nonzero = true
+			}
+		}
+		if nonzero {
+			d.diags[k] = diag
+		}
+	}
+	return d
+}
+
+// fromPermutation builds the diagonal form of the n×n 0/1 matrix that moves
+// slot src(i) to slot i. Slots with src(i) < 0 are zeroed.
+func fromPermutation[T openfhe.Slot](n int, src func(i int) int) *diagonals[T] {
+	d := &diagonals[T]{n: n, diags: make(map[int][]T)}
+	for i := 0; i < n; i++ {
+		s := src(i)
+		if s < 0 {
+			continue
+		}
+		k := ((s-i)%n + n) % n
+		diag, ok := d.diags[k]
+		if !ok {
+			diag = make([]T, n)
+			d.diags[k] = diag
+		}
+		diag[i] = 1
+	}
+	return d
+}
+
+// babyGiant returns the baby-step size n1 for dimension n: the smallest
+// power of two whose square is at least n.
+func babyGiant(n int) int {
+	n1 := 1
+	for n1*n1 < n {
+		n1 <<= 1
+	}
+	return n1
+}
+
+// rotations returns the rotation indices apply needs for these diagonals.
+func (d *diagonals[T]) rotations() []int32 {
+	ks := make([]int, 0, len(d.diags))
+	for k := range d.diags {
+		ks = append(ks, k)
+	}
+	return bsgsRotations(d.n, ks)
+}
+
+func bsgsRotations(n int, ks []int) []int32 {
+	n1 := babyGiant(n)
+	set := make(map[int32]struct{})
+	for _, k := range ks {
+		if i := k % n1; i != 0 {
+			set[int32(i)] = struct{}{}
+		}
+		if g := k - k%n1; g != 0 {
+			set[int32(g)] = struct{}{}
+		}
+	}
+	return sortedIndices(set)
+}
+
+func sortedIndices(set map[int32]struct{}) []int32 {
+	out := make([]int32, 0, len(set))
+	for k := range set {
+		out = append(out, k)
+	}
+	sort.Slice(out, func(a, b int) bool { return out[a] < out[b] })
+	return out
+}
+
+// apply evaluates the linear map on ct using the baby-step/giant-step
+// variant of the Halevi–Shoup diagonal method. Baby-step rotations share one
+// hoisted precomputation; plaintext diagonals are pre-rotated by the
+// giant step so that only n2 = n/n1 full rotations are needed.
+func (d *diagonals[T]) apply(e *Evaluator[T], ct *openfhe.Ciphertext) (*openfhe.Ciphertext, error) {
+	if len(d.diags) == 0 {
+		return nil, errors.New("matrix: linear map is zero")
+	}
+	n1 := babyGiant(d.n)
+	n2 := (d.n + n1 - 1) / n1
+
+	// Baby steps actually used by a non-zero diagonal.
+	needBaby := make([]bool, n1)
+	for k := range d.diags {
+		needBaby[k%n1] = true
+	}
+	baby := make([]*openfhe.Ciphertext, n1)
+	baby[0] = ct
+	defer func() {
+		for _, b := range baby[1:] {
+			if b != nil {
+				b.Close()
+			}
+		}
+	}()
+	if anyTrue(needBaby[1:]) {
+		precomp, err := e.cc.EvalFastRotationPrecompute(ct)
+		if err != nil {
+			return nil, err
+		}
+		defer precomp.Close()
+		for i := 1; i < n1; i++ {
+			if !needBaby[i] {
+				continue
+			}
+			if baby[i], err = e.cc.EvalFastRotation(ct, int32(i), e.m, precomp); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	level, _ := ct.GetLevel()
+	if level < 0 {
+		level = 0
+	}
+
+	var result *openfhe.Ciphertext
+	for j := 0; j < n2; j++ {
+		var inner *openfhe.Ciphertext
+		for i := 0; i < n1; i++ {
+			diag, ok := d.diags[j*n1+i]
+			if !ok {
+				continue
+			}
+			shifted := make([]T, d.n)
+			for t := range shifted {
+				shifted[t] = diag[((t-j*n1)%d.n+d.n)%d.n]
+			}
+			term, err := e.multPlain(baby[i], e.replicate(shifted), level)
+			if err != nil {
+				closeAll(inner, result)
+				return nil, err
+			}
+			if inner, err = e.accumulate(inner, term); err != nil {
+				closeAll(result)
+				return nil, err
+			}
+		}
+		if inner == nil {
+			continue
+		}
+		if j > 0 {
+			rot, err := e.cc.EvalRotate(inner, int32(j*n1))
+			inner.Close()
+			if err != nil {
+				closeAll(result)
+				return nil, err
+			}
+			inner = rot
+		}
+		var err error
+		if result, err = e.accumulate(result, inner); err != nil {
+			return nil, err
+		}
+	}
+	return e.rescale(result)
+}
+
+func anyTrue(bs []bool) bool {
+	for _, b := range bs {
+		if b {
+			return true
+		}
+	}
+	return false
+}
+
+func closeAll(cts ...*openfhe.Ciphertext) {
+	for _, ct := range cts {
+		if ct != nil {
+			ct.Close()
+		}
+	}
+}
@@ -0,0 +1,561 @@
+// Package matrix implements encrypted linear algebra on top of the openfhe
+// package: plaintext-matrix × encrypted-vector products with the
+// baby-step/giant-step Halevi–Shoup diagonal method, encrypted
+// matrix × matrix products, transposition and row/column sums.
+//
+// Vectors of dimension n are packed into the slots of one ciphertext and
+// replicated with period n, so that a slot rotation acts as a cyclic rotation
+// of the vector. Matrices are zero-padded to n×n and packed row-major with
+// period n². Dimensions must be powers of two dividing the slot count
+// (n² for matrices), which openfhe.SlotCount caps at one BFV/BGV rotation
+// row of N/2 slots. Each operation reports the rotation indices it needs
+// so they can be passed to EvalRotateKeyGen up front.
+package matrix
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dozyio/openfhe-go/openfhe"
+)
+
+// Evaluator performs matrix operations in one CryptoContext. T selects the
+// encoding: int64 for BFV/BGV, float64 or complex128 for CKKS.
+type Evaluator[T openfhe.Slot] struct {
+	cc            *openfhe.CryptoContext
+	slots         int
+	m             uint32
+	manualRescale bool
+}
+
+// NewEvaluator creates an Evaluator for cc. With CKKS in FIXEDMANUAL mode
+// every operation rescales its result.
+func NewEvaluator[T openfhe.Slot](cc *openfhe.CryptoContext) (*Evaluator[T], error) {
+	if cc == nil {
+		return nil, errors.New("CryptoContext is nil")
+	}
+	ringDim := cc.GetRingDimension()
+	if ringDim == 0 {
+		return nil, errors.New("CryptoContext is closed or invalid")
+	}
+	slots := openfhe.SlotCount(cc)
+
+	var zero T
+	_, isInt := any(zero).(int64)
+	return &Evaluator[T]{
+		cc:            cc,
+		slots:         slots,
+		m:             uint32(2 * ringDim),
+		manualRescale: !isInt && cc.GetScalingTechnique() == openfhe.FIXEDMANUAL,
+	}, nil
+}
+
+// Vector is an encrypted vector of Len values packed with period Dim.
+type Vector struct {
+	Ct  *openfhe.Ciphertext
+	Len int
+	Dim int
+}
+
+// Close frees the ciphertext.
+func (v *Vector) Close() {
+	if v.Ct != nil {
+		v.Ct.Close()
+		v.Ct = nil
+	}
+}
+
+// Matrix is an encrypted Rows×Cols matrix packed row-major in a Dim×Dim
+// block.
+type Matrix struct {
+	Ct   *openfhe.Ciphertext
+	Rows int
+	Cols int
+	Dim  int
+}
+
+// Close frees the ciphertext.
+func (m *Matrix) Close() {
+	if m.Ct != nil {
+		m.Ct.Close()
+		m.Ct = nil
+	}
+}
+
+// --- Encryption ---
+
+// EncryptVector encrypts v packed with period dim.
+func (e *Evaluator[T]) EncryptVector(keys *openfhe.KeyPair, v []T, dim int) (*Vector, error) {
+	if err := e.checkDim(dim); err != nil {
+		return nil, err
+	}
+	if len(v) == 0 || len(v) > dim {
+		return nil, fmt.Errorf("matrix: vector length %d does not fit dimension %d", len(v), dim)
+	}
+	block := make([]T, dim)
+	copy(block, v)
+	ct, err := e.encrypt(keys, e.replicate(block))
+	if err != nil {
+		return nil, err
+	}
+	return &Vector{Ct: ct, Len: len(v), Dim: dim}, nil
+}
+
+// DecryptVector decrypts v.
+func (e *Evaluator[T]) DecryptVector(keys *openfhe.KeyPair, v *Vector) ([]T, error) {
+	if v == nil || v.Ct == nil {
+		return nil, errors.New("matrix: Vector is closed or invalid")
+	}
+	vals, err := e.decrypt(keys, v.Ct, v.Len)
+	if err != nil {
+		return nil, err
+	}
+	return vals[:v.Len], nil
+}
+
+// EncryptMatrix encrypts the rows×cols matrix m in a dim×dim block.
+func (e *Evaluator[T]) EncryptMatrix(keys *openfhe.KeyPair, m [][]T, dim int) (*Matrix, error) {
+	if err := e.checkDim(dim * dim); err != nil {
+		return nil, err
+	}
+	rows, cols, err := shape(m)
+	if err != nil {
+		return nil, err
+	}
+	if rows > dim || cols > dim {
+		return nil, fmt.Errorf("matrix: %d×%d matrix does not fit dimension %d", rows, cols, dim)
+	}
+	block := make([]T, dim*dim)
+	for i, row := range m {
+		copy(block[i*dim:], row)
+	}
+	ct, err := e.encrypt(keys, e.replicate(block))
+	if err != nil {
+		return nil, err
+	}
+	return &Matrix{Ct: ct, Rows: rows, Cols: cols, Dim: dim}, nil
+}
+
+// DecryptMatrix decrypts m.
+func (e *Evaluator[T]) DecryptMatrix(keys *openfhe.KeyPair, m *Matrix) ([][]T, error) {
+	if m == nil || m.Ct == nil {
+		return nil, errors.New("matrix: Matrix is closed or invalid")
+	}
+	vals, err := e.decrypt(keys, m.Ct, m.Dim*m.Dim)
+	if err != nil {
+		return nil, err
+	}
+	out := make([][]T, m.Rows)
+	for i := range out {
+		out[i] = append([]T(nil), vals[i*m.Dim:i*m.Dim+m.Cols]...)
+	}
+	return out, nil
+}
+
+// --- Matrix–vector ---
+
+// MatVecRotations returns the rotation indices MatVec may need for vectors
+// of dimension dim. A sparse matrix may need fewer; see MatVecRotationsFor.
+func MatVecRotations(dim int) []int32 {
+	ks := make([]int, dim)
+	for k := range ks {
+		ks[k] = k
+	}
+	return bsgsRotations(dim, ks)
+}
+
+// MatVecRotationsFor returns the rotation indices MatVec needs for m.
+func MatVecRotationsFor[T openfhe.Slot](m [][]T, dim int) []int32 {
+	return fromDense(m, dim).rotations()
+}
+
+// MatVec computes m·v for a plaintext rows×cols matrix m and an encrypted
+// vector of length cols. It consumes one level.
+func (e *Evaluator[T]) MatVec(m [][]T, v *Vector) (*Vector, error) {
+	if v == nil || v.Ct == nil {
+		return nil, errors.New("matrix: Vector is closed or invalid")
+	}
+	rows, cols, err := shape(m)
+	if err != nil {
+		return nil, err
+	}
+	if cols != v.Len {
+		return nil, fmt.Errorf("matrix: cannot multiply %d×%d matrix by vector of length %d", rows, cols, v.Len)
+	}
+	if rows > v.Dim {
+		return nil, fmt.Errorf("matrix: %d rows do not fit dimension %d", rows, v.Dim)
+	}
+	ct, err := fromDense(m, v.Dim).apply(e, v.Ct)
+	if err != nil {
+		return nil, err
+	}
+	return &Vector{Ct: ct, Len: rows, Dim: v.Dim}, nil
+}
+
+// --- Matrix–matrix ---
+
+// sigma(A)[i][j] = A[i][i+j], tau(B)[i][j] = B[i+j][j] and
+// phi^k(A)[i][j] = A[i][j+k], all indices mod d (Jiang et al., CCS'18).
+func sigma[T openfhe.Slot](d int) *diagonals[T] {
+	return fromPermutation[T](d*d, func(p int) int {
+		i, j := p/d, p%d
+		return i*d + (i+j)%d
+	})
+}
+
+func tau[T openfhe.Slot](d int) *diagonals[T] {
+	return fromPermutation[T](d*d, func(p int) int {
+		i, j := p/d, p%d
+		return ((i+j)%d)*d + j
+	})
+}
+
+func phi[T openfhe.Slot](d, k int) *diagonals[T] {
+	return fromPermutation[T](d*d, func(p int) int {
+		i, j := p/d, p%d
+		return i*d + (j+k)%d
+	})
+}
+
+func transposition[T openfhe.Slot](d int) *diagonals[T] {
+	return fromPermutation[T](d*d, func(p int) int {
+		i, j := p/d, p%d
+		return j*d + i
+	})
+}
+
+// MatMulRotations returns the rotation indices MatMul needs for dimension
+// dim.
+func MatMulRotations(dim int) []int32 {
+	set := make(map[int32]struct{})
+	add := func(ks []int32) {
+		for _, k := range ks {
+			set[k] = struct{}{}
+		}
+	}
+	add(sigma[float64](dim).rotations())
+	add(tau[float64](dim).rotations())
+	for k := 1; k < dim; k++ {
+		add(phi[float64](dim, k).rotations())
+		set[int32(k*dim)] = struct{}{}
+	}
+	return sortedIndices(set)
+}
+
+// MatMul computes a·b for two encrypted matrices. It consumes three levels
+// (two plaintext masks and one ciphertext multiplication) and needs the
+// relinearization key.
+func (e *Evaluator[T]) MatMul(a, b *Matrix) (*Matrix, error) {
+	if a == nil || a.Ct == nil || b == nil || b.Ct == nil {
+		return nil, errors.New("matrix: Matrix is closed or invalid")
+	}
+	if a.Dim != b.Dim {
+		return nil, fmt.Errorf("matrix: dimension mismatch %d vs %d", a.Dim, b.Dim)
+	}
+	if a.Cols != b.Rows {
+		return nil, fmt.Errorf("matrix: cannot multiply %d×%d by %d×%d", a.Rows, a.Cols, b.Rows, b.Cols)
+	}
+	d := a.Dim
+
+	sa, err := sigma[T](d).apply(e, a.Ct)
+	if err != nil {
+		return nil, err
+	}
+	defer sa.Close()
+	tb, err := tau[T](d).apply(e, b.Ct)
+	if err != nil {
+		return nil, err
+	}
+	defer tb.Close()
+
+	var acc *openfhe.Ciphertext
+	for k := 0; k < d; k++ {
+		// phi^0 is the identity; applying it as a mask keeps every term at
+		// the same level.
+		ak, err := phi[T](d, k).apply(e, sa)
+		if err != nil {
+			closeAll(acc)
+			return nil, err
+		}
+		bk := tb
+		if k > 0 {
+			if bk, err = e.cc.EvalRotate(tb, int32(k*d)); err != nil {
+				closeAll(acc, ak)
+				return nil, err
+			}
+		}
+		prod, err := e.cc.EvalMult(ak, bk)
+		ak.Close()
+		if k > 0 {
+			bk.Close()
+		}
+		if err != nil {
+			closeAll(acc)
+			return nil, err
+		}
+		if prod, err = e.rescale(prod); err != nil {
+			closeAll(acc)
+			return nil, err
+		}
+		if acc, err = e.accumulate(acc, prod); err != nil {
+			return nil, err
+		}
+	}
+	return &Matrix{Ct: acc, Rows: a.Rows, Cols: b.Cols, Dim: d}, nil
+}
+
+// --- Transpose and sums ---
+
+// TransposeRotations returns the rotation indices Transpose needs for
+// dimension dim.
+func TransposeRotations(dim int) []int32 {
+	return transposition[float64](dim).rotations()
+}
+
+// Transpose returns aᵀ. It consumes one level.
+func (e *Evaluator[T]) Transpose(a *Matrix) (*Matrix, error) {
+	if a == nil || a.Ct == nil {
+		return nil, errors.New("matrix: Matrix is closed or invalid")
+	}
+	ct, err := transposition[T](a.Dim).apply(e, a.Ct)
+	if err != nil {
+		return nil, err
+	}
+	return &Matrix{Ct: ct, Rows: a.Cols, Cols: a.Rows, Dim: a.Dim}, nil
+}
+
+// RowSumRotations returns the rotation indices RowSums needs for dimension
+// dim.
+func RowSumRotations(dim int) []int32 {
+	var out []int32
+	for s := 1; s < dim; s <<= 1 {
+		out = append(out, int32(s))
+	}
+	return out
+}
+
+// ColSumRotations returns the rotation indices ColSums needs for dimension
+// dim.
+func ColSumRotations(dim int) []int32 {
+	var out []int32
+	for s := dim; s < dim*dim; s <<= 1 {
+		out = append(out, int32(s))
+	}
+	return out
+}
+
+// RowSums returns the Rows×1 matrix of row sums of a. It consumes one level.
+func (e *Evaluator[T]) RowSums(a *Matrix) (*Matrix, error) {
+	if a == nil || a.Ct == nil {
+		return nil, errors.New("matrix: Matrix is closed or invalid")
+	}
+	d := a.Dim
+	ct, err := e.rotateSum(a.Ct, RowSumRotations(d))
+	if err != nil {
+		return nil, err
+	}
+	defer ct.Close()
+	masked, err := e.maskBlock(ct, d*d, func(p int) bool { return p%d == 0 })
+	if err != nil {
+		return nil, err
+	}
+	return &Matrix{Ct: masked, Rows: a.Rows, Cols: 1, Dim: d}, nil
+}
+
+// ColSums returns the 1×Cols matrix of column sums of a. It consumes one
+// level.
+func (e *Evaluator[T]) ColSums(a *Matrix) (*Matrix, error) {
+	if a == nil || a.Ct == nil {
+		return nil, errors.New("matrix: Matrix is closed or invalid")
+	}
+	d := a.Dim
+	ct, err := e.rotateSum(a.Ct, ColSumRotations(d))
+	if err != nil {
+		return nil, err
+	}
+	defer ct.Close()
+	masked, err := e.maskBlock(ct, d*d, func(p int) bool { return p < d })
+	if err != nil {
+		return nil, err
+	}
+	return &Matrix{Ct: masked, Rows: 1, Cols: a.Cols, Dim: d}, nil
+}
+
+// Rotations returns the union of the rotation indices needed by every
+// operation in this package for dimension dim.
+func Rotations(dim int) []int32 {
+	set := make(map[int32]struct{})
+	for _, ks := range [][]int32{
+		MatVecRotations(dim),
+		MatMulRotations(dim),
+		TransposeRotations(dim),
+		RowSumRotations(dim),
+		ColSumRotations(dim),
+	} {
+		for _, k := range ks {
+			set[k] = struct{}{}
+		}
+	}
+	return sortedIndices(set)
+}
+
+// --- internals ---
+
+func (e *Evaluator[T]) checkDim(period int) error {
+	if period <= 0 || period&(period-1) != 0 {
+		return fmt.Errorf("matrix: packing period %d is not a power of two", period)
+	}
+	if period > e.slots {
+		return fmt.Errorf("matrix: packing period %d exceeds %d slots", period, e.slots)
+	}
+	return nil
+}
+
+func shape[T openfhe.Slot](m [][]T) (rows, cols int, err error) {
+	if len(m) == 0 || len(m[0]) == 0 {
+		return 0, 0, errors.New("matrix: empty matrix")
+	}
+	cols = len(m[0])
+	for i, row := range m {
+		if len(row) != cols {
+			return 0, 0, fmt.Errorf("matrix: row %d has %d columns, expected %d", i, len(row), cols)
+		}
+	}
+	return len(m), cols, nil
+}
+
+// replicate tiles block across all slots.
+func (e *Evaluator[T]) replicate(block []T) []T {
+	out := make([]T, e.slots)
+	for i := range out {
+		out[i] = block[i%len(block)]
+	}
+	return out
+}
+
+func (e *Evaluator[T]) encode(values []T, level int) (*openfhe.Plaintext, error) {
+	switch vals := any(values).(type) {
+	case []int64:
+		return e.cc.MakePackedPlaintext(vals)
+	case []float64:
+		return e.cc.MakeCKKSPackedPlaintextWithParams(vals, 1, uint32(level), 0)
+	case []complex128:
+		return e.cc.MakeCKKSComplexPackedPlaintextWithParams(vals, 1, uint32(level), 0)
+	}
+	return nil, errors.New("matrix: unsupported slot type")
+}
+
+func (e *Evaluator[T]) encrypt(keys *openfhe.KeyPair, values []T) (*openfhe.Ciphertext, error) {
+	pt, err := e.encode(values, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer pt.Close()
+	return e.cc.Encrypt(keys, pt)
+}
+
+func (e *Evaluator[T]) decrypt(keys *openfhe.KeyPair, ct *openfhe.Ciphertext, n int) ([]T, error) {
+	pt, err := e.cc.Decrypt(keys, ct)
+	if err != nil {
+		return nil, err
+	}
+	defer pt.Close()
+
+	var out []T
+	switch dst := any(&out).(type) {
+	case *[]int64:
+		*dst, err = pt.GetPackedValue()
+	case *[]float64:
+		*dst, err = pt.GetRealPackedValue()
+	case *[]complex128:
+		*dst, err = pt.GetComplexPackedValue()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(out) < n {
+		return nil, fmt.Errorf("matrix: decrypted %d slots, expected at least %d", len(out), n)
+	}
+	return out, nil
+}
+
+// multPlain multiplies ct by values encoded at the given level without
+// rescaling, so that several products can be summed first.
+func (e *Evaluator[T]) multPlain(ct *openfhe.Ciphertext, values []T, level int) (*openfhe.Ciphertext, error) {
+	pt, err := e.encode(values, level)
+	if err != nil {
+		return nil, err
+	}
+	defer pt.Close()
+	return e.cc.EvalMultPlain(ct, pt)
+}
+
+// accumulate returns acc + term, consuming both. A nil acc yields term.
+func (e *Evaluator[T]) accumulate(acc, term *openfhe.Ciphertext) (*openfhe.Ciphertext, error) {
+	if acc == nil {
+		return term, nil
+	}
+	sum, err := e.cc.EvalAdd(acc, term)
+	acc.Close()
+	term.Close()
+	return sum, err
+}
+
+// rescale consumes ct and returns its rescaled replacement in FIXEDMANUAL
+// mode.
+func (e *Evaluator[T]) rescale(ct *openfhe.Ciphertext) (*openfhe.Ciphertext, error) {
+	if !e.manualRescale {
+		return ct, nil
+	}
+	res, err := e.cc.Rescale(ct)
+	ct.Close()
+	return res, err
+}
+
+// rotateSum returns ct + rot(ct, s) folded over every shift s in order.
+func (e *Evaluator[T]) rotateSum(ct *openfhe.Ciphertext, shifts []int32) (*openfhe.Ciphertext, error) {
+	cur := ct
+	owned := false
+	for _, s := range shifts {
+		rot, err := e.cc.EvalRotate(cur, s)
+		if err != nil {
+			if owned {
+				cur.Close()
+			}
+			return nil, err
+		}
+		next, err := e.cc.EvalAdd(cur, rot)
+		rot.Close()
+		if owned {
+			cur.Close()
+		}
+		if err != nil {
+			return nil, err
+		}
+		cur, owned = next, true
+	}
+	if !owned {
+		return nil, errors.New("matrix: nothing to sum")
+	}
+	return cur, nil
+}
+
+// maskBlock keeps the slots p of each period-n block with keep(p) and
+// zeroes the rest.
+func (e *Evaluator[T]) maskBlock(ct *openfhe.Ciphertext, n int, keep func(p int) bool) (*openfhe.Ciphertext, error) {
+	block := make([]T, n)
+	for p := range block {
+		if keep(p) {
+			block[p] = 1
+		}
+	}
+	level, _ := ct.GetLevel()
+	if level < 0 {
+		level = 0
+	}
+	res, err := e.multPlain(ct, e.replicate(block), level)
+	if err != nil {
+		return nil, err
+	}
+	return e.rescale(res)
+}
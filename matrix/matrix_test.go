@@ -0,0 +1,274 @@
+package matrix
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/dozyio/openfhe-go/internal/testutil"
+	"github.com/dozyio/openfhe-go/openfhe"
+)
+
+func matricesApproxEqual(a, b [][]float64, tolerance float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !testutil.ApproxEqual(a[i], b[i], tolerance) {
+			return false
+		}
+	}
+	return true
+}
+
+const testDim = 4
+
+func setupMatrixContext(t *testing.T) (*openfhe.CryptoContext, *openfhe.KeyPair, *Evaluator[float64]) {
+	t.Helper()
+
+	params, err := openfhe.NewParamsCKKSRNS()
+	testutil.MustT(t, err, "NewParamsCKKSRNS")
+	defer params.Close()
+	testutil.MustT(t, params.SetMultiplicativeDepth(4), "SetMultiplicativeDepth")
+	testutil.MustT(t, params.SetScalingModSize(50), "SetScalingModSize")
+	testutil.MustT(t, params.SetBatchSize(testDim*testDim), "SetBatchSize")
+	testutil.MustT(t, params.SetScalingTechnique(openfhe.FIXEDMANUAL), "SetScalingTechnique")
+
+	cc, err := openfhe.NewCryptoContextCKKS(params)
+	testutil.MustT(t, err, "NewCryptoContextCKKS")
+	testutil.MustT(t, cc.Enable(openfhe.PKE), "Enable PKE")
+	testutil.MustT(t, cc.Enable(openfhe.KEYSWITCH), "Enable KEYSWITCH")
+	testutil.MustT(t, cc.Enable(openfhe.LEVELEDSHE), "Enable LEVELEDSHE")
+
+	keys, err := cc.KeyGen()
+	testutil.MustT(t, err, "KeyGen")
+	testutil.MustT(t, cc.EvalMultKeyGen(keys), "EvalMultKeyGen")
+	testutil.MustT(t, cc.EvalRotateKeyGen(keys, Rotations(testDim)), "EvalRotateKeyGen")
+
+	e, err := NewEvaluator[float64](cc)
+	testutil.MustT(t, err, "NewEvaluator")
+	return cc, keys, e
+}
+
+func TestMatVec(t *testing.T) {
+	cc, keys, e := setupMatrixContext(t)
+	defer cc.Close()
+	defer keys.Close()
+
+	m := [][]float64{
+		{1, 2, 0},
+		{0, -1, 3},
+		{4, 0, 0.5},
+		{1, 1, 1},
+	}
+	v, err := e.EncryptVector(keys, []float64{1, 2, 3}, testDim)
+	testutil.MustT(t, err, "EncryptVector")
+	defer v.Close()
+
+	res, err := e.MatVec(m, v)
+	testutil.MustT(t, err, "MatVec")
+	defer res.Close()
+	if res.Len != 4 {
+		t.Fatalf("MatVec length = %d, expected 4", res.Len)
+	}
+
+	got, err := e.DecryptVector(keys, res)
+	testutil.MustT(t, err, "DecryptVector")
+	if want := []float64{5, 7, 5.5, 6}; !testutil.ApproxEqual(got, want, 1e-3) {
+		t.Errorf("MatVec = %v, expected %v", got, want)
+	}
+
+	if _, err := e.MatVec([][]float64{{1, 2}}, v); err == nil {
+		t.Error("expected error for column/length mismatch")
+	}
+}
+
+func TestMatMulAndTranspose(t *testing.T) {
+	cc, keys, e := setupMatrixContext(t)
+	defer cc.Close()
+	defer keys.Close()
+
+	a := [][]float64{
+		{1, 2, 3},
+		{0, 1, -1},
+	}
+	b := [][]float64{
+		{2, 0},
+		{1, 1},
+		{0, 0.5},
+	}
+	ca, err := e.EncryptMatrix(keys, a, testDim)
+	testutil.MustT(t, err, "EncryptMatrix a")
+	defer ca.Close()
+	cb, err := e.EncryptMatrix(keys, b, testDim)
+	testutil.MustT(t, err, "EncryptMatrix b")
+	defer cb.Close()
+
+	prod, err := e.MatMul(ca, cb)
+	testutil.MustT(t, err, "MatMul")
+	defer prod.Close()
+	got, err := e.DecryptMatrix(keys, prod)
+	testutil.MustT(t, err, "DecryptMatrix product")
+	want := [][]float64{
+		{4, 3.5},
+		{1, 0.5},
+	}
+	if !matricesApproxEqual(got, want, 1e-3) {
+		t.Errorf("MatMul = %v, expected %v", got, want)
+	}
+
+	tr, err := e.Transpose(ca)
+	testutil.MustT(t, err, "Transpose")
+	defer tr.Close()
+	got, err = e.DecryptMatrix(keys, tr)
+	testutil.MustT(t, err, "DecryptMatrix transpose")
+	want = [][]float64{
+		{1, 0},
+		{2, 1},
+		{3, -1},
+	}
+	if !matricesApproxEqual(got, want, 1e-3) {
+		t.Errorf("Transpose = %v, expected %v", got, want)
+	}
+
+	if _, err := e.MatMul(ca, ca); err == nil {
+		t.Error("expected error for incompatible shapes")
+	}
+}
+
+func TestRowColSums(t *testing.T) {
+	cc, keys, e := setupMatrixContext(t)
+	defer cc.Close()
+	defer keys.Close()
+
+	a := [][]float64{
+		{1, 2, 3, 4},
+		{5, 6, 7, 8},
+		{-1, 0, 1, 0.5},
+	}
+	ca, err := e.EncryptMatrix(keys, a, testDim)
+	testutil.MustT(t, err, "EncryptMatrix")
+	defer ca.Close()
+
+	rows, err := e.RowSums(ca)
+	testutil.MustT(t, err, "RowSums")
+	defer rows.Close()
+	got, err := e.DecryptMatrix(keys, rows)
+	testutil.MustT(t, err, "DecryptMatrix rows")
+	if want := [][]float64{{10}, {26}, {0.5}}; !matricesApproxEqual(got, want, 1e-3) {
+		t.Errorf("RowSums = %v, expected %v", got, want)
+	}
+
+	cols, err := e.ColSums(ca)
+	testutil.MustT(t, err, "ColSums")
+	defer cols.Close()
+	got, err = e.DecryptMatrix(keys, cols)
+	testutil.MustT(t, err, "DecryptMatrix cols")
+	if want := [][]float64{{5, 8, 11, 12.5}}; !matricesApproxEqual(got, want, 1e-3) {
+		t.Errorf("ColSums = %v, expected %v", got, want)
+	}
+}
+
+// TestIntegerBFV runs integer matrices on a BFV context whose full batch of
+// N slots spans both rotation rows, so only N/2 slots can be used.
+func TestIntegerBFV(t *testing.T) {
+	const ringDim = 64
+	params, err := openfhe.NewParamsBFVrns()
+	testutil.MustT(t, err, "NewParamsBFVrns")
+	defer params.Close()
+	testutil.MustT(t, params.SetPlaintextModulus(65537), "SetPlaintextModulus")
+	testutil.MustT(t, params.SetMultiplicativeDepth(3), "SetMultiplicativeDepth")
+	testutil.MustT(t, params.SetSecurityLevel(openfhe.HEStdNotSet), "SetSecurityLevel")
+	testutil.MustT(t, params.SetRingDim(ringDim), "SetRingDim")
+
+	cc, err := openfhe.NewCryptoContextBFV(params)
+	testutil.MustT(t, err, "NewCryptoContextBFV")
+	defer cc.Close()
+	testutil.MustT(t, cc.Enable(openfhe.PKE), "Enable PKE")
+	testutil.MustT(t, cc.Enable(openfhe.KEYSWITCH), "Enable KEYSWITCH")
+	testutil.MustT(t, cc.Enable(openfhe.LEVELEDSHE), "Enable LEVELEDSHE")
+	keys, err := cc.KeyGen()
+	testutil.MustT(t, err, "KeyGen")
+	defer keys.Close()
+	testutil.MustT(t, cc.EvalMultKeyGen(keys), "EvalMultKeyGen")
+	testutil.MustT(t, cc.EvalRotateKeyGen(keys, Rotations(testDim)), "EvalRotateKeyGen")
+
+	if got := openfhe.SlotCount(cc); got != ringDim/2 {
+		t.Fatalf("SlotCount = %d, expected %d", got, ringDim/2)
+	}
+	e, err := NewEvaluator[int64](cc)
+	testutil.MustT(t, err, "NewEvaluator")
+
+	a := [][]int64{
+		{1, 2, 3},
+		{0, 1, -1},
+		{4, 0, 2},
+	}
+	v, err := e.EncryptVector(keys, []int64{1, 2, 3}, testDim)
+	testutil.MustT(t, err, "EncryptVector")
+	defer v.Close()
+	mv, err := e.MatVec(a, v)
+	testutil.MustT(t, err, "MatVec")
+	defer mv.Close()
+	gotV, err := e.DecryptVector(keys, mv)
+	testutil.MustT(t, err, "DecryptVector")
+	if want := []int64{14, -1, 10}; !slices.Equal(gotV, want) {
+		t.Errorf("MatVec = %v, expected %v", gotV, want)
+	}
+
+	ca, err := e.EncryptMatrix(keys, a, testDim)
+	testutil.MustT(t, err, "EncryptMatrix")
+	defer ca.Close()
+	prod, err := e.MatMul(ca, ca)
+	testutil.MustT(t, err, "MatMul")
+	defer prod.Close()
+	got, err := e.DecryptMatrix(keys, prod)
+	testutil.MustT(t, err, "DecryptMatrix product")
+	want := [][]int64{
+		{13, 4, 7},
+		{-4, 1, -3},
+		{12, 8, 16},
+	}
+	if !slices.EqualFunc(got, want, slices.Equal[[]int64]) {
+		t.Errorf("MatMul = %v, expected %v", got, want)
+	}
+
+	rows, err := e.RowSums(ca)
+	testutil.MustT(t, err, "RowSums")
+	defer rows.Close()
+	got, err = e.DecryptMatrix(keys, rows)
+	testutil.MustT(t, err, "DecryptMatrix rows")
+	if want := [][]int64{{6}, {0}, {6}}; !slices.EqualFunc(got, want, slices.Equal[[]int64]) {
+		t.Errorf("RowSums = %v, expected %v", got, want)
+	}
+
+	// An 8×8 block fills the whole batch, which crosses the rotation rows.
+	if _, err := e.EncryptMatrix(keys, a, 2*testDim); err == nil {
+		t.Error("EncryptMatrix accepted a block spanning both rotation rows")
+	}
+}
+
+func TestRotationIndices(t *testing.T) {
+	// Dimension 16 uses baby steps 1..3 and giant steps 4, 8, 12.
+	got := MatVecRotations(16)
+	want := []int32{1, 2, 3, 4, 8, 12}
+	if len(got) != len(want) {
+		t.Fatalf("MatVecRotations(16) = %v, expected %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("MatVecRotations(16) = %v, expected %v", got, want)
+		}
+	}
+
+	// A diagonal matrix needs no rotations at all.
+	if ks := MatVecRotationsFor([][]float64{{1, 0}, {0, 2}}, 2); len(ks) != 0 {
+		t.Errorf("MatVecRotationsFor(diagonal) = %v, expected none", ks)
+	}
+
+	if got := RowSumRotations(8); len(got) != 3 || got[2] != 4 {
+		t.Errorf("RowSumRotations(8) = %v, expected [1 2 4]", got)
+	}
+	if got := ColSumRotations(4); len(got) != 2 || got[0] != 4 || got[1] != 8 {
+		t.Errorf("ColSumRotations(4) = %v, expected [4 8]", got)
+	}
+}
@@ -0,0 +1,187 @@
+package ml
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dozyio/openfhe-go/matrix"
+	"github.com/dozyio/openfhe-go/openfhe"
+)
+
+// Evaluator runs models in one CKKS CryptoContext, which must have the
+// ADVANCEDSHE feature enabled.
+type Evaluator struct {
+	cc            *openfhe.CryptoContext
+	mat           *matrix.Evaluator[float64]
+	slots         int
+	manualRescale bool
+}
+
+// NewEvaluator creates an Evaluator for cc. With FIXEDMANUAL scaling every
+// product is rescaled.
+func NewEvaluator(cc *openfhe.CryptoContext) (*Evaluator, error) {
+	mat, err := matrix.NewEvaluator[float64](cc)
+	if err != nil {
+		return nil, err
+	}
+	return &Evaluator{
+		cc:            cc,
+		mat:           mat,
+		slots:         openfhe.SlotCount(cc),
+		manualRescale: cc.GetScalingTechnique() == openfhe.FIXEDMANUAL,
+	}, nil
+}
+
+// KeyGen generates the multiplication, rotation and summation keys m
+// needs.
+func (e *Evaluator) KeyGen(keys *openfhe.KeyPair, m *Model) error {
+	if err := m.Validate(); err != nil {
+		return err
+	}
+	if err := e.cc.EvalMultKeyGen(keys); err != nil {
+		return err
+	}
+	if rots := m.Rotations(); len(rots) > 0 {
+		if err := e.cc.EvalRotateKeyGen(keys, rots); err != nil {
+			return err
+		}
+	}
+	for i := range m.Layers {
+		if m.Layers[i].outputs() == 1 {
+			return e.cc.EvalSumKeyGen(keys)
+		}
+	}
+	return nil
+}
+
+// EncryptFeatures encrypts one feature vector for m.
+func (e *Evaluator) EncryptFeatures(keys *openfhe.KeyPair, m *Model, features []float64) (*matrix.Vector, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	if len(features) != m.Inputs() {
+		return nil, fmt.Errorf("ml: %d features, model takes %d", len(features), m.Inputs())
+	}
+	return e.mat.EncryptVector(keys, features, m.Dim())
+}
+
+// DecryptOutput decrypts the output of Infer.
+func (e *Evaluator) DecryptOutput(keys *openfhe.KeyPair, y *matrix.Vector) ([]float64, error) {
+	return e.mat.DecryptVector(keys, y)
+}
+
+// Infer evaluates m on the encrypted features x. It consumes m.Depth()
+// levels.
+func (e *Evaluator) Infer(m *Model, x *matrix.Vector) (*matrix.Vector, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	if x == nil || x.Ct == nil {
+		return nil, errors.New("ml: Vector is closed or invalid")
+	}
+	if x.Len != m.Inputs() || x.Dim != m.Dim() {
+		return nil, fmt.Errorf("ml: features of length %d packed with period %d, model takes %d with period %d",
+			x.Len, x.Dim, m.Inputs(), m.Dim())
+	}
+
+	cur := x
+	for i := range m.Layers {
+		next, err := e.layer(&m.Layers[i], cur)
+		if cur != x {
+			cur.Close()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("ml: layer %d: %w", i, err)
+		}
+		cur = next
+	}
+	return cur, nil
+}
+
+func (e *Evaluator) layer(l *Layer, x *matrix.Vector) (*matrix.Vector, error) {
+	var y *matrix.Vector
+	if l.outputs() == 1 {
+		ct, err := e.dot(l.Weights[0], x)
+		if err != nil {
+			return nil, err
+		}
+		y = &matrix.Vector{Ct: ct, Len: 1, Dim: x.Dim}
+	} else {
+		var err error
+		if y, err = e.mat.MatVec(l.Weights, x); err != nil {
+			return nil, err
+		}
+	}
+
+	if l.Bias != nil {
+		ct, err := e.addPlain(y.Ct, l.Bias, y.Dim)
+		y.Close()
+		if err != nil {
+			return nil, err
+		}
+		y.Ct = ct
+	}
+
+	if a := l.Activation; a != nil {
+		var ct *openfhe.Ciphertext
+		var err error
+		if len(a.Poly) > 0 {
+			ct, err = e.cc.EvalPoly(y.Ct, a.Poly)
+		} else {
+			ct, err = e.cc.EvalChebyshevSeries(y.Ct, a.Chebyshev, a.Min, a.Max)
+		}
+		y.Close()
+		if err != nil {
+			return nil, err
+		}
+		y.Ct = ct
+	}
+	return y, nil
+}
+
+// dot returns w·x in every slot: the weights are multiplied in slot-wise
+// and EvalSum folds each period of the replicated product.
+func (e *Evaluator) dot(w []float64, x *matrix.Vector) (*openfhe.Ciphertext, error) {
+	pt, err := e.encode(w, x.Dim, x.Ct)
+	if err != nil {
+		return nil, err
+	}
+	defer pt.Close()
+	prod, err := e.cc.EvalMultPlain(x.Ct, pt)
+	if err != nil {
+		return nil, err
+	}
+	if e.manualRescale {
+		res, err := e.cc.Rescale(prod)
+		prod.Close()
+		if err != nil {
+			return nil, err
+		}
+		prod = res
+	}
+	defer prod.Close()
+	return e.cc.EvalSum(prod, uint32(x.Dim))
+}
+
+func (e *Evaluator) addPlain(ct *openfhe.Ciphertext, values []float64, dim int) (*openfhe.Ciphertext, error) {
+	pt, err := e.encode(values, dim, ct)
+	if err != nil {
+		return nil, err
+	}
+	defer pt.Close()
+	return e.cc.EvalAddPlain(ct, pt)
+}
+
+// encode packs values zero-padded to dim and replicated across the slots,
+// at the level of ct.
+func (e *Evaluator) encode(values []float64, dim int, ct *openfhe.Ciphertext) (*openfhe.Plaintext, error) {
+	out := make([]float64, e.slots)
+	for i := 0; i < len(out); i += dim {
+		copy(out[i:i+dim], values)
+	}
+	level, _ := ct.GetLevel()
+	if level < 0 {
+		level = 0
+	}
+	return e.cc.MakeCKKSPackedPlaintextWithParams(out, 1, uint32(level), 0)
+}
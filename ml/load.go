@@ -0,0 +1,121 @@
+package ml
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+)
+
+// The JSON weights format is either a list of layers,
+//
+//	{"layers": [
+//	  {"weights": [[0.5, -1], [2, 0.25]], "bias": [0, 1], "activation": {"poly": [0, 0, 1]}},
+//	  {"weights": [[1, -1]], "activation": {"function": "sigmoid", "min": -8, "max": 8, "degree": 13}}
+//	]}
+//
+// or, for linear and logistic regression, a single output:
+//
+//	{"weights": [0.5, -1, 2], "bias": 0.1, "activation": {"poly": [0.5, 0.15012, 0, -0.001593]}}
+//
+// An activation gives power-basis coefficients ("poly"), Chebyshev
+// coefficients with their interval ("chebyshev", "min", "max"), or a named
+// function approximated on an interval ("function", "min", "max",
+// "degree"). The functions are sigmoid and tanh.
+
+type jsonModel struct {
+	Layers     []jsonLayer     `json:"layers"`
+	Weights    []float64       `json:"weights"`
+	Bias       *float64        `json:"bias"`
+	Activation *jsonActivation `json:"activation"`
+}
+
+type jsonLayer struct {
+	Weights    [][]float64     `json:"weights"`
+	Bias       []float64       `json:"bias"`
+	Activation *jsonActivation `json:"activation"`
+}
+
+type jsonActivation struct {
+	Poly      []float64 `json:"poly"`
+	Chebyshev []float64 `json:"chebyshev"`
+	Function  string    `json:"function"`
+	Min       float64   `json:"min"`
+	Max       float64   `json:"max"`
+	Degree    int       `json:"degree"`
+}
+
+var functions = map[string]func(float64) float64{
+	"sigmoid": sigmoid,
+	"tanh":    math.Tanh,
+}
+
+// Load reads a model in the JSON weights format.
+func Load(r io.Reader) (*Model, error) {
+	var jm jsonModel
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&jm); err != nil {
+		return nil, fmt.Errorf("ml: %w", err)
+	}
+
+	m := &Model{}
+	switch {
+	case len(jm.Layers) > 0 && (jm.Weights != nil || jm.Bias != nil || jm.Activation != nil):
+		return nil, errors.New("ml: model has both layers and top-level weights")
+	case len(jm.Layers) > 0:
+		for i, jl := range jm.Layers {
+			act, err := jl.Activation.activation()
+			if err != nil {
+				return nil, fmt.Errorf("ml: layer %d: %w", i, err)
+			}
+			m.Layers = append(m.Layers, Layer{Weights: jl.Weights, Bias: jl.Bias, Activation: act})
+		}
+	default:
+		act, err := jm.Activation.activation()
+		if err != nil {
+			return nil, fmt.Errorf("ml: %w", err)
+		}
+		var bias float64
+		if jm.Bias != nil {
+			bias = *jm.Bias
+		}
+		m = NewLogisticRegression(jm.Weights, bias, act)
+	}
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// LoadFile reads a model from a JSON weights file.
+func LoadFile(path string) (*Model, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Load(f)
+}
+
+func (ja *jsonActivation) activation() (*Activation, error) {
+	switch {
+	case ja == nil:
+		return nil, nil
+	case ja.Function != "":
+		if ja.Poly != nil || ja.Chebyshev != nil {
+			return nil, errors.New("activation has both a function and coefficients")
+		}
+		fn, ok := functions[ja.Function]
+		if !ok {
+			return nil, fmt.Errorf("unknown activation function %q", ja.Function)
+		}
+		if ja.Degree < 1 || !(ja.Min < ja.Max) {
+			return nil, fmt.Errorf("activation %q needs a degree and an interval", ja.Function)
+		}
+		return ChebyshevActivation(fn, ja.Min, ja.Max, ja.Degree), nil
+	}
+	return &Activation{Poly: ja.Poly, Chebyshev: ja.Chebyshev, Min: ja.Min, Max: ja.Max}, nil
+}
@@ -0,0 +1,142 @@
+package ml
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dozyio/openfhe-go/internal/testutil"
+	"github.com/dozyio/openfhe-go/openfhe"
+)
+
+// testNetwork is a 4-3-1 network with a square activation on the hidden
+// layer and a Chebyshev sigmoid on the output: 1 + 2 + 1 + 5 = 9 levels.
+const testNetwork = `{"layers": [
+  {"weights": [[0.5, -0.25, 0.1, 0], [0.2, 0.3, -0.4, 0.6], [-0.3, 0.1, 0.2, 0.5]],
+   "bias": [0.1, -0.2, 0],
+   "activation": {"poly": [0, 0, 1]}},
+  {"weights": [[1.5, -2, 1]], "bias": [0.25],
+   "activation": {"function": "sigmoid", "min": -8, "max": 8, "degree": 13}}
+]}`
+
+func setupMLContext(t *testing.T, depth int) (*openfhe.CryptoContext, *openfhe.KeyPair, *Evaluator) {
+	t.Helper()
+
+	params, err := openfhe.NewParamsCKKSRNS()
+	testutil.MustT(t, err, "NewParamsCKKSRNS")
+	defer params.Close()
+	testutil.MustT(t, params.SetMultiplicativeDepth(depth), "SetMultiplicativeDepth")
+	testutil.MustT(t, params.SetScalingModSize(50), "SetScalingModSize")
+	testutil.MustT(t, params.SetBatchSize(16), "SetBatchSize")
+	testutil.MustT(t, params.SetScalingTechnique(openfhe.FIXEDMANUAL), "SetScalingTechnique")
+
+	cc, err := openfhe.NewCryptoContextCKKS(params)
+	testutil.MustT(t, err, "NewCryptoContextCKKS")
+	for _, f := range []int{openfhe.PKE, openfhe.KEYSWITCH, openfhe.LEVELEDSHE, openfhe.ADVANCEDSHE} {
+		testutil.MustT(t, cc.Enable(f), "Enable")
+	}
+	keys, err := cc.KeyGen()
+	testutil.MustT(t, err, "KeyGen")
+
+	e, err := NewEvaluator(cc)
+	testutil.MustT(t, err, "NewEvaluator")
+	return cc, keys, e
+}
+
+func infer(t *testing.T, e *Evaluator, keys *openfhe.KeyPair, m *Model, features []float64) []float64 {
+	t.Helper()
+	x, err := e.EncryptFeatures(keys, m, features)
+	testutil.MustT(t, err, "EncryptFeatures")
+	defer x.Close()
+	y, err := e.Infer(m, x)
+	testutil.MustT(t, err, "Infer")
+	defer y.Close()
+	out, err := e.DecryptOutput(keys, y)
+	testutil.MustT(t, err, "DecryptOutput")
+	return out
+}
+
+func TestRegression(t *testing.T) {
+	cc, keys, e := setupMLContext(t, 4)
+	defer cc.Close()
+	defer keys.Close()
+
+	w := []float64{0.5, -1.25, 2, 0.75, -0.1}
+	features := []float64{1, 0.5, -0.25, 2, 3}
+	linear := NewLinearRegression(w, 0.3)
+	logistic := NewLogisticRegression(w, 0.3, SigmoidPoly3)
+	testutil.MustT(t, e.KeyGen(keys, logistic), "KeyGen")
+
+	for name, m := range map[string]*Model{"linear": linear, "logistic": logistic} {
+		want, err := m.Predict(features)
+		testutil.MustT(t, err, "Predict")
+		if got := infer(t, e, keys, m, features); !testutil.ApproxEqual(got, want, 1e-3) {
+			t.Errorf("%s regression = %v, expected %v", name, got, want)
+		}
+	}
+}
+
+func TestNetwork(t *testing.T) {
+	m, err := Load(strings.NewReader(testNetwork))
+	testutil.MustT(t, err, "Load")
+	if d := m.Depth(); d != 9 {
+		t.Fatalf("Depth = %d, expected 9", d)
+	}
+
+	cc, keys, e := setupMLContext(t, m.Depth())
+	defer cc.Close()
+	defer keys.Close()
+	testutil.MustT(t, e.KeyGen(keys, m), "KeyGen")
+
+	features := []float64{1, -0.5, 2, 0.25}
+	want, err := m.Predict(features)
+	testutil.MustT(t, err, "Predict")
+	if got := infer(t, e, keys, m, features); !testutil.ApproxEqual(got, want, 1e-3) {
+		t.Errorf("network = %v, expected %v", got, want)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "model.json")
+	testutil.MustT(t, os.WriteFile(path, []byte(`{"weights": [1, 2, 3], "bias": -1,
+		"activation": {"chebyshev": [1, 0.5], "min": -4, "max": 4}}`), 0o600), "WriteFile")
+	m, err := LoadFile(path)
+	testutil.MustT(t, err, "LoadFile")
+	if m.Inputs() != 3 || m.Outputs() != 1 || m.Dim() != 4 || m.Depth() != 3 {
+		t.Errorf("loaded %d→%d model with period %d and depth %d, expected 3→1, 4 and 3",
+			m.Inputs(), m.Outputs(), m.Dim(), m.Depth())
+	}
+	// 1/2 + 0.5·T1(y) with y = x/4 and x = 1 + 2 + 3 - 1.
+	if got, err := m.Predict([]float64{1, 1, 1}); err != nil || math.Abs(got[0]-(0.5+0.5*5.0/4)) > 1e-12 {
+		t.Errorf("Predict = %v, %v, expected [1.125]", got, err)
+	}
+
+	net, err := Load(strings.NewReader(testNetwork))
+	testutil.MustT(t, err, "Load")
+	if rots := net.Rotations(); len(rots) == 0 {
+		t.Error("Rotations is empty for a model with a dense hidden layer")
+	}
+	// With zero features the hidden layer is the squared bias (0.01, 0.04,
+	// 0), so the output is sigmoid(1.5·0.01 − 2·0.04 + 0.25).
+	want := 1 / (1 + math.Exp(-0.185))
+	if got, err := net.Predict([]float64{0, 0, 0, 0}); err != nil || math.Abs(got[0]-want) > 1e-3 {
+		t.Errorf("Predict = %v, %v, expected [%v]", got, err, want)
+	}
+
+	for _, bad := range []string{
+		`{"layers": [{"weights": [[1, 2]]}, {"weights": [[1, 2, 3]]}]}`,
+		`{"weights": [1], "activation": {"function": "relu", "min": -1, "max": 1, "degree": 3}}`,
+		`{"weights": [1], "activation": {"chebyshev": [1], "min": 1, "max": 1}}`,
+		`{"weights": [1], "activation": {"poly": [1], "chebyshev": [1], "min": 0, "max": 1}}`,
+		`{"layers": [{"weights": [[1]], "bias": [1, 2]}]}`,
+		`{"layers": [{"weights": [[1]]}], "weights": [1]}`,
+		`{"weights": [1], "scale": 2}`,
+		`{}`,
+	} {
+		if _, err := Load(strings.NewReader(bad)); err == nil {
+			t.Errorf("Load accepted %s", bad)
+		}
+	}
+}
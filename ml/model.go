@@ -0,0 +1,258 @@
+// Package ml evaluates plaintext models on encrypted CKKS feature vectors:
+// linear regression scores, logistic regression with a polynomial or
+// Chebyshev sigmoid, and small feed-forward networks of dense layers with
+// polynomial activations.
+//
+// A Model is a sequence of dense layers y = act(W·x + b). Features are
+// packed with the matrix package, replicated with period Model.Dim. A
+// layer with one output is an inner product (EvalMultPlain and EvalSum);
+// wider layers use the baby-step/giant-step diagonal method of
+// matrix.MatVec. Each layer consumes one level plus the depth of its
+// activation: ceil(log2(d+1)) for a degree-d polynomial and
+// openfhe.ChebyshevDepth(d) for a Chebyshev series.
+//
+//	m, err := ml.LoadFile("model.json")
+//	e, err := ml.NewEvaluator(cc)
+//	err = e.KeyGen(keys, m)
+//	x, err := e.EncryptFeatures(keys, m, features)
+//	y, err := e.Infer(m, x)
+//	scores, err := e.DecryptOutput(keys, y)
+//
+// Trainer fits logistic regression weights to an encrypted training set
+// by gradient descent, bootstrapping the weights between iterations:
+//
+//	tr, err := ml.NewTrainer(cc)
+//	data, err := tr.EncryptDataset(keys, x, y, ml.RowWise)
+//	err = tr.KeyGen(keys, data)
+//	w, err := tr.Train(keys, data, &ml.TrainOptions{Iterations: 10, Bootstrap: true})
+//	weights, err := tr.DecryptWeights(keys, w)
+package ml
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/dozyio/openfhe-go/matrix"
+	"github.com/dozyio/openfhe-go/openfhe"
+)
+
+// Activation is a polynomial applied to every slot. Exactly one of Poly
+// and Chebyshev is set.
+type Activation struct {
+	// Poly holds power-basis coefficients, lowest degree first, for
+	// EvalPoly.
+	Poly []float64
+	// Chebyshev holds the coefficients of a Chebyshev series on
+	// [Min, Max], as returned by openfhe.ChebyshevCoefficients, for
+	// EvalChebyshevSeries.
+	Chebyshev []float64
+	Min, Max  float64
+}
+
+// SigmoidPoly3 is the least-squares cubic approximation of the sigmoid on
+// [-8, 8] used by Kim et al. (2018) for logistic regression.
+var SigmoidPoly3 = &Activation{Poly: []float64{0.5, 0.15012, 0, -0.001593}}
+
+// Sigmoid approximates 1/(1+e^-x) on [lo, hi] with a Chebyshev series of
+// the given degree.
+func Sigmoid(lo, hi float64, degree int) *Activation {
+	return ChebyshevActivation(sigmoid, lo, hi, degree)
+}
+
+// ChebyshevActivation approximates fn on [lo, hi] with a Chebyshev series
+// of the given degree.
+func ChebyshevActivation(fn func(float64) float64, lo, hi float64, degree int) *Activation {
+	return &Activation{
+		Chebyshev: openfhe.ChebyshevCoefficients(fn, lo, hi, degree),
+		Min:       lo,
+		Max:       hi,
+	}
+}
+
+func sigmoid(x float64) float64 { return 1 / (1 + math.Exp(-x)) }
+
+// Depth returns the number of levels the activation consumes.
+func (a *Activation) Depth() int {
+	switch {
+	case a == nil:
+		return 0
+	case len(a.Poly) > 0:
+		return openfhe.PolyDepth(len(a.Poly) - 1)
+	default:
+		return openfhe.ChebyshevDepth(len(a.Chebyshev) - 1)
+	}
+}
+
+func (a *Activation) check() error {
+	if a == nil {
+		return nil
+	}
+	switch {
+	case len(a.Poly) > 0 && len(a.Chebyshev) > 0:
+		return errors.New("activation has both polynomial and Chebyshev coefficients")
+	case len(a.Poly) > 0:
+		return nil
+	case len(a.Chebyshev) == 0:
+		return errors.New("activation has no coefficients")
+	case !(a.Min < a.Max):
+		return fmt.Errorf("Chebyshev interval [%g, %g] is empty", a.Min, a.Max)
+	case a.Depth() < 0:
+		return fmt.Errorf("Chebyshev degree %d is not supported", len(a.Chebyshev)-1)
+	}
+	return nil
+}
+
+// eval applies a to x in the clear.
+func (a *Activation) eval(x float64) float64 {
+	if len(a.Poly) > 0 {
+		var acc float64
+		for k := len(a.Poly) - 1; k >= 0; k-- {
+			acc = acc*x + a.Poly[k]
+		}
+		return acc
+	}
+	y := (2*x - a.Min - a.Max) / (a.Max - a.Min)
+	var b1, b2 float64
+	for k := len(a.Chebyshev) - 1; k >= 1; k-- {
+		b1, b2 = 2*y*b1-b2+a.Chebyshev[k], b1
+	}
+	return y*b1 - b2 + a.Chebyshev[0]/2
+}
+
+// Layer is a dense layer y = Activation(Weights·x + Bias).
+type Layer struct {
+	Weights    [][]float64 // outputs × inputs
+	Bias       []float64   // one per output; nil means zero
+	Activation *Activation // nil means the identity
+}
+
+func (l *Layer) inputs() int  { return len(l.Weights[0]) }
+func (l *Layer) outputs() int { return len(l.Weights) }
+
+// Model is a feed-forward network of dense layers.
+type Model struct {
+	Layers []Layer
+}
+
+// NewLinearRegression returns the model w·x + bias.
+func NewLinearRegression(weights []float64, bias float64) *Model {
+	return &Model{Layers: []Layer{{
+		Weights: [][]float64{weights},
+		Bias:    []float64{bias},
+	}}}
+}
+
+// NewLogisticRegression returns the model sigmoid(w·x + bias) with the
+// given approximation of the sigmoid, such as SigmoidPoly3 or Sigmoid.
+func NewLogisticRegression(weights []float64, bias float64, sigmoid *Activation) *Model {
+	m := NewLinearRegression(weights, bias)
+	m.Layers[0].Activation = sigmoid
+	return m
+}
+
+// Validate checks that the layers fit together.
+func (m *Model) Validate() error {
+	if m == nil || len(m.Layers) == 0 {
+		return errors.New("ml: model has no layers")
+	}
+	for i := range m.Layers {
+		l := &m.Layers[i]
+		if len(l.Weights) == 0 || len(l.Weights[0]) == 0 {
+			return fmt.Errorf("ml: layer %d has no weights", i)
+		}
+		for j, row := range l.Weights {
+			if len(row) != l.inputs() {
+				return fmt.Errorf("ml: layer %d row %d has %d weights, expected %d", i, j, len(row), l.inputs())
+			}
+		}
+		if l.Bias != nil && len(l.Bias) != l.outputs() {
+			return fmt.Errorf("ml: layer %d has %d biases for %d outputs", i, len(l.Bias), l.outputs())
+		}
+		if i > 0 && l.inputs() != m.Layers[i-1].outputs() {
+			return fmt.Errorf("ml: layer %d takes %d inputs but layer %d has %d outputs",
+				i, l.inputs(), i-1, m.Layers[i-1].outputs())
+		}
+		if err := l.Activation.check(); err != nil {
+			return fmt.Errorf("ml: layer %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Inputs returns the number of features the model takes.
+func (m *Model) Inputs() int { return m.Layers[0].inputs() }
+
+// Outputs returns the number of values the model produces.
+func (m *Model) Outputs() int { return m.Layers[len(m.Layers)-1].outputs() }
+
+// Dim returns the packing period: the smallest power of two that holds the
+// inputs and outputs of every layer.
+func (m *Model) Dim() int {
+	n := 1
+	for i := range m.Layers {
+		for n < max(m.Layers[i].inputs(), m.Layers[i].outputs()) {
+			n <<= 1
+		}
+	}
+	return n
+}
+
+// Depth returns the number of levels Infer consumes.
+func (m *Model) Depth() int {
+	d := 0
+	for i := range m.Layers {
+		d += 1 + m.Layers[i].Activation.Depth()
+	}
+	return d
+}
+
+// Rotations returns the rotation indices the dense layers with more than
+// one output need. Single-output layers use the EvalSum keys instead.
+func (m *Model) Rotations() []int32 {
+	dim := m.Dim()
+	set := make(map[int32]bool)
+	var out []int32
+	for i := range m.Layers {
+		if m.Layers[i].outputs() == 1 {
+			continue
+		}
+		for _, k := range matrix.MatVecRotationsFor(m.Layers[i].Weights, dim) {
+			if !set[k] {
+				set[k] = true
+				out = append(out, k)
+			}
+		}
+	}
+	slices.Sort(out)
+	return out
+}
+
+// Predict evaluates the model in the clear, with the same activation
+// approximations Infer uses.
+func (m *Model) Predict(x []float64) ([]float64, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	if len(x) != m.Inputs() {
+		return nil, fmt.Errorf("ml: %d features, model takes %d", len(x), m.Inputs())
+	}
+	for i := range m.Layers {
+		l := &m.Layers[i]
+		y := make([]float64, l.outputs())
+		for j, row := range l.Weights {
+			for k, w := range row {
+				y[j] += w * x[k]
+			}
+			if l.Bias != nil {
+				y[j] += l.Bias[j]
+			}
+			if l.Activation != nil {
+				y[j] = l.Activation.eval(y[j])
+			}
+		}
+		x = y
+	}
+	return x, nil
+}
@@ -0,0 +1,619 @@
+package ml
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/dozyio/openfhe-go/openfhe"
+)
+
+// --- Training ---
+//
+// Trainer fits logistic regression weights to an encrypted training set by
+// full-batch gradient descent,
+//
+//	w ← w − (η/n) Σᵢ (σ(w·xᵢ) − yᵢ) xᵢ,
+//
+// with σ replaced by a polynomial (SigmoidPolynomial) evaluated with
+// EvalPoly. The weights stay encrypted throughout; when Bootstrap is set
+// they are refreshed with EvalBootstrap whenever they have fewer levels
+// left than an iteration consumes (IterationDepth). There is no separate
+// intercept: add a constant feature of 1 to learn one.
+
+// Packing selects how a Dataset lays out the training set.
+type Packing int
+
+const (
+	// RowWise packs the n×d design matrix into one ciphertext, one sample
+	// per block of d slots, with n and d padded to powers of two. Scoring
+	// the samples takes rotations within each block, but an iteration
+	// bootstraps a single ciphertext.
+	RowWise Packing = iota
+	// ColumnWise packs each feature into its own ciphertext, one sample
+	// per slot. Scoring takes no rotations and one level less, but an
+	// iteration bootstraps one ciphertext per feature.
+	ColumnWise
+)
+
+func (p Packing) String() string {
+	switch p {
+	case RowWise:
+		return "row-wise"
+	case ColumnWise:
+		return "column-wise"
+	}
+	return fmt.Sprintf("Packing(%d)", int(p))
+}
+
+// IterationDepth returns the number of levels one gradient step consumes
+// with a sigmoid polynomial of the given degree.
+func IterationDepth(p Packing, degree int) int {
+	d := openfhe.PolyDepth(degree) + 2 // score, σ, gradient
+	if p == RowWise {
+		d++ // isolating each row's score
+	}
+	return d
+}
+
+// SigmoidPolynomial returns the degree-d Chebyshev interpolant of the
+// sigmoid on [-bound, bound] in the power basis, for EvalPoly.
+func SigmoidPolynomial(bound float64, degree int) *Activation {
+	cheb := openfhe.ChebyshevCoefficients(sigmoid, -bound, bound, degree)
+	cheb[0] /= 2
+
+	// T_k(y) in the power basis, for y = x/bound.
+	poly := make([]float64, degree+1)
+	prev, cur := []float64{1}, []float64{0, 1}
+	for k, c := range cheb {
+		t := prev
+		if k > 0 {
+			t = cur
+		}
+		for i, v := range t {
+			poly[i] += c * v
+		}
+		if k > 0 {
+			next := make([]float64, k+2)
+			for i, v := range cur {
+				next[i+1] += 2 * v
+			}
+			for i, v := range prev {
+				next[i] -= v
+			}
+			prev, cur = cur, next
+		}
+	}
+	for i := range poly {
+		poly[i] /= math.Pow(bound, float64(i))
+	}
+	return &Activation{Poly: poly}
+}
+
+// TrainOptions configures Train. A nil *TrainOptions uses the defaults.
+type TrainOptions struct {
+	// LearningRate is the step size η; 0 means 1.
+	LearningRate float64
+	// Iterations is the number of gradient steps; 0 means 1.
+	Iterations int
+	// Degree is the degree of the sigmoid polynomial; 0 means 3.
+	Degree int
+	// Bound is the half-width of the interval the polynomial fits; 0
+	// means 8. Scores outside it are approximated poorly.
+	Bound float64
+	// Bootstrap refreshes the weights with EvalBootstrap when they run out
+	// of levels. Bootstrapping must have been set up, and its keys
+	// generated, for the full slot count; the weights must stay within
+	// the message range it supports.
+	Bootstrap bool
+}
+
+func (o *TrainOptions) resolve() TrainOptions {
+	var opts TrainOptions
+	if o != nil {
+		opts = *o
+	}
+	if opts.LearningRate == 0 {
+		opts.LearningRate = 1
+	}
+	if opts.Iterations == 0 {
+		opts.Iterations = 1
+	}
+	if opts.Degree == 0 {
+		opts.Degree = 3
+	}
+	if opts.Bound == 0 {
+		opts.Bound = 8
+	}
+	return opts
+}
+
+// Dataset is an encrypted training set with labels in {0, 1}.
+type Dataset struct {
+	Packing  Packing
+	Samples  int
+	Features int
+	X        []*openfhe.Ciphertext // one, or one per feature
+	Y        *openfhe.Ciphertext
+}
+
+// Close frees the ciphertexts.
+func (d *Dataset) Close() {
+	for _, ct := range d.X {
+		ct.Close()
+	}
+	d.X = nil
+	if d.Y != nil {
+		d.Y.Close()
+		d.Y = nil
+	}
+}
+
+// rows and cols are the padded sample and feature counts; ColumnWise
+// packs one feature per ciphertext.
+func (d *Dataset) rows() int { return nextPow2(d.Samples) }
+
+func (d *Dataset) cols() int {
+	if d.Packing == ColumnWise {
+		return 1
+	}
+	return nextPow2(d.Features)
+}
+
+// Rotations returns the rotation indices Train needs for d.
+func (d *Dataset) Rotations() []int32 {
+	var out []int32
+	cols := d.cols()
+	for s := 1; s < cols; s <<= 1 {
+		out = append(out, int32(s), int32(-s))
+	}
+	for s := cols; s < cols*d.rows(); s <<= 1 {
+		out = append(out, int32(s))
+	}
+	return out
+}
+
+// Weights are encrypted logistic regression weights.
+type Weights struct {
+	Packing  Packing
+	Features int
+	Cts      []*openfhe.Ciphertext // one, or one per feature
+	// Bootstraps is the number of ciphertexts Train refreshed.
+	Bootstraps int
+}
+
+// Close frees the ciphertexts.
+func (w *Weights) Close() {
+	for _, ct := range w.Cts {
+		ct.Close()
+	}
+	w.Cts = nil
+}
+
+// Trainer trains models in one CKKS CryptoContext, which must have the
+// ADVANCEDSHE feature enabled, and FHE to bootstrap.
+type Trainer struct {
+	cc            *openfhe.CryptoContext
+	slots         int
+	manualRescale bool
+}
+
+// NewTrainer creates a Trainer for cc. With FIXEDMANUAL scaling every
+// product is rescaled.
+func NewTrainer(cc *openfhe.CryptoContext) (*Trainer, error) {
+	if cc == nil {
+		return nil, errors.New("CryptoContext is nil")
+	}
+	if cc.GetRingDimension() == 0 {
+		return nil, errors.New("CryptoContext is closed or invalid")
+	}
+	return &Trainer{
+		cc:            cc,
+		slots:         openfhe.SlotCount(cc),
+		manualRescale: cc.GetScalingTechnique() == openfhe.FIXEDMANUAL,
+	}, nil
+}
+
+// EncryptDataset encrypts the n×d samples x and their labels y with the
+// given packing. Both layouts are replicated across the slots.
+func (tr *Trainer) EncryptDataset(keys *openfhe.KeyPair, x [][]float64, y []float64, packing Packing) (*Dataset, error) {
+	if len(x) == 0 || len(x[0]) == 0 {
+		return nil, errors.New("ml: empty training set")
+	}
+	if len(y) != len(x) {
+		return nil, fmt.Errorf("ml: %d labels for %d samples", len(y), len(x))
+	}
+	for i, row := range x {
+		if len(row) != len(x[0]) {
+			return nil, fmt.Errorf("ml: sample %d has %d features, expected %d", i, len(row), len(x[0]))
+		}
+	}
+	if packing != RowWise && packing != ColumnWise {
+		return nil, fmt.Errorf("ml: unknown packing %v", packing)
+	}
+	d := &Dataset{Packing: packing, Samples: len(x), Features: len(x[0])}
+	rows, cols := d.rows(), d.cols()
+	if rows*cols > tr.slots {
+		return nil, fmt.Errorf("ml: %v packing of %d×%d samples needs %d slots, have %d",
+			packing, d.Samples, d.Features, rows*cols, tr.slots)
+	}
+
+	block := make([]float64, rows*cols)
+	encrypt := func() (*openfhe.Ciphertext, error) {
+		pt, err := tr.cc.MakeCKKSPackedPlaintext(tr.replicate(block))
+		if err != nil {
+			return nil, err
+		}
+		defer pt.Close()
+		return tr.cc.Encrypt(keys, pt)
+	}
+	fail := func(err error) (*Dataset, error) {
+		d.Close()
+		return nil, err
+	}
+
+	if packing == RowWise {
+		for i, row := range x {
+			copy(block[i*cols:], row)
+		}
+		ct, err := encrypt()
+		if err != nil {
+			return fail(err)
+		}
+		d.X = append(d.X, ct)
+	} else {
+		for j := 0; j < d.Features; j++ {
+			for i, row := range x {
+				block[i] = row[j]
+			}
+			ct, err := encrypt()
+			if err != nil {
+				return fail(err)
+			}
+			d.X = append(d.X, ct)
+		}
+	}
+
+	clear(block)
+	for i, label := range y {
+		for j := 0; j < cols; j++ {
+			block[i*cols+j] = label
+		}
+	}
+	var err error
+	if d.Y, err = encrypt(); err != nil {
+		return fail(err)
+	}
+	return d, nil
+}
+
+// KeyGen generates the multiplication and rotation keys Train needs for d.
+// Bootstrapping keys are generated separately with EvalBootstrapKeyGen.
+func (tr *Trainer) KeyGen(keys *openfhe.KeyPair, d *Dataset) error {
+	if err := tr.cc.EvalMultKeyGen(keys); err != nil {
+		return err
+	}
+	if rots := d.Rotations(); len(rots) > 0 {
+		return tr.cc.EvalRotateKeyGen(keys, rots)
+	}
+	return nil
+}
+
+// DecryptWeights decrypts w.
+func (tr *Trainer) DecryptWeights(keys *openfhe.KeyPair, w *Weights) ([]float64, error) {
+	out := make([]float64, 0, w.Features)
+	for _, ct := range w.Cts {
+		pt, err := tr.cc.Decrypt(keys, ct)
+		if err != nil {
+			return nil, err
+		}
+		vals, err := pt.GetRealPackedValue()
+		pt.Close()
+		if err != nil {
+			return nil, err
+		}
+		n := 1
+		if w.Packing == RowWise {
+			n = w.Features
+		}
+		if len(vals) < n {
+			return nil, fmt.Errorf("ml: decrypted %d slots, expected at least %d", len(vals), n)
+		}
+		out = append(out, vals[:n]...)
+	}
+	return out, nil
+}
+
+// Train runs gradient descent on d from zero weights, which it encrypts
+// under keys.
+func (tr *Trainer) Train(keys *openfhe.KeyPair, d *Dataset, opts *TrainOptions) (*Weights, error) {
+	o := opts.resolve()
+	if o.Iterations < 0 || o.Degree < 1 || o.Bound <= 0 {
+		return nil, fmt.Errorf("ml: invalid training options %+v", o)
+	}
+	if d == nil || d.Y == nil || len(d.X) == 0 {
+		return nil, errors.New("ml: Dataset is closed or invalid")
+	}
+
+	// Fold η/n into σ and y so that the gradient needs no extra level.
+	step := o.LearningRate / float64(d.Samples)
+	sig := SigmoidPolynomial(o.Bound, o.Degree).Poly
+	for i := range sig {
+		sig[i] *= step
+	}
+	y, err := tr.multConst(d.Y, step)
+	if err != nil {
+		return nil, err
+	}
+	defer y.Close()
+
+	w := &Weights{Packing: d.Packing, Features: d.Features}
+	zeros := make([]float64, tr.slots)
+	for range d.X {
+		pt, err := tr.cc.MakeCKKSPackedPlaintext(zeros)
+		if err != nil {
+			w.Close()
+			return nil, err
+		}
+		ct, err := tr.cc.Encrypt(keys, pt)
+		pt.Close()
+		if err != nil {
+			w.Close()
+			return nil, err
+		}
+		w.Cts = append(w.Cts, ct)
+	}
+
+	need := IterationDepth(d.Packing, o.Degree)
+	for it := 0; it < o.Iterations; it++ {
+		if err := tr.refresh(w, need, o.Bootstrap); err != nil {
+			w.Close()
+			return nil, fmt.Errorf("ml: iteration %d: %w", it, err)
+		}
+		var next []*openfhe.Ciphertext
+		if d.Packing == RowWise {
+			var ct *openfhe.Ciphertext
+			ct, err = tr.stepRows(d, w.Cts[0], y, sig)
+			next = []*openfhe.Ciphertext{ct}
+		} else {
+			next, err = tr.stepColumns(d, w.Cts, y, sig)
+		}
+		if err != nil {
+			w.Close()
+			return nil, fmt.Errorf("ml: iteration %d: %w", it, err)
+		}
+		w.Close()
+		w.Cts = next
+	}
+	return w, nil
+}
+
+// refresh bootstraps the weights that have fewer than need levels left.
+func (tr *Trainer) refresh(w *Weights, need int, bootstrap bool) error {
+	depth := tr.cc.GetMultiplicativeDepth()
+	for i, ct := range w.Cts {
+		left, err := levelsLeft(ct, depth)
+		if err != nil {
+			return err
+		}
+		if left >= need {
+			continue
+		}
+		if !bootstrap {
+			return fmt.Errorf("needs %d levels but the weights have %d left; enable Bootstrap", need, left)
+		}
+		b, err := tr.cc.EvalBootstrap(ct)
+		if err != nil {
+			return err
+		}
+		ct.Close()
+		w.Cts[i] = b
+		w.Bootstraps++
+		if left, err = levelsLeft(b, depth); err != nil {
+			return err
+		}
+		if left < need {
+			return fmt.Errorf("needs %d levels but bootstrapping left %d", need, left)
+		}
+	}
+	return nil
+}
+
+func levelsLeft(ct *openfhe.Ciphertext, depth int) (int, error) {
+	level, ok := ct.GetLevel()
+	deg, degOK := ct.GetNoiseScaleDeg()
+	if !ok || !degOK {
+		return 0, errors.New("Input Ciphertext is closed or invalid")
+	}
+	return depth - level - (deg - 1), nil
+}
+
+// stepRows is one gradient step with RowWise packing.
+func (tr *Trainer) stepRows(d *Dataset, w, y *openfhe.Ciphertext, sig []float64) (*openfhe.Ciphertext, error) {
+	rows, cols := d.rows(), d.cols()
+	var fold, spread, across []int32
+	for s := 1; s < cols; s <<= 1 {
+		fold = append(fold, int32(s))
+		spread = append(spread, int32(-s))
+	}
+	for s := cols; s < rows*cols; s <<= 1 {
+		across = append(across, int32(s))
+	}
+
+	// Each row's score, summed into its first slot and copied back across
+	// the row.
+	p, err := tr.mult(w, d.X[0])
+	if err != nil {
+		return nil, err
+	}
+	if p, err = tr.rotateSum(p, fold); err != nil {
+		return nil, err
+	}
+	mask := make([]float64, cols)
+	mask[0] = 1
+	if p, err = tr.multPlain(p, tr.replicate(mask)); err != nil {
+		return nil, err
+	}
+	if p, err = tr.rotateSum(p, spread); err != nil {
+		return nil, err
+	}
+
+	e, err := tr.residual(p, y, sig)
+	if err != nil {
+		return nil, err
+	}
+	defer e.Close()
+	g, err := tr.mult(e, d.X[0])
+	if err != nil {
+		return nil, err
+	}
+	if g, err = tr.rotateSum(g, across); err != nil {
+		return nil, err
+	}
+	defer g.Close()
+	return tr.cc.EvalSub(w, g)
+}
+
+// stepColumns is one gradient step with ColumnWise packing.
+func (tr *Trainer) stepColumns(d *Dataset, w []*openfhe.Ciphertext, y *openfhe.Ciphertext, sig []float64) ([]*openfhe.Ciphertext, error) {
+	var across []int32
+	for s := 1; s < d.rows(); s <<= 1 {
+		across = append(across, int32(s))
+	}
+
+	score, err := tr.mult(w[0], d.X[0])
+	if err != nil {
+		return nil, err
+	}
+	for j := 1; j < len(d.X); j++ {
+		p, err := tr.mult(w[j], d.X[j])
+		if err == nil {
+			var sum *openfhe.Ciphertext
+			sum, err = tr.cc.EvalAdd(score, p)
+			p.Close()
+			score.Close()
+			score = sum
+		}
+		if err != nil {
+			if score != nil {
+				score.Close()
+			}
+			return nil, err
+		}
+	}
+
+	e, err := tr.residual(score, y, sig)
+	if err != nil {
+		return nil, err
+	}
+	defer e.Close()
+	next := make([]*openfhe.Ciphertext, 0, len(w))
+	for j, x := range d.X {
+		g, err := tr.mult(e, x)
+		if err == nil {
+			g, err = tr.rotateSum(g, across)
+		}
+		if err == nil {
+			var wj *openfhe.Ciphertext
+			wj, err = tr.cc.EvalSub(w[j], g)
+			g.Close()
+			next = append(next, wj)
+		}
+		if err != nil {
+			for _, ct := range next {
+				if ct != nil {
+					ct.Close()
+				}
+			}
+			return nil, err
+		}
+	}
+	return next, nil
+}
+
+// residual returns sig(score) − y, consuming score.
+func (tr *Trainer) residual(score, y *openfhe.Ciphertext, sig []float64) (*openfhe.Ciphertext, error) {
+	s, err := tr.cc.EvalPoly(score, sig)
+	score.Close()
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+	return tr.cc.EvalSub(s, y)
+}
+
+// --- helpers ---
+
+// replicate tiles block across all slots.
+func (tr *Trainer) replicate(block []float64) []float64 {
+	out := make([]float64, tr.slots)
+	for i := range out {
+		out[i] = block[i%len(block)]
+	}
+	return out
+}
+
+func (tr *Trainer) rescale(ct *openfhe.Ciphertext, err error) (*openfhe.Ciphertext, error) {
+	if err != nil || !tr.manualRescale {
+		return ct, err
+	}
+	defer ct.Close()
+	return tr.cc.Rescale(ct)
+}
+
+func (tr *Trainer) mult(a, b *openfhe.Ciphertext) (*openfhe.Ciphertext, error) {
+	return tr.rescale(tr.cc.EvalMult(a, b))
+}
+
+// multPlain multiplies ct by values encoded at its level, consuming ct.
+func (tr *Trainer) multPlain(ct *openfhe.Ciphertext, values []float64) (*openfhe.Ciphertext, error) {
+	defer ct.Close()
+	level, _ := ct.GetLevel()
+	pt, err := tr.cc.MakeCKKSPackedPlaintextWithParams(values, 1, uint32(max(level, 0)), 0)
+	if err != nil {
+		return nil, err
+	}
+	defer pt.Close()
+	return tr.rescale(tr.cc.EvalMultPlain(ct, pt))
+}
+
+// multConst returns ct·c without consuming ct.
+func (tr *Trainer) multConst(ct *openfhe.Ciphertext, c float64) (*openfhe.Ciphertext, error) {
+	cp, err := ct.Clone()
+	if err != nil {
+		return nil, err
+	}
+	values := make([]float64, tr.slots)
+	for i := range values {
+		values[i] = c
+	}
+	return tr.multPlain(cp, values)
+}
+
+// rotateSum returns ct + rot(ct, s) folded over every shift s in order,
+// consuming ct.
+func (tr *Trainer) rotateSum(ct *openfhe.Ciphertext, shifts []int32) (*openfhe.Ciphertext, error) {
+	for _, s := range shifts {
+		rot, err := tr.cc.EvalRotate(ct, s)
+		if err != nil {
+			ct.Close()
+			return nil, err
+		}
+		sum, err := tr.cc.EvalAdd(ct, rot)
+		rot.Close()
+		ct.Close()
+		if err != nil {
+			return nil, err
+		}
+		ct = sum
+	}
+	return ct, nil
+}
+
+func nextPow2(n int) int {
+	p := 1
+	for p < n {
+		p <<= 1
+	}
+	return p
+}
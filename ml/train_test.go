@@ -0,0 +1,146 @@
+package ml
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/dozyio/openfhe-go/internal/testutil"
+	"github.com/dozyio/openfhe-go/openfhe"
+)
+
+// trainX has an intercept column; trainY is 1 when x1 > x2.
+var (
+	trainX = [][]float64{{1, 0.5, -0.25}, {1, -0.75, 0.5}, {1, 1, 0.25}, {1, -0.5, -1}}
+	trainY = []float64{1, 0, 1, 1}
+)
+
+// trainReference is Train in float64 with the same sigmoid polynomial.
+func trainReference(x [][]float64, y []float64, opts *TrainOptions) []float64 {
+	o := opts.resolve()
+	sig := SigmoidPolynomial(o.Bound, o.Degree)
+	w := make([]float64, len(x[0]))
+	for range o.Iterations {
+		grad := make([]float64, len(w))
+		for i, row := range x {
+			var score float64
+			for j, v := range row {
+				score += w[j] * v
+			}
+			e := sig.eval(score) - y[i]
+			for j, v := range row {
+				grad[j] += e * v
+			}
+		}
+		for j := range w {
+			w[j] -= o.LearningRate / float64(len(x)) * grad[j]
+		}
+	}
+	return w
+}
+
+func train(t *testing.T, tr *Trainer, keys *openfhe.KeyPair, packing Packing, opts *TrainOptions) *Weights {
+	t.Helper()
+	data, err := tr.EncryptDataset(keys, trainX, trainY, packing)
+	testutil.MustT(t, err, "EncryptDataset")
+	defer data.Close()
+	testutil.MustT(t, tr.KeyGen(keys, data), "KeyGen")
+	w, err := tr.Train(keys, data, opts)
+	testutil.MustT(t, err, "Train")
+	return w
+}
+
+func TestSigmoidPolynomial(t *testing.T) {
+	for _, degree := range []int{1, 3, 7} {
+		poly, cheb := SigmoidPolynomial(8, degree), Sigmoid(-8, 8, degree)
+		if len(poly.Poly) != degree+1 {
+			t.Fatalf("degree %d: %d coefficients", degree, len(poly.Poly))
+		}
+		for x := -8.0; x <= 8; x += 0.5 {
+			if got, want := poly.eval(x), cheb.eval(x); math.Abs(got-want) > 1e-9 {
+				t.Errorf("degree %d: p(%g) = %g, expected %g", degree, x, got, want)
+			}
+		}
+	}
+	if d := IterationDepth(RowWise, 3); d != 5 {
+		t.Errorf("IterationDepth(RowWise, 3) = %d, expected 5", d)
+	}
+	if d := IterationDepth(ColumnWise, 7); d != 5 {
+		t.Errorf("IterationDepth(ColumnWise, 7) = %d, expected 5", d)
+	}
+}
+
+func TestTrain(t *testing.T) {
+	opts := &TrainOptions{LearningRate: 2, Iterations: 2}
+	cc, keys, _ := setupMLContext(t, 2*IterationDepth(RowWise, 3))
+	defer cc.Close()
+	defer keys.Close()
+	tr, err := NewTrainer(cc)
+	testutil.MustT(t, err, "NewTrainer")
+
+	want := trainReference(trainX, trainY, opts)
+	for _, packing := range []Packing{RowWise, ColumnWise} {
+		w := train(t, tr, keys, packing, opts)
+		got, err := tr.DecryptWeights(keys, w)
+		w.Close()
+		testutil.MustT(t, err, "DecryptWeights")
+		if !testutil.ApproxEqual(got, want, 1e-3) {
+			t.Errorf("%v weights = %v, expected %v", packing, got, want)
+		}
+	}
+
+	data, err := tr.EncryptDataset(keys, trainX, trainY, RowWise)
+	testutil.MustT(t, err, "EncryptDataset")
+	defer data.Close()
+	if _, err := tr.Train(keys, data, &TrainOptions{Iterations: 3}); err == nil || !strings.Contains(err.Error(), "Bootstrap") {
+		t.Errorf("Train past the depth without bootstrapping: err = %v", err)
+	}
+	if _, err := tr.EncryptDataset(keys, trainX, trainY[1:], RowWise); err == nil {
+		t.Error("EncryptDataset accepted fewer labels than samples")
+	}
+}
+
+func TestTrainBootstrap(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping bootstrapped training test in -short mode")
+	}
+
+	levelBudget := []uint32{4, 4}
+	params, err := openfhe.NewParamsCKKSRNS()
+	testutil.MustT(t, err, "NewParamsCKKSRNS")
+	defer params.Close()
+	testutil.MustT(t, params.SetSecretKeyDist(openfhe.SecretKeyUniformTernary), "SetSecretKeyDist")
+	testutil.MustT(t, params.SetSecurityLevel(openfhe.HEStdNotSet), "SetSecurityLevel")
+	testutil.MustT(t, params.SetRingDim(1<<12), "SetRingDim")
+	testutil.MustT(t, params.SetScalingTechnique(openfhe.FLEXIBLEAUTO), "SetScalingTechnique")
+	testutil.MustT(t, params.SetScalingModSize(59), "SetScalingModSize")
+	testutil.MustT(t, params.SetFirstModSize(60), "SetFirstModSize")
+	bootDepth := openfhe.GetBootstrapDepth(levelBudget, openfhe.SecretKeyUniformTernary)
+	testutil.MustT(t, params.SetMultiplicativeDepth(10+int(bootDepth)), "SetMultiplicativeDepth")
+
+	cc, err := openfhe.NewCryptoContextCKKS(params)
+	testutil.MustT(t, err, "NewCryptoContextCKKS")
+	defer cc.Close()
+	for _, f := range []int{openfhe.PKE, openfhe.KEYSWITCH, openfhe.LEVELEDSHE, openfhe.ADVANCEDSHE, openfhe.FHE} {
+		testutil.MustT(t, cc.Enable(f), "Enable")
+	}
+	testutil.MustT(t, cc.EvalBootstrapSetupSimple(levelBudget), "EvalBootstrapSetupSimple")
+	keys, err := cc.KeyGen()
+	testutil.MustT(t, err, "KeyGen")
+	defer keys.Close()
+	testutil.MustT(t, cc.EvalBootstrapKeyGen(keys, uint32(cc.GetRingDimension()/2)), "EvalBootstrapKeyGen")
+
+	tr, err := NewTrainer(cc)
+	testutil.MustT(t, err, "NewTrainer")
+	opts := &TrainOptions{LearningRate: 2, Iterations: 6, Bootstrap: true}
+	w := train(t, tr, keys, RowWise, opts)
+	defer w.Close()
+	if w.Bootstraps == 0 {
+		t.Errorf("%d iterations ran without bootstrapping", opts.Iterations)
+	}
+	got, err := tr.DecryptWeights(keys, w)
+	testutil.MustT(t, err, "DecryptWeights")
+	if want := trainReference(trainX, trainY, opts); !testutil.ApproxEqual(got, want, 0.02) {
+		t.Errorf("weights = %v, expected %v", got, want)
+	}
+}
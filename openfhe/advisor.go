@@ -0,0 +1,419 @@
+package openfhe
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"strings"
+)
+
+// ParamRequest describes the circuit a parameter set has to support.
+// Zero values select sensible defaults where one exists.
+type ParamRequest struct {
+	// SecurityLevel is the target HE-standard security level.
+	// HEStdNotSet disables the ring dimension bound (testing only).
+	SecurityLevel SecurityLevel
+
+	// MultiplicativeDepth is the number of levels the application circuit
+	// needs (excluding any levels consumed by bootstrapping).
+	MultiplicativeDepth int
+
+	// PrecisionBits is the number of bits of precision required.
+	// CKKS: fractional bits kept after each rescale (default 40).
+	// BFV/BGV: bit size of the plaintext modulus when PlaintextModulus is 0.
+	PrecisionBits int
+
+	// IntegerBits is the number of bits reserved for the integer part of
+	// CKKS values (default 10). Ignored for BFV/BGV.
+	IntegerBits int
+
+	// Slots is the number of SIMD slots the application packs.
+	Slots int
+
+	// LevelBudget enables CKKS bootstrapping when non-empty. It is the same
+	// value later passed to EvalBootstrapSetupSimple.
+	LevelBudget []uint32
+
+	// PlaintextModulus fixes the BFV/BGV plaintext modulus. When 0 the
+	// advisor picks a batching-friendly prime of PrecisionBits bits.
+	PlaintextModulus uint64
+}
+
+// ParamAdvice records the values chosen by the advisor and why.
+type ParamAdvice struct {
+	RingDim             uint64
+	Slots               int
+	MultiplicativeDepth int // includes BootstrapDepth
+	BootstrapDepth      uint32
+	ScalingModSize      int    // CKKS only
+	FirstModSize        int    // CKKS only
+	PlaintextModulus    uint64 // BFV/BGV only
+	EstimatedLogQP      int
+	MaxLogQP            int // HE-standard bound for RingDim, 0 when HEStdNotSet
+	Explanation         []string
+}
+
+// String returns the explanation as a multi-line string.
+func (a *ParamAdvice) String() string {
+	return strings.Join(a.Explanation, "\n")
+}
+
+const (
+	minAdvisorRingDim = 1 << 10
+	maxAdvisorRingDim = 1 << 17
+
+	defaultCKKSPrecisionBits = 40
+	defaultCKKSIntegerBits   = 10
+	ckksNoiseBits            = 10 // bits of scale lost to encoding/rescaling noise
+	minCKKSScalingModSize    = 20
+
+	defaultIntPrecisionBits = 17
+	intFirstModBits         = 60 // BFV/BGV base modulus estimate
+	intPerLevelOverheadBits = 20 // BFV/BGV per-level noise growth beyond log2(t)+log2(N)
+	keySwitchExtraBits      = 60 // one special prime for relinearization
+)
+
+// heStdMaxLogQ holds OpenFHE's HE-standard table (ternary secrets):
+// the largest log2(Q*P) that still meets each security level, indexed by
+// log2(ring dimension) from 10 (1024) to 17 (131072).
+var heStdMaxLogQ = map[SecurityLevel][8]int{
+	HEStd128Classic: {27, 54, 109, 218, 438, 881, 1747, 3523},
+	HEStd192Classic: {19, 37, 75, 152, 305, 611, 1228, 2468},
+	HEStd256Classic: {14, 29, 58, 118, 237, 476, 956, 1918},
+	HEStd128Quantum: {25, 51, 101, 202, 411, 827, 1663, 3346},
+	HEStd192Quantum: {17, 35, 70, 141, 284, 571, 1147, 2304},
+	HEStd256Quantum: {13, 27, 54, 109, 220, 443, 890, 1790},
+}
+
+// maxLogQForRingDim returns the HE-standard bound for ringDim, or 0 when
+// the security level is HEStdNotSet.
+func maxLogQForRingDim(level SecurityLevel, ringDim uint64) (int, error) {
+	if level == HEStdNotSet {
+		return 0, nil
+	}
+	table, ok := heStdMaxLogQ[level]
+	if !ok {
+		return 0, fmt.Errorf("unsupported security level %d", int(level))
+	}
+	idx := bits.Len64(ringDim) - 1 - 10
+	if idx < 0 || idx >= len(table) {
+		return 0, fmt.Errorf("ring dimension %d outside HE-standard table", ringDim)
+	}
+	return table[idx], nil
+}
+
+// pickRingDim returns the smallest power-of-two ring dimension >= minDim
+// whose HE-standard bound covers the modulus returned by logQP(ringDim).
+func pickRingDim(level SecurityLevel, minDim uint64, logQP func(uint64) int) (uint64, int, int, error) {
+	n := uint64(minAdvisorRingDim)
+	for n < minDim {
+		n <<= 1
+	}
+	for ; n <= maxAdvisorRingDim; n <<= 1 {
+		est := logQP(n)
+		bound, err := maxLogQForRingDim(level, n)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		if level == HEStdNotSet || est <= bound {
+			return n, est, bound, nil
+		}
+	}
+	return 0, 0, 0, fmt.Errorf("no ring dimension up to %d supports the estimated modulus at this security level; reduce depth or precision", maxAdvisorRingDim)
+}
+
+func isPowerOfTwo(x int) bool {
+	return x > 0 && x&(x-1) == 0
+}
+
+// planCKKS computes CKKS parameters without touching OpenFHE objects
+// other than the static bootstrapping depth helper.
+func planCKKS(req ParamRequest) (*ParamAdvice, error) {
+	if req.MultiplicativeDepth < 0 {
+		return nil, errors.New("multiplicative depth must be non-negative")
+	}
+	if req.Slots < 0 || (req.Slots > 0 && !isPowerOfTwo(req.Slots)) {
+		return nil, fmt.Errorf("CKKS slot count must be a power of two, got %d", req.Slots)
+	}
+
+	precision := req.PrecisionBits
+	if precision == 0 {
+		precision = defaultCKKSPrecisionBits
+	}
+	intBits := req.IntegerBits
+	if intBits == 0 {
+		intBits = defaultCKKSIntegerBits
+	}
+
+	maxModSize := 60
+	if GetNativeInt() == 128 {
+		maxModSize = 120
+	}
+
+	scalingModSize := precision + ckksNoiseBits
+	if scalingModSize < minCKKSScalingModSize {
+		scalingModSize = minCKKSScalingModSize
+	}
+	if scalingModSize >= maxModSize {
+		return nil, fmt.Errorf("%d precision bits need a %d-bit scaling modulus, above the %d-bit native limit",
+			precision, scalingModSize, maxModSize-1)
+	}
+	firstModSize := scalingModSize + intBits
+	if firstModSize > maxModSize {
+		firstModSize = maxModSize
+	}
+
+	adv := &ParamAdvice{
+		ScalingModSize: scalingModSize,
+		FirstModSize:   firstModSize,
+	}
+
+	depth := req.MultiplicativeDepth
+	if len(req.LevelBudget) > 0 {
+		if len(req.LevelBudget) != 2 {
+			return nil, fmt.Errorf("level budget must have 2 entries (encoding, decoding), got %d", len(req.LevelBudget))
+		}
+		for _, b := range req.LevelBudget {
+			if b == 0 {
+				return nil, errors.New("level budget entries must be at least 1")
+			}
+		}
+		adv.BootstrapDepth = GetBootstrapDepth(req.LevelBudget, SecretKeyUniformTernary)
+		depth += int(adv.BootstrapDepth)
+	}
+	adv.MultiplicativeDepth = depth
+
+	logQ := firstModSize + depth*scalingModSize
+	dnum := 3
+	if depth+1 < dnum {
+		dnum = depth + 1
+	}
+	estimate := func(uint64) int {
+		return logQ + (logQ+dnum-1)/dnum
+	}
+
+	minDim := uint64(2 * req.Slots)
+	n, est, bound, err := pickRingDim(req.SecurityLevel, minDim, estimate)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.LevelBudget) > 0 && req.SecurityLevel != HEStdNotSet && n < 1<<14 {
+		// Bootstrapping is impractical below N=2^14 at real security levels.
+		n = 1 << 14
+		bound, _ = maxLogQForRingDim(req.SecurityLevel, n)
+	}
+	slots := req.Slots
+	if slots == 0 {
+		slots = int(n / 2)
+	}
+	for _, b := range req.LevelBudget {
+		if int(b) > bits.Len(uint(slots))-1 {
+			return nil, fmt.Errorf("level budget entry %d exceeds log2(slots)=%d", b, bits.Len(uint(slots))-1)
+		}
+	}
+
+	adv.RingDim = n
+	adv.Slots = slots
+	adv.EstimatedLogQP = est
+	adv.MaxLogQP = bound
+
+	adv.Explanation = append(adv.Explanation,
+		fmt.Sprintf("scaling modulus %d bits: %d precision bits plus ~%d bits of rescaling noise", scalingModSize, precision, ckksNoiseBits),
+		fmt.Sprintf("first modulus %d bits: scaling modulus plus %d integer bits", firstModSize, firstModSize-scalingModSize))
+	if adv.BootstrapDepth > 0 {
+		adv.Explanation = append(adv.Explanation,
+			fmt.Sprintf("multiplicative depth %d: %d for the circuit plus %d consumed by bootstrapping with level budget %v",
+				depth, req.MultiplicativeDepth, adv.BootstrapDepth, req.LevelBudget))
+	} else {
+		adv.Explanation = append(adv.Explanation, fmt.Sprintf("multiplicative depth %d", depth))
+	}
+	adv.Explanation = append(adv.Explanation, ringDimExplanation(req.SecurityLevel, n, est, bound, 2*req.Slots))
+	adv.Explanation = append(adv.Explanation, fmt.Sprintf("batch size %d slots", slots))
+
+	return adv, nil
+}
+
+// planInteger computes BFV/BGV parameters. The modulus model is a
+// conservative estimate; OpenFHE still validates the final choice.
+func planInteger(req ParamRequest, scheme string) (*ParamAdvice, error) {
+	if req.MultiplicativeDepth < 0 {
+		return nil, errors.New("multiplicative depth must be non-negative")
+	}
+	if req.Slots < 0 {
+		return nil, fmt.Errorf("%s slot count must be non-negative, got %d", scheme, req.Slots)
+	}
+	if len(req.LevelBudget) > 0 {
+		return nil, fmt.Errorf("%s does not support CKKS bootstrapping (level budget given)", scheme)
+	}
+
+	tBits := req.PrecisionBits
+	if req.PlaintextModulus != 0 {
+		tBits = bits.Len64(req.PlaintextModulus)
+	} else if tBits == 0 {
+		tBits = defaultIntPrecisionBits
+	}
+	if tBits < 2 || tBits > 60 {
+		return nil, fmt.Errorf("plaintext modulus of %d bits is not supported", tBits)
+	}
+
+	depth := req.MultiplicativeDepth
+	estimate := func(n uint64) int {
+		logN := bits.Len64(n) - 1
+		return intFirstModBits + depth*(tBits+logN+intPerLevelOverheadBits) + keySwitchExtraBits
+	}
+
+	n, est, bound, err := pickRingDim(req.SecurityLevel, uint64(req.Slots), estimate)
+	if err != nil {
+		return nil, err
+	}
+
+	t := req.PlaintextModulus
+	var tNote string
+	if t == 0 {
+		t, err = findBatchingPrime(tBits, n)
+		if err != nil {
+			return nil, err
+		}
+		tNote = fmt.Sprintf("plaintext modulus %d: smallest prime of at least %d bits with t = 1 mod 2N, so all %d slots can be packed", t, tBits, n)
+	} else {
+		if req.Slots > 0 && t%(2*n) != 1 {
+			return nil, fmt.Errorf("plaintext modulus %d does not support packing at ring dimension %d (need t = 1 mod %d)", t, n, 2*n)
+		}
+		tNote = fmt.Sprintf("plaintext modulus %d (caller supplied)", t)
+	}
+
+	slots := req.Slots
+	if slots == 0 {
+		slots = int(n)
+	}
+
+	return &ParamAdvice{
+		RingDim:             n,
+		Slots:               slots,
+		MultiplicativeDepth: depth,
+		PlaintextModulus:    t,
+		EstimatedLogQP:      est,
+		MaxLogQP:            bound,
+		Explanation: []string{
+			tNote,
+			fmt.Sprintf("multiplicative depth %d: each level costs ~%d bits (log2 t + log2 N + %d)", depth, tBits+bits.Len64(n)-1+intPerLevelOverheadBits, intPerLevelOverheadBits),
+			ringDimExplanation(req.SecurityLevel, n, est, bound, req.Slots),
+		},
+	}, nil
+}
+
+func ringDimExplanation(level SecurityLevel, n uint64, est, bound, minDim int) string {
+	if level == HEStdNotSet {
+		return fmt.Sprintf("ring dimension %d: smallest power of two holding the slots (security level not set, estimated log2(QP) %d bits)", n, est)
+	}
+	s := fmt.Sprintf("ring dimension %d: smallest power of two whose HE-standard bound (%d bits) covers the estimated %d-bit log2(QP)", n, bound, est)
+	if minDim > 0 && uint64(minDim) == n {
+		s += " and holds the requested slots"
+	}
+	return s
+}
+
+// findBatchingPrime returns the smallest prime of at least bitSize bits
+// that is congruent to 1 modulo 2*ringDim.
+func findBatchingPrime(bitSize int, ringDim uint64) (uint64, error) {
+	m := 2 * ringDim
+	lo := uint64(1) << (bitSize - 1)
+	start := (lo/m)*m + 1
+	if start < lo {
+		start += m
+	}
+	for t := start; t < 1<<60; t += m {
+		if new(big.Int).SetUint64(t).ProbablyPrime(20) {
+			return t, nil
+		}
+	}
+	return 0, fmt.Errorf("no prime below 2^60 is congruent to 1 mod %d", m)
+}
+
+// AdviseParamsCKKS picks a ring dimension, moduli sizes and depth for the
+// requested CKKS circuit and returns ready-to-use parameters. Infeasible
+// combinations are rejected before any OpenFHE context is built.
+// The caller owns the returned ParamsCKKS and must Close it.
+func AdviseParamsCKKS(req ParamRequest) (*ParamsCKKS, *ParamAdvice, error) {
+	adv, err := planCKKS(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	p, err := NewParamsCKKSRNS()
+	if err != nil {
+		return nil, nil, err
+	}
+	setters := []func() error{
+		func() error { return p.SetSecretKeyDist(SecretKeyUniformTernary) },
+		func() error { return p.SetSecurityLevel(req.SecurityLevel) },
+		func() error { return p.SetRingDim(adv.RingDim) },
+		func() error { return p.SetScalingTechnique(FLEXIBLEAUTO) },
+		func() error { return p.SetScalingModSize(adv.ScalingModSize) },
+		func() error { return p.SetFirstModSize(adv.FirstModSize) },
+		func() error { return p.SetMultiplicativeDepth(adv.MultiplicativeDepth) },
+		func() error { return p.SetBatchSize(adv.Slots) },
+	}
+	for _, set := range setters {
+		if err := set(); err != nil {
+			p.Close()
+			return nil, nil, err
+		}
+	}
+	return p, adv, nil
+}
+
+// AdviseParamsBFV picks a ring dimension, plaintext modulus and depth for
+// the requested BFV circuit. The caller owns the returned ParamsBFV.
+func AdviseParamsBFV(req ParamRequest) (*ParamsBFV, *ParamAdvice, error) {
+	adv, err := planInteger(req, "BFV")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	p, err := NewParamsBFVrns()
+	if err != nil {
+		return nil, nil, err
+	}
+	setters := []func() error{
+		func() error { return p.SetPlaintextModulus(adv.PlaintextModulus) },
+		func() error { return p.SetSecurityLevel(req.SecurityLevel) },
+		func() error { return p.SetRingDim(adv.RingDim) },
+		func() error { return p.SetMultiplicativeDepth(adv.MultiplicativeDepth) },
+	}
+	for _, set := range setters {
+		if err := set(); err != nil {
+			p.Close()
+			return nil, nil, err
+		}
+	}
+	return p, adv, nil
+}
+
+// AdviseParamsBGV picks a ring dimension, plaintext modulus and depth for
+// the requested BGV circuit. The caller owns the returned ParamsBGV.
+func AdviseParamsBGV(req ParamRequest) (*ParamsBGV, *ParamAdvice, error) {
+	adv, err := planInteger(req, "BGV")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	p, err := NewParamsBGVrns()
+	if err != nil {
+		return nil, nil, err
+	}
+	setters := []func() error{
+		func() error { return p.SetPlaintextModulus(adv.PlaintextModulus) },
+		func() error { return p.SetSecurityLevel(req.SecurityLevel) },
+		func() error { return p.SetRingDim(adv.RingDim) },
+		func() error { return p.SetMultiplicativeDepth(adv.MultiplicativeDepth) },
+	}
+	for _, set := range setters {
+		if err := set(); err != nil {
+			p.Close()
+			return nil, nil, err
+		}
+	}
+	return p, adv, nil
+}
@@ -0,0 +1,207 @@
+package openfhe
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAdvisorRejectsInfeasible(t *testing.T) {
+	cases := []struct {
+		name string
+		req  ParamRequest
+		bgv  bool
+	}{
+		{"negative depth", ParamRequest{SecurityLevel: HEStd128Classic, MultiplicativeDepth: -1}, false},
+		{"non power-of-two slots", ParamRequest{SecurityLevel: HEStd128Classic, Slots: 1000}, false},
+		{"precision above native limit", ParamRequest{SecurityLevel: HEStd128Classic, PrecisionBits: 200}, false},
+		{"depth too large", ParamRequest{SecurityLevel: HEStd256Classic, MultiplicativeDepth: 200}, false},
+		{"bad level budget", ParamRequest{SecurityLevel: HEStd128Classic, LevelBudget: []uint32{4}}, false},
+		{"bootstrapping with BGV", ParamRequest{SecurityLevel: HEStd128Classic, LevelBudget: []uint32{4, 4}}, true},
+		{"non-batching modulus", ParamRequest{SecurityLevel: HEStd128Classic, Slots: 8, PlaintextModulus: 65539}, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var err error
+			if tc.bgv {
+				_, err = planInteger(tc.req, "BGV")
+			} else {
+				_, err = planCKKS(tc.req)
+			}
+			if err == nil {
+				t.Fatalf("expected %q to be rejected", tc.name)
+			}
+		})
+	}
+}
+
+func TestAdvisorCKKSRingDim(t *testing.T) {
+	adv, err := planCKKS(ParamRequest{
+		SecurityLevel:       HEStd128Classic,
+		MultiplicativeDepth: 5,
+		PrecisionBits:       40,
+		Slots:               8,
+	})
+	mustT(t, err, "planCKKS")
+
+	if adv.ScalingModSize != 50 || adv.FirstModSize != 60 {
+		t.Errorf("unexpected moduli: scaling %d, first %d", adv.ScalingModSize, adv.FirstModSize)
+	}
+	if adv.RingDim != 1<<14 {
+		t.Errorf("expected ring dimension 16384, got %d", adv.RingDim)
+	}
+	if adv.EstimatedLogQP > adv.MaxLogQP {
+		t.Errorf("estimate %d exceeds bound %d", adv.EstimatedLogQP, adv.MaxLogQP)
+	}
+	if !strings.Contains(adv.String(), "ring dimension 16384") {
+		t.Errorf("explanation does not mention ring dimension:\n%s", adv)
+	}
+}
+
+func TestAdvisorFindBatchingPrime(t *testing.T) {
+	tMod, err := findBatchingPrime(17, 1<<14)
+	mustT(t, err, "findBatchingPrime")
+	if tMod != 65537 {
+		t.Errorf("expected 65537, got %d", tMod)
+	}
+	if tMod%(2<<14) != 1 {
+		t.Errorf("%d is not 1 mod 2N", tMod)
+	}
+}
+
+func TestAdviseParamsCKKS(t *testing.T) {
+	params, adv, err := AdviseParamsCKKS(ParamRequest{
+		SecurityLevel:       HEStd128Classic,
+		MultiplicativeDepth: 2,
+		PrecisionBits:       40,
+		Slots:               8,
+	})
+	mustT(t, err, "AdviseParamsCKKS")
+	defer params.Close()
+	t.Logf("advice:\n%s", adv)
+
+	cc, err := NewCryptoContextCKKS(params)
+	mustT(t, err, "NewCryptoContextCKKS")
+	defer cc.Close()
+
+	if cc.GetRingDimension() != adv.RingDim {
+		t.Fatalf("ring dimension mismatch: advised %d, got %d", adv.RingDim, cc.GetRingDimension())
+	}
+
+	mustT(t, cc.Enable(PKE), "Enable PKE")
+	mustT(t, cc.Enable(KEYSWITCH), "Enable KEYSWITCH")
+	mustT(t, cc.Enable(LEVELEDSHE), "Enable LEVELEDSHE")
+
+	keys, err := cc.KeyGen()
+	mustT(t, err, "KeyGen")
+	defer keys.Close()
+	mustT(t, cc.EvalMultKeyGen(keys), "EvalMultKeyGen")
+
+	in := []float64{0.5, 1.0, 1.5, 2.0}
+	pt, err := cc.MakeCKKSPackedPlaintext(in)
+	mustT(t, err, "MakeCKKSPackedPlaintext")
+	defer pt.Close()
+
+	ct, err := cc.Encrypt(keys, pt)
+	mustT(t, err, "Encrypt")
+	defer ct.Close()
+
+	// Use the full advised depth: x^4 via two squarings.
+	sq, err := cc.EvalMult(ct, ct)
+	mustT(t, err, "EvalMult")
+	defer sq.Close()
+	quad, err := cc.EvalMult(sq, sq)
+	mustT(t, err, "EvalMult")
+	defer quad.Close()
+
+	ptOut, err := cc.Decrypt(keys, quad)
+	mustT(t, err, "Decrypt")
+	defer ptOut.Close()
+	got, err := ptOut.GetRealPackedValue()
+	mustT(t, err, "GetRealPackedValue")
+
+	want := make([]float64, len(in))
+	for i, v := range in {
+		want[i] = v * v * v * v
+	}
+	if !slicesApproxEqual(got[:len(in)], want, 1e-4) {
+		t.Errorf("CKKS result mismatch: want %v, got %v", want, got[:len(in)])
+	}
+}
+
+func TestAdviseParamsBFV(t *testing.T) {
+	params, adv, err := AdviseParamsBFV(ParamRequest{
+		SecurityLevel:       HEStd128Classic,
+		MultiplicativeDepth: 2,
+		PrecisionBits:       17,
+		Slots:               16,
+	})
+	mustT(t, err, "AdviseParamsBFV")
+	defer params.Close()
+	t.Logf("advice:\n%s", adv)
+
+	cc, err := NewCryptoContextBFV(params)
+	mustT(t, err, "NewCryptoContextBFV")
+	defer cc.Close()
+
+	mustT(t, cc.Enable(PKE), "Enable PKE")
+	mustT(t, cc.Enable(KEYSWITCH), "Enable KEYSWITCH")
+	mustT(t, cc.Enable(LEVELEDSHE), "Enable LEVELEDSHE")
+
+	keys, err := cc.KeyGen()
+	mustT(t, err, "KeyGen")
+	defer keys.Close()
+	mustT(t, cc.EvalMultKeyGen(keys), "EvalMultKeyGen")
+
+	in := []int64{1, 2, 3, 4, 5, 6, 7, 8}
+	pt, err := cc.MakePackedPlaintext(in)
+	mustT(t, err, "MakePackedPlaintext")
+	defer pt.Close()
+
+	ct, err := cc.Encrypt(keys, pt)
+	mustT(t, err, "Encrypt")
+	defer ct.Close()
+
+	sq, err := cc.EvalMult(ct, ct)
+	mustT(t, err, "EvalMult")
+	defer sq.Close()
+	cube, err := cc.EvalMult(sq, ct)
+	mustT(t, err, "EvalMult")
+	defer cube.Close()
+
+	ptOut, err := cc.Decrypt(keys, cube)
+	mustT(t, err, "Decrypt")
+	defer ptOut.Close()
+	got, err := ptOut.GetPackedValue()
+	mustT(t, err, "GetPackedValue")
+
+	want := make([]int64, len(in))
+	for i, v := range in {
+		want[i] = v * v * v
+	}
+	if !slicesEqual(got[:len(in)], want) {
+		t.Errorf("BFV result mismatch: want %v, got %v", want, got[:len(in)])
+	}
+}
+
+func TestAdviseParamsCKKSBootstrap(t *testing.T) {
+	levelBudget := []uint32{4, 4}
+	adv, err := planCKKS(ParamRequest{
+		SecurityLevel:       HEStd128Classic,
+		MultiplicativeDepth: 10,
+		PrecisionBits:       49,
+		LevelBudget:         levelBudget,
+	})
+	mustT(t, err, "planCKKS")
+
+	bootDepth := GetBootstrapDepth(levelBudget, SecretKeyUniformTernary)
+	if adv.BootstrapDepth != bootDepth {
+		t.Errorf("bootstrap depth: want %d, got %d", bootDepth, adv.BootstrapDepth)
+	}
+	if adv.MultiplicativeDepth != 10+int(bootDepth) {
+		t.Errorf("total depth: want %d, got %d", 10+int(bootDepth), adv.MultiplicativeDepth)
+	}
+	if adv.RingDim < 1<<14 {
+		t.Errorf("bootstrapping ring dimension too small: %d", adv.RingDim)
+	}
+}
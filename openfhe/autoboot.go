@@ -0,0 +1,154 @@
+package openfhe
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync/atomic"
+)
+
+// autoBootstrap is the state of managed bootstrapping; see
+// EnableAutoBootstrap.
+type autoBootstrap struct {
+	depth int // levels a fresh ciphertext can consume
+	after int // levels left by EvalBootstrap
+	count atomic.Uint64
+}
+
+// defaultLevelBudget is the level budget bootstrapping is set up with when
+// none is given. It is always passed to the C++ wrapper explicitly, so the
+// budget recorded for EnableAutoBootstrap is the one OpenFHE used.
+var defaultLevelBudget = []uint32{4, 4}
+
+func levelBudgetOrDefault(levelBudget []uint32) []uint32 {
+	if len(levelBudget) == 0 {
+		return defaultLevelBudget
+	}
+	return levelBudget
+}
+
+// recordLevelBudget remembers the level budget bootstrapping was set up
+// with.
+func (cc *CryptoContext) recordLevelBudget(levelBudget []uint32) {
+	cc.levelBudget = append([]uint32(nil), levelBudget...)
+}
+
+// EnableAutoBootstrap turns on managed bootstrapping for a CKKS context.
+// Before every EvalMult, EvalSquare, EvalPoly and EvalChebyshevSeries, each
+// operand that has fewer levels left than the operation consumes is
+// replaced by a bootstrapped copy; the caller's ciphertexts are not
+// modified and the copies are freed once the operation returns.
+//
+// The levels available after bootstrapping follow the level budget given
+// to EvalBootstrapSetupSimple (or EvalBootstrapSetup), which must have been
+// called before, and EvalBootstrapKeyGen must have been run for the keys in
+// use. Enabling resets the count reported by AutoBootstraps.
+func (cc *CryptoContext) EnableAutoBootstrap() error {
+	if cc.closed() {
+		return errors.New("CryptoContext is closed or invalid")
+	}
+	if cc.levelBudget == nil {
+		return errors.New("EnableAutoBootstrap: call EvalBootstrapSetupSimple first")
+	}
+	depth := cc.GetMultiplicativeDepth()
+	if depth < 0 {
+		return errors.New("EnableAutoBootstrap: context has no RNS parameters")
+	}
+	skd, err := cc.GetSecretKeyDist()
+	if err != nil {
+		return err
+	}
+	after := depth - int(GetBootstrapDepth(cc.levelBudget, skd))
+	if after < 1 {
+		return fmt.Errorf("EnableAutoBootstrap: bootstrapping with level budget %v leaves no levels out of %d",
+			cc.levelBudget, depth)
+	}
+	cc.autoBoot = &autoBootstrap{depth: depth, after: after}
+	return nil
+}
+
+// DisableAutoBootstrap turns managed bootstrapping off.
+func (cc *CryptoContext) DisableAutoBootstrap() {
+	cc.autoBoot = nil
+}
+
+// AutoBootstraps returns the number of bootstraps inserted since
+// EnableAutoBootstrap, or 0 when managed bootstrapping is off.
+func (cc *CryptoContext) AutoBootstraps() uint64 {
+	if a := cc.autoBoot; a != nil {
+		return a.count.Load()
+	}
+	return 0
+}
+
+// levelsLeft returns how many more levels ct can consume. A pending scaling
+// factor counts as the level its rescale will use.
+func (a *autoBootstrap) levelsLeft(ct *Ciphertext) (int, error) {
+	level, ok := ct.GetLevel()
+	deg, degOK := ct.GetNoiseScaleDeg()
+	if !ok || !degOK {
+		return 0, errors.New("Input Ciphertext is closed or invalid")
+	}
+	return a.depth - level - (deg - 1), nil
+}
+
+// ensureLevels returns cts with every ciphertext that has fewer than cost
+// levels left replaced by a bootstrapped copy, and a function that frees
+// the copies.
+func (cc *CryptoContext) ensureLevels(a *autoBootstrap, op string, cost int, cts ...*Ciphertext) ([]*Ciphertext, func(), error) {
+	if cost > a.after {
+		return nil, nil, fmt.Errorf("%s: needs %d levels but bootstrapping leaves %d", op, cost, a.after)
+	}
+	out := slices.Clone(cts)
+	var fresh []*Ciphertext
+	release := func() {
+		for _, ct := range fresh {
+			ct.Close()
+		}
+	}
+	for i, ct := range cts {
+		if j := slices.Index(cts[:i], ct); j >= 0 {
+			out[i] = out[j]
+			continue
+		}
+		left, err := a.levelsLeft(ct)
+		if err != nil {
+			release()
+			return nil, nil, err
+		}
+		if left >= cost {
+			continue
+		}
+		b, err := cc.autoBootstrap(a, ct)
+		if err != nil {
+			release()
+			return nil, nil, fmt.Errorf("%s: automatic bootstrap: %w", op, err)
+		}
+		fresh = append(fresh, b)
+		if left, err = a.levelsLeft(b); err != nil || left < cost {
+			release()
+			return nil, nil, fmt.Errorf("%s: needs %d levels but bootstrapping left %d", op, cost, left)
+		}
+		out[i] = b
+	}
+	return out, release, nil
+}
+
+// autoBootstrap refreshes ct, first removing a scaling factor FIXEDMANUAL
+// left pending.
+func (cc *CryptoContext) autoBootstrap(a *autoBootstrap, ct *Ciphertext) (*Ciphertext, error) {
+	if deg, _ := ct.GetNoiseScaleDeg(); deg > 1 && cc.GetScalingTechnique() == FIXEDMANUAL {
+		rescaled, err := cc.Rescale(ct)
+		if err != nil {
+			return nil, err
+		}
+		defer rescaled.Close()
+		ct = rescaled
+	}
+	b, err := cc.EvalBootstrap(ct)
+	if err != nil {
+		return nil, err
+	}
+	a.count.Add(1)
+	return b, nil
+}
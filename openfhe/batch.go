@@ -0,0 +1,167 @@
+package openfhe
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// BatchOptions configures EncryptBatch and DecryptBatch. A nil
+// *BatchOptions uses the defaults.
+type BatchOptions struct {
+	// Workers is the number of chunks processed at once; 0 means
+	// runtime.GOMAXPROCS(0).
+	Workers int
+	// ChunkSize is the number of values packed into each ciphertext; 0
+	// means every slot, as given by the batch size of the context.
+	// DecryptBatch must be given the chunk size EncryptBatch used.
+	ChunkSize int
+	// Progress, when set, is called after each chunk with the number of
+	// chunks finished so far and the total. Calls are serialized.
+	Progress func(done, total int)
+}
+
+func (o *BatchOptions) resolve(ev Evaluator) (workers, chunk int, err error) {
+	var opts BatchOptions
+	if o != nil {
+		opts = *o
+	}
+	slots := slotCount(ev)
+	if slots == 0 {
+		return 0, 0, errors.New("could not determine the slot count of the context")
+	}
+	chunk = opts.ChunkSize
+	switch {
+	case chunk == 0:
+		chunk = slots
+	case chunk < 0 || chunk > slots:
+		return 0, 0, fmt.Errorf("chunk size %d is not in [1, %d]", chunk, slots)
+	}
+	workers = opts.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	return workers, chunk, nil
+}
+
+// EncryptBatch splits values into chunks of BatchOptions.ChunkSize, encodes
+// and encrypts them under keys on a pool of workers, and returns one
+// ciphertext per chunk in order. The last chunk may be partial. On error
+// no ciphertexts are returned.
+func EncryptBatch[T Slot](ev Evaluator, keys *KeyPair, values []T, opts *BatchOptions) ([]*Ciphertext, error) {
+	if len(values) == 0 {
+		return nil, errors.New("EncryptBatch: input is empty")
+	}
+	workers, chunk, err := opts.resolve(ev)
+	if err != nil {
+		return nil, fmt.Errorf("EncryptBatch: %w", err)
+	}
+
+	out := make([]*Ciphertext, (len(values)+chunk-1)/chunk)
+	err = runChunks(len(out), workers, opts, func(i int) error {
+		pt, err := encodeSlots(ev, values[i*chunk:min((i+1)*chunk, len(values))], 0)
+		if err != nil {
+			return err
+		}
+		defer pt.Close()
+		out[i], err = ev.Encrypt(keys, pt)
+		return err
+	})
+	if err != nil {
+		for _, ct := range out {
+			if ct != nil {
+				ct.Close()
+			}
+		}
+		return nil, fmt.Errorf("EncryptBatch: %w", err)
+	}
+	return out, nil
+}
+
+// DecryptBatch reverses EncryptBatch: it decrypts cts on a pool of workers
+// and returns the first n values, concatenated in order. opts.ChunkSize
+// must match the one used to encrypt.
+func DecryptBatch[T Slot](ev Evaluator, keys *KeyPair, cts []*Ciphertext, n int, opts *BatchOptions) ([]T, error) {
+	workers, chunk, err := opts.resolve(ev)
+	if err != nil {
+		return nil, fmt.Errorf("DecryptBatch: %w", err)
+	}
+	if n <= 0 || (n+chunk-1)/chunk != len(cts) {
+		return nil, fmt.Errorf("DecryptBatch: %d values do not fill %d ciphertexts of %d", n, len(cts), chunk)
+	}
+
+	out := make([]T, n)
+	err = runChunks(len(cts), workers, opts, func(i int) error {
+		pt, err := ev.Decrypt(keys, cts[i])
+		if err != nil {
+			return err
+		}
+		defer pt.Close()
+		vals, err := decodeSlots[T](pt)
+		if err != nil {
+			return err
+		}
+		dst := out[i*chunk : min((i+1)*chunk, n)]
+		if len(vals) < len(dst) {
+			return fmt.Errorf("decrypted %d values, expected %d", len(vals), len(dst))
+		}
+		copy(dst, vals)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("DecryptBatch: %w", err)
+	}
+	return out, nil
+}
+
+// runChunks calls fn for the chunks 0..total-1 on up to workers goroutines.
+// It stops handing out chunks after the first error, which it returns.
+func runChunks(total, workers int, opts *BatchOptions, fn func(i int) error) error {
+	var progress func(done, total int)
+	if opts != nil {
+		progress = opts.Progress
+	}
+
+	var (
+		mu       sync.Mutex
+		next     int
+		done     int
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	claim := func() (int, bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		if firstErr != nil || next == total {
+			return 0, false
+		}
+		next++
+		return next - 1, true
+	}
+	for range min(workers, total) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i, ok := claim()
+				if !ok {
+					return
+				}
+				err := fn(i)
+				mu.Lock()
+				if err != nil && firstErr == nil {
+					firstErr = fmt.Errorf("chunk %d: %w", i, err)
+				} else if err == nil {
+					done++
+					if progress != nil {
+						progress(done, total)
+					}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return firstErr
+}
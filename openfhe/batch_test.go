@@ -0,0 +1,121 @@
+package openfhe
+
+import (
+	"strings"
+	"testing"
+)
+
+func closeCiphertexts(cts []*Ciphertext) {
+	for _, ct := range cts {
+		ct.Close()
+	}
+}
+
+func TestEncryptBatchCKKS(t *testing.T) {
+	cc, keys := setupCKKSContextAndKeys(t)
+	defer cc.Close()
+	defer keys.Close()
+
+	in := make([]float64, 100)
+	for i := range in {
+		in[i] = float64(i) / 10
+	}
+	var calls, last int
+	opts := &BatchOptions{
+		Workers: 4,
+		Progress: func(done, total int) {
+			calls++
+			if done != last+1 || total != 13 {
+				t.Errorf("Progress(%d, %d) after %d, expected %d of 13", done, total, last, last+1)
+			}
+			last = done
+		},
+	}
+	cts, err := EncryptBatch(cc, keys, in, opts)
+	mustT(t, err, "EncryptBatch")
+	defer closeCiphertexts(cts)
+	if len(cts) != 13 || calls != 13 {
+		t.Fatalf("EncryptBatch made %d ciphertexts with %d progress calls, expected 13 (8 slots each)", len(cts), calls)
+	}
+
+	opts.Progress = nil
+	got, err := DecryptBatch[float64](cc, keys, cts, len(in), opts)
+	mustT(t, err, "DecryptBatch")
+	if !slicesApproxEqual(got, in, 0.001) {
+		t.Errorf("DecryptBatch = %v, expected %v", got, in)
+	}
+}
+
+func TestEncryptBatchBFV(t *testing.T) {
+	cc, keys := setupBFVContextAndKeys(t)
+	defer cc.Close()
+	defer keys.Close()
+
+	in := make([]int64, 23)
+	for i := range in {
+		in[i] = int64(i*i) - 100
+	}
+	opts := &BatchOptions{ChunkSize: 5}
+	cts, err := EncryptBatch(cc, keys, in, opts)
+	mustT(t, err, "EncryptBatch")
+	defer closeCiphertexts(cts)
+	if len(cts) != 5 {
+		t.Fatalf("EncryptBatch made %d ciphertexts, expected 5", len(cts))
+	}
+	got, err := DecryptBatch[int64](cc, keys, cts, len(in), opts)
+	mustT(t, err, "DecryptBatch")
+	if !slicesEqual(got, in) {
+		t.Errorf("DecryptBatch = %v, expected %v", got, in)
+	}
+
+	// The chunks are ordinary packed ciphertexts.
+	if got := decryptInts(t, cc, keys, cts[1], 5); !slicesEqual(got, in[5:10]) {
+		t.Errorf("second chunk = %v, expected %v", got, in[5:10])
+	}
+}
+
+func TestEncryptBatchSimulator(t *testing.T) {
+	sim, keys := newTestSimulator(t, SimParams{
+		Scheme:              SimCKKS,
+		RingDimension:       64,
+		MultiplicativeDepth: 1,
+		ScalingTechnique:    FLEXIBLEAUTO,
+		NoiseStdDev:         1e-9,
+	})
+	defer sim.Close()
+
+	in := make([]complex128, 10000)
+	for i := range in {
+		in[i] = complex(float64(i), -float64(i))
+	}
+	cts, err := EncryptBatch(sim, keys, in, &BatchOptions{Workers: 8})
+	mustT(t, err, "EncryptBatch")
+	defer closeCiphertexts(cts)
+	if want := (len(in) + 31) / 32; len(cts) != want {
+		t.Fatalf("EncryptBatch made %d ciphertexts, expected %d", len(cts), want)
+	}
+	got, err := DecryptBatch[complex128](sim, keys, cts, len(in), &BatchOptions{Workers: 8})
+	mustT(t, err, "DecryptBatch")
+	for i := range in {
+		if d := got[i] - in[i]; real(d)*real(d)+imag(d)*imag(d) > 1e-12 {
+			t.Fatalf("slot %d = %v, expected %v", i, got[i], in[i])
+		}
+	}
+
+	if _, err := DecryptBatch[complex128](sim, keys, cts, len(in)+32, nil); err == nil {
+		t.Error("DecryptBatch accepted more values than the ciphertexts hold")
+	}
+	if _, err := EncryptBatch(sim, keys, in, &BatchOptions{ChunkSize: 33}); err == nil {
+		t.Error("EncryptBatch accepted a chunk size larger than the slot count")
+	}
+	if _, err := EncryptBatch(sim, keys, []float64{}, nil); err == nil {
+		t.Error("EncryptBatch accepted an empty input")
+	}
+
+	// A failing chunk stops the batch and reports which chunk failed.
+	cts[3].Close()
+	_, err = DecryptBatch[complex128](sim, keys, cts, len(in), nil)
+	if err == nil || !strings.Contains(err.Error(), "chunk 3") {
+		t.Errorf("DecryptBatch with a closed ciphertext = %v, expected a chunk 3 error", err)
+	}
+}
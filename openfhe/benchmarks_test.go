@@ -228,6 +228,86 @@ func BenchmarkCKKSDecrypt(b *testing.B) {
 	}
 }
 
+// Plaintext decoding benchmarks: a fully packed CKKS plaintext with 8192
+// slots, decoded with a fresh slice per call and into a reused buffer.
+
+func decryptedFullCKKSPlaintext(b *testing.B) (*CryptoContext, *KeyPair, *Plaintext) {
+	const slots = 8192
+
+	parameters, err := NewParamsCKKSRNS()
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer parameters.Close()
+	parameters.SetMultiplicativeDepth(1)
+	parameters.SetScalingModSize(50)
+	parameters.SetRingDim(2 * slots)
+	parameters.SetBatchSize(slots)
+
+	cc, err := NewCryptoContextCKKS(parameters)
+	if err != nil {
+		b.Fatal(err)
+	}
+	cc.Enable(PKE)
+	keys, _ := cc.KeyGen()
+
+	vec := make([]float64, slots)
+	for i := range vec {
+		vec[i] = float64(i%16) / 16
+	}
+	pt, _ := cc.MakeCKKSPackedPlaintext(vec)
+	defer pt.Close()
+	ct, _ := cc.Encrypt(keys, pt)
+	defer ct.Close()
+	ptDec, err := cc.Decrypt(keys, ct)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return cc, keys, ptDec
+}
+
+func BenchmarkCKKSGetRealPackedValue(b *testing.B) {
+	cc, keys, pt := decryptedFullCKKSPlaintext(b)
+	defer cc.Close()
+	defer keys.Close()
+	defer pt.Close()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = pt.GetRealPackedValue()
+	}
+}
+
+func BenchmarkCKKSDecodeInto(b *testing.B) {
+	cc, keys, pt := decryptedFullCKKSPlaintext(b)
+	defer cc.Close()
+	defer keys.Close()
+	defer pt.Close()
+
+	buf, _ := pt.DecodeInto(nil)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf, _ = pt.DecodeInto(buf)
+	}
+}
+
+func BenchmarkCKKSDecodeComplexInto(b *testing.B) {
+	cc, keys, pt := decryptedFullCKKSPlaintext(b)
+	defer cc.Close()
+	defer keys.Close()
+	defer pt.Close()
+
+	buf, _ := pt.DecodeComplexInto(nil)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf, _ = pt.DecodeComplexInto(buf)
+	}
+}
+
 func BenchmarkCKKSAdd(b *testing.B) {
 	cc, keys := setupCKKSContextAndKeys(&testing.T{})
 	defer cc.Close()
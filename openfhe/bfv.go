@@ -29,9 +29,10 @@ func NewParamsBFVrns() (*ParamsBFV, error) {
 }
 
 func (p *ParamsBFV) SetPlaintextModulus(mod uint64) error {
-	if p.ptr == nil {
+	if !p.acquire() {
 		return errors.New("ParamsBFV is closed or invalid")
 	}
+	defer p.release()
 	status := C.ParamsBFV_SetPlaintextModulus(p.ptr, C.uint64_t(mod))
 	err := checkPKEErrorMsg(status)
 	if err != nil {
@@ -41,9 +42,10 @@ func (p *ParamsBFV) SetPlaintextModulus(mod uint64) error {
 }
 
 func (p *ParamsBFV) SetMultiplicativeDepth(depth int) error {
-	if p.ptr == nil {
+	if !p.acquire() {
 		return errors.New("ParamsBFV is closed or invalid")
 	}
+	defer p.release()
 	status := C.ParamsBFV_SetMultiplicativeDepth(p.ptr, C.int(depth))
 	err := checkPKEErrorMsg(status)
 	if err != nil {
@@ -53,9 +55,10 @@ func (p *ParamsBFV) SetMultiplicativeDepth(depth int) error {
 }
 
 func (p *ParamsBFV) SetSecurityLevel(level SecurityLevel) error {
-	if p.ptr == nil {
+	if !p.acquire() {
 		return errors.New("ParamsBFV is closed or invalid")
 	}
+	defer p.release()
 	status := C.ParamsBFV_SetSecurityLevel(p.ptr, C.OFHESecurityLevel(level))
 	err := checkPKEErrorMsg(status)
 	if err != nil {
@@ -65,9 +68,10 @@ func (p *ParamsBFV) SetSecurityLevel(level SecurityLevel) error {
 }
 
 func (p *ParamsBFV) SetRingDim(ringDim uint64) error {
-	if p.ptr == nil {
+	if !p.acquire() {
 		return errors.New("ParamsBFV is closed or invalid")
 	}
+	defer p.release()
 	status := C.ParamsBFV_SetRingDim(p.ptr, C.uint64_t(ringDim))
 	err := checkPKEErrorMsg(status)
 	if err != nil {
@@ -76,8 +80,22 @@ func (p *ParamsBFV) SetRingDim(ringDim uint64) error {
 	return nil
 }
 
+// SetPREMode selects the proxy re-encryption security model.
+func (p *ParamsBFV) SetPREMode(mode int) error {
+	if !p.acquire() {
+		return errors.New("ParamsBFV is closed or invalid")
+	}
+	defer p.release()
+	status := C.ParamsBFV_SetPREMode(p.ptr, C.int(mode))
+	err := checkPKEErrorMsg(status)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 func (p *ParamsBFV) Close() {
-	if p.ptr != nil {
+	if p.ref.close() && p.ptr != nil {
 		C.DestroyParamsBFV(p.ptr)
 		p.ptr = nil
 	}
@@ -85,9 +103,10 @@ func (p *ParamsBFV) Close() {
 
 // --- BFV CryptoContext ---
 func NewCryptoContextBFV(p *ParamsBFV) (*CryptoContext, error) {
-	if p == nil || p.ptr == nil {
+	if !p.acquire() {
 		return nil, errors.New("ParamsBFV is closed or invalid")
 	}
+	defer p.release()
 	var ccH C.CryptoContextPtr
 	status := C.NewCryptoContextBFV(p.ptr, &ccH)
 	err := checkPKEErrorMsg(status)
@@ -103,9 +122,10 @@ func NewCryptoContextBFV(p *ParamsBFV) (*CryptoContext, error) {
 
 // --- BFV Plaintext ---
 func (cc *CryptoContext) MakePackedPlaintext(vec []int64) (*Plaintext, error) {
-	if cc.ptr == nil {
+	if !cc.acquire() {
 		return nil, errors.New("CryptoContext is closed or invalid")
 	}
+	defer cc.release()
 	if len(vec) == 0 {
 		// Or return error? For now, match old behavior.
 		// Let's return error, it's safer.
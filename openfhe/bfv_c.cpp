@@ -67,6 +67,18 @@ PKEErr ParamsBFV_SetRingDim(ParamsBFVPtr p, uint64_t ringDim) {
   PKE_CATCH_RETURN()
 }
 
+PKEErr ParamsBFV_SetPREMode(ParamsBFVPtr p, int mode) {
+  try {
+    if (!p) {
+      return MakePKEError("ParamsBFV_SetPREMode: null params");
+    }
+    reinterpret_cast<CCParams<CryptoContextBFVRNS> *>(p)->SetPREMode(
+        static_cast<ProxyReEncryptionMode>(mode));
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
 void DestroyParamsBFV(ParamsBFVPtr p) {
   delete reinterpret_cast<CCParams<CryptoContextBFVRNS> *>(p);
 }
@@ -13,6 +13,7 @@ import "errors"
 // Opaque struct to hold the C pointer for BGV Params
 type ParamsBGV struct {
 	ptr C.ParamsBGVPtr
+	ref ref
 }
 
 // --- BGV Params Functions ---
@@ -31,9 +32,10 @@ func NewParamsBGVrns() (*ParamsBGV, error) {
 }
 
 func (p *ParamsBGV) SetPlaintextModulus(mod uint64) error {
-	if p.ptr == nil {
+	if !p.acquire() {
 		return errors.New("ParamsBGV is closed or invalid")
 	}
+	defer p.release()
 	status := C.ParamsBGV_SetPlaintextModulus(p.ptr, C.uint64_t(mod))
 	err := checkPKEErrorMsg(status)
 	if err != nil {
@@ -43,9 +45,10 @@ func (p *ParamsBGV) SetPlaintextModulus(mod uint64) error {
 }
 
 func (p *ParamsBGV) SetMultiplicativeDepth(depth int) error {
-	if p.ptr == nil {
+	if !p.acquire() {
 		return errors.New("ParamsBGV is closed or invalid")
 	}
+	defer p.release()
 	status := C.ParamsBGV_SetMultiplicativeDepth(p.ptr, C.int(depth))
 	err := checkPKEErrorMsg(status)
 	if err != nil {
@@ -55,9 +58,10 @@ func (p *ParamsBGV) SetMultiplicativeDepth(depth int) error {
 }
 
 func (p *ParamsBGV) SetScalingTechnique(technique int) error {
-	if p.ptr == nil {
+	if !p.acquire() {
 		return errors.New("ParamsBGV is closed or invalid")
 	}
+	defer p.release()
 	status := C.ParamsBGV_SetScalingTechnique(p.ptr, C.int(technique))
 	err := checkPKEErrorMsg(status)
 	if err != nil {
@@ -66,9 +70,123 @@ func (p *ParamsBGV) SetScalingTechnique(technique int) error {
 	return nil
 }
 
+func (p *ParamsBGV) SetSecurityLevel(level SecurityLevel) error {
+	if !p.acquire() {
+		return errors.New("ParamsBGV is closed or invalid")
+	}
+	defer p.release()
+	status := C.ParamsBGV_SetSecurityLevel(p.ptr, C.OFHESecurityLevel(level))
+	err := checkPKEErrorMsg(status)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *ParamsBGV) SetRingDim(ringDim uint64) error {
+	if !p.acquire() {
+		return errors.New("ParamsBGV is closed or invalid")
+	}
+	defer p.release()
+	status := C.ParamsBGV_SetRingDim(p.ptr, C.uint64_t(ringDim))
+	err := checkPKEErrorMsg(status)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// SetPREMode selects the proxy re-encryption security model: INDCPA,
+// FIXED_NOISE_HRA or NOISE_FLOODING_HRA.
+func (p *ParamsBGV) SetPREMode(mode int) error {
+	if !p.acquire() {
+		return errors.New("ParamsBGV is closed or invalid")
+	}
+	defer p.release()
+	status := C.ParamsBGV_SetPREMode(p.ptr, C.int(mode))
+	err := checkPKEErrorMsg(status)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// SetPRENumHops sets the number of re-encryption hops the HRA-secure modes
+// must support.
+func (p *ParamsBGV) SetPRENumHops(numHops uint32) error {
+	if !p.acquire() {
+		return errors.New("ParamsBGV is closed or invalid")
+	}
+	defer p.release()
+	status := C.ParamsBGV_SetPRENumHops(p.ptr, C.uint32_t(numHops))
+	err := checkPKEErrorMsg(status)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// SetStatisticalSecurity sets the statistical security (in bits) of the noise
+// flooding used by NOISE_FLOODING_HRA.
+func (p *ParamsBGV) SetStatisticalSecurity(bits uint32) error {
+	if !p.acquire() {
+		return errors.New("ParamsBGV is closed or invalid")
+	}
+	defer p.release()
+	status := C.ParamsBGV_SetStatisticalSecurity(p.ptr, C.uint32_t(bits))
+	err := checkPKEErrorMsg(status)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// SetNumAdversarialQueries sets the number of re-encryption queries an
+// adversary may make, used to size the NOISE_FLOODING_HRA noise.
+func (p *ParamsBGV) SetNumAdversarialQueries(queries uint32) error {
+	if !p.acquire() {
+		return errors.New("ParamsBGV is closed or invalid")
+	}
+	defer p.release()
+	status := C.ParamsBGV_SetNumAdversarialQueries(p.ptr, C.uint32_t(queries))
+	err := checkPKEErrorMsg(status)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// SetKeySwitchTechnique selects BV or HYBRID key switching.
+func (p *ParamsBGV) SetKeySwitchTechnique(technique int) error {
+	if !p.acquire() {
+		return errors.New("ParamsBGV is closed or invalid")
+	}
+	defer p.release()
+	status := C.ParamsBGV_SetKeySwitchTechnique(p.ptr, C.int(technique))
+	err := checkPKEErrorMsg(status)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// SetDigitSize sets the digit size used by BV key switching.
+func (p *ParamsBGV) SetDigitSize(digitSize int) error {
+	if !p.acquire() {
+		return errors.New("ParamsBGV is closed or invalid")
+	}
+	defer p.release()
+	status := C.ParamsBGV_SetDigitSize(p.ptr, C.int(digitSize))
+	err := checkPKEErrorMsg(status)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 // Close method for ParamsBGV
 func (p *ParamsBGV) Close() {
-	if p.ptr != nil {
+	if p.ref.close() && p.ptr != nil {
 		C.DestroyParamsBGV(p.ptr)
 		p.ptr = nil
 	}
@@ -76,9 +194,10 @@ func (p *ParamsBGV) Close() {
 
 // --- BGV CryptoContext ---
 func NewCryptoContextBGV(p *ParamsBGV) (*CryptoContext, error) {
-	if p == nil || p.ptr == nil {
+	if !p.acquire() {
 		return nil, errors.New("ParamsBGV is closed or invalid")
 	}
+	defer p.release()
 	var ccH C.CryptoContextPtr
 	status := C.NewCryptoContextBGV(p.ptr, &ccH)
 	err := checkPKEErrorMsg(status)
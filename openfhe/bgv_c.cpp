@@ -53,6 +53,101 @@ PKEErr ParamsBGV_SetScalingTechnique(ParamsBGVPtr p, int technique) {
   PKE_CATCH_RETURN()
 }
 
+PKEErr ParamsBGV_SetSecurityLevel(ParamsBGVPtr p, OFHESecurityLevel level) {
+  try {
+    if (!p) {
+      return MakePKEError("ParamsBGV_SetSecurityLevel: null params");
+    }
+    auto params = reinterpret_cast<CCParams<CryptoContextBGVRNS> *>(p);
+    params->SetSecurityLevel(static_cast<lbcrypto::SecurityLevel>(level));
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
+PKEErr ParamsBGV_SetRingDim(ParamsBGVPtr p, uint64_t ringDim) {
+  try {
+    if (!p) {
+      return MakePKEError("ParamsBGV_SetRingDim: null params");
+    }
+    reinterpret_cast<CCParams<CryptoContextBGVRNS> *>(p)->SetRingDim(ringDim);
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
+PKEErr ParamsBGV_SetPREMode(ParamsBGVPtr p, int mode) {
+  try {
+    if (!p) {
+      return MakePKEError("ParamsBGV_SetPREMode: null params");
+    }
+    reinterpret_cast<CCParams<CryptoContextBGVRNS> *>(p)->SetPREMode(
+        static_cast<ProxyReEncryptionMode>(mode));
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
+PKEErr ParamsBGV_SetPRENumHops(ParamsBGVPtr p, uint32_t numHops) {
+  try {
+    if (!p) {
+      return MakePKEError("ParamsBGV_SetPRENumHops: null params");
+    }
+    reinterpret_cast<CCParams<CryptoContextBGVRNS> *>(p)->SetPRENumHops(
+        numHops);
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
+PKEErr ParamsBGV_SetStatisticalSecurity(ParamsBGVPtr p, uint32_t bits) {
+  try {
+    if (!p) {
+      return MakePKEError("ParamsBGV_SetStatisticalSecurity: null params");
+    }
+    reinterpret_cast<CCParams<CryptoContextBGVRNS> *>(p)
+        ->SetStatisticalSecurity(bits);
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
+PKEErr ParamsBGV_SetNumAdversarialQueries(ParamsBGVPtr p, uint32_t queries) {
+  try {
+    if (!p) {
+      return MakePKEError("ParamsBGV_SetNumAdversarialQueries: null params");
+    }
+    reinterpret_cast<CCParams<CryptoContextBGVRNS> *>(p)
+        ->SetNumAdversarialQueries(queries);
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
+PKEErr ParamsBGV_SetKeySwitchTechnique(ParamsBGVPtr p, int technique) {
+  try {
+    if (!p) {
+      return MakePKEError("ParamsBGV_SetKeySwitchTechnique: null params");
+    }
+    reinterpret_cast<CCParams<CryptoContextBGVRNS> *>(p)
+        ->SetKeySwitchTechnique(static_cast<KeySwitchTechnique>(technique));
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
+PKEErr ParamsBGV_SetDigitSize(ParamsBGVPtr p, int digitSize) {
+  try {
+    if (!p) {
+      return MakePKEError("ParamsBGV_SetDigitSize: null params");
+    }
+    reinterpret_cast<CCParams<CryptoContextBGVRNS> *>(p)->SetDigitSize(
+        digitSize);
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
 void DestroyParamsBGV(ParamsBGVPtr p) {
   delete reinterpret_cast<CCParams<CryptoContextBGVRNS> *>(p);
 }
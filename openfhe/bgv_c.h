@@ -15,6 +15,14 @@ PKEErr NewParamsBGV(ParamsBGVPtr *out);
 PKEErr ParamsBGV_SetPlaintextModulus(ParamsBGVPtr p, uint64_t mod);
 PKEErr ParamsBGV_SetMultiplicativeDepth(ParamsBGVPtr p, int depth);
 PKEErr ParamsBGV_SetScalingTechnique(ParamsBGVPtr p, int technique);
+PKEErr ParamsBGV_SetSecurityLevel(ParamsBGVPtr p, OFHESecurityLevel level);
+PKEErr ParamsBGV_SetRingDim(ParamsBGVPtr p, uint64_t ringDim);
+PKEErr ParamsBGV_SetPREMode(ParamsBGVPtr p, int mode);
+PKEErr ParamsBGV_SetPRENumHops(ParamsBGVPtr p, uint32_t numHops);
+PKEErr ParamsBGV_SetStatisticalSecurity(ParamsBGVPtr p, uint32_t bits);
+PKEErr ParamsBGV_SetNumAdversarialQueries(ParamsBGVPtr p, uint32_t queries);
+PKEErr ParamsBGV_SetKeySwitchTechnique(ParamsBGVPtr p, int technique);
+PKEErr ParamsBGV_SetDigitSize(ParamsBGVPtr p, int digitSize);
 void DestroyParamsBGV(ParamsBGVPtr p);
 
 // --- BGV CryptoContext ---
@@ -5,8 +5,10 @@ package openfhe
 import "C"
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"unsafe"
 )
 
 type BinFHEParamset C.BINFHE_PARAMSET_C
@@ -88,9 +90,21 @@ const (
 
 // --- Wrapper Structs (Use Handles) ---
 type (
-	BinFHEContext    struct{ h C.BinFHEContextH }
-	BinFHESecretKey  struct{ h C.LWESecretKeyH }
-	BinFHECiphertext struct{ h C.LWECiphertextH }
+	BinFHEContext struct {
+		h   C.BinFHEContextH
+		ref ref
+		// owner is the CryptoContext a scheme-switching context belongs
+		// to; such a context is freed with its owner, not by Close.
+		owner *CryptoContext
+	}
+	BinFHESecretKey struct {
+		h   C.LWESecretKeyH
+		ref ref
+	}
+	BinFHECiphertext struct {
+		h   C.LWECiphertextH
+		ref ref
+	}
 )
 
 // --- Context ---
@@ -115,8 +129,10 @@ func NewBinFHEContext() (*BinFHEContext, error) {
 	return ctx, nil
 }
 
+// Close frees the context once the calls using it have returned. Closing a
+// context from GetBinCCForSchemeSwitch only invalidates the handle.
 func (cc *BinFHEContext) Close() {
-	if cc.h != nil {
+	if cc.ref.close() && cc.owner == nil && cc.h != nil {
 		C.BinFHEContext_Delete(cc.h)
 		cc.h = nil
 	}
@@ -125,9 +141,10 @@ func (cc *BinFHEContext) Close() {
 func (cc *BinFHEContext) Release() { cc.Close() }
 
 func (cc *BinFHEContext) GenerateBinFHEContext(paramset BinFHEParamset, method BinFHEMethod) error {
-	if cc.h == nil {
+	if !cc.acquire() {
 		return errors.New("BinFHEContext is closed or invalid")
 	}
+	defer cc.release()
 
 	status := C.BinFHEContext_Generate(cc.h, C.BINFHE_PARAMSET_C(paramset), C.BINFHE_METHOD_C(method))
 	err := checkBinFHEErrorMsg(status)
@@ -140,9 +157,10 @@ func (cc *BinFHEContext) GenerateBinFHEContext(paramset BinFHEParamset, method B
 
 // --- Keys ---
 func (cc *BinFHEContext) KeyGen() (*BinFHESecretKey, error) {
-	if cc.h == nil {
+	if !cc.acquire() {
 		return nil, errors.New("BinFHEContext is closed or invalid")
 	}
+	defer cc.release()
 
 	var skH C.LWESecretKeyH
 
@@ -161,7 +179,7 @@ func (cc *BinFHEContext) KeyGen() (*BinFHESecretKey, error) {
 }
 
 func (sk *BinFHESecretKey) Close() {
-	if sk.h != nil {
+	if sk.ref.close() && sk.h != nil {
 		C.LWESecretKey_Delete(sk.h)
 		sk.h = nil
 	}
@@ -169,13 +187,15 @@ func (sk *BinFHESecretKey) Close() {
 func (sk *BinFHESecretKey) Release() { sk.Close() }
 
 func (cc *BinFHEContext) BTKeyGen(sk *BinFHESecretKey) error {
-	if cc.h == nil {
+	if !cc.acquire() {
 		return errors.New("BinFHEContext is closed or invalid")
 	}
+	defer cc.release()
 
-	if sk == nil || sk.h == nil {
+	if !sk.acquire() {
 		return errors.New("BinFHESecretKey is closed or invalid")
 	}
+	defer sk.release()
 
 	status := C.BinFHEContext_BTKeyGen(cc.h, sk.h)
 	err := checkBinFHEErrorMsg(status)
@@ -188,13 +208,15 @@ func (cc *BinFHEContext) BTKeyGen(sk *BinFHESecretKey) error {
 
 // --- Operations ---
 func (cc *BinFHEContext) Encrypt(sk *BinFHESecretKey, message int) (*BinFHECiphertext, error) {
-	if cc.h == nil {
+	if !cc.acquire() {
 		return nil, errors.New("BinFHEContext is closed or invalid")
 	}
+	defer cc.release()
 
-	if sk == nil || sk.h == nil {
+	if !sk.acquire() {
 		return nil, errors.New("BinFHESecretKey is closed or invalid")
 	}
+	defer sk.release()
 
 	var ctH C.LWECiphertextH
 
@@ -214,7 +236,7 @@ func (cc *BinFHEContext) Encrypt(sk *BinFHESecretKey, message int) (*BinFHECiphe
 }
 
 func (ct *BinFHECiphertext) Close() {
-	if ct.h != nil {
+	if ct.ref.close() && ct.h != nil {
 		C.LWECiphertext_Delete(ct.h)
 		ct.h = nil
 	}
@@ -222,17 +244,20 @@ func (ct *BinFHECiphertext) Close() {
 func (ct *BinFHECiphertext) Release() { ct.Close() }
 
 func (cc *BinFHEContext) EvalBinGate(gate BinFHEGate, ct1, ct2 *BinFHECiphertext) (*BinFHECiphertext, error) {
-	if cc.h == nil {
+	if !cc.acquire() {
 		return nil, errors.New("BinFHEContext is closed or invalid")
 	}
+	defer cc.release()
 
-	if ct1 == nil || ct1.h == nil {
+	if !ct1.acquire() {
 		return nil, errors.New("first BinFHECiphertext is closed or invalid")
 	}
+	defer ct1.release()
 
-	if ct2 == nil || ct2.h == nil {
+	if !ct2.acquire() {
 		return nil, errors.New("second BinFHECiphertext is closed or invalid")
 	}
+	defer ct2.release()
 
 	var ctOutH C.LWECiphertextH
 
@@ -250,14 +275,22 @@ func (cc *BinFHEContext) EvalBinGate(gate BinFHEGate, ct1, ct2 *BinFHECiphertext
 	return ct, nil
 }
 
+// EvalBinGates applies gate to each pair ct1[i], ct2[i]. On error the
+// results computed so far are closed.
+func (cc *BinFHEContext) EvalBinGates(gate BinFHEGate, ct1, ct2 []*BinFHECiphertext) ([]*BinFHECiphertext, error) {
+	return cc.EvalBinGatesCtx(context.Background(), gate, ct1, ct2)
+}
+
 func (cc *BinFHEContext) Bootstrap(ctIn *BinFHECiphertext) (*BinFHECiphertext, error) {
-	if cc.h == nil {
+	if !cc.acquire() {
 		return nil, errors.New("BinFHEContext is closed or invalid")
 	}
+	defer cc.release()
 
-	if ctIn == nil || ctIn.h == nil {
+	if !ctIn.acquire() {
 		return nil, errors.New("input BinFHECiphertext is closed or invalid")
 	}
+	defer ctIn.release()
 
 	var ctOutH C.LWECiphertextH
 
@@ -277,17 +310,20 @@ func (cc *BinFHEContext) Bootstrap(ctIn *BinFHECiphertext) (*BinFHECiphertext, e
 }
 
 func (cc *BinFHEContext) Decrypt(sk *BinFHESecretKey, ct *BinFHECiphertext) (int, error) {
-	if cc.h == nil {
+	if !cc.acquire() {
 		return 0, errors.New("BinFHEContext is closed or invalid")
 	}
+	defer cc.release()
 
-	if sk == nil || sk.h == nil {
+	if !sk.acquire() {
 		return 0, errors.New("BinFHESecretKey is closed or invalid")
 	}
+	defer sk.release()
 
-	if ct == nil || ct.h == nil {
+	if !ct.acquire() {
 		return 0, errors.New("BinFHECiphertext is closed or invalid")
 	}
+	defer ct.release()
 
 	var resultBit C.int
 
@@ -304,17 +340,20 @@ func (cc *BinFHEContext) Decrypt(sk *BinFHESecretKey, ct *BinFHECiphertext) (int
 // Returns int64 to match OpenFHE's LWEPlaintext type
 // Note: returned values are always in range [0, p-1] despite being signed
 func (cc *BinFHEContext) DecryptModulus(sk *BinFHESecretKey, ct *BinFHECiphertext, p uint64) (int64, error) {
-	if cc.h == nil {
+	if !cc.acquire() {
 		return 0, errors.New("BinFHEContext is closed or invalid")
 	}
+	defer cc.release()
 
-	if sk == nil || sk.h == nil {
+	if !sk.acquire() {
 		return 0, errors.New("BinFHESecretKey is closed or invalid")
 	}
+	defer sk.release()
 
-	if ct == nil || ct.h == nil {
+	if !ct.acquire() {
 		return 0, errors.New("BinFHECiphertext is closed or invalid")
 	}
+	defer ct.release()
 
 	var result C.int64_t
 
@@ -329,9 +368,10 @@ func (cc *BinFHEContext) DecryptModulus(sk *BinFHESecretKey, ct *BinFHECiphertex
 
 // GetMaxPlaintextSpace returns the maximum plaintext space
 func (cc *BinFHEContext) GetMaxPlaintextSpace() (uint32, error) {
-	if cc.h == nil {
+	if !cc.acquire() {
 		return 0, errors.New("BinFHEContext is closed or invalid")
 	}
+	defer cc.release()
 
 	var result C.uint32_t
 	status := C.BinFHEContext_GetMaxPlaintextSpace(cc.h, &result)
@@ -345,9 +385,10 @@ func (cc *BinFHEContext) GetMaxPlaintextSpace() (uint32, error) {
 
 // Getn returns the lattice parameter n
 func (cc *BinFHEContext) Getn() (uint32, error) {
-	if cc.h == nil {
+	if !cc.acquire() {
 		return 0, errors.New("BinFHEContext is closed or invalid")
 	}
+	defer cc.release()
 
 	var result C.uint32_t
 	status := C.BinFHEContext_Getn(cc.h, &result)
@@ -361,9 +402,10 @@ func (cc *BinFHEContext) Getn() (uint32, error) {
 
 // Getq returns the ciphertext modulus q
 func (cc *BinFHEContext) Getq() (uint64, error) {
-	if cc.h == nil {
+	if !cc.acquire() {
 		return 0, errors.New("BinFHEContext is closed or invalid")
 	}
+	defer cc.release()
 
 	var result C.uint64_t
 	status := C.BinFHEContext_Getq(cc.h, &result)
@@ -377,9 +419,10 @@ func (cc *BinFHEContext) Getq() (uint64, error) {
 
 // GetBeta returns the beta parameter
 func (cc *BinFHEContext) GetBeta() (uint32, error) {
-	if cc.h == nil {
+	if !cc.acquire() {
 		return 0, errors.New("BinFHEContext is closed or invalid")
 	}
+	defer cc.release()
 
 	var result C.uint32_t
 	status := C.BinFHEContext_GetBeta(cc.h, &result)
@@ -393,13 +436,15 @@ func (cc *BinFHEContext) GetBeta() (uint32, error) {
 
 // EvalSign evaluates the sign function on an LWE ciphertext
 func (cc *BinFHEContext) EvalSign(ct *BinFHECiphertext) (*BinFHECiphertext, error) {
-	if cc.h == nil {
+	if !cc.acquire() {
 		return nil, errors.New("BinFHEContext is closed or invalid")
 	}
+	defer cc.release()
 
-	if ct == nil || ct.h == nil {
+	if !ct.acquire() {
 		return nil, errors.New("BinFHECiphertext is closed or invalid")
 	}
+	defer ct.release()
 
 	var outH C.LWECiphertextH
 	status := C.BinFHEContext_EvalSign(cc.h, ct.h, &outH)
@@ -417,13 +462,15 @@ func (cc *BinFHEContext) EvalSign(ct *BinFHECiphertext) (*BinFHECiphertext, erro
 
 // EvalFloor evaluates the floor function, removing the lowest 'bits' bits
 func (cc *BinFHEContext) EvalFloor(ct *BinFHECiphertext, bits uint32) (*BinFHECiphertext, error) {
-	if cc.h == nil {
+	if !cc.acquire() {
 		return nil, errors.New("BinFHEContext is closed or invalid")
 	}
+	defer cc.release()
 
-	if ct == nil || ct.h == nil {
+	if !ct.acquire() {
 		return nil, errors.New("BinFHECiphertext is closed or invalid")
 	}
+	defer ct.release()
 
 	var outH C.LWECiphertextH
 	status := C.BinFHEContext_EvalFloor(cc.h, ct.h, C.uint32_t(bits), &outH)
@@ -441,13 +488,15 @@ func (cc *BinFHEContext) EvalFloor(ct *BinFHECiphertext, bits uint32) (*BinFHECi
 
 // EvalNOT evaluates the NOT operation on a ciphertext
 func (cc *BinFHEContext) EvalNOT(ct *BinFHECiphertext) (*BinFHECiphertext, error) {
-	if cc.h == nil {
+	if !cc.acquire() {
 		return nil, errors.New("BinFHEContext is closed or invalid")
 	}
+	defer cc.release()
 
-	if ct == nil || ct.h == nil {
+	if !ct.acquire() {
 		return nil, errors.New("BinFHECiphertext is closed or invalid")
 	}
+	defer ct.release()
 
 	var outH C.LWECiphertextH
 	status := C.BinFHEContext_EvalNOT(cc.h, ct.h, &outH)
@@ -462,3 +511,66 @@ func (cc *BinFHEContext) EvalNOT(ct *BinFHECiphertext) (*BinFHECiphertext, error
 
 	return &BinFHECiphertext{h: outH}, nil
 }
+
+// GenerateLUTViaFunction tabulates f over the plaintext space Z_p for use
+// with EvalFunc. The returned table has one entry per element of Z_q; p must
+// be a power of two no larger than q, and f must map into [0, p).
+func (cc *BinFHEContext) GenerateLUTViaFunction(f func(m, p uint64) uint64, p uint64) ([]uint64, error) {
+	if f == nil {
+		return nil, errors.New("GenerateLUTViaFunction: nil function")
+	}
+	if p < 2 || p&(p-1) != 0 {
+		return nil, fmt.Errorf("GenerateLUTViaFunction: plaintext modulus %d is not a power of two", p)
+	}
+
+	q, err := cc.Getq()
+	if err != nil {
+		return nil, err
+	}
+	if p > q {
+		return nil, fmt.Errorf("GenerateLUTViaFunction: plaintext modulus %d exceeds q=%d", p, q)
+	}
+
+	interval := q / p
+	lut := make([]uint64, q)
+	for i := range lut {
+		v := f(uint64(i)/interval, p)
+		if v >= p {
+			return nil, fmt.Errorf("GenerateLUTViaFunction: f(%d) = %d is outside Z_%d", uint64(i)/interval, v, p)
+		}
+		lut[i] = v * interval
+	}
+	return lut, nil
+}
+
+// EvalFunc evaluates an arbitrary function, given as a lookup table from
+// GenerateLUTViaFunction, on a ciphertext. The context must have been
+// generated with arbitrary function evaluation enabled.
+func (cc *BinFHEContext) EvalFunc(ct *BinFHECiphertext, lut []uint64) (*BinFHECiphertext, error) {
+	if !cc.acquire() {
+		return nil, errors.New("BinFHEContext is closed or invalid")
+	}
+	defer cc.release()
+
+	if !ct.acquire() {
+		return nil, errors.New("BinFHECiphertext is closed or invalid")
+	}
+	defer ct.release()
+
+	if len(lut) == 0 {
+		return nil, errors.New("EvalFunc: empty lookup table")
+	}
+
+	var outH C.LWECiphertextH
+	status := C.BinFHEContext_EvalFunc(cc.h, ct.h, (*C.uint64_t)(unsafe.Pointer(&lut[0])), C.int(len(lut)), &outH)
+	err := checkBinFHEErrorMsg(status)
+	if err != nil {
+		return nil, err
+	}
+
+	if outH == nil {
+		return nil, fmt.Errorf("EvalFunc returned OK but null handle")
+	}
+
+	return &BinFHECiphertext{h: outH}, nil
+}
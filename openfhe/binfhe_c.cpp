@@ -1,8 +1,11 @@
 #include "binfhe_c.h"
+#include "binfhecontext-ser.h"
 #include "binfhecontext.h"
 #include "helpers_c.h"
 #include <exception>
+#include <sstream>
 #include <utility>
+#include <vector>
 
 // Helper macros for try/catch blocks
 #define BINFHE_CATCH_RETURN()                                                  \
@@ -366,6 +369,30 @@ BinFHEErr BinFHEContext_EvalNOT(BinFHEContextH h, LWECiphertextH cth,
   BINFHE_CATCH_RETURN()
 }
 
+BinFHEErr BinFHEContext_EvalFunc(BinFHEContextH h, LWECiphertextH cth,
+                                 const uint64_t *lut, int lutLen,
+                                 LWECiphertextH *out) {
+  try {
+    if (!h) {
+      return MakeBinFHEError("Null BinFHEContext handle");
+    }
+    if (!cth) {
+      return MakeBinFHEError("Null LWECiphertext handle");
+    }
+    if (!lut || lutLen <= 0) {
+      return MakeBinFHEError("Empty lookup table for EvalFunc");
+    }
+    if (!out) {
+      return MakeBinFHEError("Null output pointer for EvalFunc");
+    }
+    std::vector<lbcrypto::NativeInteger> table(lut, lut + lutLen);
+    auto ct_val = AsBinFHEContext(h)->EvalFunc(*AsLWECiphertext(cth), table);
+    *out = new lbcrypto::LWECiphertext(std::move(ct_val));
+    return MakeBinFHEOk();
+  }
+  BINFHE_CATCH_RETURN()
+}
+
 BinFHEErr BinFHEContext_DecryptModulusLWEKey(BinFHEContextH h, void *skh,
                                              LWECiphertextH cth, uint64_t p,
                                              int64_t *out_val) {
@@ -396,4 +423,72 @@ BinFHEErr BinFHEContext_DecryptModulusLWEKey(BinFHEContextH h, void *skh,
   BINFHE_CATCH_RETURN()
 }
 
+BinFHEErr BinFHEContext_BTKeyGenLWEKey(BinFHEContextH h, void *skh) {
+  try {
+    if (!h) {
+      return MakeBinFHEError("Null BinFHEContext handle");
+    }
+    if (!skh) {
+      return MakeBinFHEError("Null LWEPrivateKey handle");
+    }
+    auto *lwesk = static_cast<lbcrypto::LWEPrivateKey *>(skh);
+    AsBinFHEContext(h)->BTKeyGen(*lwesk);
+    return MakeBinFHEOk();
+  }
+  BINFHE_CATCH_RETURN()
+}
+
+// --- Serialization ---
+BinFHEErr BinFHEContext_SerializeToBytes(BinFHEContextH h, char **outBytes,
+                                         size_t *outLen) {
+  try {
+    if (!h) {
+      return MakeBinFHEError("Null BinFHEContext handle");
+    }
+    if (!outBytes || !outLen) {
+      return MakeBinFHEError("Null output pointer for SerializeToBytes");
+    }
+    auto *cc = AsBinFHEContext(h);
+    std::stringstream ss;
+    lbcrypto::Serial::Serialize(*cc, ss, lbcrypto::SerType::BINARY);
+    lbcrypto::Serial::Serialize(cc->GetRefreshKey(), ss,
+                                lbcrypto::SerType::BINARY);
+    lbcrypto::Serial::Serialize(cc->GetSwitchKey(), ss,
+                                lbcrypto::SerType::BINARY);
+    std::string data = ss.str();
+    *outBytes = CopyStringToC(data);
+    if (!*outBytes) {
+      return MakeBinFHEError("Failed to allocate serialization buffer");
+    }
+    *outLen = data.size();
+    return MakeBinFHEOk();
+  }
+  BINFHE_CATCH_RETURN()
+}
+
+BinFHEErr BinFHEContext_DeserializeFromBytes(const char *inData, int inLen,
+                                             BinFHEContextH *out) {
+  try {
+    if (!inData || inLen <= 0) {
+      return MakeBinFHEError("No data to deserialize");
+    }
+    if (!out) {
+      return MakeBinFHEError("Null output pointer for DeserializeFromBytes");
+    }
+    std::stringstream ss(std::string(inData, inLen));
+    auto cc = std::make_unique<lbcrypto::BinFHEContext>();
+    lbcrypto::Serial::Deserialize(*cc, ss, lbcrypto::SerType::BINARY);
+    lbcrypto::RingGSWACCKey refreshKey;
+    lbcrypto::Serial::Deserialize(refreshKey, ss, lbcrypto::SerType::BINARY);
+    lbcrypto::LWESwitchingKey switchKey;
+    lbcrypto::Serial::Deserialize(switchKey, ss, lbcrypto::SerType::BINARY);
+    if (refreshKey && switchKey) {
+      cc->BTKeyLoad({refreshKey, switchKey});
+    }
+    *out = cc.release();
+    return MakeBinFHEOk();
+  }
+  BINFHE_CATCH_RETURN()
+}
+
 } // extern "C"
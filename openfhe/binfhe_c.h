@@ -138,6 +138,17 @@ BinFHEErr BinFHEContext_DecryptModulusLWEKey(BinFHEContextH h, void *sk,
                                              LWECiphertextH ct, uint64_t p,
                                              int64_t *out_val);
 
+// Generates bootstrapping keys from an LWEPrivateKey (from scheme switching)
+BinFHEErr BinFHEContext_BTKeyGenLWEKey(BinFHEContextH h, void *sk);
+
+// --- Serialization ---
+// Serializes the context parameters together with its bootstrapping keys,
+// if generated. outBytes is malloc'd; release it with free().
+BinFHEErr BinFHEContext_SerializeToBytes(BinFHEContextH h, char **outBytes,
+                                         size_t *outLen);
+BinFHEErr BinFHEContext_DeserializeFromBytes(const char *inData, int inLen,
+                                             BinFHEContextH *out);
+
 // --- Parameter Getters ---
 BinFHEErr BinFHEContext_GetMaxPlaintextSpace(BinFHEContextH h, uint32_t *out);
 BinFHEErr BinFHEContext_Getn(BinFHEContextH h, uint32_t *out);
@@ -152,8 +163,13 @@ BinFHEErr BinFHEContext_EvalFloor(BinFHEContextH h, LWECiphertextH ct,
 BinFHEErr BinFHEContext_EvalNOT(BinFHEContextH h, LWECiphertextH ct,
                                 LWECiphertextH *out);
 
-// Note: LUT and arbitrary function evaluation require more complex types
-// and will be added in a future enhancement if needed
+
+// --- Arbitrary function evaluation ---
+// Evaluates a lookup table of lutLen entries (one per element of Z_q, as
+// produced by GenerateLUTviaFunction) on ct.
+BinFHEErr BinFHEContext_EvalFunc(BinFHEContextH h, LWECiphertextH ct,
+                                 const uint64_t *lut, int lutLen,
+                                 LWECiphertextH *out);
 
 #ifdef __cplusplus
 }
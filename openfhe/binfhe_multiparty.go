@@ -0,0 +1,440 @@
+package openfhe
+
+/*
+#cgo CPPFLAGS: -I${SRCDIR}/../openfhe-install/include -I${SRCDIR}/../openfhe-install/include/openfhe -I${SRCDIR}/../openfhe-install/include/openfhe/core -I${SRCDIR}/../openfhe-install/include/openfhe/pke -I${SRCDIR}/../openfhe-install/include/openfhe/binfhe -I${SRCDIR}/../openfhe-install/include/openfhe/cereal
+#cgo CXXFLAGS: -std=c++17
+#include <stdint.h>
+#include "binfhe_multiparty_c.h"
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Threshold FHEW lets several parties evaluate boolean circuits over data
+// encrypted under a joint key that none of them holds. The protocol runs
+// against one BinFHEContext per party, all generated with the same
+// parameters via GenerateBinFHEContextMultiparty:
+//
+//  1. Key generation, in party order: the lead calls KeyGenPair, every other
+//     party calls MultipartyKeyGen on its predecessor's key pair. The last
+//     party's public key is the joint public key.
+//  2. The lead publishes a MultipartyCRS. Each party draws a ring key with
+//     RingKeyGen and publishes MultipartyRGSWShare; anyone sums the shares
+//     with MultipartyRGSWAdd.
+//  3. Bootstrapping keys, in party order: each party calls
+//     MultipartyBTKeyGen on its predecessor's share (nil for the lead). The
+//     last share is loaded into every context with MultipartyBTKeyLoad.
+//  4. Data is encrypted with EncryptPublic under the joint public key and
+//     evaluated with the usual gate operations.
+//  5. Each party produces a partial decryption (MultipartyDecryptLead for
+//     the lead, MultipartyDecryptMain otherwise); MultipartyDecryptFusion
+//     combines all of them into the plaintext.
+
+type (
+	// BinFHEKeyPair is one party's LWE key pair. Its public key is the
+	// joint public key of this party and all parties before it.
+	BinFHEKeyPair struct {
+		h   C.LWEKeyPairH
+		ref ref
+	}
+	// BinFHERingKey is a party's RGSW secret for bootstrapping key
+	// generation. It never leaves the party.
+	BinFHERingKey struct {
+		h   C.BinFHERingKeyH
+		ref ref
+	}
+	// BinFHEMultipartyCRS is the public randomness shared by all parties.
+	BinFHEMultipartyCRS struct {
+		h   C.BinFHEMPCRSH
+		ref ref
+	}
+	// BinFHERGSWShares are RGSW encryptions of zero under one party's
+	// ring key, or under the joint ring key once summed.
+	BinFHERGSWShares struct {
+		h   C.BinFHERGSWSharesH
+		ref ref
+	}
+	// BinFHEBTKeyShare holds the bootstrapping keys after some prefix of
+	// the parties has contributed.
+	BinFHEBTKeyShare struct {
+		h   C.BinFHEBTKeyShareH
+		ref ref
+	}
+)
+
+// GenerateBinFHEContextMultiparty generates a context for numParties
+// parties. Only the GINX and LMKCDEY methods support threshold FHEW.
+func (cc *BinFHEContext) GenerateBinFHEContextMultiparty(paramset BinFHEParamset, method BinFHEMethod, numParties uint32) error {
+	if !cc.acquire() {
+		return errors.New("BinFHEContext is closed or invalid")
+	}
+	defer cc.release()
+	if method != GINX && method != LMKCDEY {
+		return errors.New("threshold FHEW requires the GINX or LMKCDEY method")
+	}
+	if numParties == 0 {
+		return errors.New("numParties must be at least 1")
+	}
+
+	status := C.BinFHEContext_GenerateMultiparty(cc.h, C.BINFHE_PARAMSET_C(paramset), C.BINFHE_METHOD_C(method), C.uint32_t(numParties))
+	return checkBinFHEErrorMsg(status)
+}
+
+// --- Joint public key ---
+
+// KeyGenPair generates the lead party's key pair.
+func (cc *BinFHEContext) KeyGenPair() (*BinFHEKeyPair, error) {
+	if !cc.acquire() {
+		return nil, errors.New("BinFHEContext is closed or invalid")
+	}
+	defer cc.release()
+
+	var kpH C.LWEKeyPairH
+	status := C.BinFHEContext_KeyGenPair(cc.h, &kpH)
+	if err := checkBinFHEErrorMsg(status); err != nil {
+		return nil, err
+	}
+	if kpH == nil {
+		return nil, errors.New("KeyGenPair returned OK but null handle")
+	}
+	return &BinFHEKeyPair{h: kpH}, nil
+}
+
+// MultipartyKeyGen generates the key pair of the party after prev. Only the
+// public key of prev is used.
+func (cc *BinFHEContext) MultipartyKeyGen(prev *BinFHEKeyPair) (*BinFHEKeyPair, error) {
+	if !cc.acquire() {
+		return nil, errors.New("BinFHEContext is closed or invalid")
+	}
+	defer cc.release()
+	if !prev.acquire() {
+		return nil, errors.New("previous BinFHEKeyPair is closed or invalid")
+	}
+	defer prev.release()
+
+	var kpH C.LWEKeyPairH
+	status := C.BinFHEContext_MultipartyKeyGen(cc.h, prev.h, &kpH)
+	if err := checkBinFHEErrorMsg(status); err != nil {
+		return nil, err
+	}
+	if kpH == nil {
+		return nil, errors.New("MultipartyKeyGen returned OK but null handle")
+	}
+	return &BinFHEKeyPair{h: kpH}, nil
+}
+
+// SecretKey returns a copy of this party's secret key share.
+func (kp *BinFHEKeyPair) SecretKey() (*BinFHESecretKey, error) {
+	if !kp.acquire() {
+		return nil, errors.New("BinFHEKeyPair is closed or invalid")
+	}
+	defer kp.release()
+
+	var skH C.LWESecretKeyH
+	status := C.LWEKeyPair_GetSecretKey(kp.h, &skH)
+	if err := checkBinFHEErrorMsg(status); err != nil {
+		return nil, err
+	}
+	if skH == nil {
+		return nil, errors.New("GetSecretKey returned OK but null handle")
+	}
+	return &BinFHESecretKey{h: skH}, nil
+}
+
+func (kp *BinFHEKeyPair) Close() {
+	if kp.ref.close() && kp.h != nil {
+		C.LWEKeyPair_Delete(kp.h)
+		kp.h = nil
+	}
+}
+
+func (kp *BinFHEKeyPair) Release() { kp.Close() }
+
+// EncryptPublic encrypts a bit under the public key of kp. The ciphertext is
+// key-switched down to dimension n, so the bootstrapping keys must already
+// be loaded.
+func (cc *BinFHEContext) EncryptPublic(kp *BinFHEKeyPair, message int) (*BinFHECiphertext, error) {
+	if !cc.acquire() {
+		return nil, errors.New("BinFHEContext is closed or invalid")
+	}
+	defer cc.release()
+	if !kp.acquire() {
+		return nil, errors.New("BinFHEKeyPair is closed or invalid")
+	}
+	defer kp.release()
+
+	var ctH C.LWECiphertextH
+	status := C.BinFHEContext_EncryptPublic(cc.h, kp.h, C.int(message), &ctH)
+	if err := checkBinFHEErrorMsg(status); err != nil {
+		return nil, err
+	}
+	if ctH == nil {
+		return nil, errors.New("EncryptPublic returned OK but null handle")
+	}
+	return &BinFHECiphertext{h: ctH}, nil
+}
+
+// --- Distributed bootstrapping key generation ---
+
+// MultipartyCRS samples the common reference string for bootstrapping key
+// generation. The lead party generates it once; all parties must use it.
+func (cc *BinFHEContext) MultipartyCRS() (*BinFHEMultipartyCRS, error) {
+	if !cc.acquire() {
+		return nil, errors.New("BinFHEContext is closed or invalid")
+	}
+	defer cc.release()
+
+	var crsH C.BinFHEMPCRSH
+	status := C.BinFHEContext_MultipartyCRSGen(cc.h, &crsH)
+	if err := checkBinFHEErrorMsg(status); err != nil {
+		return nil, err
+	}
+	if crsH == nil {
+		return nil, errors.New("MultipartyCRSGen returned OK but null handle")
+	}
+	return &BinFHEMultipartyCRS{h: crsH}, nil
+}
+
+func (crs *BinFHEMultipartyCRS) Close() {
+	if crs.ref.close() && crs.h != nil {
+		C.BinFHEMPCRS_Delete(crs.h)
+		crs.h = nil
+	}
+}
+
+// RingKeyGen samples this party's ring secret.
+func (cc *BinFHEContext) RingKeyGen() (*BinFHERingKey, error) {
+	if !cc.acquire() {
+		return nil, errors.New("BinFHEContext is closed or invalid")
+	}
+	defer cc.release()
+
+	var zH C.BinFHERingKeyH
+	status := C.BinFHEContext_RingKeyGen(cc.h, &zH)
+	if err := checkBinFHEErrorMsg(status); err != nil {
+		return nil, err
+	}
+	if zH == nil {
+		return nil, errors.New("RingKeyGen returned OK but null handle")
+	}
+	return &BinFHERingKey{h: zH}, nil
+}
+
+func (z *BinFHERingKey) Close() {
+	if z.ref.close() && z.h != nil {
+		C.BinFHERingKey_Delete(z.h)
+		z.h = nil
+	}
+}
+
+// MultipartyRGSWShare computes this party's RGSW encryptions of zero under
+// its ring key. lead must be set for exactly one party.
+func (cc *BinFHEContext) MultipartyRGSWShare(crs *BinFHEMultipartyCRS, z *BinFHERingKey, lead bool) (*BinFHERGSWShares, error) {
+	if !cc.acquire() {
+		return nil, errors.New("BinFHEContext is closed or invalid")
+	}
+	defer cc.release()
+	if !crs.acquire() {
+		return nil, errors.New("BinFHEMultipartyCRS is closed or invalid")
+	}
+	defer crs.release()
+	if !z.acquire() {
+		return nil, errors.New("BinFHERingKey is closed or invalid")
+	}
+	defer z.release()
+
+	var leadFlag C.int
+	if lead {
+		leadFlag = 1
+	}
+
+	var sH C.BinFHERGSWSharesH
+	status := C.BinFHEContext_MultipartyRGSWShare(cc.h, crs.h, z.h, leadFlag, &sH)
+	if err := checkBinFHEErrorMsg(status); err != nil {
+		return nil, err
+	}
+	if sH == nil {
+		return nil, errors.New("MultipartyRGSWShare returned OK but null handle")
+	}
+	return &BinFHERGSWShares{h: sH}, nil
+}
+
+// MultipartyRGSWAdd sums the RGSW shares of all parties.
+func (cc *BinFHEContext) MultipartyRGSWAdd(shares []*BinFHERGSWShares) (*BinFHERGSWShares, error) {
+	if !cc.acquire() {
+		return nil, errors.New("BinFHEContext is closed or invalid")
+	}
+	defer cc.release()
+	if len(shares) == 0 {
+		return nil, errors.New("no RGSW shares to add")
+	}
+
+	handles := make([]C.BinFHERGSWSharesH, len(shares))
+	for i, s := range shares {
+		if !s.acquire() {
+			return nil, fmt.Errorf("RGSW share %d is closed or invalid", i)
+		}
+		defer s.release()
+		handles[i] = s.h
+	}
+
+	var sH C.BinFHERGSWSharesH
+	status := C.BinFHEContext_MultipartyRGSWAdd(cc.h, &handles[0], C.int(len(handles)), &sH)
+	if err := checkBinFHEErrorMsg(status); err != nil {
+		return nil, err
+	}
+	if sH == nil {
+		return nil, errors.New("MultipartyRGSWAdd returned OK but null handle")
+	}
+	return &BinFHERGSWShares{h: sH}, nil
+}
+
+func (s *BinFHERGSWShares) Close() {
+	if s.ref.close() && s.h != nil {
+		C.BinFHERGSWShares_Delete(s.h)
+		s.h = nil
+	}
+}
+
+// MultipartyBTKeyGen adds this party's contribution to the bootstrapping
+// keys. prev is nil for the lead party and the previous party's share for
+// everyone else.
+func (cc *BinFHEContext) MultipartyBTKeyGen(kp *BinFHEKeyPair, z *BinFHERingKey, crs *BinFHEMultipartyCRS, joint *BinFHERGSWShares, prev *BinFHEBTKeyShare) (*BinFHEBTKeyShare, error) {
+	if !cc.acquire() {
+		return nil, errors.New("BinFHEContext is closed or invalid")
+	}
+	defer cc.release()
+	if !kp.acquire() {
+		return nil, errors.New("BinFHEKeyPair is closed or invalid")
+	}
+	defer kp.release()
+	if !z.acquire() {
+		return nil, errors.New("BinFHERingKey is closed or invalid")
+	}
+	defer z.release()
+	if !crs.acquire() {
+		return nil, errors.New("BinFHEMultipartyCRS is closed or invalid")
+	}
+	defer crs.release()
+	if !joint.acquire() {
+		return nil, errors.New("joint BinFHERGSWShares is closed or invalid")
+	}
+	defer joint.release()
+
+	var prevH C.BinFHEBTKeyShareH
+	if prev != nil {
+		if !prev.acquire() {
+			return nil, errors.New("previous BinFHEBTKeyShare is closed")
+		}
+		defer prev.release()
+		prevH = prev.h
+	}
+
+	var bH C.BinFHEBTKeyShareH
+	status := C.BinFHEContext_MultipartyBTKeyGen(cc.h, kp.h, z.h, crs.h, joint.h, prevH, &bH)
+	if err := checkBinFHEErrorMsg(status); err != nil {
+		return nil, err
+	}
+	if bH == nil {
+		return nil, errors.New("MultipartyBTKeyGen returned OK but null handle")
+	}
+	return &BinFHEBTKeyShare{h: bH}, nil
+}
+
+// MultipartyBTKeyLoad installs the final party's share as the bootstrapping
+// keys of this context.
+func (cc *BinFHEContext) MultipartyBTKeyLoad(share *BinFHEBTKeyShare) error {
+	if !cc.acquire() {
+		return errors.New("BinFHEContext is closed or invalid")
+	}
+	defer cc.release()
+	if !share.acquire() {
+		return errors.New("BinFHEBTKeyShare is closed or invalid")
+	}
+	defer share.release()
+
+	status := C.BinFHEContext_MultipartyBTKeyLoad(cc.h, share.h)
+	return checkBinFHEErrorMsg(status)
+}
+
+func (s *BinFHEBTKeyShare) Close() {
+	if s.ref.close() && s.h != nil {
+		C.BinFHEBTKeyShare_Delete(s.h)
+		s.h = nil
+	}
+}
+
+// --- Threshold decryption ---
+
+// MultipartyDecryptLead computes the lead party's partial decryption of ct.
+// p is the plaintext modulus; use 4 for boolean ciphertexts.
+func (cc *BinFHEContext) MultipartyDecryptLead(kp *BinFHEKeyPair, ct *BinFHECiphertext, p uint64) (*BinFHECiphertext, error) {
+	return cc.multipartyDecrypt(kp, ct, p, true)
+}
+
+// MultipartyDecryptMain computes a non-lead party's partial decryption of
+// ct.
+func (cc *BinFHEContext) MultipartyDecryptMain(kp *BinFHEKeyPair, ct *BinFHECiphertext, p uint64) (*BinFHECiphertext, error) {
+	return cc.multipartyDecrypt(kp, ct, p, false)
+}
+
+func (cc *BinFHEContext) multipartyDecrypt(kp *BinFHEKeyPair, ct *BinFHECiphertext, p uint64, lead bool) (*BinFHECiphertext, error) {
+	if !cc.acquire() {
+		return nil, errors.New("BinFHEContext is closed or invalid")
+	}
+	defer cc.release()
+	if !kp.acquire() {
+		return nil, errors.New("BinFHEKeyPair is closed or invalid")
+	}
+	defer kp.release()
+	if !ct.acquire() {
+		return nil, errors.New("BinFHECiphertext is closed or invalid")
+	}
+	defer ct.release()
+
+	var outH C.LWECiphertextH
+	var status C.BinFHEErr
+	if lead {
+		status = C.BinFHEContext_MultipartyDecryptLead(cc.h, kp.h, ct.h, C.uint64_t(p), &outH)
+	} else {
+		status = C.BinFHEContext_MultipartyDecryptMain(cc.h, kp.h, ct.h, C.uint64_t(p), &outH)
+	}
+	if err := checkBinFHEErrorMsg(status); err != nil {
+		return nil, err
+	}
+	if outH == nil {
+		return nil, errors.New("MultipartyDecrypt returned OK but null handle")
+	}
+	return &BinFHECiphertext{h: outH}, nil
+}
+
+// MultipartyDecryptFusion combines the partial decryptions of every party
+// into the plaintext, which lies in [0, p).
+func (cc *BinFHEContext) MultipartyDecryptFusion(partials []*BinFHECiphertext, p uint64) (int64, error) {
+	if !cc.acquire() {
+		return 0, errors.New("BinFHEContext is closed or invalid")
+	}
+	defer cc.release()
+	if len(partials) == 0 {
+		return 0, errors.New("no partial decryptions to fuse")
+	}
+
+	handles := make([]C.LWECiphertextH, len(partials))
+	for i, pd := range partials {
+		if !pd.acquire() {
+			return 0, fmt.Errorf("partial decryption %d is closed or invalid", i)
+		}
+		defer pd.release()
+		handles[i] = pd.h
+	}
+
+	var result C.int64_t
+	status := C.BinFHEContext_MultipartyDecryptFusion(cc.h, &handles[0], C.int(len(handles)), C.uint64_t(p), &result)
+	if err := checkBinFHEErrorMsg(status); err != nil {
+		return 0, err
+	}
+	return int64(result), nil
+}
@@ -0,0 +1,431 @@
+#include "binfhe_multiparty_c.h"
+#include "binfhecontext.h"
+#include "helpers_c.h"
+#include <exception>
+#include <string>
+#include <utility>
+#include <vector>
+
+using namespace lbcrypto;
+
+#define BINFHE_CATCH_RETURN()                                                  \
+  catch (const std::exception &e) {                                            \
+    return MakeBinFHEError(e.what());                                          \
+  }                                                                            \
+  catch (...) {                                                                \
+    return MakeBinFHEError("Unknown C++ exception caught in BinFHE.");         \
+  }
+
+static inline BinFHEErr MakeBinFHEOk() {
+  return (BinFHEErr){BINFHE_OK_CODE, NULL};
+}
+
+static inline BinFHEErr MakeBinFHEError(const std::string &msg) {
+  return (BinFHEErr){BINFHE_ERR_CODE, DupString(msg)};
+}
+
+namespace {
+
+// Public randomness every party uses for its RGSW and automorphism key
+// contributions. acrs holds one row of polynomials per LWE secret
+// coefficient, acrsauto one row per automorphism key.
+struct MultipartyCRS {
+  std::vector<std::vector<NativePoly>> acrs;
+  std::vector<std::vector<NativePoly>> acrsauto;
+};
+
+// Bootstrapping keys after some prefix of the parties has contributed.
+struct BTKeyShare {
+  RingGSWACCKey refreshKey;
+  LWESwitchingKey switchKey;
+};
+
+inline BinFHEContext *AsBinFHEContext(BinFHEContextH h) {
+  return static_cast<BinFHEContext *>(h);
+}
+inline LWEKeyPair *AsLWEKeyPair(LWEKeyPairH h) {
+  return static_cast<LWEKeyPair *>(h);
+}
+inline LWECiphertext *AsLWECiphertext(LWECiphertextH h) {
+  return static_cast<LWECiphertext *>(h);
+}
+inline NativePoly *AsRingKey(BinFHERingKeyH h) {
+  return static_cast<NativePoly *>(h);
+}
+inline MultipartyCRS *AsCRS(BinFHEMPCRSH h) {
+  return static_cast<MultipartyCRS *>(h);
+}
+inline std::vector<RingGSWEvalKey> *AsRGSWShares(BinFHERGSWSharesH h) {
+  return static_cast<std::vector<RingGSWEvalKey> *>(h);
+}
+inline BTKeyShare *AsBTKeyShare(BinFHEBTKeyShareH h) {
+  return static_cast<BTKeyShare *>(h);
+}
+
+// The ring secret doubles as the LWE secret of dimension N that the
+// switching key maps back from.
+LWEPrivateKey ringKeyAsLWE(const NativePoly &z) {
+  NativePoly zc(z);
+  zc.SetFormat(Format::COEFFICIENT);
+  return std::make_shared<LWEPrivateKeyImpl>(zc.GetValues());
+}
+
+} // namespace
+
+// --- Context ---
+BinFHEErr BinFHEContext_GenerateMultiparty(BinFHEContextH h,
+                                           BINFHE_PARAMSET_C paramset,
+                                           BINFHE_METHOD_C method,
+                                           uint32_t numParties) {
+  try {
+    if (!h) {
+      return MakeBinFHEError("Null BinFHEContext handle");
+    }
+    if (method != BINFHE_METHOD_GINX && method != BINFHE_METHOD_LMKCDEY) {
+      return MakeBinFHEError(
+          "Threshold FHEW requires the GINX or LMKCDEY method");
+    }
+    if (numParties == 0) {
+      return MakeBinFHEError("numParties must be at least 1");
+    }
+
+    AsBinFHEContext(h)->GenerateBinFHEContext(
+        static_cast<BINFHE_PARAMSET>(paramset),
+        static_cast<BINFHE_METHOD>(method), numParties);
+    return MakeBinFHEOk();
+  }
+  BINFHE_CATCH_RETURN()
+}
+
+// --- Joint LWE public key ---
+BinFHEErr BinFHEContext_KeyGenPair(BinFHEContextH h, LWEKeyPairH *out) {
+  try {
+    if (!h) {
+      return MakeBinFHEError("Null BinFHEContext handle");
+    }
+    if (!out) {
+      return MakeBinFHEError("Null output pointer for KeyGenPair");
+    }
+
+    *out = new LWEKeyPair(AsBinFHEContext(h)->KeyGenPair());
+    return MakeBinFHEOk();
+  }
+  BINFHE_CATCH_RETURN()
+}
+
+BinFHEErr BinFHEContext_MultipartyKeyGen(BinFHEContextH h, LWEKeyPairH prev,
+                                         LWEKeyPairH *out) {
+  try {
+    if (!h) {
+      return MakeBinFHEError("Null BinFHEContext handle");
+    }
+    if (!prev) {
+      return MakeBinFHEError("Null previous LWEKeyPair handle");
+    }
+    if (!out) {
+      return MakeBinFHEError("Null output pointer for MultipartyKeyGen");
+    }
+
+    auto &prevKeys = *AsLWEKeyPair(prev);
+    *out = new LWEKeyPair(
+        AsBinFHEContext(h)->MultipartyKeyGen(prevKeys->publicKey));
+    return MakeBinFHEOk();
+  }
+  BINFHE_CATCH_RETURN()
+}
+
+BinFHEErr LWEKeyPair_GetSecretKey(LWEKeyPairH kp, LWESecretKeyH *out) {
+  try {
+    if (!kp) {
+      return MakeBinFHEError("Null LWEKeyPair handle");
+    }
+    if (!out) {
+      return MakeBinFHEError("Null output pointer for GetSecretKey");
+    }
+
+    *out = new LWEPrivateKey((*AsLWEKeyPair(kp))->secretKey);
+    return MakeBinFHEOk();
+  }
+  BINFHE_CATCH_RETURN()
+}
+
+void LWEKeyPair_Delete(LWEKeyPairH kp) { delete AsLWEKeyPair(kp); }
+
+BinFHEErr BinFHEContext_EncryptPublic(BinFHEContextH h, LWEKeyPairH kp,
+                                      int message, LWECiphertextH *out) {
+  try {
+    if (!h) {
+      return MakeBinFHEError("Null BinFHEContext handle");
+    }
+    if (!kp) {
+      return MakeBinFHEError("Null LWEKeyPair handle");
+    }
+    if (!out) {
+      return MakeBinFHEError("Null output pointer for EncryptPublic");
+    }
+
+    auto ct = AsBinFHEContext(h)->Encrypt((*AsLWEKeyPair(kp))->publicKey,
+                                          message);
+    *out = new LWECiphertext(std::move(ct));
+    return MakeBinFHEOk();
+  }
+  BINFHE_CATCH_RETURN()
+}
+
+// --- Distributed bootstrapping key generation ---
+BinFHEErr BinFHEContext_MultipartyCRSGen(BinFHEContextH h, BinFHEMPCRSH *out) {
+  try {
+    if (!h) {
+      return MakeBinFHEError("Null BinFHEContext handle");
+    }
+    if (!out) {
+      return MakeBinFHEError("Null output pointer for MultipartyCRSGen");
+    }
+
+    auto cc = AsBinFHEContext(h);
+    auto params = cc->GetParams();
+    uint32_t n = params->GetLWEParams()->Getn();
+    uint32_t digits = params->GetRingGSWParams()->GetDigitsG();
+    uint32_t numAutoKeys = params->GetRingGSWParams()->GetNumAutoKeys();
+
+    auto crs = new MultipartyCRS();
+    crs->acrs.resize(n);
+    for (auto &row : crs->acrs) {
+      for (uint32_t j = 0; j < 2 * digits; ++j) {
+        row.push_back(cc->Generateacrs());
+      }
+    }
+    crs->acrsauto.resize(numAutoKeys + 1);
+    for (auto &row : crs->acrsauto) {
+      for (uint32_t j = 0; j < digits; ++j) {
+        row.push_back(cc->Generateacrs());
+      }
+    }
+    *out = crs;
+    return MakeBinFHEOk();
+  }
+  BINFHE_CATCH_RETURN()
+}
+
+void BinFHEMPCRS_Delete(BinFHEMPCRSH crs) { delete AsCRS(crs); }
+
+BinFHEErr BinFHEContext_RingKeyGen(BinFHEContextH h, BinFHERingKeyH *out) {
+  try {
+    if (!h) {
+      return MakeBinFHEError("Null BinFHEContext handle");
+    }
+    if (!out) {
+      return MakeBinFHEError("Null output pointer for RingKeyGen");
+    }
+
+    *out = new NativePoly(AsBinFHEContext(h)->RGSWKeygen());
+    return MakeBinFHEOk();
+  }
+  BINFHE_CATCH_RETURN()
+}
+
+void BinFHERingKey_Delete(BinFHERingKeyH z) { delete AsRingKey(z); }
+
+BinFHEErr BinFHEContext_MultipartyRGSWShare(BinFHEContextH h, BinFHEMPCRSH crs,
+                                            BinFHERingKeyH z, int lead,
+                                            BinFHERGSWSharesH *out) {
+  try {
+    if (!h) {
+      return MakeBinFHEError("Null BinFHEContext handle");
+    }
+    if (!crs) {
+      return MakeBinFHEError("Null CRS handle");
+    }
+    if (!z) {
+      return MakeBinFHEError("Null ring key handle");
+    }
+    if (!out) {
+      return MakeBinFHEError("Null output pointer for MultipartyRGSWShare");
+    }
+
+    auto cc = AsBinFHEContext(h);
+    auto &acrs = AsCRS(crs)->acrs;
+    auto shares = std::vector<RingGSWEvalKey>();
+    shares.reserve(acrs.size());
+    for (const auto &row : acrs) {
+      shares.push_back(cc->RGSWEncrypt(row, *AsRingKey(z), 0, lead != 0));
+    }
+    *out = new std::vector<RingGSWEvalKey>(std::move(shares));
+    return MakeBinFHEOk();
+  }
+  BINFHE_CATCH_RETURN()
+}
+
+BinFHEErr BinFHEContext_MultipartyRGSWAdd(BinFHEContextH h,
+                                          BinFHERGSWSharesH *shares, int n,
+                                          BinFHERGSWSharesH *out) {
+  try {
+    if (!h) {
+      return MakeBinFHEError("Null BinFHEContext handle");
+    }
+    if (!shares || n <= 0) {
+      return MakeBinFHEError("No RGSW shares to add");
+    }
+    if (!out) {
+      return MakeBinFHEError("Null output pointer for MultipartyRGSWAdd");
+    }
+    for (int i = 0; i < n; ++i) {
+      if (!shares[i]) {
+        return MakeBinFHEError("Null RGSW share handle");
+      }
+    }
+
+    auto cc = AsBinFHEContext(h);
+    auto sum = *AsRGSWShares(shares[0]);
+    for (int i = 1; i < n; ++i) {
+      auto &next = *AsRGSWShares(shares[i]);
+      if (next.size() != sum.size()) {
+        return MakeBinFHEError("RGSW shares were generated from different CRS");
+      }
+      for (size_t j = 0; j < sum.size(); ++j) {
+        sum[j] = cc->RGSWEvalAdd(sum[j], next[j]);
+      }
+    }
+    *out = new std::vector<RingGSWEvalKey>(std::move(sum));
+    return MakeBinFHEOk();
+  }
+  BINFHE_CATCH_RETURN()
+}
+
+void BinFHERGSWShares_Delete(BinFHERGSWSharesH s) { delete AsRGSWShares(s); }
+
+BinFHEErr BinFHEContext_MultipartyBTKeyGen(BinFHEContextH h, LWEKeyPairH kp,
+                                           BinFHERingKeyH z, BinFHEMPCRSH crs,
+                                           BinFHERGSWSharesH joint,
+                                           BinFHEBTKeyShareH prev,
+                                           BinFHEBTKeyShareH *out) {
+  try {
+    if (!h) {
+      return MakeBinFHEError("Null BinFHEContext handle");
+    }
+    if (!kp) {
+      return MakeBinFHEError("Null LWEKeyPair handle");
+    }
+    if (!z) {
+      return MakeBinFHEError("Null ring key handle");
+    }
+    if (!crs) {
+      return MakeBinFHEError("Null CRS handle");
+    }
+    if (!joint) {
+      return MakeBinFHEError("Null joint RGSW handle");
+    }
+    if (!out) {
+      return MakeBinFHEError("Null output pointer for MultipartyBTKeyGen");
+    }
+
+    auto cc = AsBinFHEContext(h);
+    auto &sk = (*AsLWEKeyPair(kp))->secretKey;
+    bool lead = prev == nullptr;
+    RingGSWACCKey prevRefresh = lead ? nullptr : AsBTKeyShare(prev)->refreshKey;
+    LWESwitchingKey prevSwitch = lead ? nullptr : AsBTKeyShare(prev)->switchKey;
+
+    auto switchKey =
+        cc->MultiPartyKeySwitchGen(sk, ringKeyAsLWE(*AsRingKey(z)), prevSwitch);
+    auto refreshKey =
+        cc->MultiPartyBTKeyGen(sk, prevRefresh, *AsRingKey(z),
+                               AsCRS(crs)->acrsauto, *AsRGSWShares(joint),
+                               switchKey, lead);
+
+    *out = new BTKeyShare{refreshKey, switchKey};
+    return MakeBinFHEOk();
+  }
+  BINFHE_CATCH_RETURN()
+}
+
+BinFHEErr BinFHEContext_MultipartyBTKeyLoad(BinFHEContextH h,
+                                            BinFHEBTKeyShareH share) {
+  try {
+    if (!h) {
+      return MakeBinFHEError("Null BinFHEContext handle");
+    }
+    if (!share) {
+      return MakeBinFHEError("Null bootstrapping key share handle");
+    }
+
+    auto s = AsBTKeyShare(share);
+    AsBinFHEContext(h)->BTKeyLoad({s->refreshKey, s->switchKey});
+    return MakeBinFHEOk();
+  }
+  BINFHE_CATCH_RETURN()
+}
+
+void BinFHEBTKeyShare_Delete(BinFHEBTKeyShareH s) { delete AsBTKeyShare(s); }
+
+// --- Threshold decryption ---
+static BinFHEErr multipartyDecrypt(BinFHEContextH h, LWEKeyPairH kp,
+                                   LWECiphertextH ct, uint64_t p, bool lead,
+                                   LWECiphertextH *out) {
+  try {
+    if (!h) {
+      return MakeBinFHEError("Null BinFHEContext handle");
+    }
+    if (!kp) {
+      return MakeBinFHEError("Null LWEKeyPair handle");
+    }
+    if (!ct) {
+      return MakeBinFHEError("Null LWECiphertext handle");
+    }
+    if (!out) {
+      return MakeBinFHEError("Null output pointer for MultipartyDecrypt");
+    }
+
+    auto cc = AsBinFHEContext(h);
+    auto &sk = (*AsLWEKeyPair(kp))->secretKey;
+    auto &in = *AsLWECiphertext(ct);
+    auto partial = lead ? cc->MultipartyDecryptLead(sk, in, p)
+                        : cc->MultipartyDecryptMain(sk, in, p);
+    *out = new LWECiphertext(std::move(partial));
+    return MakeBinFHEOk();
+  }
+  BINFHE_CATCH_RETURN()
+}
+
+BinFHEErr BinFHEContext_MultipartyDecryptLead(BinFHEContextH h, LWEKeyPairH kp,
+                                              LWECiphertextH ct, uint64_t p,
+                                              LWECiphertextH *out) {
+  return multipartyDecrypt(h, kp, ct, p, true, out);
+}
+
+BinFHEErr BinFHEContext_MultipartyDecryptMain(BinFHEContextH h, LWEKeyPairH kp,
+                                              LWECiphertextH ct, uint64_t p,
+                                              LWECiphertextH *out) {
+  return multipartyDecrypt(h, kp, ct, p, false, out);
+}
+
+BinFHEErr BinFHEContext_MultipartyDecryptFusion(BinFHEContextH h,
+                                                LWECiphertextH *partials,
+                                                int n, uint64_t p,
+                                                int64_t *out) {
+  try {
+    if (!h) {
+      return MakeBinFHEError("Null BinFHEContext handle");
+    }
+    if (!partials || n <= 0) {
+      return MakeBinFHEError("No partial decryptions to fuse");
+    }
+    if (!out) {
+      return MakeBinFHEError("Null output pointer for MultipartyDecryptFusion");
+    }
+
+    std::vector<LWECiphertext> vec;
+    vec.reserve(n);
+    for (int i = 0; i < n; ++i) {
+      if (!partials[i]) {
+        return MakeBinFHEError("Null partial decryption handle");
+      }
+      vec.push_back(*AsLWECiphertext(partials[i]));
+    }
+
+    LWEPlaintext result = 0;
+    AsBinFHEContext(h)->MultipartyDecryptFusion(vec, &result, p);
+    *out = static_cast<int64_t>(result);
+    return MakeBinFHEOk();
+  }
+  BINFHE_CATCH_RETURN()
+}
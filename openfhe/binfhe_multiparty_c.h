@@ -0,0 +1,86 @@
+#ifndef BINFHE_MULTIPARTY_C_H
+#define BINFHE_MULTIPARTY_C_H
+
+#include "binfhe_c.h"
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// Opaque Handles
+typedef void *LWEKeyPairH;       // LWE public/secret key pair of one party
+typedef void *BinFHERingKeyH;    // a party's RGSW (ring) secret
+typedef void *BinFHEMPCRSH;      // common reference string shared by parties
+typedef void *BinFHERGSWSharesH; // RGSW encryptions of zero under z
+typedef void *BinFHEBTKeyShareH; // running refresh + switching key
+
+// --- Context ---
+
+// Generates a context for numParties parties. Threshold FHEW is only
+// supported for the GINX and LMKCDEY bootstrapping methods.
+BinFHEErr BinFHEContext_GenerateMultiparty(BinFHEContextH h,
+                                           BINFHE_PARAMSET_C paramset,
+                                           BINFHE_METHOD_C method,
+                                           uint32_t numParties);
+
+// --- Joint LWE public key ---
+
+// KeyGenPair generates the lead party's key pair. MultipartyKeyGen extends
+// the previous party's public key into the joint public key of all parties
+// so far.
+BinFHEErr BinFHEContext_KeyGenPair(BinFHEContextH h, LWEKeyPairH *out);
+BinFHEErr BinFHEContext_MultipartyKeyGen(BinFHEContextH h, LWEKeyPairH prev,
+                                         LWEKeyPairH *out);
+BinFHEErr LWEKeyPair_GetSecretKey(LWEKeyPairH kp, LWESecretKeyH *out);
+void LWEKeyPair_Delete(LWEKeyPairH kp);
+
+BinFHEErr BinFHEContext_EncryptPublic(BinFHEContextH h, LWEKeyPairH kp,
+                                      int message, LWECiphertextH *out);
+
+// --- Distributed bootstrapping key generation ---
+BinFHEErr BinFHEContext_MultipartyCRSGen(BinFHEContextH h, BinFHEMPCRSH *out);
+void BinFHEMPCRS_Delete(BinFHEMPCRSH crs);
+
+BinFHEErr BinFHEContext_RingKeyGen(BinFHEContextH h, BinFHERingKeyH *out);
+void BinFHERingKey_Delete(BinFHERingKeyH z);
+
+// RGSWShare encrypts zero under one party's ring secret using the CRS;
+// RGSWAdd sums the shares of all parties into encryptions under the joint
+// ring secret.
+BinFHEErr BinFHEContext_MultipartyRGSWShare(BinFHEContextH h, BinFHEMPCRSH crs,
+                                            BinFHERingKeyH z, int lead,
+                                            BinFHERGSWSharesH *out);
+BinFHEErr BinFHEContext_MultipartyRGSWAdd(BinFHEContextH h,
+                                          BinFHERGSWSharesH *shares, int n,
+                                          BinFHERGSWSharesH *out);
+void BinFHERGSWShares_Delete(BinFHERGSWSharesH s);
+
+// MultipartyBTKeyGen adds one party's contribution to the bootstrapping
+// keys. prev is NULL for the lead party and the previous party's output for
+// everyone else.
+BinFHEErr BinFHEContext_MultipartyBTKeyGen(BinFHEContextH h, LWEKeyPairH kp,
+                                           BinFHERingKeyH z, BinFHEMPCRSH crs,
+                                           BinFHERGSWSharesH joint,
+                                           BinFHEBTKeyShareH prev,
+                                           BinFHEBTKeyShareH *out);
+BinFHEErr BinFHEContext_MultipartyBTKeyLoad(BinFHEContextH h,
+                                            BinFHEBTKeyShareH share);
+void BinFHEBTKeyShare_Delete(BinFHEBTKeyShareH s);
+
+// --- Threshold decryption ---
+BinFHEErr BinFHEContext_MultipartyDecryptLead(BinFHEContextH h, LWEKeyPairH kp,
+                                              LWECiphertextH ct, uint64_t p,
+                                              LWECiphertextH *out);
+BinFHEErr BinFHEContext_MultipartyDecryptMain(BinFHEContextH h, LWEKeyPairH kp,
+                                              LWECiphertextH ct, uint64_t p,
+                                              LWECiphertextH *out);
+BinFHEErr BinFHEContext_MultipartyDecryptFusion(BinFHEContextH h,
+                                                LWECiphertextH *partials,
+                                                int n, uint64_t p,
+                                                int64_t *out);
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif // BINFHE_MULTIPARTY_C_H
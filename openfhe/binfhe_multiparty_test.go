@@ -0,0 +1,164 @@
+package openfhe
+
+import (
+	"testing"
+)
+
+// setupThresholdFHEW runs joint key generation and distributed bootstrapping
+// key generation for numParties parties sharing one context.
+func setupThresholdFHEW(t *testing.T, numParties int) (*BinFHEContext, []*BinFHEKeyPair) {
+	t.Helper()
+
+	cc, err := NewBinFHEContext()
+	mustT(t, err, "creating context")
+	t.Cleanup(cc.Close)
+
+	err = cc.GenerateBinFHEContextMultiparty(TOY, LMKCDEY, uint32(numParties))
+	mustT(t, err, "generating multiparty context")
+
+	keys := make([]*BinFHEKeyPair, numParties)
+	ringKeys := make([]*BinFHERingKey, numParties)
+	for i := range keys {
+		if i == 0 {
+			keys[i], err = cc.KeyGenPair()
+		} else {
+			keys[i], err = cc.MultipartyKeyGen(keys[i-1])
+		}
+		mustT(t, err, "generating key pair")
+		t.Cleanup(keys[i].Close)
+
+		ringKeys[i], err = cc.RingKeyGen()
+		mustT(t, err, "generating ring key")
+		defer ringKeys[i].Close()
+	}
+
+	crs, err := cc.MultipartyCRS()
+	mustT(t, err, "generating CRS")
+	defer crs.Close()
+
+	shares := make([]*BinFHERGSWShares, numParties)
+	for i := range shares {
+		shares[i], err = cc.MultipartyRGSWShare(crs, ringKeys[i], i == 0)
+		mustT(t, err, "generating RGSW share")
+		defer shares[i].Close()
+	}
+	joint, err := cc.MultipartyRGSWAdd(shares)
+	mustT(t, err, "adding RGSW shares")
+	defer joint.Close()
+
+	var bt *BinFHEBTKeyShare
+	for i := range keys {
+		next, err := cc.MultipartyBTKeyGen(keys[i], ringKeys[i], crs, joint, bt)
+		mustT(t, err, "generating bootstrapping key share")
+		if bt != nil {
+			bt.Close()
+		}
+		bt = next
+	}
+	defer bt.Close()
+	mustT(t, cc.MultipartyBTKeyLoad(bt), "loading bootstrapping keys")
+
+	return cc, keys
+}
+
+func binfheThresholdDecrypt(t *testing.T, cc *BinFHEContext, keys []*BinFHEKeyPair, ct *BinFHECiphertext) int64 {
+	t.Helper()
+
+	partials := make([]*BinFHECiphertext, len(keys))
+	for i, kp := range keys {
+		var err error
+		if i == 0 {
+			partials[i], err = cc.MultipartyDecryptLead(kp, ct, 4)
+		} else {
+			partials[i], err = cc.MultipartyDecryptMain(kp, ct, 4)
+		}
+		mustT(t, err, "partial decryption")
+		defer partials[i].Close()
+	}
+
+	result, err := cc.MultipartyDecryptFusion(partials, 4)
+	mustT(t, err, "fusing partial decryptions")
+	return result
+}
+
+func TestBinFHEThresholdGates(t *testing.T) {
+	const numParties = 3
+	cc, keys := setupThresholdFHEW(t, numParties)
+	jointKey := keys[numParties-1]
+
+	for _, tc := range []struct {
+		gate BinFHEGate
+		name string
+		want func(a, b int) int
+	}{
+		{AND, "AND", func(a, b int) int { return a & b }},
+		{OR, "OR", func(a, b int) int { return a | b }},
+		{XOR, "XOR", func(a, b int) int { return a ^ b }},
+	} {
+		for _, in := range [][2]int{{0, 0}, {0, 1}, {1, 0}, {1, 1}} {
+			ct1, err := cc.EncryptPublic(jointKey, in[0])
+			mustT(t, err, "encrypting first input")
+			ct2, err := cc.EncryptPublic(jointKey, in[1])
+			mustT(t, err, "encrypting second input")
+
+			ctOut, err := cc.EvalBinGate(tc.gate, ct1, ct2)
+			mustT(t, err, "evaluating gate")
+
+			got := binfheThresholdDecrypt(t, cc, keys, ctOut)
+			if want := tc.want(in[0], in[1]); got != int64(want) {
+				t.Errorf("%s(%d, %d) = %d, expected %d", tc.name, in[0], in[1], got, want)
+			}
+
+			ct1.Close()
+			ct2.Close()
+			ctOut.Close()
+		}
+	}
+}
+
+func TestBinFHEThresholdNOT(t *testing.T) {
+	cc, keys := setupThresholdFHEW(t, 2)
+
+	sk, err := keys[0].SecretKey()
+	mustT(t, err, "extracting secret key share")
+	sk.Close()
+
+	for _, bit := range []int{0, 1} {
+		ct, err := cc.EncryptPublic(keys[1], bit)
+		mustT(t, err, "encrypting")
+
+		ctNot, err := cc.EvalNOT(ct)
+		mustT(t, err, "evaluating NOT")
+
+		if got := binfheThresholdDecrypt(t, cc, keys, ctNot); got != int64(1-bit) {
+			t.Errorf("NOT(%d) = %d, expected %d", bit, got, 1-bit)
+		}
+		ct.Close()
+		ctNot.Close()
+	}
+}
+
+func TestBinFHEThresholdInvalidInputs(t *testing.T) {
+	cc, err := NewBinFHEContext()
+	mustT(t, err, "creating context")
+	defer cc.Close()
+
+	if err := cc.GenerateBinFHEContextMultiparty(TOY, AP, 2); err == nil {
+		t.Error("expected error for AP method")
+	}
+	if err := cc.GenerateBinFHEContextMultiparty(TOY, GINX, 0); err == nil {
+		t.Error("expected error for zero parties")
+	}
+	if _, err := cc.MultipartyKeyGen(nil); err == nil {
+		t.Error("expected error for nil previous key pair")
+	}
+	if _, err := cc.MultipartyRGSWAdd(nil); err == nil {
+		t.Error("expected error for empty share list")
+	}
+	if _, err := cc.MultipartyDecryptFusion(nil, 4); err == nil {
+		t.Error("expected error for empty partial list")
+	}
+	if _, err := cc.MultipartyDecryptFusion([]*BinFHECiphertext{nil}, 4); err == nil {
+		t.Error("expected error for nil partial decryption")
+	}
+}
@@ -867,3 +867,95 @@ func TestBinFHEEvalXNORNilCiphertext(t *testing.T) {
 		t.Error("Expected error for nil second ciphertext, got nil")
 	}
 }
+
+func TestBinFHEGenerateLUTViaFunction(t *testing.T) {
+	cc, err := NewBinFHEContext()
+	mustT(t, err, "creating context")
+	defer cc.Close()
+
+	err = cc.GenerateBinFHEContext(TOY, GINX)
+	mustT(t, err, "generating context")
+
+	q, err := cc.Getq()
+	mustT(t, err, "getting q")
+
+	p := uint64(4)
+	lut, err := cc.GenerateLUTViaFunction(func(m, p uint64) uint64 { return (m + 1) % p }, p)
+	mustT(t, err, "generating LUT")
+
+	if uint64(len(lut)) != q {
+		t.Fatalf("LUT has %d entries, expected q=%d", len(lut), q)
+	}
+	interval := q / p
+	for _, m := range []uint64{0, 1, 2, 3} {
+		if got, want := lut[m*interval], ((m+1)%p)*interval; got != want {
+			t.Errorf("LUT[%d] = %d, expected %d", m*interval, got, want)
+		}
+	}
+
+	if _, err := cc.GenerateLUTViaFunction(func(m, p uint64) uint64 { return m }, 3); err == nil {
+		t.Error("Expected error for non-power-of-two modulus, got nil")
+	}
+	if _, err := cc.GenerateLUTViaFunction(func(m, p uint64) uint64 { return p }, 4); err == nil {
+		t.Error("Expected error for function outside Z_p, got nil")
+	}
+}
+
+func TestBinFHEEvalFuncNilCiphertext(t *testing.T) {
+	cc, err := NewBinFHEContext()
+	mustT(t, err, "creating context")
+	defer cc.Close()
+
+	err = cc.GenerateBinFHEContext(TOY, GINX)
+	mustT(t, err, "generating context")
+
+	_, err = cc.EvalFunc(nil, []uint64{0, 1})
+	if err == nil {
+		t.Error("Expected error for nil ciphertext, got nil")
+	}
+}
+
+func TestBinFHEContextSerialization(t *testing.T) {
+	cc, err := NewBinFHEContext()
+	mustT(t, err, "creating context")
+	defer cc.Close()
+
+	err = cc.GenerateBinFHEContext(TOY, GINX)
+	mustT(t, err, "generating context")
+
+	sk, err := cc.KeyGen()
+	mustT(t, err, "generating key")
+	defer sk.Close()
+
+	err = cc.BTKeyGen(sk)
+	mustT(t, err, "generating BT keys")
+
+	data, err := SerializeBinFHEContextToBytes(cc)
+	mustT(t, err, "serializing context")
+
+	loaded, err := DeserializeBinFHEContextFromBytes(data)
+	mustT(t, err, "deserializing context")
+	defer loaded.Close()
+
+	ct1, err := cc.Encrypt(sk, 1)
+	mustT(t, err, "encrypting 1")
+	defer ct1.Close()
+
+	// Bootstrapping on the loaded context needs the deserialized keys.
+	ctNot, err := loaded.EvalNOT(ct1)
+	mustT(t, err, "evaluating NOT on loaded context")
+	defer ctNot.Close()
+	ctAnd, err := loaded.EvalBinGate(AND, ct1, ctNot)
+	mustT(t, err, "evaluating AND on loaded context")
+	defer ctAnd.Close()
+
+	result, err := cc.Decrypt(sk, ctAnd)
+	mustT(t, err, "decrypting")
+	if result != 0 {
+		t.Errorf("AND(1, 0) = %d, expected 0", result)
+	}
+
+	if _, err := DeserializeBinFHEContextFromBytes(nil); err == nil {
+		t.Error("Expected error for empty data, got nil")
+	}
+}
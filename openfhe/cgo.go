@@ -4,7 +4,7 @@ package openfhe
 #cgo CPPFLAGS: -I${SRCDIR}/../openfhe-install/include -I${SRCDIR}/../openfhe-install/include/openfhe -I${SRCDIR}/../openfhe-install/include/openfhe/core -I${SRCDIR}/../openfhe-install/include/openfhe/pke -I${SRCDIR}/../openfhe-install/include/openfhe/binfhe -I${SRCDIR}/../openfhe-install/include/openfhe/cereal
 #cgo CXXFLAGS: -std=c++17
 #cgo LDFLAGS: ${SRCDIR}/../openfhe-install/lib/libOPENFHEpke_static.a ${SRCDIR}/../openfhe-install/lib/libOPENFHEcore_static.a ${SRCDIR}/../openfhe-install/lib/libOPENFHEbinfhe_static.a
-//CGO_SOURCES: pke_common_c.cpp bfv_c.cpp bgv_c.cpp ckks_c.cpp binfhe_c.cpp pre_c.cpp schemeswitch_c.cpp
+//CGO_SOURCES: pke_common_c.cpp bfv_c.cpp bgv_c.cpp ckks_c.cpp binfhe_c.cpp pre_c.cpp schemeswitch_c.cpp fbt_c.cpp multiparty_c.cpp binfhe_multiparty_c.cpp
 
 #include <stdint.h>
 #include "binfhe_c.h"
@@ -14,26 +14,62 @@ package openfhe
 #include "ckks_c.h"
 #include "pre_c.h"
 #include "schemeswitch_c.h"
+#include "fbt_c.h"
+#include "multiparty_c.h"
+#include "binfhe_multiparty_c.h"
 */
 import "C"
 
 import (
 	"errors"
 	"fmt"
+	"sync/atomic"
 	"unsafe"
 )
 
 // --- Structs ---
 type (
-	ParamsBFV  struct{ ptr C.ParamsBFVPtr }
-	ParamsCKKS struct{ ptr C.ParamsCKKSPtr }
+	ParamsBFV struct {
+		ptr C.ParamsBFVPtr
+		ref ref
+	}
+	ParamsCKKS struct {
+		ptr C.ParamsCKKSPtr
+		ref ref
+	}
 )
 
 type (
-	CryptoContext    struct{ ptr C.CryptoContextPtr }
-	KeyPair          struct{ ptr C.KeyPairPtr }
-	Plaintext        struct{ ptr C.PlaintextPtr }
-	Ciphertext       struct{ ptr C.CiphertextPtr }
+	CryptoContext struct {
+		ptr C.CryptoContextPtr
+		ref ref // calls in flight; see ref.go
+		// trace is set while PlanKeys dry-runs a computation.
+		trace atomic.Pointer[keyTrace]
+		// composeRotations makes EvalRotate split indices into powers of
+		// two; set by KeyGenForPlan for a decomposed plan.
+		composeRotations bool
+		// levelBudget is recorded by EvalBootstrapSetup; autoBoot is set by
+		// EnableAutoBootstrap.
+		levelBudget []uint32
+		autoBoot    *autoBootstrap
+	}
+	KeyPair struct {
+		ptr C.KeyPairPtr
+		ref ref
+		sim *simKeyPair // set by a Simulator at creation; never changed
+	}
+	Plaintext struct {
+		ptr C.PlaintextPtr
+		ref ref
+		sim *simPlaintext // set by a Simulator at creation; never changed
+	}
+	Ciphertext struct {
+		ptr C.CiphertextPtr
+		ref ref
+		sim *simCiphertext // set by a Simulator at creation; never changed
+		// noise is the tracked BFV/BGV noise bound; nil when untracked.
+		noise *noiseBound
+	}
 	DistributionType C.DistributionType
 	SecurityLevel    C.OFHESecurityLevel
 	SecretKeyDist    C.OFHESecretKeyDist
@@ -10,6 +10,9 @@ import "C"
 
 import (
 	"errors"
+	"fmt"
+	"math"
+	"math/bits"
 	"unsafe"
 )
 
@@ -35,9 +38,10 @@ func NewParamsCKKSRNS() (*ParamsCKKS, error) {
 }
 
 func (p *ParamsCKKS) SetScalingModSize(modSize int) error {
-	if p.ptr == nil {
+	if !p.acquire() {
 		return errors.New("ParamsCKKS is closed or invalid")
 	}
+	defer p.release()
 
 	status := C.ParamsCKKS_SetScalingModSize(p.ptr, C.int(modSize))
 	err := checkPKEErrorMsg(status)
@@ -49,9 +53,10 @@ func (p *ParamsCKKS) SetScalingModSize(modSize int) error {
 }
 
 func (p *ParamsCKKS) SetBatchSize(batchSize int) error {
-	if p.ptr == nil {
+	if !p.acquire() {
 		return errors.New("ParamsCKKS is closed or invalid")
 	}
+	defer p.release()
 
 	status := C.ParamsCKKS_SetBatchSize(p.ptr, C.int(batchSize))
 	err := checkPKEErrorMsg(status)
@@ -63,9 +68,10 @@ func (p *ParamsCKKS) SetBatchSize(batchSize int) error {
 }
 
 func (p *ParamsCKKS) SetMultiplicativeDepth(depth int) error {
-	if p.ptr == nil {
+	if !p.acquire() {
 		return errors.New("ParamsCKKS is closed or invalid")
 	}
+	defer p.release()
 
 	status := C.ParamsCKKS_SetMultiplicativeDepth(p.ptr, C.int(depth))
 	err := checkPKEErrorMsg(status)
@@ -77,9 +83,10 @@ func (p *ParamsCKKS) SetMultiplicativeDepth(depth int) error {
 }
 
 func (p *ParamsCKKS) SetSecurityLevel(level SecurityLevel) error {
-	if p.ptr == nil {
+	if !p.acquire() {
 		return errors.New("ParamsCKKS is closed or invalid")
 	}
+	defer p.release()
 
 	status := C.ParamsCKKS_SetSecurityLevel(p.ptr, C.OFHESecurityLevel(level))
 	err := checkPKEErrorMsg(status)
@@ -91,9 +98,10 @@ func (p *ParamsCKKS) SetSecurityLevel(level SecurityLevel) error {
 }
 
 func (p *ParamsCKKS) SetRingDim(ringDim uint64) error {
-	if p.ptr == nil {
+	if !p.acquire() {
 		return errors.New("ParamsCKKS is closed or invalid")
 	}
+	defer p.release()
 
 	status := C.ParamsCKKS_SetRingDim(p.ptr, C.uint64_t(ringDim))
 	err := checkPKEErrorMsg(status)
@@ -105,9 +113,10 @@ func (p *ParamsCKKS) SetRingDim(ringDim uint64) error {
 }
 
 func (p *ParamsCKKS) SetScalingTechnique(technique int) error {
-	if p.ptr == nil {
+	if !p.acquire() {
 		return errors.New("ParamsCKKS is closed or invalid")
 	}
+	defer p.release()
 
 	status := C.ParamsCKKS_SetScalingTechnique(p.ptr, C.int(technique))
 	err := checkPKEErrorMsg(status)
@@ -119,9 +128,10 @@ func (p *ParamsCKKS) SetScalingTechnique(technique int) error {
 }
 
 func (p *ParamsCKKS) SetFirstModSize(modSize int) error {
-	if p.ptr == nil {
+	if !p.acquire() {
 		return errors.New("ParamsCKKS is closed or invalid")
 	}
+	defer p.release()
 
 	status := C.ParamsCKKS_SetFirstModSize(p.ptr, C.int(modSize))
 	err := checkPKEErrorMsg(status)
@@ -133,9 +143,10 @@ func (p *ParamsCKKS) SetFirstModSize(modSize int) error {
 }
 
 func (p *ParamsCKKS) SetNumLargeDigits(numDigits int) error {
-	if p.ptr == nil {
+	if !p.acquire() {
 		return errors.New("ParamsCKKS is closed or invalid")
 	}
+	defer p.release()
 
 	status := C.ParamsCKKS_SetNumLargeDigits(p.ptr, C.int(numDigits))
 	err := checkPKEErrorMsg(status)
@@ -147,9 +158,10 @@ func (p *ParamsCKKS) SetNumLargeDigits(numDigits int) error {
 }
 
 func (p *ParamsCKKS) SetSecretKeyDist(d SecretKeyDist) error {
-	if p.ptr == nil {
+	if !p.acquire() {
 		return errors.New("ParamsCKKS is closed or invalid")
 	}
+	defer p.release()
 
 	status := C.ParamsCKKS_SetSecretKeyDist(p.ptr, C.OFHESecretKeyDist(d))
 	err := checkPKEErrorMsg(status)
@@ -161,9 +173,10 @@ func (p *ParamsCKKS) SetSecretKeyDist(d SecretKeyDist) error {
 }
 
 func (p *ParamsCKKS) SetDigitSize(digitSize int) error {
-	if p.ptr == nil {
+	if !p.acquire() {
 		return errors.New("ParamsCKKS is closed or invalid")
 	}
+	defer p.release()
 
 	status := C.ParamsCKKS_SetDigitSize(p.ptr, C.int(digitSize))
 	err := checkPKEErrorMsg(status)
@@ -175,9 +188,10 @@ func (p *ParamsCKKS) SetDigitSize(digitSize int) error {
 }
 
 func (p *ParamsCKKS) SetKeySwitchTechnique(technique int) error {
-	if p.ptr == nil {
+	if !p.acquire() {
 		return errors.New("ParamsCKKS is closed or invalid")
 	}
+	defer p.release()
 
 	status := C.ParamsCKKS_SetKeySwitchTechnique(p.ptr, C.int(technique))
 	err := checkPKEErrorMsg(status)
@@ -188,9 +202,40 @@ func (p *ParamsCKKS) SetKeySwitchTechnique(technique int) error {
 	return nil
 }
 
+// SetMultipartyMode selects the threshold FHE noise handling, e.g.
+// NOISE_FLOODING_MULTIPARTY for interactive multiparty bootstrapping.
+func (p *ParamsCKKS) SetMultipartyMode(mode int) error {
+	if !p.acquire() {
+		return errors.New("ParamsCKKS is closed or invalid")
+	}
+	defer p.release()
+
+	status := C.ParamsCKKS_SetMultipartyMode(p.ptr, C.int(mode))
+	err := checkPKEErrorMsg(status)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SetPREMode selects the proxy re-encryption security model.
+func (p *ParamsCKKS) SetPREMode(mode int) error {
+	if !p.acquire() {
+		return errors.New("ParamsCKKS is closed or invalid")
+	}
+	defer p.release()
+	status := C.ParamsCKKS_SetPREMode(p.ptr, C.int(mode))
+	err := checkPKEErrorMsg(status)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 // Close method for ParamsCKKS
 func (p *ParamsCKKS) Close() {
-	if p.ptr != nil {
+	if p.ref.close() && p.ptr != nil {
 		C.DestroyParamsCKKS(p.ptr)
 		p.ptr = nil
 	}
@@ -198,18 +243,65 @@ func (p *ParamsCKKS) Close() {
 
 // Expose ring dimension
 func (cc *CryptoContext) GetRingDimension() uint64 {
-	if cc.ptr == nil {
+	if !cc.acquire() {
 		return 0
 	}
+	defer cc.release()
 
 	return uint64(C.CryptoContext_GetRingDimension(cc.ptr))
 }
 
+// GetBatchSize returns the number of plaintext slots in use.
+func (cc *CryptoContext) GetBatchSize() uint32 {
+	if !cc.acquire() {
+		return 0
+	}
+	defer cc.release()
+
+	return uint32(C.CryptoContext_GetBatchSize(cc.ptr))
+}
+
+// GetScalingTechnique returns the scaling technique (FIXEDMANUAL,
+// FLEXIBLEAUTO, ...) or -1 if the context is closed.
+func (cc *CryptoContext) GetScalingTechnique() int {
+	if !cc.acquire() {
+		return -1
+	}
+	defer cc.release()
+
+	return int(C.CryptoContext_GetScalingTechnique(cc.ptr))
+}
+
+// GetMultiplicativeDepth returns the number of levels a fresh ciphertext can
+// consume, or -1 if the context is closed.
+func (cc *CryptoContext) GetMultiplicativeDepth() int {
+	if !cc.acquire() {
+		return -1
+	}
+	defer cc.release()
+
+	return int(C.CryptoContext_GetMultiplicativeDepth(cc.ptr))
+}
+
+// GetSecretKeyDist returns the secret key distribution of the context.
+func (cc *CryptoContext) GetSecretKeyDist() (SecretKeyDist, error) {
+	if !cc.acquire() {
+		return 0, errors.New("CryptoContext is closed or invalid")
+	}
+	defer cc.release()
+	d := C.CryptoContext_GetSecretKeyDist(cc.ptr)
+	if d < 0 {
+		return 0, errors.New("GetSecretKeyDist: context has no RNS parameters")
+	}
+	return SecretKeyDist(d), nil
+}
+
 // --- CKKS CryptoContext ---
 func NewCryptoContextCKKS(p *ParamsCKKS) (*CryptoContext, error) {
-	if p == nil || p.ptr == nil {
+	if !p.acquire() {
 		return nil, errors.New("ParamsCKKS is closed or invalid")
 	}
+	defer p.release()
 
 	var ccH C.CryptoContextPtr
 
@@ -230,9 +322,10 @@ func NewCryptoContextCKKS(p *ParamsCKKS) (*CryptoContext, error) {
 
 // --- CKKS Plaintext ---
 func (cc *CryptoContext) MakeCKKSPackedPlaintext(vec []float64) (*Plaintext, error) {
-	if cc.ptr == nil {
+	if !cc.acquire() {
 		return nil, errors.New("CryptoContext is closed or invalid")
 	}
+	defer cc.release()
 
 	if len(vec) == 0 {
 		return nil, errors.New("MakeCKKSPackedPlaintext: input vector is empty")
@@ -258,11 +351,46 @@ func (cc *CryptoContext) MakeCKKSPackedPlaintext(vec []float64) (*Plaintext, err
 	return pt, nil
 }
 
+// MakeCKKSPackedPlaintextWithParams encodes vec with an explicit noise scale
+// degree, level and slot count. Use slots < N/2 for sparse packing, e.g. to
+// match a sparse bootstrapping setup; 0 keeps the default of N/2.
+func (cc *CryptoContext) MakeCKKSPackedPlaintextWithParams(vec []float64, noiseScaleDeg, level, slots uint32) (*Plaintext, error) {
+	if !cc.acquire() {
+		return nil, errors.New("CryptoContext is closed or invalid")
+	}
+	defer cc.release()
+
+	if len(vec) == 0 {
+		return nil, errors.New("MakeCKKSPackedPlaintextWithParams: input vector is empty")
+	}
+
+	cVec := (*C.double)(unsafe.Pointer(&vec[0]))
+	cLen := C.int(len(vec))
+
+	var ptH C.PlaintextPtr
+
+	status := C.CryptoContext_MakeCKKSPackedPlaintextWithParams(cc.ptr, cVec, cLen,
+		C.uint32_t(noiseScaleDeg), C.uint32_t(level), C.uint32_t(slots), &ptH)
+	err := checkPKEErrorMsg(status)
+	if err != nil {
+		return nil, err
+	}
+
+	if ptH == nil {
+		return nil, errors.New("MakeCKKSPackedPlaintextWithParams returned OK but null handle")
+	}
+
+	pt := &Plaintext{ptr: ptH}
+
+	return pt, nil
+}
+
 // MakeCKKSComplexPackedPlaintext creates a CKKS plaintext from a slice of complex128.
 func (cc *CryptoContext) MakeCKKSComplexPackedPlaintext(vec []complex128) (*Plaintext, error) {
-	if cc.ptr == nil {
+	if !cc.acquire() {
 		return nil, errors.New("CryptoContext is closed or invalid")
 	}
+	defer cc.release()
 
 	if len(vec) == 0 {
 		return nil, errors.New("MakeCKKSComplexPackedPlaintext: input vector is empty")
@@ -294,15 +422,55 @@ func (cc *CryptoContext) MakeCKKSComplexPackedPlaintext(vec []complex128) (*Plai
 	return pt, nil
 }
 
+// MakeCKKSComplexPackedPlaintextWithParams is the complex counterpart of
+// MakeCKKSPackedPlaintextWithParams.
+func (cc *CryptoContext) MakeCKKSComplexPackedPlaintextWithParams(vec []complex128, noiseScaleDeg, level, slots uint32) (*Plaintext, error) {
+	if !cc.acquire() {
+		return nil, errors.New("CryptoContext is closed or invalid")
+	}
+	defer cc.release()
+
+	if len(vec) == 0 {
+		return nil, errors.New("MakeCKKSComplexPackedPlaintextWithParams: input vector is empty")
+	}
+
+	cVec := make([]C.complex_double_t, len(vec))
+	for i, v := range vec {
+		cVec[i].real = C.double(real(v))
+		cVec[i].imag = C.double(imag(v))
+	}
+
+	cLen := C.int(len(vec))
+
+	var ptH C.PlaintextPtr
+
+	status := C.CryptoContext_MakeCKKSComplexPackedPlaintextWithParams(cc.ptr, &cVec[0], cLen,
+		C.uint32_t(noiseScaleDeg), C.uint32_t(level), C.uint32_t(slots), &ptH)
+	err := checkPKEErrorMsg(status)
+	if err != nil {
+		return nil, err
+	}
+
+	if ptH == nil {
+		return nil, errors.New("MakeCKKSComplexPackedPlaintextWithParams returned OK but null handle")
+	}
+
+	pt := &Plaintext{ptr: ptH}
+
+	return pt, nil
+}
+
 // --- CKKS Operations ---
 func (cc *CryptoContext) Rescale(ct *Ciphertext) (*Ciphertext, error) {
-	if cc.ptr == nil {
+	if !cc.acquire() {
 		return nil, errors.New("CryptoContext is closed or invalid")
 	}
+	defer cc.release()
 
-	if ct == nil || ct.ptr == nil {
+	if !ct.acquire() {
 		return nil, errors.New("Input Ciphertext is closed or invalid")
 	}
+	defer ct.release()
 
 	var ctH C.CiphertextPtr
 
@@ -318,18 +486,21 @@ func (cc *CryptoContext) Rescale(ct *Ciphertext) (*Ciphertext, error) {
 
 	resCt := &Ciphertext{ptr: ctH}
 
+	cc.trackNoise(resCt, noiseModReduce, ct)
 	return resCt, nil
 }
 
 // ModReduce reduces the modulus of the ciphertext without rescaling.
 func (cc *CryptoContext) ModReduce(ct *Ciphertext) (*Ciphertext, error) {
-	if cc.ptr == nil {
+	if !cc.acquire() {
 		return nil, errors.New("CryptoContext is closed or invalid")
 	}
+	defer cc.release()
 
-	if ct == nil || ct.ptr == nil {
+	if !ct.acquire() {
 		return nil, errors.New("Input Ciphertext is closed or invalid")
 	}
+	defer ct.release()
 
 	var ctH C.CiphertextPtr
 
@@ -345,6 +516,7 @@ func (cc *CryptoContext) ModReduce(ct *Ciphertext) (*Ciphertext, error) {
 
 	resCt := &Ciphertext{ptr: ctH}
 
+	cc.trackNoise(resCt, noiseModReduce, ct)
 	return resCt, nil
 }
 
@@ -352,17 +524,28 @@ func (cc *CryptoContext) ModReduce(ct *Ciphertext) (*Ciphertext, error) {
 // coefficients: A slice of doubles representing the polynomial coefficients in ascending order (e.g., [c0, c1, c2] for c0 + c1*x + c2*x^2).
 // Returns the resulting ciphertext and a potential error.
 func (cc *CryptoContext) EvalPoly(ct *Ciphertext, coefficients []float64) (*Ciphertext, error) {
-	if cc.ptr == nil {
-		return nil, errors.New("CryptoContext is closed or invalid")
+	if len(coefficients) == 0 {
+		return nil, errors.New("EvalPoly requires at least one coefficient")
+	}
+	if a := cc.autoBoot; a != nil && len(coefficients) > 1 {
+		// OpenFHE evaluates a degree-d polynomial in ceil(log2(d+1)) levels.
+		cts, release, err := cc.ensureLevels(a, "EvalPoly", PolyDepth(len(coefficients)-1), ct)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+		ct = cts[0]
 	}
 
-	if ct == nil || ct.ptr == nil {
-		return nil, errors.New("Input Ciphertext is closed or invalid")
+	if !cc.acquire() {
+		return nil, errors.New("CryptoContext is closed or invalid")
 	}
+	defer cc.release()
 
-	if len(coefficients) == 0 {
-		return nil, errors.New("EvalPoly requires at least one coefficient")
+	if !ct.acquire() {
+		return nil, errors.New("Input Ciphertext is closed or invalid")
 	}
+	defer ct.release()
 
 	cCoefficients := (*C.double)(unsafe.Pointer(&coefficients[0]))
 	cCount := C.size_t(len(coefficients))
@@ -383,16 +566,109 @@ func (cc *CryptoContext) EvalPoly(ct *Ciphertext, coefficients []float64) (*Ciph
 	return newCt, nil
 }
 
-func GetBootstrapDepth(levelBudget []uint32, skd SecretKeyDist) uint32 {
-	var ptr *C.uint32_t
-	var n C.int
+// EvalChebyshevSeries evaluates the Chebyshev series sum c[i]*T_i(y) on
+// ct, where y maps [a, b] onto [-1, 1] and the first coefficient is halved,
+// as returned by ChebyshevCoefficients. It consumes ChebyshevDepth(degree)
+// levels. Requires the ADVANCEDSHE feature.
+func (cc *CryptoContext) EvalChebyshevSeries(ct *Ciphertext, coefficients []float64, a, b float64) (*Ciphertext, error) {
+	if len(coefficients) == 0 {
+		return nil, errors.New("EvalChebyshevSeries requires at least one coefficient")
+	}
+	if !(a < b) {
+		return nil, fmt.Errorf("EvalChebyshevSeries: interval [%g, %g] is empty", a, b)
+	}
+	if ab := cc.autoBoot; ab != nil && len(coefficients) > 1 {
+		cts, release, err := cc.ensureLevels(ab, "EvalChebyshevSeries", ChebyshevDepth(len(coefficients)-1), ct)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+		ct = cts[0]
+	}
+
+	if !cc.acquire() {
+		return nil, errors.New("CryptoContext is closed or invalid")
+	}
+	defer cc.release()
+
+	if !ct.acquire() {
+		return nil, errors.New("Input Ciphertext is closed or invalid")
+	}
+	defer ct.release()
+
+	cCoefficients := (*C.double)(unsafe.Pointer(&coefficients[0]))
+	cCount := C.size_t(len(coefficients))
+	var resultPtr C.CiphertextPtr
+
+	status := C.CryptoContext_EvalChebyshevSeries(cc.ptr, ct.ptr, cCoefficients, cCount, C.double(a), C.double(b), &resultPtr)
+	err := checkPKEErrorMsg(status)
+	if err != nil {
+		return nil, err
+	}
+
+	if resultPtr == nil {
+		return nil, errors.New("CryptoContext_EvalChebyshevSeries returned OK but null handle")
+	}
+
+	return &Ciphertext{ptr: resultPtr}, nil
+}
 
-	if len(levelBudget) > 0 {
-		ptr = (*C.uint32_t)(&levelBudget[0])
-		n = C.int(len(levelBudget))
+// ChebyshevCoefficients returns the degree+1 coefficients of the Chebyshev
+// interpolant of fn on [a, b], for use with EvalChebyshevSeries.
+func ChebyshevCoefficients(fn func(float64) float64, a, b float64, degree int) []float64 {
+	if degree < 0 {
+		return nil
+	}
+	n := degree + 1
+	vals := make([]float64, n)
+	for k := range vals {
+		y := math.Cos(math.Pi * (float64(k) + 0.5) / float64(n))
+		vals[k] = fn(y*(b-a)/2 + (b+a)/2)
+	}
+	coeffs := make([]float64, n)
+	for j := range coeffs {
+		var sum float64
+		for k, v := range vals {
+			sum += v * math.Cos(math.Pi*float64(j)*(float64(k)+0.5)/float64(n))
+		}
+		coeffs[j] = 2 * sum / float64(n)
+	}
+	return coeffs
+}
+
+// PolyDepth returns the multiplicative depth EvalPoly uses for a polynomial
+// of the given degree.
+func PolyDepth(degree int) int {
+	if degree < 1 {
+		return 0
 	}
+	return bits.Len(uint(degree))
+}
+
+// chebyshevDepths lists the largest degree OpenFHE's Chebyshev series
+// evaluation handles at each multiplicative depth, starting from depth 2.
+var chebyshevDepths = []int{1, 2, 5, 13, 27, 59, 119, 247, 495, 1007, 2031}
 
-	d := C.CKKS_GetBootstrapDepth(ptr, n, C.int(skd))
+// ChebyshevDepth returns the multiplicative depth EvalChebyshevSeries uses
+// for a series of the given degree, or -1 when the degree is too large.
+func ChebyshevDepth(degree int) int {
+	if degree < 1 {
+		return 0
+	}
+	for i, maxDegree := range chebyshevDepths {
+		if degree <= maxDegree {
+			return i + 2
+		}
+	}
+	return -1
+}
+
+// GetBootstrapDepth returns the levels bootstrapping with levelBudget
+// consumes; an empty budget means the {4, 4} default of
+// EvalBootstrapSetupSimple and EvalBootstrapSetup.
+func GetBootstrapDepth(levelBudget []uint32, skd SecretKeyDist) uint32 {
+	levelBudget = levelBudgetOrDefault(levelBudget)
+	d := C.CKKS_GetBootstrapDepth((*C.uint32_t)(&levelBudget[0]), C.int(len(levelBudget)), C.int(skd))
 
 	return uint32(d)
 }
@@ -403,12 +679,14 @@ func GetBootstrapDepth(levelBudget []uint32, skd SecretKeyDist) uint32 {
 // This must be called before using EvalSum or EvalInnerProduct.
 // The function generates all necessary rotation keys for summing slots.
 func (cc *CryptoContext) EvalSumKeyGen(keys *KeyPair) error {
-	if cc.ptr == nil {
+	if !cc.acquireExclusive() {
 		return errors.New("CryptoContext is closed or invalid")
 	}
-	if keys == nil || keys.ptr == nil {
+	defer cc.releaseExclusive()
+	if !keys.acquire() {
 		return errors.New("KeyPair is closed or invalid")
 	}
+	defer keys.release()
 
 	status := C.CryptoContext_EvalSumKeyGen(cc.ptr, keys.ptr)
 	err := checkPKEErrorMsg(status)
@@ -432,12 +710,17 @@ func (cc *CryptoContext) EvalSumKeyGen(keys *KeyPair) error {
 //	input:  [1, 2, 3, 4, 5, 6, 7, 8]
 //	output: [36, 36, 36, 36, 36, 36, 36, 36]  // sum = 1+2+3+4+5+6+7+8 = 36
 func (cc *CryptoContext) EvalSum(ct *Ciphertext, batchSize uint32) (*Ciphertext, error) {
-	if cc.ptr == nil {
+	if !cc.acquire() {
 		return nil, errors.New("CryptoContext is closed or invalid")
 	}
-	if ct == nil || ct.ptr == nil {
+	defer cc.release()
+	if !ct.acquire() {
 		return nil, errors.New("Input Ciphertext is closed or invalid")
 	}
+	defer ct.release()
+	if tr := cc.trace.Load(); tr != nil {
+		return tr.sum(ct, batchSize)
+	}
 
 	var ctH C.CiphertextPtr
 	status := C.CryptoContext_EvalSum(cc.ptr, ct.ptr, C.uint32_t(batchSize), &ctH)
@@ -471,12 +754,21 @@ func (cc *CryptoContext) EvalSum(ct *Ciphertext, batchSize uint32) (*Ciphertext,
 //	ct2:    [5, 6, 7, 8]
 //	output: [70, 70, 70, 70]  // 1*5 + 2*6 + 3*7 + 4*8 = 70
 func (cc *CryptoContext) EvalInnerProduct(ct1, ct2 *Ciphertext, batchSize uint32) (*Ciphertext, error) {
-	if cc.ptr == nil {
+	if !cc.acquire() {
 		return nil, errors.New("CryptoContext is closed or invalid")
 	}
-	if ct1 == nil || ct1.ptr == nil || ct2 == nil || ct2.ptr == nil {
+	defer cc.release()
+	if !ct1.acquire() {
+		return nil, errors.New("Input Ciphertext is closed or invalid")
+	}
+	defer ct1.release()
+	if !ct2.acquire() {
 		return nil, errors.New("Input Ciphertext is closed or invalid")
 	}
+	defer ct2.release()
+	if tr := cc.trace.Load(); tr != nil {
+		return tr.sum(ct1, batchSize)
+	}
 
 	var ctH C.CiphertextPtr
 	status := C.CryptoContext_EvalInnerProduct(cc.ptr, ct1.ptr, ct2.ptr,
@@ -493,3 +785,48 @@ func (cc *CryptoContext) EvalInnerProduct(ct1, ct2 *Ciphertext, batchSize uint32
 	resCt := &Ciphertext{ptr: ctH}
 	return resCt, nil
 }
+
+// EvalConjugateKeyGen generates the automorphism key used by EvalConjugate.
+func (cc *CryptoContext) EvalConjugateKeyGen(keys *KeyPair) error {
+	if !cc.acquireExclusive() {
+		return errors.New("CryptoContext is closed or invalid")
+	}
+	defer cc.releaseExclusive()
+	if !keys.acquire() {
+		return errors.New("KeyPair is closed or invalid")
+	}
+	defer keys.release()
+
+	status := C.CryptoContext_EvalConjugateKeyGen(cc.ptr, keys.ptr)
+	return checkPKEErrorMsg(status)
+}
+
+// EvalConjugate returns the slot-wise complex conjugate of a CKKS ciphertext.
+// Requires EvalConjugateKeyGen to have been called first.
+func (cc *CryptoContext) EvalConjugate(ct *Ciphertext) (*Ciphertext, error) {
+	if !cc.acquire() {
+		return nil, errors.New("CryptoContext is closed or invalid")
+	}
+	defer cc.release()
+	if !ct.acquire() {
+		return nil, errors.New("Input Ciphertext is closed or invalid")
+	}
+	defer ct.release()
+	if tr := cc.trace.Load(); tr != nil {
+		return tr.conjugate(ct)
+	}
+
+	var ctH C.CiphertextPtr
+	status := C.CryptoContext_EvalConjugate(cc.ptr, ct.ptr, &ctH)
+	err := checkPKEErrorMsg(status)
+	if err != nil {
+		return nil, err
+	}
+
+	if ctH == nil {
+		return nil, errors.New("EvalConjugate returned OK but null handle")
+	}
+
+	resCt := &Ciphertext{ptr: ctH}
+	return resCt, nil
+}
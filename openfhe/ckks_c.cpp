@@ -1,5 +1,6 @@
 #include "ckks_c.h"
 #include "pke_helpers_c.h"
+#include <algorithm>
 #include <complex>
 
 using namespace lbcrypto;
@@ -176,6 +177,31 @@ PKEErr ParamsCKKS_SetKeySwitchTechnique(ParamsCKKSPtr p, int technique) {
   PKE_CATCH_RETURN()
 }
 
+PKEErr ParamsCKKS_SetMultipartyMode(ParamsCKKSPtr p, int mode) {
+  try {
+    if (!p) {
+      return MakePKEError("ParamsCKKS_SetMultipartyMode: null params");
+    }
+    // INVALID = 0, FIXED_NOISE = 1, NOISE_FLOODING = 2 (same as in OpenFHE)
+    reinterpret_cast<CCParams<CryptoContextCKKSRNS> *>(p)->SetMultipartyMode(
+        static_cast<MultipartyMode>(mode));
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
+PKEErr ParamsCKKS_SetPREMode(ParamsCKKSPtr p, int mode) {
+  try {
+    if (!p) {
+      return MakePKEError("ParamsCKKS_SetPREMode: null params");
+    }
+    reinterpret_cast<CCParams<CryptoContextCKKSRNS> *>(p)->SetPREMode(
+        static_cast<ProxyReEncryptionMode>(mode));
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
 void DestroyParamsCKKS(ParamsCKKSPtr p) {
   delete reinterpret_cast<CCParams<CryptoContextCKKSRNS> *>(p);
 }
@@ -229,6 +255,36 @@ PKEErr CryptoContext_MakeCKKSPackedPlaintext(CryptoContextPtr cc_ptr_to_sptr,
   PKE_CATCH_RETURN()
 }
 
+PKEErr CryptoContext_MakeCKKSPackedPlaintextWithParams(
+    CryptoContextPtr cc_ptr_to_sptr, double *values, int len,
+    uint32_t noiseScaleDeg, uint32_t level, uint32_t slots, PlaintextPtr *out) {
+  try {
+    if (!cc_ptr_to_sptr) {
+      return MakePKEError(
+          "CryptoContext_MakeCKKSPackedPlaintextWithParams: null context");
+    }
+
+    if (len > 0 && !values) {
+      return MakePKEError("CryptoContext_MakeCKKSPackedPlaintextWithParams: "
+                          "non-zero length with null values");
+    }
+
+    if (!out) {
+      return MakePKEError("CryptoContext_MakeCKKSPackedPlaintextWithParams: "
+                          "null output pointer");
+    }
+
+    auto &cc_sptr = GetCCSharedPtr(cc_ptr_to_sptr);
+    std::vector<double> vec(values, values + len);
+    Plaintext pt_sptr = cc_sptr->MakeCKKSPackedPlaintext(
+        vec, noiseScaleDeg, level, nullptr, slots);
+    *out = reinterpret_cast<PlaintextPtr>(new PlaintextSharedPtr(pt_sptr));
+
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
 PKEErr
 CryptoContext_MakeCKKSComplexPackedPlaintext(CryptoContextPtr cc_ptr_to_sptr,
                                              complex_double_t *values, int len,
@@ -265,6 +321,43 @@ CryptoContext_MakeCKKSComplexPackedPlaintext(CryptoContextPtr cc_ptr_to_sptr,
   PKE_CATCH_RETURN()
 }
 
+PKEErr CryptoContext_MakeCKKSComplexPackedPlaintextWithParams(
+    CryptoContextPtr cc_ptr_to_sptr, complex_double_t *values, int len,
+    uint32_t noiseScaleDeg, uint32_t level, uint32_t slots, PlaintextPtr *out) {
+  try {
+    if (!cc_ptr_to_sptr) {
+      return MakePKEError(
+          "CryptoContext_MakeCKKSComplexPackedPlaintextWithParams: "
+          "null context");
+    }
+
+    if (len > 0 && !values) {
+      return MakePKEError(
+          "CryptoContext_MakeCKKSComplexPackedPlaintextWithParams: "
+          "non-zero length with null values");
+    }
+
+    if (!out) {
+      return MakePKEError(
+          "CryptoContext_MakeCKKSComplexPackedPlaintextWithParams: "
+          "null output pointer");
+    }
+
+    auto &cc_sptr = GetCCSharedPtr(cc_ptr_to_sptr);
+    std::vector<std::complex<double>> vec(len);
+    for (int i = 0; i < len; ++i) {
+      vec[i] = std::complex<double>(values[i].real, values[i].imag);
+    }
+
+    Plaintext pt_sptr = cc_sptr->MakeCKKSPackedPlaintext(
+        vec, noiseScaleDeg, level, nullptr, slots);
+    *out = reinterpret_cast<PlaintextPtr>(new PlaintextSharedPtr(pt_sptr));
+
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
 PKEErr Plaintext_GetComplexPackedValueLength(PlaintextPtr pt_ptr_to_sptr,
                                              int *out_len) {
   try {
@@ -290,31 +383,30 @@ PKEErr Plaintext_GetComplexPackedValueLength(PlaintextPtr pt_ptr_to_sptr,
   PKE_CATCH_RETURN()
 }
 
-PKEErr Plaintext_GetComplexPackedValueAt(PlaintextPtr pt_ptr_to_sptr, int i,
-                                         complex_double_t *out) {
+PKEErr Plaintext_CopyComplexPackedValue(PlaintextPtr pt_ptr_to_sptr,
+                                        complex_double_t *dst, int cap,
+                                        int *out_len) {
   try {
-    if (!!pt_ptr_to_sptr) {
-      return MakePKEError("Plaintext_GetComplexPackedValueAt: null plaintext");
+    if (!pt_ptr_to_sptr) {
+      return MakePKEError("Plaintext_CopyComplexPackedValue: null plaintext");
     }
-
-    if (!out) {
+    if (!out_len) {
       return MakePKEError(
-          "Plaintext_GetComplexPackedValueAt: null output pointer");
+          "Plaintext_CopyComplexPackedValue: null output pointer");
     }
-
-    auto &pt_sptr = GetPTSharedPtr(pt_ptr_to_sptr);
-    const auto &complex_vec =
-        pt_sptr->GetCKKSPackedValue(); // Assuming this is correct
-
-    // bounds check
-    if (i < 0 || (size_t)i >= complex_vec.size()) {
+    if (cap > 0 && !dst) {
       return MakePKEError(
-          "Plaintext_GetComplexPackedValueAt: index out of bounds");
+          "Plaintext_CopyComplexPackedValue: "
+          "non-zero capacity with null buffer");
     }
-
-    out->real = complex_vec[i].real();
-    out->imag = complex_vec[i].imag();
-
+    auto &pt_sptr = GetPTSharedPtr(pt_ptr_to_sptr);
+    const auto &values = pt_sptr->GetCKKSPackedValue();
+    size_t n = std::min(values.size(), static_cast<size_t>(cap > 0 ? cap : 0));
+    for (size_t i = 0; i < n; ++i) {
+      dst[i].real = values[i].real();
+      dst[i].imag = values[i].imag();
+    }
+    *out_len = static_cast<int>(values.size());
     return MakePKEOk();
   }
   PKE_CATCH_RETURN()
@@ -415,7 +507,50 @@ PKEErr CryptoContext_EvalPoly(CryptoContextPtr cc_ptr_to_sptr,
   PKE_CATCH_RETURN()
 }
 
+PKEErr CryptoContext_EvalChebyshevSeries(CryptoContextPtr cc_ptr_to_sptr,
+                                         CiphertextPtr ct_ptr_to_sptr,
+                                         const double *coefficients,
+                                         size_t count, double a, double b,
+                                         CiphertextPtr *out) {
+  try {
+    if (!cc_ptr_to_sptr) {
+      return MakePKEError("CryptoContext_EvalChebyshevSeries: null context");
+    }
+    if (!ct_ptr_to_sptr) {
+      return MakePKEError(
+          "CryptoContext_EvalChebyshevSeries: null input ciphertext");
+    }
+    if (count == 0 || !coefficients) {
+      return MakePKEError(
+          "CryptoContext_EvalChebyshevSeries: no coefficients");
+    }
+    if (!out) {
+      return MakePKEError(
+          "CryptoContext_EvalChebyshevSeries: null output pointer");
+    }
+    *out = nullptr;
+
+    auto &cc = GetCCSharedPtr(cc_ptr_to_sptr);
+    auto &ct = GetCTSharedPtr(ct_ptr_to_sptr);
+    std::vector<double> coeffs(coefficients, coefficients + count);
+    Ciphertext<DCRTPoly> result = cc->EvalChebyshevSeries(ct, coeffs, a, b);
+    *out = reinterpret_cast<CiphertextPtr>(new CiphertextSharedPtr(result));
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
 // --- CKKS Bootstrapping ---
+
+// levelBudgetOrDefault copies a level budget, substituting the {4, 4}
+// default every bootstrapping entry point uses for an empty one.
+static std::vector<uint32_t> levelBudgetOrDefault(const uint32_t *lb,
+                                                  int len) {
+  if (lb && len > 0)
+    return std::vector<uint32_t>(lb, lb + len);
+  return {4, 4};
+}
+
 PKEErr CryptoContext_EvalBootstrapSetup_Simple(CryptoContextPtr cc_ptr_to_sptr,
                                                const uint32_t *lb, int len) {
   try {
@@ -424,13 +559,7 @@ PKEErr CryptoContext_EvalBootstrapSetup_Simple(CryptoContextPtr cc_ptr_to_sptr,
           "CryptoContext_EvalBootstrapSetup_Simple: null context");
     }
     auto &cc = GetCCSharedPtr(cc_ptr_to_sptr);
-    std::vector<uint32_t> levelBudget;
-    if (lb && len > 0)
-      levelBudget.assign(lb, lb + len);
-    else
-      levelBudget = {4, 4}; // Default
-
-    cc->EvalBootstrapSetup(levelBudget);
+    cc->EvalBootstrapSetup(levelBudgetOrDefault(lb, len));
     return MakePKEOk();
   }
   PKE_CATCH_RETURN()
@@ -483,14 +612,76 @@ PKEErr CryptoContext_EvalBootstrap(CryptoContextPtr cc_ptr_to_sptr,
   PKE_CATCH_RETURN()
 }
 
+PKEErr CryptoContext_EvalBootstrapSetup(CryptoContextPtr cc_ptr_to_sptr,
+                                        const uint32_t *lb, int lbLen,
+                                        const uint32_t *d1, int dim1Len,
+                                        uint32_t slots,
+                                        uint32_t correctionFactor,
+                                        int precompute) {
+  try {
+    if (!cc_ptr_to_sptr) {
+      return MakePKEError("CryptoContext_EvalBootstrapSetup: null context");
+    }
+    auto &cc = GetCCSharedPtr(cc_ptr_to_sptr);
+    auto levelBudget = levelBudgetOrDefault(lb, lbLen);
+    std::vector<uint32_t> dim1;
+    if (d1 && dim1Len > 0)
+      dim1.assign(d1, d1 + dim1Len);
+    else
+      dim1 = {0, 0}; // let OpenFHE pick the BSGS dimensions
+
+    cc->EvalBootstrapSetup(levelBudget, dim1, slots, correctionFactor,
+                           precompute != 0);
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
+PKEErr CryptoContext_EvalBootstrapPrecompute(CryptoContextPtr cc_ptr_to_sptr,
+                                             uint32_t slots) {
+  try {
+    if (!cc_ptr_to_sptr) {
+      return MakePKEError(
+          "CryptoContext_EvalBootstrapPrecompute: null context");
+    }
+    auto &cc = GetCCSharedPtr(cc_ptr_to_sptr);
+    cc->EvalBootstrapPrecompute(slots);
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
+PKEErr CryptoContext_EvalBootstrapIterations(CryptoContextPtr cc_ptr_to_sptr,
+                                             CiphertextPtr ct_ptr_to_sptr,
+                                             uint32_t numIterations,
+                                             uint32_t precision,
+                                             CiphertextPtr *out) {
+  try {
+    if (!cc_ptr_to_sptr) {
+      return MakePKEError("CryptoContext_EvalBootstrapIterations: null context");
+    }
+    if (!ct_ptr_to_sptr) {
+      return MakePKEError(
+          "CryptoContext_EvalBootstrapIterations: null ciphertext");
+    }
+    if (!out) {
+      return MakePKEError(
+          "CryptoContext_EvalBootstrapIterations: null output pointer");
+    }
+
+    auto &cc = GetCCSharedPtr(cc_ptr_to_sptr);
+    auto &ct = GetCTSharedPtr(ct_ptr_to_sptr);
+    auto out_ct = cc->EvalBootstrap(ct, numIterations, precision);
+    *out = reinterpret_cast<CiphertextPtr>(new CiphertextSharedPtr(out_ct));
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
 uint32_t CKKS_GetBootstrapDepth(const uint32_t *levelBudget, int len,
                                 int secretKeyDist) {
   // This is a static helper, no error handling needed
-  std::vector<uint32_t> lb;
-  if (levelBudget && len > 0)
-    lb.assign(levelBudget, levelBudget + len);
-  else
-    lb = {4, 4};
+  auto lb = levelBudgetOrDefault(levelBudget, len);
   auto skd = static_cast<lbcrypto::SecretKeyDist>(secretKeyDist);
   return FHECKKSRNS::GetBootstrapDepth(lb, skd);
 }
@@ -578,3 +769,63 @@ PKEErr CryptoContext_EvalInnerProduct(CryptoContextPtr cc_ptr_to_sptr,
 }
 
 } // extern "C"
+
+// Complex conjugation is the automorphism X -> X^(M-1), M the cyclotomic
+// order. Its key lives in the same map as the rotation keys.
+PKEErr CryptoContext_EvalConjugateKeyGen(CryptoContextPtr cc_ptr_to_sptr,
+                                         KeyPairPtr keys_raw_ptr) {
+  try {
+    if (!cc_ptr_to_sptr) {
+      return MakePKEError("CryptoContext_EvalConjugateKeyGen: null context");
+    }
+    if (!keys_raw_ptr) {
+      return MakePKEError("CryptoContext_EvalConjugateKeyGen: null keypair");
+    }
+
+    auto &cc_sptr = GetCCSharedPtr(cc_ptr_to_sptr);
+    auto kp_raw = reinterpret_cast<KeyPairRawPtr>(keys_raw_ptr);
+
+    if (!kp_raw->secretKey) {
+      return MakePKEError(
+          "CryptoContext_EvalConjugateKeyGen: keypair has no secret key");
+    }
+
+    uint32_t m = cc_sptr->GetCyclotomicOrder();
+    auto keyMap = cc_sptr->EvalAutomorphismKeyGen(kp_raw->secretKey, {m - 1});
+    CryptoContextImpl<DCRTPoly>::InsertEvalAutomorphismKey(
+        keyMap, kp_raw->secretKey->GetKeyTag());
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
+PKEErr CryptoContext_EvalConjugate(CryptoContextPtr cc_ptr_to_sptr,
+                                   CiphertextPtr ct_ptr_to_sptr,
+                                   CiphertextPtr *out) {
+  try {
+    if (!cc_ptr_to_sptr) {
+      return MakePKEError("CryptoContext_EvalConjugate: null context");
+    }
+    if (!ct_ptr_to_sptr) {
+      return MakePKEError("CryptoContext_EvalConjugate: null ciphertext");
+    }
+    if (!out) {
+      return MakePKEError("CryptoContext_EvalConjugate: null output pointer");
+    }
+
+    auto &cc_sptr = GetCCSharedPtr(cc_ptr_to_sptr);
+    auto &ct_sptr = GetCTSharedPtr(ct_ptr_to_sptr);
+
+    uint32_t m = cc_sptr->GetCyclotomicOrder();
+    auto &keyMap =
+        CryptoContextImpl<DCRTPoly>::GetEvalAutomorphismKeyMap(
+            ct_sptr->GetKeyTag());
+    Ciphertext<DCRTPoly> result_ct_sptr =
+        cc_sptr->EvalAutomorphism(ct_sptr, m - 1, keyMap);
+    *out = reinterpret_cast<CiphertextPtr>(
+        new CiphertextSharedPtr(result_ct_sptr));
+
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
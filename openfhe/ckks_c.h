@@ -29,6 +29,8 @@ PKEErr ParamsCKKS_SetNumLargeDigits(ParamsCKKSPtr p, int numDigits);
 PKEErr ParamsCKKS_SetSecretKeyDist(ParamsCKKSPtr p, OFHESecretKeyDist dist);
 PKEErr ParamsCKKS_SetDigitSize(ParamsCKKSPtr p, int digitSize);
 PKEErr ParamsCKKS_SetKeySwitchTechnique(ParamsCKKSPtr p, int technique);
+PKEErr ParamsCKKS_SetMultipartyMode(ParamsCKKSPtr p, int mode);
+PKEErr ParamsCKKS_SetPREMode(ParamsCKKSPtr p, int mode);
 void DestroyParamsCKKS(ParamsCKKSPtr p);
 
 // --- CKKS CryptoContext ---
@@ -38,9 +40,15 @@ PKEErr NewCryptoContextCKKS(ParamsCKKSPtr p, CryptoContextPtr *out);
 PKEErr CryptoContext_MakeCKKSPackedPlaintext(CryptoContextPtr cc,
                                              double *values, int len,
                                              PlaintextPtr *out);
+PKEErr CryptoContext_MakeCKKSPackedPlaintextWithParams(
+    CryptoContextPtr cc, double *values, int len, uint32_t noiseScaleDeg,
+    uint32_t level, uint32_t slots, PlaintextPtr *out);
 PKEErr CryptoContext_MakeCKKSComplexPackedPlaintext(CryptoContextPtr cc,
                                                     complex_double_t *values,
                                                     int len, PlaintextPtr *out);
+PKEErr CryptoContext_MakeCKKSComplexPackedPlaintextWithParams(
+    CryptoContextPtr cc, complex_double_t *values, int len,
+    uint32_t noiseScaleDeg, uint32_t level, uint32_t slots, PlaintextPtr *out);
 
 // --- CKKS Operations ---
 PKEErr CryptoContext_Rescale(CryptoContextPtr cc, CiphertextPtr ct,
@@ -57,17 +65,34 @@ PKEErr CryptoContext_EvalBootstrapKeyGen(CryptoContextPtr cc, KeyPairPtr keys,
                                          uint32_t slots);
 PKEErr CryptoContext_EvalBootstrap(CryptoContextPtr cc, CiphertextPtr ct,
                                    CiphertextPtr *out);
+PKEErr CryptoContext_EvalBootstrapSetup(CryptoContextPtr cc,
+                                        const uint32_t *levelBudget, int lbLen,
+                                        const uint32_t *dim1, int dim1Len,
+                                        uint32_t slots,
+                                        uint32_t correctionFactor,
+                                        int precompute);
+PKEErr CryptoContext_EvalBootstrapPrecompute(CryptoContextPtr cc,
+                                             uint32_t slots);
+PKEErr CryptoContext_EvalBootstrapIterations(CryptoContextPtr cc,
+                                             CiphertextPtr ct,
+                                             uint32_t numIterations,
+                                             uint32_t precision,
+                                             CiphertextPtr *out);
 PKEErr CryptoContext_EvalPoly(CryptoContextPtr cc, CiphertextPtr ct,
                               const double *coefficients, size_t count,
                               CiphertextPtr *out);
+PKEErr CryptoContext_EvalChebyshevSeries(CryptoContextPtr cc, CiphertextPtr ct,
+                                         const double *coefficients,
+                                         size_t count, double a, double b,
+                                         CiphertextPtr *out);
 
 uint32_t CKKS_GetBootstrapDepth(const uint32_t *levelBudget, int len,
                                 int secretKeyDist);
 
 // -- CKKS Complex number support ---
 PKEErr Plaintext_GetComplexPackedValueLength(PlaintextPtr pt, int *out_len);
-PKEErr Plaintext_GetComplexPackedValueAt(PlaintextPtr pt, int i,
-                                         complex_double_t *out_val);
+PKEErr Plaintext_CopyComplexPackedValue(PlaintextPtr pt, complex_double_t *dst,
+                                        int cap, int *out_len);
 
 // --- CKKS Advanced Operations ---
 PKEErr CryptoContext_EvalSumKeyGen(CryptoContextPtr cc, KeyPairPtr keys);
@@ -76,6 +101,9 @@ PKEErr CryptoContext_EvalSum(CryptoContextPtr cc, CiphertextPtr ct,
 PKEErr CryptoContext_EvalInnerProduct(CryptoContextPtr cc, CiphertextPtr ct1,
                                       CiphertextPtr ct2, uint32_t batchSize,
                                       CiphertextPtr *out);
+PKEErr CryptoContext_EvalConjugateKeyGen(CryptoContextPtr cc, KeyPairPtr keys);
+PKEErr CryptoContext_EvalConjugate(CryptoContextPtr cc, CiphertextPtr ct,
+                                   CiphertextPtr *out);
 
 #ifdef __cplusplus
 }
@@ -2,6 +2,7 @@ package openfhe
 
 import (
 	"math"
+	"strings"
 	"testing"
 )
 
@@ -160,6 +161,213 @@ func TestCKKSBootstrap_AfterArithmetic(t *testing.T) {
 	}
 }
 
+// setupCKKSBootstrapContextFull uses EvalBootstrapSetup with an explicit slot
+// count and reserves numIterations-1 extra levels for iterative bootstrapping.
+func setupCKKSBootstrapContextFull(t *testing.T, slots, numIterations uint32) (*CryptoContext, *KeyPair, uint32) {
+	t.Helper()
+
+	levelBudget := []uint32{3, 3}
+	secretKeyDist := SecretKeyUniformTernary
+
+	params, err := NewParamsCKKSRNS()
+	mustT(t, err, "NewParamsCKKSRNS")
+	defer params.Close()
+
+	mustT(t, params.SetSecretKeyDist(secretKeyDist), "SetSecretKeyDist")
+	mustT(t, params.SetSecurityLevel(HEStdNotSet), "SetSecurityLevel")
+	mustT(t, params.SetRingDim(uint64(1<<12)), "SetRingDim")
+	mustT(t, params.SetScalingTechnique(FLEXIBLEAUTO), "SetScalingTechnique")
+	mustT(t, params.SetScalingModSize(59), "SetScalingModSize")
+	mustT(t, params.SetFirstModSize(60), "SetFirstModSize")
+
+	levelsAfter := uint32(10)
+	depth := levelsAfter + GetBootstrapDepth(levelBudget, secretKeyDist) + (numIterations - 1)
+	mustT(t, params.SetMultiplicativeDepth(int(depth)), "SetMultiplicativeDepth")
+
+	cc, err := NewCryptoContextCKKS(params)
+	mustT(t, err, "NewCryptoContextCKKS")
+
+	mustT(t, cc.Enable(PKE), "Enable PKE")
+	mustT(t, cc.Enable(KEYSWITCH), "Enable KEYSWITCH")
+	mustT(t, cc.Enable(LEVELEDSHE), "Enable LEVELEDSHE")
+	mustT(t, cc.Enable(ADVANCEDSHE), "Enable ADVANCEDSHE")
+	mustT(t, cc.Enable(FHE), "Enable FHE")
+
+	if slots == 0 {
+		slots = uint32(cc.GetRingDimension() / 2)
+	}
+
+	// Split setup: skip precomputation in EvalBootstrapSetup, then run it explicitly.
+	mustT(t, cc.EvalBootstrapSetup(levelBudget, []uint32{0, 0}, slots, 0, false), "EvalBootstrapSetup")
+	mustT(t, cc.EvalBootstrapPrecompute(slots), "EvalBootstrapPrecompute")
+
+	kp, err := cc.KeyGen()
+	mustT(t, err, "KeyGen")
+	mustT(t, cc.EvalMultKeyGen(kp), "EvalMultKeyGen")
+	mustT(t, cc.EvalBootstrapKeyGen(kp, slots), "EvalBootstrapKeyGen")
+
+	return cc, kp, depth
+}
+
+func TestCKKSBootstrap_SparseSlots(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping CKKS bootstrapping test in -short mode")
+	}
+
+	const slots = 8
+	cc, kp, depth := setupCKKSBootstrapContextFull(t, slots, 1)
+	defer cc.Close()
+	defer kp.Close()
+
+	in := []float64{0.1, -0.2, 0.3, -0.4, 0.5, -0.6, 0.7, -0.8}
+	pt, err := cc.MakeCKKSPackedPlaintextWithParams(in, 1, depth-1, slots)
+	mustT(t, err, "MakeCKKSPackedPlaintextWithParams")
+	defer pt.Close()
+
+	ct, err := cc.Encrypt(kp, pt)
+	mustT(t, err, "Encrypt")
+	defer ct.Close()
+
+	ctB, err := cc.EvalBootstrap(ct)
+	mustT(t, err, "EvalBootstrap")
+	defer ctB.Close()
+
+	ptOut, err := cc.Decrypt(kp, ctB)
+	mustT(t, err, "Decrypt")
+	defer ptOut.Close()
+
+	mustT(t, ptOut.SetLength(slots), "SetLength")
+	got, err := ptOut.GetRealPackedValue()
+	mustT(t, err, "GetRealPackedValue")
+
+	if !slicesApproxEqual(got[:slots], in, 0.01) {
+		t.Fatalf("sparse bootstrap mismatch.\nwant ~%v\ngot  %v", in, got[:slots])
+	}
+}
+
+func TestCKKSBootstrap_Iterative(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping CKKS bootstrapping test in -short mode")
+	}
+
+	const slots = 8
+	const numIterations = 2
+	cc, kp, depth := setupCKKSBootstrapContextFull(t, slots, numIterations)
+	defer cc.Close()
+	defer kp.Close()
+
+	in := []float64{0.11, -0.23, 0.35, -0.47, 0.59, -0.61, 0.73, -0.85}
+	pt, err := cc.MakeCKKSPackedPlaintextWithParams(in, 1, depth-1, slots)
+	mustT(t, err, "MakeCKKSPackedPlaintextWithParams")
+	defer pt.Close()
+
+	ct, err := cc.Encrypt(kp, pt)
+	mustT(t, err, "Encrypt")
+	defer ct.Close()
+
+	maxErr := func(c *Ciphertext) float64 {
+		ptOut, err := cc.Decrypt(kp, c)
+		mustT(t, err, "Decrypt")
+		defer ptOut.Close()
+		mustT(t, ptOut.SetLength(slots), "SetLength")
+		got, err := ptOut.GetRealPackedValue()
+		mustT(t, err, "GetRealPackedValue")
+		var m float64
+		for i := range in {
+			m = math.Max(m, math.Abs(got[i]-in[i]))
+		}
+		return m
+	}
+
+	ctOnce, err := cc.EvalBootstrap(ct)
+	mustT(t, err, "EvalBootstrap")
+	defer ctOnce.Close()
+	errOnce := maxErr(ctOnce)
+
+	// 17 bits is the single-iteration precision OpenFHE measures for these parameters.
+	ctTwice, err := cc.EvalBootstrapWithIterations(ct, numIterations, 17)
+	mustT(t, err, "EvalBootstrapWithIterations")
+	defer ctTwice.Close()
+	errTwice := maxErr(ctTwice)
+
+	t.Logf("bootstrap precision: 1 iteration %.1f bits, %d iterations %.1f bits",
+		-math.Log2(errOnce), numIterations, -math.Log2(errTwice))
+	if errTwice > 0.01 {
+		t.Fatalf("iterative bootstrap error too large: %g", errTwice)
+	}
+	if errTwice >= errOnce {
+		t.Fatalf("%d iterations did not improve on one: error %g, single bootstrap %g",
+			numIterations, errTwice, errOnce)
+	}
+}
+
+// Managed mode: repeated products outrun the depth and get bootstrapped
+// without the caller tracking levels.
+func TestCKKSAutoBootstrap(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping CKKS bootstrapping test in -short mode")
+	}
+
+	plain, plainKeys := setupCKKSContextAndKeys(t)
+	defer plain.Close()
+	defer plainKeys.Close()
+	if err := plain.EnableAutoBootstrap(); err == nil {
+		t.Fatal("EnableAutoBootstrap succeeded without EvalBootstrapSetupSimple")
+	}
+
+	cc, kp, _ := setupCKKSBootstrapContext(t)
+	defer cc.Close()
+	defer kp.Close()
+	mustT(t, cc.EnableAutoBootstrap(), "EnableAutoBootstrap")
+
+	in := []float64{0.25, -0.5, 0.75, 1.0}
+	x := encryptReals(t, cc, kp, in)
+	defer x.Close()
+	one := encryptReals(t, cc, kp, []float64{1, 1, 1, 1})
+	defer one.Close()
+
+	// Multiplying by an encryption of one keeps the values while consuming
+	// a level per step, more than a fresh ciphertext has.
+	steps := cc.GetMultiplicativeDepth() + 5
+	acc, err := x.Clone()
+	mustT(t, err, "Clone")
+	for i := 0; i < steps; i++ {
+		next, err := cc.EvalMult(acc, one)
+		mustT(t, err, "EvalMult")
+		acc.Close()
+		acc = next
+	}
+	defer acc.Close()
+
+	if n := cc.AutoBootstraps(); n == 0 {
+		t.Fatalf("AutoBootstraps = 0 after %d products", steps)
+	}
+	if got := decryptReals(t, cc, kp, acc, len(in)); !slicesApproxEqual(got, in, 0.02) {
+		t.Fatalf("managed bootstrap mismatch.\nwant ~%v\ngot  %v", in, got)
+	}
+
+	// A polynomial deeper than the levels left after bootstrapping cannot
+	// be helped.
+	coefficients := make([]float64, 1025)
+	coefficients[1024] = 1
+	if _, err := cc.EvalPoly(x, coefficients); err == nil || !strings.Contains(err.Error(), "bootstrapping leaves") {
+		t.Errorf("EvalPoly error = %v, expected a level budget error", err)
+	}
+
+	cc.DisableAutoBootstrap()
+	if n := cc.AutoBootstraps(); n != 0 {
+		t.Errorf("AutoBootstraps = %d after DisableAutoBootstrap, expected 0", n)
+	}
+}
+
+// An empty level budget means {4, 4} for every entry point.
+func TestGetBootstrapDepthDefault(t *testing.T) {
+	got := GetBootstrapDepth(nil, SecretKeyUniformTernary)
+	if want := GetBootstrapDepth([]uint32{4, 4}, SecretKeyUniformTernary); got != want {
+		t.Errorf("GetBootstrapDepth(nil) = %d, expected %d", got, want)
+	}
+}
+
 // Test 3: EvalSum - basic functionality
 func TestCKKS_EvalSum(t *testing.T) {
 	// Setup
@@ -222,6 +430,64 @@ func TestCKKS_EvalSum(t *testing.T) {
 	}
 }
 
+func TestCKKS_EvalSquareAndChebyshevSeries(t *testing.T) {
+	params, err := NewParamsCKKSRNS()
+	mustT(t, err, "NewParamsCKKSRNS")
+	defer params.Close()
+
+	mustT(t, params.SetMultiplicativeDepth(6), "SetMultiplicativeDepth")
+	mustT(t, params.SetScalingModSize(50), "SetScalingModSize")
+	mustT(t, params.SetBatchSize(8), "SetBatchSize")
+
+	cc, err := NewCryptoContextCKKS(params)
+	mustT(t, err, "NewCryptoContextCKKS")
+	defer cc.Close()
+
+	mustT(t, cc.Enable(PKE), "Enable PKE")
+	mustT(t, cc.Enable(KEYSWITCH), "Enable KEYSWITCH")
+	mustT(t, cc.Enable(LEVELEDSHE), "Enable LEVELEDSHE")
+	mustT(t, cc.Enable(ADVANCEDSHE), "Enable ADVANCEDSHE")
+
+	keys, err := cc.KeyGen()
+	mustT(t, err, "KeyGen")
+	defer keys.Close()
+	mustT(t, cc.EvalMultKeyGen(keys), "EvalMultKeyGen")
+
+	input := []float64{0.5, 1, 2, 3, 4.5, 6, 7, 8}
+	ct := encryptReals(t, cc, keys, input)
+	defer ct.Close()
+
+	sq, err := cc.EvalSquare(ct)
+	mustT(t, err, "EvalSquare")
+	defer sq.Close()
+	want := make([]float64, len(input))
+	for i, v := range input {
+		want[i] = v * v
+	}
+	if got := decryptReals(t, cc, keys, sq, len(input)); !slicesApproxEqual(got, want, 0.01) {
+		t.Errorf("EvalSquare = %v, expected %v", got, want)
+	}
+
+	// 1/x on [0.5, 8] with a degree-27 series, which uses 6 levels.
+	if d := ChebyshevDepth(27); d != 6 {
+		t.Fatalf("ChebyshevDepth(27) = %d, expected 6", d)
+	}
+	coeffs := ChebyshevCoefficients(func(x float64) float64 { return 1 / x }, 0.5, 8, 27)
+	inv, err := cc.EvalChebyshevSeries(ct, coeffs, 0.5, 8)
+	mustT(t, err, "EvalChebyshevSeries")
+	defer inv.Close()
+	for i, v := range input {
+		want[i] = 1 / v
+	}
+	if got := decryptReals(t, cc, keys, inv, len(input)); !slicesApproxEqual(got, want, 0.01) {
+		t.Errorf("EvalChebyshevSeries(1/x) = %v, expected %v", got, want)
+	}
+
+	if _, err := cc.EvalChebyshevSeries(ct, coeffs, 8, 0.5); err == nil {
+		t.Error("EvalChebyshevSeries accepted an empty interval")
+	}
+}
+
 // Test 4: EvalSum - small batch size
 func TestCKKS_EvalSum_SmallBatch(t *testing.T) {
 	params, err := NewParamsCKKSRNS()
@@ -0,0 +1,271 @@
+package openfhe
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// These tests are meant to be run with -race (make test-race).
+
+const stressGoroutines = 16
+
+func decryptRealsErr(cc *CryptoContext, keys *KeyPair, ct *Ciphertext, n int) ([]float64, error) {
+	pt, err := cc.Decrypt(keys, ct)
+	if err != nil {
+		return nil, err
+	}
+	defer pt.Close()
+	vals, err := pt.GetRealPackedValue()
+	if err != nil {
+		return nil, err
+	}
+	return vals[:n], nil
+}
+
+func isClosedErr(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "closed or invalid")
+}
+
+func TestConcurrentEval(t *testing.T) {
+	cc, keys := setupCKKSContextAndKeys(t)
+	defer cc.Close()
+	defer keys.Close()
+	mustT(t, cc.EvalRotateKeyGen(keys, []int32{1}), "EvalRotateKeyGen")
+
+	in := []float64{1, 2, 3, 4, 5, 6, 7, 8}
+	ct := encryptReals(t, cc, keys, in)
+	defer ct.Close()
+	want := make([]float64, len(in))
+	for i, v := range in {
+		want[i] = v*v + in[(i+1)%len(in)]
+	}
+
+	iters := 8
+	if testing.Short() {
+		iters = 2
+	}
+	var wg sync.WaitGroup
+	for g := 0; g < stressGoroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iters; i++ {
+				prod, err := cc.EvalMult(ct, ct)
+				if err != nil {
+					t.Errorf("EvalMult: %v", err)
+					return
+				}
+				rot, err := cc.EvalRotate(ct, 1)
+				if err != nil {
+					prod.Close()
+					t.Errorf("EvalRotate: %v", err)
+					return
+				}
+				sum, err := cc.EvalAdd(prod, rot)
+				prod.Close()
+				rot.Close()
+				if err != nil {
+					t.Errorf("EvalAdd: %v", err)
+					return
+				}
+				got, err := decryptRealsErr(cc, keys, sum, len(want))
+				sum.Close()
+				if err != nil {
+					t.Errorf("Decrypt: %v", err)
+					return
+				}
+				if !slicesApproxEqual(got, want, 0.01) {
+					t.Errorf("x^2 + rot(x, 1) = %v, expected %v", got, want)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestConcurrentKeyGen(t *testing.T) {
+	cc, keys := setupCKKSContextAndKeys(t)
+	defer cc.Close()
+	defer keys.Close()
+	mustT(t, cc.EvalRotateKeyGen(keys, []int32{1}), "EvalRotateKeyGen")
+
+	in := []float64{1, 2, 3, 4, 5, 6, 7, 8}
+	ct := encryptReals(t, cc, keys, in)
+	defer ct.Close()
+
+	// Evaluation keeps running against the shared key store while new keys
+	// are added to it.
+	indices := []int32{2, 3, 4, 5, 6, 7}
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	for g := 0; g < stressGoroutines/2; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				for _, op := range []func() (*Ciphertext, error){
+					func() (*Ciphertext, error) { return cc.EvalRotate(ct, 1) },
+					func() (*Ciphertext, error) { return cc.EvalMult(ct, ct) },
+				} {
+					out, err := op()
+					if err != nil {
+						t.Errorf("evaluation during key generation: %v", err)
+						return
+					}
+					out.Close()
+				}
+			}
+		}()
+	}
+	for _, k := range indices {
+		mustT(t, cc.EvalRotateKeyGen(keys, []int32{k}), "EvalRotateKeyGen")
+	}
+	mustT(t, cc.EvalMultKeyGen(keys), "EvalMultKeyGen")
+	close(stop)
+	wg.Wait()
+
+	for _, k := range indices {
+		rot, err := cc.EvalRotate(ct, k)
+		mustT(t, err, "EvalRotate")
+		got := decryptReals(t, cc, keys, rot, len(in))
+		rot.Close()
+		if want := in[k%int32(len(in))]; got[0] < want-0.01 || got[0] > want+0.01 {
+			t.Errorf("EvalRotate by %d: slot 0 = %v, expected %v", k, got[0], want)
+		}
+	}
+}
+
+func TestCloseWhileInFlight(t *testing.T) {
+	cc, keys := setupCKKSContextAndKeys(t)
+	defer cc.Close()
+	defer keys.Close()
+
+	ct := encryptReals(t, cc, keys, []float64{0.5, 1, 1.5})
+	defer ct.Close()
+
+	// hammer runs op until it fails, which it may only do because a handle
+	// it uses was closed.
+	hammer := func(wg *sync.WaitGroup, op func() (*Ciphertext, error)) {
+		for g := 0; g < stressGoroutines; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					out, err := op()
+					if err != nil {
+						if !isClosedErr(err) {
+							t.Errorf("operation failed with %v, expected a closed handle", err)
+						}
+						return
+					}
+					out.Close()
+				}
+			}()
+		}
+	}
+
+	// Closing an input while other goroutines use it.
+	victim, err := ct.Clone()
+	mustT(t, err, "Clone")
+	var wg sync.WaitGroup
+	hammer(&wg, func() (*Ciphertext, error) { return cc.EvalMult(victim, ct) })
+	time.Sleep(20 * time.Millisecond)
+	victim.Close()
+	wg.Wait()
+	if _, err := cc.EvalMult(victim, ct); !isClosedErr(err) {
+		t.Errorf("EvalMult on a closed Ciphertext = %v, expected a closed handle error", err)
+	}
+	if _, ok := victim.GetLevel(); ok {
+		t.Error("GetLevel succeeded on a closed Ciphertext")
+	}
+
+	// Closing the context itself.
+	hammer(&wg, func() (*Ciphertext, error) { return cc.EvalAdd(ct, ct) })
+	time.Sleep(20 * time.Millisecond)
+	cc.Close()
+	wg.Wait()
+	if _, err := cc.EvalAdd(ct, ct); !isClosedErr(err) {
+		t.Errorf("EvalAdd on a closed CryptoContext = %v, expected a closed handle error", err)
+	}
+}
+
+func TestBinFHECloseWhileInFlight(t *testing.T) {
+	cc, err := NewBinFHEContext()
+	mustT(t, err, "NewBinFHEContext")
+	defer cc.Close()
+	mustT(t, cc.GenerateBinFHEContext(TOY, GINX), "GenerateBinFHEContext")
+	sk, err := cc.KeyGen()
+	mustT(t, err, "KeyGen")
+	defer sk.Close()
+
+	victim, err := cc.Encrypt(sk, 1)
+	mustT(t, err, "Encrypt")
+	hammer := func(wg *sync.WaitGroup, ct *BinFHECiphertext) {
+		for g := 0; g < stressGoroutines; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					out, err := cc.EvalNOT(ct)
+					if err != nil {
+						if !isClosedErr(err) {
+							t.Errorf("EvalNOT failed with %v, expected a closed handle", err)
+						}
+						return
+					}
+					out.Close()
+				}
+			}()
+		}
+	}
+
+	// Closing the input, then the context, while gates use them.
+	var wg sync.WaitGroup
+	hammer(&wg, victim)
+	time.Sleep(20 * time.Millisecond)
+	victim.Close()
+	wg.Wait()
+
+	ct, err := cc.Encrypt(sk, 0)
+	mustT(t, err, "Encrypt")
+	defer ct.Close()
+	hammer(&wg, ct)
+	time.Sleep(20 * time.Millisecond)
+	cc.Close()
+	wg.Wait()
+	if _, err := cc.Decrypt(sk, ct); !isClosedErr(err) {
+		t.Errorf("Decrypt on a closed BinFHEContext = %v, expected a closed handle error", err)
+	}
+}
+
+func TestRefCloseWaits(t *testing.T) {
+	var r ref
+	if !r.acquire() {
+		t.Fatal("acquire failed on an open handle")
+	}
+	closed := make(chan bool)
+	go func() { closed <- r.close() }()
+	select {
+	case <-closed:
+		t.Fatal("close returned while a reference was held")
+	case <-time.After(20 * time.Millisecond):
+	}
+	r.release()
+	if !<-closed {
+		t.Error("first close did not report that it frees the handle")
+	}
+	if r.acquire() {
+		t.Error("acquire succeeded after close")
+	}
+	if r.close() {
+		t.Error("second close reported that it frees the handle")
+	}
+}
@@ -0,0 +1,198 @@
+package openfhe
+
+/*
+#cgo CPPFLAGS: -I${SRCDIR}/../openfhe-install/include -I${SRCDIR}/../openfhe-install/include/openfhe -I${SRCDIR}/../openfhe-install/include/openfhe/core -I${SRCDIR}/../openfhe-install/include/openfhe/pke -I${SRCDIR}/../openfhe-install/include/openfhe/binfhe -I${SRCDIR}/../openfhe-install/include/openfhe/cereal
+#cgo CXXFLAGS: -std=c++17
+#include <stdint.h>
+#include "pke_common_c.h"
+*/
+import "C"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+)
+
+// --- context.Context variants ---
+//
+// OpenFHE cannot interrupt a call once it has started, so the ...Ctx
+// variants split their work into separate native calls wherever the
+// operation allows it and check ctx between them; on cancellation they
+// return ctx.Err() and free any partial results. By operation:
+//
+//   - Stopped between chunks: EvalRotateKeyGenCtx (one rotation index per
+//     call), EvalBinGatesCtx (one gate per call) and
+//     EvalFunctionViaSchemeSwitchingCtx (ctx is checked before the switch
+//     to FHEW, before every EvalFunc, which runs one LWE ciphertext per
+//     call, and once more before the switch back to CKKS).
+//   - Checked before starting only: EvalCKKStoFHEWCtx, EvalBootstrapKeyGenCtx
+//     and the BTKeyGenCtx methods. The switch to FHEW is a single OpenFHE
+//     call that produces every LWE ciphertext at once, and key generation
+//     must not be left half done in the shared key store; these run to
+//     completion in the caller's goroutine once started.
+//   - Abandon-only: EvalBootstrapCtx. A bootstrap is one OpenFHE call with
+//     no intermediate boundary. When ctx is done first it returns ctx.Err()
+//     at once, but the bootstrap keeps running in the background, using CPU
+//     and memory and holding its own references to the context and input,
+//     until it completes and its result is freed. The caller may close the
+//     context and the input meanwhile. At most GOMAXPROCS bootstraps run
+//     this way at a time, abandoned or not; further calls wait for one of
+//     them to finish, or for their own ctx, before starting.
+
+// shared returns a handle that keeps the C++ context alive after cc is
+// closed. It carries none of cc's Go-side state.
+func (cc *CryptoContext) shared() *CryptoContext {
+	return &CryptoContext{ptr: C.CryptoContext_Share(cc.ptr)}
+}
+
+// shared returns a handle that keeps the C++ ciphertext alive after ct is
+// closed.
+func (ct *Ciphertext) shared() *Ciphertext {
+	return &Ciphertext{ptr: C.Ciphertext_Share(ct.ptr)}
+}
+
+// bootstrapSlots bounds the bootstraps EvalBootstrapCtx has running, so
+// abandoned ones cannot pile up under repeated deadlines.
+var bootstrapSlots = make(chan struct{}, runtime.GOMAXPROCS(0))
+
+// abandonable runs call on its own goroutine and returns its result, or
+// ctx.Err() as soon as ctx is done. release frees the result of an
+// abandoned call; done runs once call has returned in either case.
+func abandonable[T any](ctx context.Context, call func() (T, error), release func(T), done func()) (T, error) {
+	type outcome struct {
+		v   T
+		err error
+	}
+	ch := make(chan outcome, 1)
+	go func() {
+		v, err := call()
+		done()
+		ch <- outcome{v, err}
+	}()
+	select {
+	case o := <-ch:
+		return o.v, o.err
+	case <-ctx.Done():
+		go func() {
+			if o := <-ch; o.err == nil {
+				release(o.v)
+			}
+		}()
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// EvalRotateKeyGenCtx is EvalRotateKeyGen generating one rotation key at a
+// time and stopping when ctx is done. Keys generated before cancellation
+// stay in the context.
+func (cc *CryptoContext) EvalRotateKeyGenCtx(ctx context.Context, keys *KeyPair, indices []int32) error {
+	for i := range indices {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := cc.EvalRotateKeyGen(keys, indices[i:i+1]); err != nil {
+			return fmt.Errorf("EvalRotateKeyGen: index %d: %w", indices[i], err)
+		}
+	}
+	return nil
+}
+
+// EvalBootstrapKeyGenCtx is EvalBootstrapKeyGen that does not start when
+// ctx is already done.
+func (cc *CryptoContext) EvalBootstrapKeyGenCtx(ctx context.Context, keys *KeyPair, slots uint32) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return cc.EvalBootstrapKeyGen(keys, slots)
+}
+
+// EvalBootstrapCtx is EvalBootstrap that returns ctx.Err() as soon as ctx
+// is done. It is abandon-only: the bootstrap itself runs to completion in
+// the background. It waits while GOMAXPROCS earlier bootstraps, including
+// abandoned ones, are still running.
+func (cc *CryptoContext) EvalBootstrapCtx(ctx context.Context, ct *Ciphertext) (*Ciphertext, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	select {
+	case bootstrapSlots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if !cc.acquire() {
+		<-bootstrapSlots
+		return nil, errors.New("CryptoContext is closed or invalid")
+	}
+	if !ct.acquire() {
+		cc.release()
+		<-bootstrapSlots
+		return nil, errors.New("Input Ciphertext is closed or invalid")
+	}
+	sc, sct := cc.shared(), ct.shared()
+	ct.release()
+	cc.release()
+	return abandonable(ctx,
+		func() (*Ciphertext, error) { return sc.EvalBootstrap(sct) },
+		(*Ciphertext).Close,
+		func() {
+			sct.Close()
+			sc.Close()
+			<-bootstrapSlots
+		})
+}
+
+// EvalCKKStoFHEWCtx is EvalCKKStoFHEW that does not start when ctx is
+// already done. The switch itself cannot be interrupted.
+func (cc *CryptoContext) EvalCKKStoFHEWCtx(ctx context.Context, ct *Ciphertext, numValues uint32) ([]*LWECiphertext, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return cc.EvalCKKStoFHEW(ct, numValues)
+}
+
+// EvalFunctionViaSchemeSwitchingCtx is EvalFunctionViaSchemeSwitching that
+// stops between its stages and between LWE ciphertexts when ctx is done.
+func (cc *CryptoContext) EvalFunctionViaSchemeSwitchingCtx(ctx context.Context, ct *Ciphertext, lut []uint64, numValues uint32) (*Ciphertext, error) {
+	return cc.evalFunctionViaSchemeSwitching(ctx, ct, lut, numValues)
+}
+
+// BTKeyGenCtx is BTKeyGen that does not start when ctx is already done.
+func (cc *BinFHEContext) BTKeyGenCtx(ctx context.Context, sk *BinFHESecretKey) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return cc.BTKeyGen(sk)
+}
+
+// BTKeyGenCtx is BTKeyGen that does not start when ctx is already done.
+func (lwesk *LWEPrivateKey) BTKeyGenCtx(ctx context.Context, ccLWE *BinFHEContext) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return lwesk.BTKeyGen(ccLWE)
+}
+
+// EvalBinGatesCtx is EvalBinGates that stops between gates when ctx is
+// done.
+func (cc *BinFHEContext) EvalBinGatesCtx(ctx context.Context, gate BinFHEGate, ct1, ct2 []*BinFHECiphertext) ([]*BinFHECiphertext, error) {
+	if len(ct1) != len(ct2) {
+		return nil, fmt.Errorf("EvalBinGates: %d first operands but %d second operands", len(ct1), len(ct2))
+	}
+	out := make([]*BinFHECiphertext, 0, len(ct1))
+	for i := range ct1 {
+		if err := ctx.Err(); err != nil {
+			closeLWECiphertexts(out)
+			return nil, err
+		}
+		ct, err := cc.EvalBinGate(gate, ct1[i], ct2[i])
+		if err != nil {
+			closeLWECiphertexts(out)
+			return nil, fmt.Errorf("EvalBinGates: gate %d: %w", i, err)
+		}
+		out = append(out, ct)
+	}
+	return out, nil
+}
@@ -0,0 +1,218 @@
+package openfhe
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEvalRotateKeyGenCtx(t *testing.T) {
+	cc, keys := setupCKKSContextAndKeys(t)
+	defer cc.Close()
+	defer keys.Close()
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := cc.EvalRotateKeyGenCtx(cancelled, keys, []int32{1, 2}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("EvalRotateKeyGenCtx on a cancelled context = %v, expected context.Canceled", err)
+	}
+
+	mustT(t, cc.EvalRotateKeyGenCtx(context.Background(), keys, []int32{1, -1}), "EvalRotateKeyGenCtx")
+	ct := encryptReals(t, cc, keys, []float64{1, 2, 3, 4})
+	defer ct.Close()
+	for _, k := range []int32{1, -1} {
+		rot, err := cc.EvalRotate(ct, k)
+		mustT(t, err, "EvalRotate")
+		rot.Close()
+	}
+}
+
+func TestEvalBootstrapCtx(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping CKKS bootstrapping test in -short mode")
+	}
+
+	cc, kp, _ := setupCKKSBootstrapContext(t)
+	defer cc.Close()
+	defer kp.Close()
+
+	in := []float64{0.25, 0.5, 0.75, 1.0}
+	ct := encryptReals(t, cc, kp, in)
+	defer ct.Close()
+
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	if _, err := cc.EvalBootstrapCtx(expired, ct); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("EvalBootstrapCtx past its deadline = %v, expected context.DeadlineExceeded", err)
+	}
+
+	// A deadline far shorter than a bootstrap abandons the call; closing
+	// the input afterwards must be safe.
+	short, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	tmp, err := ct.Clone()
+	mustT(t, err, "Clone")
+	start := time.Now()
+	if _, err := cc.EvalBootstrapCtx(short, tmp); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("EvalBootstrapCtx with a 1ms timeout = %v, expected context.DeadlineExceeded", err)
+	}
+	tmp.Close()
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("EvalBootstrapCtx returned after %v, expected it to give up at the deadline", elapsed)
+	}
+
+	out, err := cc.EvalBootstrapCtx(context.Background(), ct)
+	mustT(t, err, "EvalBootstrapCtx")
+	defer out.Close()
+	if got := decryptReals(t, cc, kp, out, len(in)); !slicesApproxEqual(got, in, 0.02) {
+		t.Errorf("EvalBootstrapCtx = %v, expected ~%v", got, in)
+	}
+
+	// The abandoned bootstrap gives its slot back once it finishes.
+	waitUntil(t, "abandoned bootstrap to finish", func() bool { return len(bootstrapSlots) == 0 })
+
+	// With every slot held, a new call waits for its ctx instead of
+	// starting another bootstrap.
+	for range cap(bootstrapSlots) {
+		bootstrapSlots <- struct{}{}
+	}
+	busy, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = cc.EvalBootstrapCtx(busy, ct)
+	for range cap(bootstrapSlots) {
+		<-bootstrapSlots
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("EvalBootstrapCtx with every slot busy = %v, expected context.DeadlineExceeded", err)
+	}
+}
+
+// cancelAfter is a context whose Err reports context.Canceled from its
+// (n+1)th call on, cancelling a pipeline at a fixed point between stages.
+type cancelAfter struct {
+	context.Context
+	n     int32
+	calls atomic.Int32
+}
+
+func (c *cancelAfter) Err() error {
+	if c.calls.Add(1) > c.n {
+		return context.Canceled
+	}
+	return nil
+}
+
+// waitUntil polls cond for up to a few seconds.
+func waitUntil(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(5 * time.Second); !cond(); {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestEvalFunctionViaSchemeSwitchingCtx(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping scheme-switching function evaluation test in -short mode")
+	}
+
+	const slots = 8
+	cc, keys, lwesk, pLWE := setupSchemeSwitchingEval(t, 14, slots, func(p *SchSwchParams) {
+		mustT(t, p.SetArbitraryFunctionEvaluation(true), "SetArbitraryFunctionEvaluation")
+	})
+	defer cc.Close()
+	defer keys.Close()
+	defer lwesk.Close()
+
+	ccLWE, err := cc.GetBinCCForSchemeSwitch()
+	mustT(t, err, "GetBinCCForSchemeSwitch")
+	mustT(t, lwesk.BTKeyGen(ccLWE), "BTKeyGen")
+	mustT(t, cc.EvalCKKStoFHEWPrecompute(1.0/float64(pLWE)), "EvalCKKStoFHEWPrecompute")
+
+	lut := make([]uint64, pLWE)
+	for m := range lut {
+		lut[m] = uint64(m)
+	}
+	ct := encryptReals(t, cc, keys, make([]float64, slots))
+	defer ct.Close()
+
+	baseline := runtime.NumGoroutine()
+	// The first Err call is the check before the switch to FHEW and the
+	// second lets one EvalFunc start, so cancellation lands mid-loop.
+	ctx := &cancelAfter{Context: context.Background(), n: 2}
+	if _, err := cc.EvalFunctionViaSchemeSwitchingCtx(ctx, ct, lut, slots); !errors.Is(err, context.Canceled) {
+		t.Fatalf("EvalFunctionViaSchemeSwitchingCtx cancelled mid-pipeline = %v, expected context.Canceled", err)
+	}
+	if ctx.calls.Load() <= 2 {
+		t.Fatalf("EvalFunctionViaSchemeSwitchingCtx checked ctx %d times, expected it to reach the EvalFunc loop", ctx.calls.Load())
+	}
+
+	// Every EvalFunc has returned and released the context and input.
+	for name, r := range map[string]*ref{"CryptoContext": &cc.ref, "Ciphertext": &ct.ref} {
+		r.mu.Lock()
+		users := r.users
+		r.mu.Unlock()
+		if users != 0 {
+			t.Errorf("%s has %d references in flight after cancellation, expected 0", name, users)
+		}
+	}
+	waitUntil(t, "EvalFunc goroutines to exit", func() bool { return runtime.NumGoroutine() <= baseline })
+
+	// The context is still usable afterwards.
+	res, err := cc.EvalFunctionViaSchemeSwitchingCtx(context.Background(), ct, lut, slots)
+	mustT(t, err, "EvalFunctionViaSchemeSwitchingCtx")
+	res.Close()
+}
+
+func TestEvalBinGatesCtx(t *testing.T) {
+	cc, err := NewBinFHEContext()
+	mustT(t, err, "NewBinFHEContext")
+	defer cc.Close()
+	mustT(t, cc.GenerateBinFHEContext(TOY, GINX), "GenerateBinFHEContext")
+	sk, err := cc.KeyGen()
+	mustT(t, err, "KeyGen")
+	defer sk.Close()
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := cc.BTKeyGenCtx(cancelled, sk); !errors.Is(err, context.Canceled) {
+		t.Fatalf("BTKeyGenCtx on a cancelled context = %v, expected context.Canceled", err)
+	}
+	mustT(t, cc.BTKeyGenCtx(context.Background(), sk), "BTKeyGenCtx")
+
+	bits1 := []int{0, 0, 1, 1}
+	bits2 := []int{0, 1, 0, 1}
+	var a, b []*BinFHECiphertext
+	for i := range bits1 {
+		ca, err := cc.Encrypt(sk, bits1[i])
+		mustT(t, err, "Encrypt")
+		defer ca.Close()
+		cb, err := cc.Encrypt(sk, bits2[i])
+		mustT(t, err, "Encrypt")
+		defer cb.Close()
+		a, b = append(a, ca), append(b, cb)
+	}
+
+	if _, err := cc.EvalBinGatesCtx(cancelled, AND, a, b); !errors.Is(err, context.Canceled) {
+		t.Fatalf("EvalBinGatesCtx on a cancelled context = %v, expected context.Canceled", err)
+	}
+	if _, err := cc.EvalBinGates(AND, a, b[:2]); err == nil {
+		t.Error("EvalBinGates accepted operand slices of different lengths")
+	}
+
+	out, err := cc.EvalBinGates(AND, a, b)
+	mustT(t, err, "EvalBinGates")
+	defer closeLWECiphertexts(out)
+	for i, ct := range out {
+		got, err := cc.Decrypt(sk, ct)
+		mustT(t, err, "Decrypt")
+		if want := bits1[i] & bits2[i]; got != want {
+			t.Errorf("AND(%d, %d) = %d, expected %d", bits1[i], bits2[i], got, want)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package openfhe
+
+// Evaluator is the set of CryptoContext operations application code needs
+// to run a packed computation. *CryptoContext implements it with OpenFHE;
+// *Simulator implements it in pure Go on cleartext slots so that
+// application logic can be unit-tested without paying for encryption.
+//
+// Keys, plaintexts and ciphertexts belong to the Evaluator that created
+// them and cannot be mixed between implementations.
+type Evaluator interface {
+	Enable(feature int) error
+	KeyGen() (*KeyPair, error)
+	EvalMultKeyGen(keys *KeyPair) error
+	EvalRotateKeyGen(keys *KeyPair, indices []int32) error
+	EvalSumKeyGen(keys *KeyPair) error
+
+	GetRingDimension() uint64
+	GetBatchSize() uint32
+	GetScalingTechnique() int
+
+	MakePackedPlaintext(vec []int64) (*Plaintext, error)
+	MakeCKKSPackedPlaintext(vec []float64) (*Plaintext, error)
+	MakeCKKSPackedPlaintextWithParams(vec []float64, noiseScaleDeg, level, slots uint32) (*Plaintext, error)
+	MakeCKKSComplexPackedPlaintext(vec []complex128) (*Plaintext, error)
+	MakeCKKSComplexPackedPlaintextWithParams(vec []complex128, noiseScaleDeg, level, slots uint32) (*Plaintext, error)
+
+	Encrypt(keys *KeyPair, pt *Plaintext) (*Ciphertext, error)
+	Decrypt(keys *KeyPair, ct *Ciphertext) (*Plaintext, error)
+
+	EvalAdd(ct1, ct2 *Ciphertext) (*Ciphertext, error)
+	EvalSub(ct1, ct2 *Ciphertext) (*Ciphertext, error)
+	EvalMult(ct1, ct2 *Ciphertext) (*Ciphertext, error)
+	EvalSquare(ct *Ciphertext) (*Ciphertext, error)
+	EvalAddPlain(ct *Ciphertext, pt *Plaintext) (*Ciphertext, error)
+	EvalSubPlain(ct *Ciphertext, pt *Plaintext) (*Ciphertext, error)
+	EvalMultPlain(ct *Ciphertext, pt *Plaintext) (*Ciphertext, error)
+	EvalRotate(ct *Ciphertext, index int32) (*Ciphertext, error)
+	Rescale(ct *Ciphertext) (*Ciphertext, error)
+	ModReduce(ct *Ciphertext) (*Ciphertext, error)
+	EvalSum(ct *Ciphertext, batchSize uint32) (*Ciphertext, error)
+	EvalInnerProduct(ct1, ct2 *Ciphertext, batchSize uint32) (*Ciphertext, error)
+	EvalPoly(ct *Ciphertext, coefficients []float64) (*Ciphertext, error)
+	EvalChebyshevSeries(ct *Ciphertext, coefficients []float64, a, b float64) (*Ciphertext, error)
+
+	Close()
+}
+
+var (
+	_ Evaluator = (*CryptoContext)(nil)
+	_ Evaluator = (*Simulator)(nil)
+)
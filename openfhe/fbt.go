@@ -0,0 +1,358 @@
+package openfhe
+
+/*
+#cgo CPPFLAGS: -I${SRCDIR}/../openfhe-install/include -I${SRCDIR}/../openfhe-install/include/openfhe -I${SRCDIR}/../openfhe-install/include/openfhe/core -I${SRCDIR}/../openfhe-install/include/openfhe/pke -I${SRCDIR}/../openfhe-install/include/openfhe/binfhe -I${SRCDIR}/../openfhe-install/include/openfhe/cereal
+#cgo CXXFLAGS: -std=c++17
+#include <stdint.h>
+#include <stdlib.h>
+#include "fbt_c.h"
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+)
+
+// FBTLookupTable is a lookup table on Z_PIn prepared for CKKS functional
+// bootstrapping. Coefficients holds its Hermite trigonometric interpolation.
+type FBTLookupTable struct {
+	PIn          uint64
+	Order        uint32
+	Scale        uint64
+	Coefficients []complex128
+}
+
+// FBTConfig describes a functional-bootstrapping pipeline. Inputs are
+// encrypted as coefficient-packed RLWE ciphertexts modulo 2^QInitBits,
+// switched to 2^QBits, and converted to CKKS with 2^BigqBits; outputs are
+// decrypted modulo POut.
+type FBTConfig struct {
+	PIn  uint64
+	POut uint64
+
+	QInitBits uint32
+	QBits     uint32
+	BigqBits  uint32
+
+	Slots       uint32
+	LevelBudget []uint32
+	Dim1        []uint32
+
+	// InputLevel is the number of towers dropped for the RLWE inputs.
+	InputLevel              uint32
+	LevelsAfterBootstrap    uint32
+	DepthLeveledComputation uint32
+}
+
+// FBTSeries holds the powers precomputed by EvalMVBPrecompute.
+type FBTSeries struct {
+	ptr C.FBTSeriesPtr
+	ref ref
+}
+
+// Close frees the underlying C++ series.
+func (s *FBTSeries) Close() {
+	if s.ref.close() && s.ptr != nil {
+		C.DestroyFBTSeries(s.ptr)
+		s.ptr = nil
+	}
+}
+
+// NewFBTLookupTable interpolates table, whose length is the input plaintext
+// modulus (a power of two). order is the Hermite interpolation order (1 for
+// plain trigonometric interpolation) and scale the post-scaling factor, 1 if 0.
+func NewFBTLookupTable(table []int64, order uint32, scale uint64) (*FBTLookupTable, error) {
+	if len(table) < 2 || len(table)&(len(table)-1) != 0 {
+		return nil, fmt.Errorf("NewFBTLookupTable: table length %d is not a power of two", len(table))
+	}
+	if order == 0 {
+		order = 1
+	}
+	if scale == 0 {
+		scale = 1
+	}
+
+	var cCoeffs *C.complex_double_t
+	var cLen C.int
+	status := C.CKKS_GetHermiteTrigCoefficients((*C.int64_t)(unsafe.Pointer(&table[0])),
+		C.uint32_t(len(table)), C.uint32_t(order), C.double(scale), &cCoeffs, &cLen)
+	err := checkPKEErrorMsg(status)
+	if err != nil {
+		return nil, err
+	}
+	defer C.FreeFBTCoefficients(cCoeffs)
+
+	raw := unsafe.Slice(cCoeffs, int(cLen))
+	coeffs := make([]complex128, len(raw))
+	for i, c := range raw {
+		coeffs[i] = complex(float64(c.real), float64(c.imag))
+	}
+
+	return &FBTLookupTable{PIn: uint64(len(table)), Order: order, Scale: scale, Coefficients: coeffs}, nil
+}
+
+// NewFBTLookupTableFunc tabulates f on [0, pIn) and interpolates it.
+func NewFBTLookupTableFunc(f func(int64) int64, pIn uint64, order uint32, scale uint64) (*FBTLookupTable, error) {
+	if f == nil {
+		return nil, errors.New("NewFBTLookupTableFunc: nil function")
+	}
+	table := make([]int64, pIn)
+	for i := range table {
+		table[i] = f(int64(i))
+	}
+	return NewFBTLookupTable(table, order, scale)
+}
+
+func (lut *FBTLookupTable) cCoefficients() []C.complex_double_t {
+	c := make([]C.complex_double_t, len(lut.Coefficients))
+	for i, v := range lut.Coefficients {
+		c[i].real = C.double(real(v))
+		c[i].imag = C.double(imag(v))
+	}
+	return c
+}
+
+func (cfg *FBTConfig) toC(lut *FBTLookupTable) (C.FBTConfig, error) {
+	var c C.FBTConfig
+	if cfg.PIn < 2 || cfg.PIn&(cfg.PIn-1) != 0 {
+		return c, fmt.Errorf("FBTConfig: PIn %d is not a power of two", cfg.PIn)
+	}
+	if cfg.POut < 2 {
+		return c, fmt.Errorf("FBTConfig: invalid POut %d", cfg.POut)
+	}
+	c.pIn = C.uint64_t(cfg.PIn)
+	c.pOut = C.uint64_t(cfg.POut)
+	c.qInitBits = C.uint32_t(cfg.QInitBits)
+	c.qBits = C.uint32_t(cfg.QBits)
+	c.bigqBits = C.uint32_t(cfg.BigqBits)
+	c.slots = C.uint32_t(cfg.Slots)
+	c.inputLevel = C.uint32_t(cfg.InputLevel)
+	c.levelsAfterBootstrap = C.uint32_t(cfg.LevelsAfterBootstrap)
+	c.depthLeveledComputation = C.uint32_t(cfg.DepthLeveledComputation)
+	c.order = 1
+	c.scale = 1
+	if lut != nil {
+		if lut.PIn != cfg.PIn {
+			return c, fmt.Errorf("lookup table is over Z_%d but config has PIn %d", lut.PIn, cfg.PIn)
+		}
+		if len(lut.Coefficients) == 0 {
+			return c, errors.New("lookup table has no coefficients")
+		}
+		c.order = C.uint32_t(lut.Order)
+		c.scale = C.uint64_t(lut.Scale)
+	}
+	return c, nil
+}
+
+func uint32SlicePtr(s []uint32) (*C.uint32_t, C.int) {
+	if len(s) == 0 {
+		return nil, 0
+	}
+	return (*C.uint32_t)(unsafe.Pointer(&s[0])), C.int(len(s))
+}
+
+// GetFBTDepth returns the multiplicative depth needed to run functional
+// bootstrapping for lut with cfg, including cfg.LevelsAfterBootstrap.
+func GetFBTDepth(lut *FBTLookupTable, cfg FBTConfig, skd SecretKeyDist) (uint32, error) {
+	if lut == nil {
+		return 0, errors.New("GetFBTDepth: nil lookup table")
+	}
+	cCfg, err := cfg.toC(lut)
+	if err != nil {
+		return 0, err
+	}
+	coeffs := lut.cCoefficients()
+	lbPtr, lbLen := uint32SlicePtr(cfg.LevelBudget)
+	d := C.CKKS_GetFBTDepth(&coeffs[0], C.int(len(coeffs)), lbPtr, lbLen, cCfg, C.int(skd))
+	return uint32(d), nil
+}
+
+// EvalFBTSetup precomputes the CKKS bootstrapping transforms for functional
+// bootstrapping with lut. Call EvalBootstrapKeyGen with cfg.Slots afterwards.
+func (cc *CryptoContext) EvalFBTSetup(keys *KeyPair, lut *FBTLookupTable, cfg FBTConfig) error {
+	if !cc.acquireExclusive() {
+		return errors.New("CryptoContext is closed or invalid")
+	}
+	defer cc.releaseExclusive()
+	if !keys.acquire() {
+		return errors.New("KeyPair is closed or invalid")
+	}
+	defer keys.release()
+	if lut == nil {
+		return errors.New("EvalFBTSetup: nil lookup table")
+	}
+	cCfg, err := cfg.toC(lut)
+	if err != nil {
+		return err
+	}
+	coeffs := lut.cCoefficients()
+	lbPtr, lbLen := uint32SlicePtr(cfg.LevelBudget)
+	d1Ptr, d1Len := uint32SlicePtr(cfg.Dim1)
+
+	status := C.CryptoContext_EvalFBTSetup(cc.ptr, keys.ptr, &coeffs[0], C.int(len(coeffs)),
+		lbPtr, lbLen, d1Ptr, d1Len, cCfg)
+	return checkPKEErrorMsg(status)
+}
+
+// EvalFBT bootstraps ct while evaluating lut on every slot.
+func (cc *CryptoContext) EvalFBT(ct *Ciphertext, lut *FBTLookupTable, cfg FBTConfig) (*Ciphertext, error) {
+	if !cc.acquire() {
+		return nil, errors.New("CryptoContext is closed or invalid")
+	}
+	defer cc.release()
+	if !ct.acquire() {
+		return nil, errors.New("Input Ciphertext is closed or invalid")
+	}
+	defer ct.release()
+	if lut == nil {
+		return nil, errors.New("EvalFBT: nil lookup table")
+	}
+	cCfg, err := cfg.toC(lut)
+	if err != nil {
+		return nil, err
+	}
+	coeffs := lut.cCoefficients()
+
+	var ctH C.CiphertextPtr
+	status := C.CryptoContext_EvalFBT(cc.ptr, ct.ptr, &coeffs[0], C.int(len(coeffs)), cCfg, &ctH)
+	err = checkPKEErrorMsg(status)
+	if err != nil {
+		return nil, err
+	}
+	if ctH == nil {
+		return nil, errors.New("EvalFBT returned OK but null handle")
+	}
+	return &Ciphertext{ptr: ctH}, nil
+}
+
+// EvalMVBPrecompute runs the shared part of multi-value bootstrapping. The
+// result can be passed to EvalMVB once per lookup table; lut must use the
+// same PIn and order as those tables.
+func (cc *CryptoContext) EvalMVBPrecompute(ct *Ciphertext, lut *FBTLookupTable, cfg FBTConfig) (*FBTSeries, error) {
+	if !cc.acquire() {
+		return nil, errors.New("CryptoContext is closed or invalid")
+	}
+	defer cc.release()
+	if !ct.acquire() {
+		return nil, errors.New("Input Ciphertext is closed or invalid")
+	}
+	defer ct.release()
+	if lut == nil {
+		return nil, errors.New("EvalMVBPrecompute: nil lookup table")
+	}
+	cCfg, err := cfg.toC(lut)
+	if err != nil {
+		return nil, err
+	}
+	coeffs := lut.cCoefficients()
+
+	var sH C.FBTSeriesPtr
+	status := C.CryptoContext_EvalMVBPrecompute(cc.ptr, ct.ptr, &coeffs[0], C.int(len(coeffs)), cCfg, &sH)
+	err = checkPKEErrorMsg(status)
+	if err != nil {
+		return nil, err
+	}
+	if sH == nil {
+		return nil, errors.New("EvalMVBPrecompute returned OK but null handle")
+	}
+	return &FBTSeries{ptr: sH}, nil
+}
+
+// EvalMVB evaluates lut on the powers precomputed by EvalMVBPrecompute.
+func (cc *CryptoContext) EvalMVB(series *FBTSeries, lut *FBTLookupTable, cfg FBTConfig) (*Ciphertext, error) {
+	if !cc.acquire() {
+		return nil, errors.New("CryptoContext is closed or invalid")
+	}
+	defer cc.release()
+	if !series.acquire() {
+		return nil, errors.New("FBTSeries is closed or invalid")
+	}
+	defer series.release()
+	if lut == nil {
+		return nil, errors.New("EvalMVB: nil lookup table")
+	}
+	cCfg, err := cfg.toC(lut)
+	if err != nil {
+		return nil, err
+	}
+	coeffs := lut.cCoefficients()
+
+	var ctH C.CiphertextPtr
+	status := C.CryptoContext_EvalMVB(cc.ptr, series.ptr, &coeffs[0], C.int(len(coeffs)), cCfg, &ctH)
+	err = checkPKEErrorMsg(status)
+	if err != nil {
+		return nil, err
+	}
+	if ctH == nil {
+		return nil, errors.New("EvalMVB returned OK but null handle")
+	}
+	return &Ciphertext{ptr: ctH}, nil
+}
+
+// FBTEncrypt encrypts values modulo cfg.PIn as a coefficient-packed RLWE
+// ciphertext and converts it to the CKKS ciphertext EvalFBT expects.
+func (cc *CryptoContext) FBTEncrypt(keys *KeyPair, values []int64, cfg FBTConfig) (*Ciphertext, error) {
+	if !cc.acquire() {
+		return nil, errors.New("CryptoContext is closed or invalid")
+	}
+	defer cc.release()
+	if !keys.acquire() {
+		return nil, errors.New("KeyPair is closed or invalid")
+	}
+	defer keys.release()
+	if len(values) == 0 {
+		return nil, errors.New("FBTEncrypt: input vector is empty")
+	}
+	cCfg, err := cfg.toC(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var ctH C.CiphertextPtr
+	status := C.CryptoContext_FBTEncrypt(cc.ptr, keys.ptr, (*C.int64_t)(unsafe.Pointer(&values[0])),
+		C.int(len(values)), cCfg, &ctH)
+	err = checkPKEErrorMsg(status)
+	if err != nil {
+		return nil, err
+	}
+	if ctH == nil {
+		return nil, errors.New("FBTEncrypt returned OK but null handle")
+	}
+	return &Ciphertext{ptr: ctH}, nil
+}
+
+// FBTDecrypt converts the output of EvalFBT/EvalMVB back to RLWE and decrypts
+// the first n values modulo cfg.POut. It fails if the ciphertext decodes to
+// fewer than n values.
+func (cc *CryptoContext) FBTDecrypt(keys *KeyPair, ct *Ciphertext, cfg FBTConfig, n int) ([]int64, error) {
+	if !cc.acquire() {
+		return nil, errors.New("CryptoContext is closed or invalid")
+	}
+	defer cc.release()
+	if !keys.acquire() {
+		return nil, errors.New("KeyPair is closed or invalid")
+	}
+	defer keys.release()
+	if !ct.acquire() {
+		return nil, errors.New("Input Ciphertext is closed or invalid")
+	}
+	defer ct.release()
+	if n <= 0 {
+		return nil, fmt.Errorf("FBTDecrypt: invalid length %d", n)
+	}
+	cCfg, err := cfg.toC(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]int64, n)
+	status := C.CryptoContext_FBTDecrypt(cc.ptr, keys.ptr, ct.ptr, cCfg, C.int(n),
+		(*C.int64_t)(unsafe.Pointer(&out[0])))
+	err = checkPKEErrorMsg(status)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
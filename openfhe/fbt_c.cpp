@@ -0,0 +1,319 @@
+#include "fbt_c.h"
+#include "pke_helpers_c.h"
+#include <complex>
+#include <openfhe/core/math/hermite.h>
+#include <openfhe/pke/schemelet/rlwe-mp.h>
+
+using namespace lbcrypto;
+
+using FBTSeriesSharedPtr = std::shared_ptr<seriesPowers<DCRTPoly>>;
+
+static std::vector<std::complex<double>>
+ToComplexVector(const complex_double_t *coeffs, int len) {
+  std::vector<std::complex<double>> v;
+  v.reserve(len > 0 ? len : 0);
+  for (int i = 0; i < len; ++i)
+    v.emplace_back(coeffs[i].real, coeffs[i].imag);
+  return v;
+}
+
+static BigInteger PowerOfTwo(uint32_t bits) { return BigInteger(1) << bits; }
+
+static uint32_t Log2(uint64_t p) {
+  uint32_t bits = 0;
+  while (p > 1) {
+    p >>= 1;
+    ++bits;
+  }
+  return bits;
+}
+
+// Element parameters for the RLWE ciphertexts at cfg.inputLevel: the full
+// CKKS modulus chain with the last inputLevel towers dropped.
+static std::shared_ptr<DCRTPoly::Params>
+FBTElementParams(const CryptoContextSharedPtr &cc, uint32_t inputLevel) {
+  auto ep = std::make_shared<DCRTPoly::Params>(*cc->GetElementParams());
+  for (uint32_t i = 0; i < inputLevel; ++i)
+    ep->PopLastParam();
+  return ep;
+}
+
+extern "C" {
+
+// --- Lookup tables ---
+
+PKEErr CKKS_GetHermiteTrigCoefficients(const int64_t *lut, uint32_t p,
+                                       uint32_t order, double scale,
+                                       complex_double_t **out, int *outLen) {
+  try {
+    if (!lut || p == 0) {
+      return MakePKEError("CKKS_GetHermiteTrigCoefficients: empty table");
+    }
+    if (!out || !outLen) {
+      return MakePKEError(
+          "CKKS_GetHermiteTrigCoefficients: null output pointer");
+    }
+
+    std::vector<int64_t> table(lut, lut + p);
+    auto f = [&table, p](int64_t x) -> int64_t {
+      int64_t m = x % static_cast<int64_t>(p);
+      if (m < 0)
+        m += p;
+      return table[m];
+    };
+    auto coeffs = GetHermiteTrigCoefficients(f, p, order, scale);
+
+    auto *res = static_cast<complex_double_t *>(
+        malloc(sizeof(complex_double_t) * coeffs.size()));
+    if (!res) {
+      return MakePKEError("CKKS_GetHermiteTrigCoefficients: out of memory");
+    }
+    for (size_t i = 0; i < coeffs.size(); ++i) {
+      res[i].real = coeffs[i].real();
+      res[i].imag = coeffs[i].imag();
+    }
+    *out = res;
+    *outLen = static_cast<int>(coeffs.size());
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
+void FreeFBTCoefficients(complex_double_t *coeffs) { free(coeffs); }
+
+uint32_t CKKS_GetFBTDepth(const complex_double_t *coeffs, int len,
+                          const uint32_t *levelBudget, int lbLen,
+                          FBTConfig cfg, int secretKeyDist) {
+  // Static helper like CKKS_GetBootstrapDepth; no error handling needed
+  std::vector<uint32_t> lb;
+  if (levelBudget && lbLen > 0)
+    lb.assign(levelBudget, levelBudget + lbLen);
+  else
+    lb = {3, 3};
+  auto skd = static_cast<lbcrypto::SecretKeyDist>(secretKeyDist);
+  uint32_t depth = cfg.levelsAfterBootstrap;
+  for (auto l : lb)
+    depth += l;
+  return depth + FHECKKSRNS::AdjustDepthFuncBT(ToComplexVector(coeffs, len),
+                                               BigInteger(cfg.pIn), cfg.order,
+                                               skd);
+}
+
+// --- Functional bootstrapping ---
+
+PKEErr CryptoContext_EvalFBTSetup(CryptoContextPtr cc_ptr_to_sptr,
+                                  KeyPairPtr keys_raw_ptr,
+                                  const complex_double_t *coeffs, int len,
+                                  const uint32_t *lb, int lbLen,
+                                  const uint32_t *d1, int dim1Len,
+                                  FBTConfig cfg) {
+  try {
+    if (!cc_ptr_to_sptr) {
+      return MakePKEError("CryptoContext_EvalFBTSetup: null context");
+    }
+    auto kp_raw = reinterpret_cast<KeyPairRawPtr>(keys_raw_ptr);
+    if (!kp_raw || !kp_raw->publicKey) {
+      return MakePKEError("CryptoContext_EvalFBTSetup: missing public key");
+    }
+    if (!coeffs || len <= 0) {
+      return MakePKEError("CryptoContext_EvalFBTSetup: empty coefficients");
+    }
+
+    auto &cc = GetCCSharedPtr(cc_ptr_to_sptr);
+    std::vector<uint32_t> levelBudget;
+    if (lb && lbLen > 0)
+      levelBudget.assign(lb, lb + lbLen);
+    else
+      levelBudget = {3, 3};
+    std::vector<uint32_t> dim1;
+    if (d1 && dim1Len > 0)
+      dim1.assign(d1, d1 + dim1Len);
+    else
+      dim1 = {0, 0};
+
+    cc->EvalFBTSetup(ToComplexVector(coeffs, len), cfg.slots,
+                     BigInteger(cfg.pIn), BigInteger(cfg.pOut),
+                     PowerOfTwo(cfg.bigqBits), kp_raw->publicKey, dim1,
+                     levelBudget, cfg.levelsAfterBootstrap,
+                     cfg.depthLeveledComputation, cfg.order);
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
+PKEErr CryptoContext_EvalFBT(CryptoContextPtr cc_ptr_to_sptr,
+                             CiphertextPtr ct_ptr_to_sptr,
+                             const complex_double_t *coeffs, int len,
+                             FBTConfig cfg, CiphertextPtr *out) {
+  try {
+    if (!cc_ptr_to_sptr) {
+      return MakePKEError("CryptoContext_EvalFBT: null context");
+    }
+    if (!ct_ptr_to_sptr) {
+      return MakePKEError("CryptoContext_EvalFBT: null ciphertext");
+    }
+    if (!coeffs || len <= 0) {
+      return MakePKEError("CryptoContext_EvalFBT: empty coefficients");
+    }
+    if (!out) {
+      return MakePKEError("CryptoContext_EvalFBT: null output pointer");
+    }
+
+    auto &cc = GetCCSharedPtr(cc_ptr_to_sptr);
+    auto &ct = GetCTSharedPtr(ct_ptr_to_sptr);
+    auto ep = FBTElementParams(cc, cfg.inputLevel);
+
+    auto out_ct = cc->EvalFBT(ct, ToComplexVector(coeffs, len), Log2(cfg.pIn),
+                              ep->GetModulus(), cfg.scale, 0, cfg.order);
+    *out = reinterpret_cast<CiphertextPtr>(new CiphertextSharedPtr(out_ct));
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
+PKEErr CryptoContext_EvalMVBPrecompute(CryptoContextPtr cc_ptr_to_sptr,
+                                       CiphertextPtr ct_ptr_to_sptr,
+                                       const complex_double_t *coeffs, int len,
+                                       FBTConfig cfg, FBTSeriesPtr *out) {
+  try {
+    if (!cc_ptr_to_sptr) {
+      return MakePKEError("CryptoContext_EvalMVBPrecompute: null context");
+    }
+    if (!ct_ptr_to_sptr) {
+      return MakePKEError("CryptoContext_EvalMVBPrecompute: null ciphertext");
+    }
+    if (!coeffs || len <= 0) {
+      return MakePKEError(
+          "CryptoContext_EvalMVBPrecompute: empty coefficients");
+    }
+    if (!out) {
+      return MakePKEError(
+          "CryptoContext_EvalMVBPrecompute: null output pointer");
+    }
+
+    auto &cc = GetCCSharedPtr(cc_ptr_to_sptr);
+    auto &ct = GetCTSharedPtr(ct_ptr_to_sptr);
+    auto ep = FBTElementParams(cc, cfg.inputLevel);
+
+    auto series =
+        cc->EvalMVBPrecompute(ct, ToComplexVector(coeffs, len), Log2(cfg.pIn),
+                              ep->GetModulus(), cfg.order);
+    *out = reinterpret_cast<FBTSeriesPtr>(new FBTSeriesSharedPtr(series));
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
+PKEErr CryptoContext_EvalMVB(CryptoContextPtr cc_ptr_to_sptr,
+                             FBTSeriesPtr series_ptr,
+                             const complex_double_t *coeffs, int len,
+                             FBTConfig cfg, CiphertextPtr *out) {
+  try {
+    if (!cc_ptr_to_sptr) {
+      return MakePKEError("CryptoContext_EvalMVB: null context");
+    }
+    if (!series_ptr) {
+      return MakePKEError("CryptoContext_EvalMVB: null series");
+    }
+    if (!coeffs || len <= 0) {
+      return MakePKEError("CryptoContext_EvalMVB: empty coefficients");
+    }
+    if (!out) {
+      return MakePKEError("CryptoContext_EvalMVB: null output pointer");
+    }
+
+    auto &cc = GetCCSharedPtr(cc_ptr_to_sptr);
+    auto &series = *reinterpret_cast<FBTSeriesSharedPtr *>(series_ptr);
+
+    auto out_ct = cc->EvalMVB(series, ToComplexVector(coeffs, len),
+                              Log2(cfg.pIn), cfg.scale, 0, cfg.order);
+    *out = reinterpret_cast<CiphertextPtr>(new CiphertextSharedPtr(out_ct));
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
+void DestroyFBTSeries(FBTSeriesPtr series) {
+  delete reinterpret_cast<FBTSeriesSharedPtr *>(series);
+}
+
+// --- Coefficient-encoded RLWE inputs/outputs ---
+
+PKEErr CryptoContext_FBTEncrypt(CryptoContextPtr cc_ptr_to_sptr,
+                                KeyPairPtr keys_raw_ptr, const int64_t *values,
+                                int len, FBTConfig cfg, CiphertextPtr *out) {
+  try {
+    if (!cc_ptr_to_sptr) {
+      return MakePKEError("CryptoContext_FBTEncrypt: null context");
+    }
+    auto kp_raw = reinterpret_cast<KeyPairRawPtr>(keys_raw_ptr);
+    if (!kp_raw || !kp_raw->secretKey || !kp_raw->publicKey) {
+      return MakePKEError("CryptoContext_FBTEncrypt: incomplete keypair");
+    }
+    if (len > 0 && !values) {
+      return MakePKEError(
+          "CryptoContext_FBTEncrypt: non-zero length with null values");
+    }
+    if (!out) {
+      return MakePKEError("CryptoContext_FBTEncrypt: null output pointer");
+    }
+
+    auto &cc = GetCCSharedPtr(cc_ptr_to_sptr);
+    auto ep = FBTElementParams(cc, cfg.inputLevel);
+    std::vector<int64_t> input(values, values + len);
+
+    auto qInit = PowerOfTwo(cfg.qInitBits);
+    auto q = PowerOfTwo(cfg.qBits);
+    auto polys = SchemeletRLWEMP::EncryptCoeff(input, qInit, BigInteger(cfg.pIn),
+                                               kp_raw->secretKey, ep);
+    SchemeletRLWEMP::ModSwitch(polys, q, qInit);
+    auto ct = SchemeletRLWEMP::ConvertRLWEToCKKS(
+        *cc, polys, kp_raw->publicKey, PowerOfTwo(cfg.bigqBits), cfg.slots,
+        cfg.inputLevel);
+
+    *out = reinterpret_cast<CiphertextPtr>(new CiphertextSharedPtr(ct));
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
+PKEErr CryptoContext_FBTDecrypt(CryptoContextPtr cc_ptr_to_sptr,
+                                KeyPairPtr keys_raw_ptr,
+                                CiphertextPtr ct_ptr_to_sptr, FBTConfig cfg,
+                                int len, int64_t *out) {
+  try {
+    if (!cc_ptr_to_sptr) {
+      return MakePKEError("CryptoContext_FBTDecrypt: null context");
+    }
+    auto kp_raw = reinterpret_cast<KeyPairRawPtr>(keys_raw_ptr);
+    if (!kp_raw || !kp_raw->secretKey) {
+      return MakePKEError("CryptoContext_FBTDecrypt: missing secret key");
+    }
+    if (!ct_ptr_to_sptr) {
+      return MakePKEError("CryptoContext_FBTDecrypt: null ciphertext");
+    }
+    if (len > 0 && !out) {
+      return MakePKEError("CryptoContext_FBTDecrypt: null output pointer");
+    }
+
+    auto &cc = GetCCSharedPtr(cc_ptr_to_sptr);
+    auto &ct = GetCTSharedPtr(ct_ptr_to_sptr);
+    auto ep = FBTElementParams(cc, cfg.inputLevel);
+    auto q = PowerOfTwo(cfg.qBits);
+
+    auto polys = SchemeletRLWEMP::ConvertCKKSToRLWE(ct, q);
+    auto values = SchemeletRLWEMP::DecryptCoeff(
+        polys, q, BigInteger(cfg.pOut), kp_raw->secretKey, ep, cfg.slots, len);
+    if (values.size() < static_cast<size_t>(len)) {
+      return MakePKEError("CryptoContext_FBTDecrypt: decoded " +
+                          std::to_string(values.size()) +
+                          " values, expected " + std::to_string(len));
+    }
+    for (int i = 0; i < len; ++i)
+      out[i] = values[i];
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
+} // extern "C"
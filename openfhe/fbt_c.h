@@ -0,0 +1,78 @@
+#ifndef FBT_C_H
+#define FBT_C_H
+
+#include "ckks_c.h"
+#include "pke_common_c.h"
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// Opaque handle for the power series precomputed by EvalMVBPrecompute.
+typedef void *FBTSeriesPtr;
+
+// Configuration shared by setup, encryption, evaluation and decryption.
+// Moduli other than pIn/pOut are powers of two given by their bit sizes.
+typedef struct {
+  uint64_t pIn;
+  uint64_t pOut;
+  uint32_t qInitBits;
+  uint32_t qBits;
+  uint32_t bigqBits;
+  uint32_t slots;
+  uint32_t inputLevel;
+  uint32_t levelsAfterBootstrap;
+  uint32_t depthLeveledComputation;
+  uint32_t order;
+  uint64_t scale;
+} FBTConfig;
+
+// --- Lookup tables ---
+
+// Computes the Hermite trigonometric interpolation coefficients of the table
+// lut (len == p). The returned array must be freed with FreeFBTCoefficients.
+PKEErr CKKS_GetHermiteTrigCoefficients(const int64_t *lut, uint32_t p,
+                                       uint32_t order, double scale,
+                                       complex_double_t **out, int *outLen);
+void FreeFBTCoefficients(complex_double_t *coeffs);
+
+// Multiplicative depth needed for functional bootstrapping with these
+// coefficients, including the level budget and levels left afterwards.
+uint32_t CKKS_GetFBTDepth(const complex_double_t *coeffs, int len,
+                          const uint32_t *levelBudget, int lbLen,
+                          FBTConfig cfg, int secretKeyDist);
+
+// --- Functional bootstrapping ---
+PKEErr CryptoContext_EvalFBTSetup(CryptoContextPtr cc, KeyPairPtr keys,
+                                  const complex_double_t *coeffs, int len,
+                                  const uint32_t *levelBudget, int lbLen,
+                                  const uint32_t *dim1, int dim1Len,
+                                  FBTConfig cfg);
+PKEErr CryptoContext_EvalFBT(CryptoContextPtr cc, CiphertextPtr ct,
+                             const complex_double_t *coeffs, int len,
+                             FBTConfig cfg, CiphertextPtr *out);
+
+// Multi-value bootstrapping: precompute the powers once, then evaluate
+// several lookup tables on them.
+PKEErr CryptoContext_EvalMVBPrecompute(CryptoContextPtr cc, CiphertextPtr ct,
+                                       const complex_double_t *coeffs, int len,
+                                       FBTConfig cfg, FBTSeriesPtr *out);
+PKEErr CryptoContext_EvalMVB(CryptoContextPtr cc, FBTSeriesPtr series,
+                             const complex_double_t *coeffs, int len,
+                             FBTConfig cfg, CiphertextPtr *out);
+void DestroyFBTSeries(FBTSeriesPtr series);
+
+// --- Coefficient-encoded RLWE inputs/outputs ---
+PKEErr CryptoContext_FBTEncrypt(CryptoContextPtr cc, KeyPairPtr keys,
+                                const int64_t *values, int len, FBTConfig cfg,
+                                CiphertextPtr *out);
+// Decrypts len values into out (caller-allocated).
+PKEErr CryptoContext_FBTDecrypt(CryptoContextPtr cc, KeyPairPtr keys,
+                                CiphertextPtr ct, FBTConfig cfg, int len,
+                                int64_t *out);
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif // FBT_C_H
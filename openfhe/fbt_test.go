@@ -0,0 +1,163 @@
+package openfhe
+
+import (
+	"testing"
+)
+
+// fbtTestConfig follows the small 64-bit parameters of OpenFHE's
+// functional-bootstrapping example: 4-bit inputs and outputs, N=2048.
+func fbtTestConfig() FBTConfig {
+	return FBTConfig{
+		PIn:                  16,
+		POut:                 16,
+		QInitBits:            47,
+		QBits:                47,
+		BigqBits:             33,
+		Slots:                8,
+		LevelBudget:          []uint32{3, 3},
+		Dim1:                 []uint32{0, 0},
+		LevelsAfterBootstrap: 0,
+	}
+}
+
+func setupFBTContext(t *testing.T, lut *FBTLookupTable, cfg *FBTConfig) (*CryptoContext, *KeyPair) {
+	t.Helper()
+
+	skd := SecretKeySparseEncapsulated
+	depth, err := GetFBTDepth(lut, *cfg, skd)
+	mustT(t, err, "GetFBTDepth")
+
+	params, err := NewParamsCKKSRNS()
+	mustT(t, err, "NewParamsCKKSRNS")
+	defer params.Close()
+
+	mustT(t, params.SetSecretKeyDist(skd), "SetSecretKeyDist")
+	mustT(t, params.SetSecurityLevel(HEStdNotSet), "SetSecurityLevel")
+	mustT(t, params.SetRingDim(uint64(1<<11)), "SetRingDim")
+	mustT(t, params.SetScalingTechnique(FIXEDMANUAL), "SetScalingTechnique")
+	mustT(t, params.SetScalingModSize(int(cfg.BigqBits)), "SetScalingModSize")
+	mustT(t, params.SetFirstModSize(int(cfg.QBits)), "SetFirstModSize")
+	mustT(t, params.SetNumLargeDigits(3), "SetNumLargeDigits")
+	mustT(t, params.SetBatchSize(int(cfg.Slots)), "SetBatchSize")
+	mustT(t, params.SetMultiplicativeDepth(int(depth)), "SetMultiplicativeDepth")
+
+	cc, err := NewCryptoContextCKKS(params)
+	mustT(t, err, "NewCryptoContextCKKS")
+
+	mustT(t, cc.Enable(PKE), "Enable PKE")
+	mustT(t, cc.Enable(KEYSWITCH), "Enable KEYSWITCH")
+	mustT(t, cc.Enable(LEVELEDSHE), "Enable LEVELEDSHE")
+	mustT(t, cc.Enable(ADVANCEDSHE), "Enable ADVANCEDSHE")
+	mustT(t, cc.Enable(FHE), "Enable FHE")
+
+	kp, err := cc.KeyGen()
+	mustT(t, err, "KeyGen")
+
+	// Inputs live at the top of the chain, just below the leveled computation.
+	cfg.InputLevel = depth - cfg.LevelsAfterBootstrap
+
+	mustT(t, cc.EvalFBTSetup(kp, lut, *cfg), "EvalFBTSetup")
+	mustT(t, cc.EvalMultKeyGen(kp), "EvalMultKeyGen")
+	mustT(t, cc.EvalBootstrapKeyGen(kp, cfg.Slots), "EvalBootstrapKeyGen")
+
+	return cc, kp
+}
+
+func TestFBTLookupTable_RejectsBadSize(t *testing.T) {
+	if _, err := NewFBTLookupTable([]int64{0, 1, 2}, 1, 1); err == nil {
+		t.Fatal("expected error for non-power-of-two table")
+	}
+	if _, err := NewFBTLookupTableFunc(nil, 8, 1, 1); err == nil {
+		t.Fatal("expected error for nil function")
+	}
+}
+
+func TestFBTConfig_PInMismatch(t *testing.T) {
+	lut := &FBTLookupTable{PIn: 8, Order: 1, Scale: 1, Coefficients: []complex128{1}}
+	cfg := fbtTestConfig()
+	if _, err := cfg.toC(lut); err == nil {
+		t.Fatal("expected error for lookup table over a different modulus")
+	}
+}
+
+func TestCKKS_EvalFBT(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping CKKS functional bootstrapping test in -short mode")
+	}
+
+	cfg := fbtTestConfig()
+	square := func(x int64) int64 { return (x * x) % int64(cfg.POut) }
+	lut, err := NewFBTLookupTableFunc(square, cfg.PIn, 1, 1)
+	mustT(t, err, "NewFBTLookupTableFunc")
+
+	cc, kp := setupFBTContext(t, lut, &cfg)
+	defer cc.Close()
+	defer kp.Close()
+
+	in := []int64{0, 1, 2, 3, 4, 5, 6, 7}
+	ct, err := cc.FBTEncrypt(kp, in, cfg)
+	mustT(t, err, "FBTEncrypt")
+	defer ct.Close()
+
+	ctOut, err := cc.EvalFBT(ct, lut, cfg)
+	mustT(t, err, "EvalFBT")
+	defer ctOut.Close()
+
+	got, err := cc.FBTDecrypt(kp, ctOut, cfg, len(in))
+	mustT(t, err, "FBTDecrypt")
+
+	want := make([]int64, len(in))
+	for i, v := range in {
+		want[i] = square(v)
+	}
+	if !slicesEqual(got, want) {
+		t.Fatalf("EvalFBT mismatch.\nwant %v\ngot  %v", want, got)
+	}
+}
+
+func TestCKKS_EvalMVB(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping CKKS multi-value bootstrapping test in -short mode")
+	}
+
+	cfg := fbtTestConfig()
+	p := int64(cfg.POut)
+	double, err := NewFBTLookupTableFunc(func(x int64) int64 { return (2 * x) % p }, cfg.PIn, 1, 1)
+	mustT(t, err, "NewFBTLookupTableFunc double")
+	negate, err := NewFBTLookupTableFunc(func(x int64) int64 { return (p - x) % p }, cfg.PIn, 1, 1)
+	mustT(t, err, "NewFBTLookupTableFunc negate")
+
+	cc, kp := setupFBTContext(t, double, &cfg)
+	defer cc.Close()
+	defer kp.Close()
+
+	in := []int64{1, 3, 5, 7, 9, 11, 13, 15}
+	ct, err := cc.FBTEncrypt(kp, in, cfg)
+	mustT(t, err, "FBTEncrypt")
+	defer ct.Close()
+
+	series, err := cc.EvalMVBPrecompute(ct, double, cfg)
+	mustT(t, err, "EvalMVBPrecompute")
+	defer series.Close()
+
+	for name, lut := range map[string]*FBTLookupTable{"double": double, "negate": negate} {
+		ctOut, err := cc.EvalMVB(series, lut, cfg)
+		mustT(t, err, "EvalMVB "+name)
+
+		got, err := cc.FBTDecrypt(kp, ctOut, cfg, len(in))
+		ctOut.Close()
+		mustT(t, err, "FBTDecrypt "+name)
+
+		want := make([]int64, len(in))
+		for i, v := range in {
+			if name == "double" {
+				want[i] = (2 * v) % p
+			} else {
+				want[i] = (p - v) % p
+			}
+		}
+		if !slicesEqual(got, want) {
+			t.Errorf("EvalMVB %s mismatch.\nwant %v\ngot  %v", name, want, got)
+		}
+	}
+}
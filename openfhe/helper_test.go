@@ -35,3 +35,27 @@ func mustT(t *testing.T, err error, where string) {
 		t.Fatalf("%s: %v", where, err)
 	}
 }
+
+// requireNoiseBudget fails the test when ct has fewer than minBits of noise
+// budget left, measured with the secret key.
+func requireNoiseBudget(t *testing.T, cc *CryptoContext, keys *KeyPair, ct *Ciphertext, minBits float64) float64 {
+	t.Helper()
+	budget, err := ct.NoiseBudget(cc, keys)
+	mustT(t, err, "NoiseBudget")
+	if budget < minBits {
+		t.Fatalf("noise budget %.1f bits, expected at least %.1f", budget, minBits)
+	}
+	return budget
+}
+
+// requirePrecision fails the test when the CKKS ciphertext ct decrypts to
+// fewer than minBits of precision against want.
+func requirePrecision(t *testing.T, ev Evaluator, keys *KeyPair, ct *Ciphertext, want []float64, minBits float64) float64 {
+	t.Helper()
+	bits, err := ct.Precision(ev, keys, want)
+	mustT(t, err, "Precision")
+	if bits < minBits {
+		t.Fatalf("precision %.1f bits, expected at least %.1f", bits, minBits)
+	}
+	return bits
+}
@@ -0,0 +1,229 @@
+package openfhe
+
+import (
+	"errors"
+	"sort"
+	"sync"
+)
+
+// KeyPlan lists the rotation-type evaluation keys a computation uses.
+// Obtain one with PlanKeys and generate the keys with KeyGenForPlan.
+type KeyPlan struct {
+	// Rotations are the EvalRotate indices, sorted and without 0.
+	Rotations []int32
+	// FastRotations are the EvalFastRotation indices. They always need
+	// exact keys because a hoisted rotation cannot be split.
+	FastRotations []int32
+	// SumBatchSizes are the batch sizes passed to EvalSum and
+	// EvalInnerProduct.
+	SumBatchSizes []uint32
+	// Conjugation is set when EvalConjugate is used.
+	Conjugation bool
+	// Decomposed is set when Rotations have been reduced to signed powers
+	// of two by Decompose.
+	Decomposed bool
+}
+
+// keyTrace records the keys a PlanKeys computation uses; fn may call into
+// it from several goroutines.
+type keyTrace struct {
+	mu            sync.Mutex
+	rotations     map[int32]struct{}
+	fastRotations map[int32]struct{}
+	sumBatchSizes map[uint32]struct{}
+	conjugation   bool
+}
+
+// PlanKeys dry-runs fn and records every rotation index, EvalSum batch size
+// and conjugation it uses, without needing the corresponding keys.
+//
+// While fn runs, EvalRotate, EvalFastRotation, EvalSum, EvalInnerProduct
+// and EvalConjugate on cc return a copy of their (first) input instead of
+// evaluating, so fn must not depend on decrypted intermediate values. All
+// other operations run normally; EvalMult still needs the relinearization
+// key.
+//
+// fn may run operations on several goroutines, as circuit.Execute does, but
+// the dry run applies to every caller of cc: PlanKeys must not overlap any
+// other use of the context, which would silently get copies instead of
+// rotations.
+func (cc *CryptoContext) PlanKeys(fn func() error) (*KeyPlan, error) {
+	if cc.closed() {
+		return nil, errors.New("CryptoContext is closed or invalid")
+	}
+	if fn == nil {
+		return nil, errors.New("PlanKeys: nil computation")
+	}
+	tr := &keyTrace{
+		rotations:     make(map[int32]struct{}),
+		fastRotations: make(map[int32]struct{}),
+		sumBatchSizes: make(map[uint32]struct{}),
+	}
+	if !cc.trace.CompareAndSwap(nil, tr) {
+		return nil, errors.New("PlanKeys: already planning on this CryptoContext")
+	}
+	defer cc.trace.Store(nil)
+
+	if err := fn(); err != nil {
+		return nil, err
+	}
+
+	plan := &KeyPlan{
+		Rotations:     sortedInt32Set(tr.rotations),
+		FastRotations: sortedInt32Set(tr.fastRotations),
+		Conjugation:   tr.conjugation,
+	}
+	for b := range tr.sumBatchSizes {
+		plan.SumBatchSizes = append(plan.SumBatchSizes, b)
+	}
+	sort.Slice(plan.SumBatchSizes, func(i, j int) bool {
+		return plan.SumBatchSizes[i] < plan.SumBatchSizes[j]
+	})
+	return plan, nil
+}
+
+// RotationIndices returns the indices to pass to EvalRotateKeyGen: the
+// union of Rotations and FastRotations.
+func (p *KeyPlan) RotationIndices() []int32 {
+	set := make(map[int32]struct{}, len(p.Rotations)+len(p.FastRotations))
+	for _, k := range p.Rotations {
+		set[k] = struct{}{}
+	}
+	for _, k := range p.FastRotations {
+		set[k] = struct{}{}
+	}
+	return sortedInt32Set(set)
+}
+
+// Decompose returns a copy of p whose Rotations are the signed powers of
+// two of the non-adjacent form of each index. This trades key size for
+// rotation count: each rotation by k then costs as many key switches as k
+// has non-zero NAF digits. FastRotations are kept as they are.
+func (p *KeyPlan) Decompose() *KeyPlan {
+	set := make(map[int32]struct{})
+	for _, k := range p.Rotations {
+		for _, s := range nafRotations(k) {
+			set[s] = struct{}{}
+		}
+	}
+	return &KeyPlan{
+		Rotations:     sortedInt32Set(set),
+		FastRotations: append([]int32(nil), p.FastRotations...),
+		SumBatchSizes: append([]uint32(nil), p.SumBatchSizes...),
+		Conjugation:   p.Conjugation,
+		Decomposed:    true,
+	}
+}
+
+// KeyGenForPlan generates every key in plan. For a decomposed plan,
+// EvalRotate on cc afterwards composes rotations from power-of-two steps.
+func (cc *CryptoContext) KeyGenForPlan(keys *KeyPair, plan *KeyPlan) error {
+	if cc.closed() {
+		return errors.New("CryptoContext is closed or invalid")
+	}
+	if plan == nil {
+		return errors.New("KeyGenForPlan: nil plan")
+	}
+	if err := cc.EvalRotateKeyGen(keys, plan.RotationIndices()); err != nil {
+		return err
+	}
+	if len(plan.SumBatchSizes) > 0 {
+		if err := cc.EvalSumKeyGen(keys); err != nil {
+			return err
+		}
+	}
+	if plan.Conjugation {
+		if err := cc.EvalConjugateKeyGen(keys); err != nil {
+			return err
+		}
+	}
+	cc.composeRotations = plan.Decomposed
+	return nil
+}
+
+// --- tracing ---
+
+func (t *keyTrace) rotate(ct *Ciphertext, index int32) (*Ciphertext, error) {
+	t.mu.Lock()
+	if index != 0 {
+		t.rotations[index] = struct{}{}
+	}
+	t.mu.Unlock()
+	return ct.Clone()
+}
+
+func (t *keyTrace) fastRotate(ct *Ciphertext, index int32) (*Ciphertext, error) {
+	t.mu.Lock()
+	if index != 0 {
+		t.fastRotations[index] = struct{}{}
+	}
+	t.mu.Unlock()
+	return ct.Clone()
+}
+
+func (t *keyTrace) sum(ct *Ciphertext, batchSize uint32) (*Ciphertext, error) {
+	t.mu.Lock()
+	t.sumBatchSizes[batchSize] = struct{}{}
+	t.mu.Unlock()
+	return ct.Clone()
+}
+
+func (t *keyTrace) conjugate(ct *Ciphertext) (*Ciphertext, error) {
+	t.mu.Lock()
+	t.conjugation = true
+	t.mu.Unlock()
+	return ct.Clone()
+}
+
+// --- power-of-two rotations ---
+
+// nafRotations splits k into the signed powers of two of its non-adjacent
+// form, lowest first, e.g. 7 -> [-1 8].
+func nafRotations(k int32) []int32 {
+	var out []int32
+	n, p := int64(k), int64(1)
+	for n != 0 {
+		if n&1 != 0 {
+			d := 2 - ((n%4)+4)%4 // 1 or -1
+			out = append(out, int32(d*p))
+			n -= d
+		}
+		n /= 2
+		p *= 2
+	}
+	return out
+}
+
+func isPow2Rotation(k int32) bool {
+	if k < 0 {
+		k = -k
+	}
+	return k != 0 && k&(k-1) == 0
+}
+
+func (cc *CryptoContext) evalRotateComposed(ct *Ciphertext, index int32) (*Ciphertext, error) {
+	cur := ct
+	for _, s := range nafRotations(index) {
+		next, err := cc.EvalRotate(cur, s)
+		if cur != ct {
+			cur.Close()
+		}
+		if err != nil {
+			return nil, err
+		}
+		cur = next
+	}
+	if cur == ct {
+		return ct.Clone()
+	}
+	return cur, nil
+}
+
+func sortedInt32Set(set map[int32]struct{}) []int32 {
+	out := make([]int32, 0, len(set))
+	for k := range set {
+		out = append(out, k)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
@@ -0,0 +1,276 @@
+package openfhe
+
+import (
+	"math/cmplx"
+	"sync"
+	"testing"
+)
+
+// setupCKKSPlanContext returns a CKKS context with only the
+// relinearization key, so every rotation key has to come from a plan.
+func setupCKKSPlanContext(t *testing.T) (*CryptoContext, *KeyPair) {
+	t.Helper()
+
+	params, err := NewParamsCKKSRNS()
+	mustT(t, err, "NewParamsCKKSRNS")
+	defer params.Close()
+	mustT(t, params.SetMultiplicativeDepth(2), "SetMultiplicativeDepth")
+	mustT(t, params.SetScalingModSize(50), "SetScalingModSize")
+	mustT(t, params.SetBatchSize(8), "SetBatchSize")
+
+	cc, err := NewCryptoContextCKKS(params)
+	mustT(t, err, "NewCryptoContextCKKS")
+	mustT(t, cc.Enable(PKE), "Enable PKE")
+	mustT(t, cc.Enable(KEYSWITCH), "Enable KEYSWITCH")
+	mustT(t, cc.Enable(LEVELEDSHE), "Enable LEVELEDSHE")
+	mustT(t, cc.Enable(ADVANCEDSHE), "Enable ADVANCEDSHE")
+
+	keys, err := cc.KeyGen()
+	mustT(t, err, "KeyGen")
+	mustT(t, cc.EvalMultKeyGen(keys), "EvalMultKeyGen")
+	return cc, keys
+}
+
+func int32sEqual(a, b []int32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestNAFRotations(t *testing.T) {
+	cases := map[int32][]int32{
+		1:  {1},
+		3:  {-1, 4},
+		7:  {-1, 8},
+		-3: {1, -4},
+		12: {-4, 16},
+		6:  {-2, 8},
+	}
+	for k, want := range cases {
+		if got := nafRotations(k); !int32sEqual(got, want) {
+			t.Errorf("nafRotations(%d) = %v, expected %v", k, got, want)
+		}
+	}
+}
+
+func TestPlanKeys(t *testing.T) {
+	cc, keys := setupCKKSPlanContext(t)
+	defer cc.Close()
+	defer keys.Close()
+
+	vals := []float64{1, 2, 3, 4, 5, 6, 7, 8}
+	pt, err := cc.MakeCKKSPackedPlaintext(vals)
+	mustT(t, err, "MakeCKKSPackedPlaintext")
+	defer pt.Close()
+	ct, err := cc.Encrypt(keys, pt)
+	mustT(t, err, "Encrypt")
+	defer ct.Close()
+
+	// rot(ct, 3) + rot(ct, -1), then summed over all 8 slots.
+	compute := func() (*Ciphertext, error) {
+		a, err := cc.EvalRotate(ct, 3)
+		if err != nil {
+			return nil, err
+		}
+		defer a.Close()
+		b, err := cc.EvalRotate(ct, -1)
+		if err != nil {
+			return nil, err
+		}
+		defer b.Close()
+		ab, err := cc.EvalAdd(a, b)
+		if err != nil {
+			return nil, err
+		}
+		defer ab.Close()
+		return cc.EvalSum(ab, 8)
+	}
+
+	plan, err := cc.PlanKeys(func() error {
+		res, err := compute()
+		if err != nil {
+			return err
+		}
+		res.Close()
+		return nil
+	})
+	mustT(t, err, "PlanKeys")
+	if want := []int32{-1, 3}; !int32sEqual(plan.Rotations, want) {
+		t.Errorf("plan rotations = %v, expected %v", plan.Rotations, want)
+	}
+	if len(plan.SumBatchSizes) != 1 || plan.SumBatchSizes[0] != 8 {
+		t.Errorf("plan sum batch sizes = %v, expected [8]", plan.SumBatchSizes)
+	}
+	if plan.Conjugation || len(plan.FastRotations) != 0 {
+		t.Errorf("unexpected plan entries: %+v", plan)
+	}
+
+	mustT(t, cc.KeyGenForPlan(keys, plan), "KeyGenForPlan")
+	res, err := compute()
+	mustT(t, err, "compute")
+	defer res.Close()
+
+	ptDec, err := cc.Decrypt(keys, res)
+	mustT(t, err, "Decrypt")
+	defer ptDec.Close()
+	got, err := ptDec.GetRealPackedValue()
+	mustT(t, err, "GetRealPackedValue")
+	if !slicesApproxEqual(got[:1], []float64{72}, 1e-3) {
+		t.Errorf("planned computation = %v, expected 72", got[0])
+	}
+
+	if _, err := cc.PlanKeys(nil); err == nil {
+		t.Error("expected error for nil computation")
+	}
+}
+
+func TestPlanKeysParallel(t *testing.T) {
+	cc, keys := setupCKKSPlanContext(t)
+	defer cc.Close()
+	defer keys.Close()
+
+	pt, err := cc.MakeCKKSPackedPlaintext([]float64{1, 2, 3, 4})
+	mustT(t, err, "MakeCKKSPackedPlaintext")
+	defer pt.Close()
+	ct, err := cc.Encrypt(keys, pt)
+	mustT(t, err, "Encrypt")
+	defer ct.Close()
+
+	// Each goroutine rotates by its own index and sums, as a parallel
+	// circuit would.
+	plan, err := cc.PlanKeys(func() error {
+		var wg sync.WaitGroup
+		errs := make([]error, stressGoroutines)
+		for g := range stressGoroutines {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				rot, err := cc.EvalRotate(ct, int32(g+1))
+				if err != nil {
+					errs[g] = err
+					return
+				}
+				defer rot.Close()
+				sum, err := cc.EvalSum(rot, uint32(1<<(g%3+1)))
+				if err != nil {
+					errs[g] = err
+					return
+				}
+				sum.Close()
+			}()
+		}
+		wg.Wait()
+		for _, err := range errs {
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	mustT(t, err, "PlanKeys")
+	want := make([]int32, stressGoroutines)
+	for i := range want {
+		want[i] = int32(i + 1)
+	}
+	if !int32sEqual(plan.Rotations, want) {
+		t.Errorf("plan rotations = %v, expected %v", plan.Rotations, want)
+	}
+	if len(plan.SumBatchSizes) != 3 {
+		t.Errorf("plan sum batch sizes = %v, expected [2 4 8]", plan.SumBatchSizes)
+	}
+	if _, err := cc.EvalRotate(ct, 1); err == nil {
+		t.Error("EvalRotate without a key succeeded after PlanKeys returned")
+	}
+}
+
+func TestPlanKeysDecomposed(t *testing.T) {
+	cc, keys := setupCKKSPlanContext(t)
+	defer cc.Close()
+	defer keys.Close()
+
+	vals := []float64{1, 2, 3, 4, 5, 6, 7, 8}
+	pt, err := cc.MakeCKKSPackedPlaintext(vals)
+	mustT(t, err, "MakeCKKSPackedPlaintext")
+	defer pt.Close()
+	ct, err := cc.Encrypt(keys, pt)
+	mustT(t, err, "Encrypt")
+	defer ct.Close()
+
+	plan, err := cc.PlanKeys(func() error {
+		for _, k := range []int32{3, 5, 7} {
+			rot, err := cc.EvalRotate(ct, k)
+			if err != nil {
+				return err
+			}
+			rot.Close()
+		}
+		return nil
+	})
+	mustT(t, err, "PlanKeys")
+
+	small := plan.Decompose()
+	if want := []int32{-1, 1, 4, 8}; !int32sEqual(small.Rotations, want) {
+		t.Fatalf("decomposed rotations = %v, expected %v", small.Rotations, want)
+	}
+	mustT(t, cc.KeyGenForPlan(keys, small), "KeyGenForPlan")
+
+	rot, err := cc.EvalRotate(ct, 7)
+	mustT(t, err, "EvalRotate")
+	defer rot.Close()
+	ptDec, err := cc.Decrypt(keys, rot)
+	mustT(t, err, "Decrypt")
+	defer ptDec.Close()
+	got, err := ptDec.GetRealPackedValue()
+	mustT(t, err, "GetRealPackedValue")
+	if want := []float64{8, 1, 2, 3, 4, 5, 6, 7}; !slicesApproxEqual(got[:8], want, 1e-3) {
+		t.Errorf("composed rotation = %v, expected %v", got[:8], want)
+	}
+}
+
+func TestPlanKeysConjugation(t *testing.T) {
+	cc, keys := setupCKKSPlanContext(t)
+	defer cc.Close()
+	defer keys.Close()
+
+	vec := []complex128{complex(1, 2), complex(-3, 0.5)}
+	pt, err := cc.MakeCKKSComplexPackedPlaintextWithParams(vec, 1, 0, 0)
+	mustT(t, err, "MakeCKKSComplexPackedPlaintextWithParams")
+	defer pt.Close()
+	ct, err := cc.Encrypt(keys, pt)
+	mustT(t, err, "Encrypt")
+	defer ct.Close()
+
+	plan, err := cc.PlanKeys(func() error {
+		conj, err := cc.EvalConjugate(ct)
+		if err != nil {
+			return err
+		}
+		conj.Close()
+		return nil
+	})
+	mustT(t, err, "PlanKeys")
+	if !plan.Conjugation || len(plan.RotationIndices()) != 0 {
+		t.Fatalf("plan = %+v, expected conjugation only", plan)
+	}
+	mustT(t, cc.KeyGenForPlan(keys, plan), "KeyGenForPlan")
+
+	conj, err := cc.EvalConjugate(ct)
+	mustT(t, err, "EvalConjugate")
+	defer conj.Close()
+	ptDec, err := cc.Decrypt(keys, conj)
+	mustT(t, err, "Decrypt")
+	defer ptDec.Close()
+	got, err := ptDec.GetComplexPackedValue()
+	mustT(t, err, "GetComplexPackedValue")
+	for i, v := range vec {
+		if cmplx.Abs(got[i]-cmplx.Conj(v)) > 1e-3 {
+			t.Errorf("slot %d = %v, expected ~%v", i, got[i], cmplx.Conj(v))
+		}
+	}
+}
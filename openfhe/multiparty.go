@@ -0,0 +1,295 @@
+package openfhe
+
+/*
+#cgo CPPFLAGS: -I${SRCDIR}/../openfhe-install/include -I${SRCDIR}/../openfhe-install/include/openfhe -I${SRCDIR}/../openfhe-install/include/openfhe/core -I${SRCDIR}/../openfhe-install/include/openfhe/pke -I${SRCDIR}/../openfhe-install/include/openfhe/binfhe -I${SRCDIR}/../openfhe-install/include/openfhe/cereal
+#cgo CXXFLAGS: -std=c++17
+#include <stdint.h>
+#include "multiparty_c.h"
+*/
+import "C"
+
+import (
+	"errors"
+)
+
+// IntMPBootShares is one party's contribution to interactive multiparty
+// bootstrapping: a masked decryption share and a re-encryption share.
+type IntMPBootShares struct {
+	MaskedDecryption *Ciphertext
+	ReEncryption     *Ciphertext
+}
+
+// Close frees both shares.
+func (s *IntMPBootShares) Close() {
+	if s.MaskedDecryption != nil {
+		s.MaskedDecryption.Close()
+	}
+	if s.ReEncryption != nil {
+		s.ReEncryption.Close()
+	}
+}
+
+// --- Threshold key generation ---
+
+// MultipartyKeyGen generates the key pair of the next party in a threshold
+// setup. prevKeys is the previous party's key pair (only its public key is
+// used); the returned public key is the joint key of all parties so far.
+//
+// The MULTIPARTY feature must be enabled on the CryptoContext.
+func (cc *CryptoContext) MultipartyKeyGen(prevKeys *KeyPair) (*KeyPair, error) {
+	if !cc.acquire() {
+		return nil, errors.New("CryptoContext is closed or invalid")
+	}
+	defer cc.release()
+	if !prevKeys.acquire() {
+		return nil, errors.New("KeyPair is closed or invalid")
+	}
+	defer prevKeys.release()
+	var kpH C.KeyPairPtr
+	status := C.CryptoContext_MultipartyKeyGen(cc.ptr, prevKeys.ptr, &kpH)
+	err := checkPKEErrorMsg(status)
+	if err != nil {
+		return nil, err
+	}
+	if kpH == nil {
+		return nil, errors.New("MultipartyKeyGen returned OK but null handle")
+	}
+	return &KeyPair{ptr: kpH}, nil
+}
+
+// --- Distributed decryption ---
+
+// MultipartyDecryptLead computes the lead party's partial decryption of ct.
+func (cc *CryptoContext) MultipartyDecryptLead(ct *Ciphertext, keys *KeyPair) (*Ciphertext, error) {
+	return cc.multipartyDecrypt(ct, keys, true)
+}
+
+// MultipartyDecryptMain computes a non-lead party's partial decryption of ct.
+func (cc *CryptoContext) MultipartyDecryptMain(ct *Ciphertext, keys *KeyPair) (*Ciphertext, error) {
+	return cc.multipartyDecrypt(ct, keys, false)
+}
+
+func (cc *CryptoContext) multipartyDecrypt(ct *Ciphertext, keys *KeyPair, lead bool) (*Ciphertext, error) {
+	if !cc.acquire() {
+		return nil, errors.New("CryptoContext is closed or invalid")
+	}
+	defer cc.release()
+	if !ct.acquire() {
+		return nil, errors.New("Input Ciphertext is closed or invalid")
+	}
+	defer ct.release()
+	if !keys.acquire() {
+		return nil, errors.New("KeyPair is closed or invalid")
+	}
+	defer keys.release()
+	var ctH C.CiphertextPtr
+	var status C.PKEErr
+	if lead {
+		status = C.CryptoContext_MultipartyDecryptLead(cc.ptr, ct.ptr, keys.ptr, &ctH)
+	} else {
+		status = C.CryptoContext_MultipartyDecryptMain(cc.ptr, ct.ptr, keys.ptr, &ctH)
+	}
+	err := checkPKEErrorMsg(status)
+	if err != nil {
+		return nil, err
+	}
+	if ctH == nil {
+		return nil, errors.New("MultipartyDecrypt returned OK but null handle")
+	}
+	return &Ciphertext{ptr: ctH}, nil
+}
+
+// MultipartyDecryptFusion combines the partial decryptions of all parties.
+func (cc *CryptoContext) MultipartyDecryptFusion(partials []*Ciphertext) (*Plaintext, error) {
+	if !cc.acquire() {
+		return nil, errors.New("CryptoContext is closed or invalid")
+	}
+	defer cc.release()
+	if len(partials) == 0 {
+		return nil, errors.New("MultipartyDecryptFusion: no partial decryptions")
+	}
+	cParts := make([]C.CiphertextPtr, len(partials))
+	for i, p := range partials {
+		if !p.acquire() {
+			return nil, errors.New("MultipartyDecryptFusion: partial decryption is closed or invalid")
+		}
+		defer p.release()
+		cParts[i] = p.ptr
+	}
+
+	var ptH C.PlaintextPtr
+	status := C.CryptoContext_MultipartyDecryptFusion(cc.ptr, &cParts[0], C.int(len(cParts)), &ptH)
+	err := checkPKEErrorMsg(status)
+	if err != nil {
+		return nil, err
+	}
+	if ptH == nil {
+		return nil, errors.New("MultipartyDecryptFusion returned OK but null handle")
+	}
+	return &Plaintext{ptr: ptH}, nil
+}
+
+// --- Interactive multiparty (threshold) CKKS bootstrapping ---
+//
+// The protocol refreshes a CKKS ciphertext without bootstrapping keys:
+//
+//	ct, _ = cc.IntMPBootAdjustScale(ct)
+//	a, _ := cc.IntMPBootRandomElementGen(jointKeys)   // lead party
+//	shares_i, _ := cc.IntMPBootDecrypt(keys_i, ct, a)  // every party
+//	agg, _ := cc.IntMPBootAdd(shares)                  // lead party
+//	fresh, _ := cc.IntMPBootEncrypt(jointKeys, agg, a, ct)
+//
+// The context needs the MULTIPARTY feature and NOISE_FLOODING_MULTIPARTY mode.
+
+// IntMPBootAdjustScale prepares ct for interactive bootstrapping by bringing
+// it to the expected level and scale.
+func (cc *CryptoContext) IntMPBootAdjustScale(ct *Ciphertext) (*Ciphertext, error) {
+	if !cc.acquire() {
+		return nil, errors.New("CryptoContext is closed or invalid")
+	}
+	defer cc.release()
+	if !ct.acquire() {
+		return nil, errors.New("Input Ciphertext is closed or invalid")
+	}
+	defer ct.release()
+	var ctH C.CiphertextPtr
+	status := C.CryptoContext_IntMPBootAdjustScale(cc.ptr, ct.ptr, &ctH)
+	err := checkPKEErrorMsg(status)
+	if err != nil {
+		return nil, err
+	}
+	if ctH == nil {
+		return nil, errors.New("IntMPBootAdjustScale returned OK but null handle")
+	}
+	return &Ciphertext{ptr: ctH}, nil
+}
+
+// IntMPBootRandomElementGen generates the common random polynomial shared by
+// all parties. keys must hold the joint public key.
+func (cc *CryptoContext) IntMPBootRandomElementGen(keys *KeyPair) (*Ciphertext, error) {
+	if !cc.acquire() {
+		return nil, errors.New("CryptoContext is closed or invalid")
+	}
+	defer cc.release()
+	if !keys.acquire() {
+		return nil, errors.New("KeyPair is closed or invalid")
+	}
+	defer keys.release()
+	var ctH C.CiphertextPtr
+	status := C.CryptoContext_IntMPBootRandomElementGen(cc.ptr, keys.ptr, &ctH)
+	err := checkPKEErrorMsg(status)
+	if err != nil {
+		return nil, err
+	}
+	if ctH == nil {
+		return nil, errors.New("IntMPBootRandomElementGen returned OK but null handle")
+	}
+	return &Ciphertext{ptr: ctH}, nil
+}
+
+// IntMPBootDecrypt computes this party's shares of ct, which must already
+// have been passed through IntMPBootAdjustScale, using the common random
+// element a.
+func (cc *CryptoContext) IntMPBootDecrypt(keys *KeyPair, ct, a *Ciphertext) (*IntMPBootShares, error) {
+	if !cc.acquire() {
+		return nil, errors.New("CryptoContext is closed or invalid")
+	}
+	defer cc.release()
+	if !keys.acquire() {
+		return nil, errors.New("KeyPair is closed or invalid")
+	}
+	defer keys.release()
+	if !ct.acquire() {
+		return nil, errors.New("Input Ciphertext is closed or invalid")
+	}
+	defer ct.release()
+	if !a.acquire() {
+		return nil, errors.New("Input Ciphertext is closed or invalid")
+	}
+	defer a.release()
+	var maskedH, reEncH C.CiphertextPtr
+	status := C.CryptoContext_IntMPBootDecrypt(cc.ptr, keys.ptr, ct.ptr, a.ptr, &maskedH, &reEncH)
+	err := checkPKEErrorMsg(status)
+	if err != nil {
+		return nil, err
+	}
+	if maskedH == nil || reEncH == nil {
+		return nil, errors.New("IntMPBootDecrypt returned OK but null handle")
+	}
+	return &IntMPBootShares{
+		MaskedDecryption: &Ciphertext{ptr: maskedH},
+		ReEncryption:     &Ciphertext{ptr: reEncH},
+	}, nil
+}
+
+// IntMPBootAdd aggregates the shares of all parties.
+func (cc *CryptoContext) IntMPBootAdd(shares []*IntMPBootShares) (*IntMPBootShares, error) {
+	if !cc.acquire() {
+		return nil, errors.New("CryptoContext is closed or invalid")
+	}
+	defer cc.release()
+	if len(shares) == 0 {
+		return nil, errors.New("IntMPBootAdd: no shares")
+	}
+	masked := make([]C.CiphertextPtr, len(shares))
+	reEnc := make([]C.CiphertextPtr, len(shares))
+	for i, s := range shares {
+		if !s.acquire() {
+			return nil, errors.New("IntMPBootAdd: shares are closed or invalid")
+		}
+		defer s.release()
+		masked[i] = s.MaskedDecryption.ptr
+		reEnc[i] = s.ReEncryption.ptr
+	}
+
+	var maskedH, reEncH C.CiphertextPtr
+	status := C.CryptoContext_IntMPBootAdd(cc.ptr, &masked[0], &reEnc[0], C.int(len(shares)), &maskedH, &reEncH)
+	err := checkPKEErrorMsg(status)
+	if err != nil {
+		return nil, err
+	}
+	if maskedH == nil || reEncH == nil {
+		return nil, errors.New("IntMPBootAdd returned OK but null handle")
+	}
+	return &IntMPBootShares{
+		MaskedDecryption: &Ciphertext{ptr: maskedH},
+		ReEncryption:     &Ciphertext{ptr: reEncH},
+	}, nil
+}
+
+// IntMPBootEncrypt finishes the protocol: it re-encrypts the aggregated
+// shares under the joint public key in keys, returning a refreshed
+// ciphertext at the maximum level.
+func (cc *CryptoContext) IntMPBootEncrypt(keys *KeyPair, shares *IntMPBootShares, a, ct *Ciphertext) (*Ciphertext, error) {
+	if !cc.acquire() {
+		return nil, errors.New("CryptoContext is closed or invalid")
+	}
+	defer cc.release()
+	if !keys.acquire() {
+		return nil, errors.New("KeyPair is closed or invalid")
+	}
+	defer keys.release()
+	if !shares.acquire() {
+		return nil, errors.New("IntMPBootEncrypt: shares are closed or invalid")
+	}
+	defer shares.release()
+	if !ct.acquire() {
+		return nil, errors.New("Input Ciphertext is closed or invalid")
+	}
+	defer ct.release()
+	if !a.acquire() {
+		return nil, errors.New("Input Ciphertext is closed or invalid")
+	}
+	defer a.release()
+	var ctH C.CiphertextPtr
+	status := C.CryptoContext_IntMPBootEncrypt(cc.ptr, keys.ptr, shares.MaskedDecryption.ptr,
+		shares.ReEncryption.ptr, a.ptr, ct.ptr, &ctH)
+	err := checkPKEErrorMsg(status)
+	if err != nil {
+		return nil, err
+	}
+	if ctH == nil {
+		return nil, errors.New("IntMPBootEncrypt returned OK but null handle")
+	}
+	return &Ciphertext{ptr: ctH}, nil
+}
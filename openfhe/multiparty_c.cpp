@@ -0,0 +1,285 @@
+#include "multiparty_c.h"
+#include "pke_helpers_c.h"
+
+using namespace lbcrypto;
+
+static std::vector<Ciphertext<DCRTPoly>> ToCiphertextVector(CiphertextPtr *cts,
+                                                            int n) {
+  std::vector<Ciphertext<DCRTPoly>> v;
+  v.reserve(n > 0 ? n : 0);
+  for (int i = 0; i < n; ++i) {
+    if (!cts[i])
+      throw std::invalid_argument("null ciphertext in vector");
+    v.push_back(GetCTSharedPtr(cts[i]));
+  }
+  return v;
+}
+
+extern "C" {
+
+// --- Threshold key generation ---
+
+PKEErr CryptoContext_MultipartyKeyGen(CryptoContextPtr cc_ptr_to_sptr,
+                                      KeyPairPtr prev_raw_ptr,
+                                      KeyPairPtr *out) {
+  try {
+    if (!cc_ptr_to_sptr) {
+      return MakePKEError("CryptoContext_MultipartyKeyGen: null context");
+    }
+    auto prev = reinterpret_cast<KeyPairRawPtr>(prev_raw_ptr);
+    if (!prev || !prev->publicKey) {
+      return MakePKEError(
+          "CryptoContext_MultipartyKeyGen: previous keypair has no public key");
+    }
+    if (!out) {
+      return MakePKEError("CryptoContext_MultipartyKeyGen: null output pointer");
+    }
+
+    auto &cc = GetCCSharedPtr(cc_ptr_to_sptr);
+    *out = new KeyPair<DCRTPoly>(cc->MultipartyKeyGen(prev->publicKey));
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
+// --- Distributed decryption ---
+
+static PKEErr MultipartyDecryptPartial(const char *fn,
+                                       CryptoContextPtr cc_ptr_to_sptr,
+                                       CiphertextPtr ct_ptr_to_sptr,
+                                       KeyPairPtr keys_raw_ptr, bool lead,
+                                       CiphertextPtr *out) {
+  if (!cc_ptr_to_sptr) {
+    return MakePKEError(std::string(fn) + ": null context");
+  }
+  if (!ct_ptr_to_sptr) {
+    return MakePKEError(std::string(fn) + ": null ciphertext");
+  }
+  auto kp = reinterpret_cast<KeyPairRawPtr>(keys_raw_ptr);
+  if (!kp || !kp->secretKey) {
+    return MakePKEError(std::string(fn) + ": missing secret key");
+  }
+  if (!out) {
+    return MakePKEError(std::string(fn) + ": null output pointer");
+  }
+
+  auto &cc = GetCCSharedPtr(cc_ptr_to_sptr);
+  auto &ct = GetCTSharedPtr(ct_ptr_to_sptr);
+  auto partials = lead ? cc->MultipartyDecryptLead({ct}, kp->secretKey)
+                       : cc->MultipartyDecryptMain({ct}, kp->secretKey);
+  *out = reinterpret_cast<CiphertextPtr>(new CiphertextSharedPtr(partials[0]));
+  return MakePKEOk();
+}
+
+PKEErr CryptoContext_MultipartyDecryptLead(CryptoContextPtr cc, CiphertextPtr ct,
+                                           KeyPairPtr keys,
+                                           CiphertextPtr *out) {
+  try {
+    return MultipartyDecryptPartial("CryptoContext_MultipartyDecryptLead", cc,
+                                    ct, keys, true, out);
+  }
+  PKE_CATCH_RETURN()
+}
+
+PKEErr CryptoContext_MultipartyDecryptMain(CryptoContextPtr cc, CiphertextPtr ct,
+                                           KeyPairPtr keys,
+                                           CiphertextPtr *out) {
+  try {
+    return MultipartyDecryptPartial("CryptoContext_MultipartyDecryptMain", cc,
+                                    ct, keys, false, out);
+  }
+  PKE_CATCH_RETURN()
+}
+
+PKEErr CryptoContext_MultipartyDecryptFusion(CryptoContextPtr cc_ptr_to_sptr,
+                                             CiphertextPtr *partials, int n,
+                                             PlaintextPtr *out) {
+  try {
+    if (!cc_ptr_to_sptr) {
+      return MakePKEError("CryptoContext_MultipartyDecryptFusion: null context");
+    }
+    if (!partials || n <= 0) {
+      return MakePKEError(
+          "CryptoContext_MultipartyDecryptFusion: no partial decryptions");
+    }
+    if (!out) {
+      return MakePKEError(
+          "CryptoContext_MultipartyDecryptFusion: null output pointer");
+    }
+
+    auto &cc = GetCCSharedPtr(cc_ptr_to_sptr);
+    Plaintext pt;
+    cc->MultipartyDecryptFusion(ToCiphertextVector(partials, n), &pt);
+    *out = reinterpret_cast<PlaintextPtr>(new PlaintextSharedPtr(pt));
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
+// --- Interactive multiparty (threshold) CKKS bootstrapping ---
+
+PKEErr CryptoContext_IntMPBootAdjustScale(CryptoContextPtr cc_ptr_to_sptr,
+                                          CiphertextPtr ct_ptr_to_sptr,
+                                          CiphertextPtr *out) {
+  try {
+    if (!cc_ptr_to_sptr) {
+      return MakePKEError("CryptoContext_IntMPBootAdjustScale: null context");
+    }
+    if (!ct_ptr_to_sptr) {
+      return MakePKEError("CryptoContext_IntMPBootAdjustScale: null ciphertext");
+    }
+    if (!out) {
+      return MakePKEError(
+          "CryptoContext_IntMPBootAdjustScale: null output pointer");
+    }
+
+    auto &cc = GetCCSharedPtr(cc_ptr_to_sptr);
+    auto &ct = GetCTSharedPtr(ct_ptr_to_sptr);
+    auto res = cc->IntMPBootAdjustScale(ct);
+    *out = reinterpret_cast<CiphertextPtr>(new CiphertextSharedPtr(res));
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
+PKEErr CryptoContext_IntMPBootRandomElementGen(CryptoContextPtr cc_ptr_to_sptr,
+                                               KeyPairPtr keys_raw_ptr,
+                                               CiphertextPtr *out) {
+  try {
+    if (!cc_ptr_to_sptr) {
+      return MakePKEError(
+          "CryptoContext_IntMPBootRandomElementGen: null context");
+    }
+    auto kp = reinterpret_cast<KeyPairRawPtr>(keys_raw_ptr);
+    if (!kp || !kp->publicKey) {
+      return MakePKEError(
+          "CryptoContext_IntMPBootRandomElementGen: missing public key");
+    }
+    if (!out) {
+      return MakePKEError(
+          "CryptoContext_IntMPBootRandomElementGen: null output pointer");
+    }
+
+    auto &cc = GetCCSharedPtr(cc_ptr_to_sptr);
+    auto a = cc->IntMPBootRandomElementGen(kp->publicKey);
+    *out = reinterpret_cast<CiphertextPtr>(new CiphertextSharedPtr(a));
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
+PKEErr CryptoContext_IntMPBootDecrypt(CryptoContextPtr cc_ptr_to_sptr,
+                                      KeyPairPtr keys_raw_ptr,
+                                      CiphertextPtr ct_ptr_to_sptr,
+                                      CiphertextPtr a_ptr_to_sptr,
+                                      CiphertextPtr *outMaskedDecryption,
+                                      CiphertextPtr *outReEncryption) {
+  try {
+    if (!cc_ptr_to_sptr) {
+      return MakePKEError("CryptoContext_IntMPBootDecrypt: null context");
+    }
+    auto kp = reinterpret_cast<KeyPairRawPtr>(keys_raw_ptr);
+    if (!kp || !kp->secretKey) {
+      return MakePKEError("CryptoContext_IntMPBootDecrypt: missing secret key");
+    }
+    if (!ct_ptr_to_sptr || !a_ptr_to_sptr) {
+      return MakePKEError("CryptoContext_IntMPBootDecrypt: null ciphertext");
+    }
+    if (!outMaskedDecryption || !outReEncryption) {
+      return MakePKEError("CryptoContext_IntMPBootDecrypt: null output pointer");
+    }
+
+    auto &cc = GetCCSharedPtr(cc_ptr_to_sptr);
+    auto &ct = GetCTSharedPtr(ct_ptr_to_sptr);
+    auto &a = GetCTSharedPtr(a_ptr_to_sptr);
+
+    // Each party only needs c1: drop c0 from a copy of the ciphertext.
+    auto c1 = ct->Clone();
+    c1->GetElements().erase(c1->GetElements().begin());
+
+    auto shares = cc->IntMPBootDecrypt(kp->secretKey, c1, a);
+    if (shares.size() != 2) {
+      return MakePKEError(
+          "CryptoContext_IntMPBootDecrypt: unexpected number of shares");
+    }
+    *outMaskedDecryption =
+        reinterpret_cast<CiphertextPtr>(new CiphertextSharedPtr(shares[0]));
+    *outReEncryption =
+        reinterpret_cast<CiphertextPtr>(new CiphertextSharedPtr(shares[1]));
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
+PKEErr CryptoContext_IntMPBootAdd(CryptoContextPtr cc_ptr_to_sptr,
+                                  CiphertextPtr *maskedDecryptions,
+                                  CiphertextPtr *reEncryptions, int n,
+                                  CiphertextPtr *outMaskedDecryption,
+                                  CiphertextPtr *outReEncryption) {
+  try {
+    if (!cc_ptr_to_sptr) {
+      return MakePKEError("CryptoContext_IntMPBootAdd: null context");
+    }
+    if (!maskedDecryptions || !reEncryptions || n <= 0) {
+      return MakePKEError("CryptoContext_IntMPBootAdd: no shares");
+    }
+    if (!outMaskedDecryption || !outReEncryption) {
+      return MakePKEError("CryptoContext_IntMPBootAdd: null output pointer");
+    }
+
+    auto &cc = GetCCSharedPtr(cc_ptr_to_sptr);
+    auto masked = ToCiphertextVector(maskedDecryptions, n);
+    auto reenc = ToCiphertextVector(reEncryptions, n);
+    std::vector<std::vector<Ciphertext<DCRTPoly>>> sharesPairVec;
+    sharesPairVec.reserve(n);
+    for (int i = 0; i < n; ++i)
+      sharesPairVec.push_back({masked[i], reenc[i]});
+
+    auto agg = cc->IntMPBootAdd(sharesPairVec);
+    *outMaskedDecryption =
+        reinterpret_cast<CiphertextPtr>(new CiphertextSharedPtr(agg[0]));
+    *outReEncryption =
+        reinterpret_cast<CiphertextPtr>(new CiphertextSharedPtr(agg[1]));
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
+PKEErr CryptoContext_IntMPBootEncrypt(CryptoContextPtr cc_ptr_to_sptr,
+                                      KeyPairPtr keys_raw_ptr,
+                                      CiphertextPtr maskedDecryption,
+                                      CiphertextPtr reEncryption,
+                                      CiphertextPtr a_ptr_to_sptr,
+                                      CiphertextPtr ct_ptr_to_sptr,
+                                      CiphertextPtr *out) {
+  try {
+    if (!cc_ptr_to_sptr) {
+      return MakePKEError("CryptoContext_IntMPBootEncrypt: null context");
+    }
+    auto kp = reinterpret_cast<KeyPairRawPtr>(keys_raw_ptr);
+    if (!kp || !kp->publicKey) {
+      return MakePKEError("CryptoContext_IntMPBootEncrypt: missing public key");
+    }
+    if (!maskedDecryption || !reEncryption) {
+      return MakePKEError("CryptoContext_IntMPBootEncrypt: null shares");
+    }
+    if (!a_ptr_to_sptr || !ct_ptr_to_sptr) {
+      return MakePKEError("CryptoContext_IntMPBootEncrypt: null ciphertext");
+    }
+    if (!out) {
+      return MakePKEError("CryptoContext_IntMPBootEncrypt: null output pointer");
+    }
+
+    auto &cc = GetCCSharedPtr(cc_ptr_to_sptr);
+    std::vector<Ciphertext<DCRTPoly>> sharesPair = {
+        GetCTSharedPtr(maskedDecryption), GetCTSharedPtr(reEncryption)};
+    auto res = cc->IntMPBootEncrypt(kp->publicKey, sharesPair,
+                                    GetCTSharedPtr(a_ptr_to_sptr),
+                                    GetCTSharedPtr(ct_ptr_to_sptr));
+    *out = reinterpret_cast<CiphertextPtr>(new CiphertextSharedPtr(res));
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
+} // extern "C"
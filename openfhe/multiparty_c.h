@@ -0,0 +1,55 @@
+#ifndef MULTIPARTY_C_H
+#define MULTIPARTY_C_H
+
+#include "pke_common_c.h"
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+// --- Threshold key generation ---
+
+// MultipartyKeyGen generates a key pair for the next party. The new public
+// key is the joint public key of all parties so far.
+PKEErr CryptoContext_MultipartyKeyGen(CryptoContextPtr cc, KeyPairPtr prevKeys,
+                                      KeyPairPtr *out);
+
+// --- Distributed decryption ---
+PKEErr CryptoContext_MultipartyDecryptLead(CryptoContextPtr cc,
+                                           CiphertextPtr ct, KeyPairPtr keys,
+                                           CiphertextPtr *out);
+PKEErr CryptoContext_MultipartyDecryptMain(CryptoContextPtr cc,
+                                           CiphertextPtr ct, KeyPairPtr keys,
+                                           CiphertextPtr *out);
+PKEErr CryptoContext_MultipartyDecryptFusion(CryptoContextPtr cc,
+                                             CiphertextPtr *partials, int n,
+                                             PlaintextPtr *out);
+
+// --- Interactive multiparty (threshold) CKKS bootstrapping ---
+PKEErr CryptoContext_IntMPBootAdjustScale(CryptoContextPtr cc, CiphertextPtr ct,
+                                          CiphertextPtr *out);
+PKEErr CryptoContext_IntMPBootRandomElementGen(CryptoContextPtr cc,
+                                               KeyPairPtr keys,
+                                               CiphertextPtr *out);
+// Produces this party's masked decryption and re-encryption shares of ct.
+PKEErr CryptoContext_IntMPBootDecrypt(CryptoContextPtr cc, KeyPairPtr keys,
+                                      CiphertextPtr ct, CiphertextPtr a,
+                                      CiphertextPtr *outMaskedDecryption,
+                                      CiphertextPtr *outReEncryption);
+// Aggregates the share pairs of n parties.
+PKEErr CryptoContext_IntMPBootAdd(CryptoContextPtr cc,
+                                  CiphertextPtr *maskedDecryptions,
+                                  CiphertextPtr *reEncryptions, int n,
+                                  CiphertextPtr *outMaskedDecryption,
+                                  CiphertextPtr *outReEncryption);
+PKEErr CryptoContext_IntMPBootEncrypt(CryptoContextPtr cc, KeyPairPtr keys,
+                                      CiphertextPtr maskedDecryption,
+                                      CiphertextPtr reEncryption,
+                                      CiphertextPtr a, CiphertextPtr ct,
+                                      CiphertextPtr *out);
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif // MULTIPARTY_C_H
@@ -0,0 +1,198 @@
+package openfhe
+
+import (
+	"testing"
+)
+
+func setupThresholdCKKS(t *testing.T) (*CryptoContext, *KeyPair, *KeyPair) {
+	t.Helper()
+
+	params, err := NewParamsCKKSRNS()
+	mustT(t, err, "NewParamsCKKSRNS")
+	defer params.Close()
+
+	mustT(t, params.SetMultiplicativeDepth(7), "SetMultiplicativeDepth")
+	mustT(t, params.SetScalingModSize(50), "SetScalingModSize")
+	mustT(t, params.SetBatchSize(16), "SetBatchSize")
+	mustT(t, params.SetScalingTechnique(FLEXIBLEAUTO), "SetScalingTechnique")
+	mustT(t, params.SetMultipartyMode(NOISE_FLOODING_MULTIPARTY), "SetMultipartyMode")
+
+	cc, err := NewCryptoContextCKKS(params)
+	mustT(t, err, "NewCryptoContextCKKS")
+
+	mustT(t, cc.Enable(PKE), "Enable PKE")
+	mustT(t, cc.Enable(KEYSWITCH), "Enable KEYSWITCH")
+	mustT(t, cc.Enable(LEVELEDSHE), "Enable LEVELEDSHE")
+	mustT(t, cc.Enable(ADVANCEDSHE), "Enable ADVANCEDSHE")
+	mustT(t, cc.Enable(MULTIPARTY), "Enable MULTIPARTY")
+
+	kp1, err := cc.KeyGen()
+	mustT(t, err, "KeyGen party 1")
+	kp2, err := cc.MultipartyKeyGen(kp1)
+	mustT(t, err, "MultipartyKeyGen party 2")
+
+	return cc, kp1, kp2 // kp2 holds the joint public key
+}
+
+func thresholdDecrypt(t *testing.T, cc *CryptoContext, ct *Ciphertext, lead, main *KeyPair, n int) []float64 {
+	t.Helper()
+
+	p1, err := cc.MultipartyDecryptLead(ct, lead)
+	mustT(t, err, "MultipartyDecryptLead")
+	defer p1.Close()
+	p2, err := cc.MultipartyDecryptMain(ct, main)
+	mustT(t, err, "MultipartyDecryptMain")
+	defer p2.Close()
+
+	pt, err := cc.MultipartyDecryptFusion([]*Ciphertext{p1, p2})
+	mustT(t, err, "MultipartyDecryptFusion")
+	defer pt.Close()
+
+	mustT(t, pt.SetLength(n), "SetLength")
+	got, err := pt.GetRealPackedValue()
+	mustT(t, err, "GetRealPackedValue")
+	return got[:n]
+}
+
+func TestMultipartyCKKS_DecryptFusion(t *testing.T) {
+	cc, kp1, kp2 := setupThresholdCKKS(t)
+	defer cc.Close()
+	defer kp1.Close()
+	defer kp2.Close()
+
+	in := []float64{1.5, -2.25, 3.0, 0.125}
+	pt, err := cc.MakeCKKSPackedPlaintext(in)
+	mustT(t, err, "MakeCKKSPackedPlaintext")
+	defer pt.Close()
+
+	ct, err := cc.Encrypt(kp2, pt)
+	mustT(t, err, "Encrypt")
+	defer ct.Close()
+
+	got := thresholdDecrypt(t, cc, ct, kp1, kp2, len(in))
+	if !slicesApproxEqual(got, in, 1e-4) {
+		t.Fatalf("threshold decryption mismatch.\nwant ~%v\ngot  %v", in, got)
+	}
+}
+
+func TestMultipartyCKKS_DecryptFusionNeedsAllParties(t *testing.T) {
+	cc, kp1, kp2 := setupThresholdCKKS(t)
+	defer cc.Close()
+	defer kp1.Close()
+	defer kp2.Close()
+
+	in := []float64{1, 2, 3, 4}
+	pt, err := cc.MakeCKKSPackedPlaintext(in)
+	mustT(t, err, "MakeCKKSPackedPlaintext")
+	defer pt.Close()
+	ct, err := cc.Encrypt(kp2, pt)
+	mustT(t, err, "Encrypt")
+	defer ct.Close()
+
+	p1, err := cc.MultipartyDecryptLead(ct, kp1)
+	mustT(t, err, "MultipartyDecryptLead")
+	defer p1.Close()
+
+	ptOut, err := cc.MultipartyDecryptFusion([]*Ciphertext{p1})
+	if err != nil {
+		return // rejecting a single share is fine too
+	}
+	defer ptOut.Close()
+	mustT(t, ptOut.SetLength(len(in)), "SetLength")
+	got, err := ptOut.GetRealPackedValue()
+	if err == nil && slicesApproxEqual(got[:len(in)], in, 1e-2) {
+		t.Fatal("decryption with one of two shares recovered the plaintext")
+	}
+}
+
+func TestMultipartyCKKS_IntMPBoot(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping interactive bootstrapping test in -short mode")
+	}
+
+	cc, kp1, kp2 := setupThresholdCKKS(t)
+	defer cc.Close()
+	defer kp1.Close()
+	defer kp2.Close()
+
+	in := []float64{-0.9, -0.8, 0.2, 0.4, 0.6, 0.8, 0.95, 1.0}
+	pt, err := cc.MakeCKKSPackedPlaintext(in)
+	mustT(t, err, "MakeCKKSPackedPlaintext")
+	defer pt.Close()
+
+	ct, err := cc.Encrypt(kp2, pt)
+	mustT(t, err, "Encrypt")
+	defer ct.Close()
+
+	// Burn some levels so the refresh is observable.
+	sum, err := cc.EvalAdd(ct, ct)
+	mustT(t, err, "EvalAdd")
+	defer sum.Close()
+	half, err := cc.EvalMultPlain(sum, pt0p5(t, cc, len(in)))
+	mustT(t, err, "EvalMultPlain")
+	defer half.Close()
+
+	adj, err := cc.IntMPBootAdjustScale(half)
+	mustT(t, err, "IntMPBootAdjustScale")
+	defer adj.Close()
+
+	a, err := cc.IntMPBootRandomElementGen(kp2)
+	mustT(t, err, "IntMPBootRandomElementGen")
+	defer a.Close()
+
+	s1, err := cc.IntMPBootDecrypt(kp1, adj, a)
+	mustT(t, err, "IntMPBootDecrypt party 1")
+	defer s1.Close()
+	s2, err := cc.IntMPBootDecrypt(kp2, adj, a)
+	mustT(t, err, "IntMPBootDecrypt party 2")
+	defer s2.Close()
+
+	agg, err := cc.IntMPBootAdd([]*IntMPBootShares{s1, s2})
+	mustT(t, err, "IntMPBootAdd")
+	defer agg.Close()
+
+	fresh, err := cc.IntMPBootEncrypt(kp2, agg, a, adj)
+	mustT(t, err, "IntMPBootEncrypt")
+	defer fresh.Close()
+
+	got := thresholdDecrypt(t, cc, fresh, kp1, kp2, len(in))
+	if !slicesApproxEqual(got, in, 1e-3) {
+		t.Fatalf("interactive bootstrapping mismatch.\nwant ~%v\ngot  %v", in, got)
+	}
+
+	s2.ReEncryption.Close()
+	if _, err := cc.IntMPBootAdd([]*IntMPBootShares{s1, s2}); !isClosedErr(err) {
+		t.Errorf("IntMPBootAdd with a closed share = %v, expected a closed handle error", err)
+	}
+	agg.Close()
+	if _, err := cc.IntMPBootEncrypt(kp2, agg, a, adj); !isClosedErr(err) {
+		t.Errorf("IntMPBootEncrypt with closed shares = %v, expected a closed handle error", err)
+	}
+}
+
+func TestMultipartyCKKS_IntMPBootAddRejectsEmpty(t *testing.T) {
+	cc, kp1, kp2 := setupThresholdCKKS(t)
+	defer cc.Close()
+	defer kp1.Close()
+	defer kp2.Close()
+
+	if _, err := cc.IntMPBootAdd(nil); err == nil {
+		t.Fatal("expected error for empty share list")
+	}
+	if _, err := cc.IntMPBootAdd([]*IntMPBootShares{{}}); err == nil {
+		t.Fatal("expected error for invalid shares")
+	}
+}
+
+// pt0p5 encodes a vector of 0.5s.
+func pt0p5(t *testing.T, cc *CryptoContext, n int) *Plaintext {
+	t.Helper()
+	v := make([]float64, n)
+	for i := range v {
+		v[i] = 0.5
+	}
+	pt, err := cc.MakeCKKSPackedPlaintext(v)
+	mustT(t, err, "MakeCKKSPackedPlaintext")
+	t.Cleanup(pt.Close)
+	return pt
+}
@@ -0,0 +1,194 @@
+package openfhe
+
+/*
+#cgo CPPFLAGS: -I${SRCDIR}/../openfhe-install/include -I${SRCDIR}/../openfhe-install/include/openfhe -I${SRCDIR}/../openfhe-install/include/openfhe/core -I${SRCDIR}/../openfhe-install/include/openfhe/pke -I${SRCDIR}/../openfhe-install/include/openfhe/binfhe -I${SRCDIR}/../openfhe-install/include/openfhe/cereal
+#cgo CXXFLAGS: -std=c++17
+#include <stdint.h>
+#include "pke_common_c.h"
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// NoiseBudget returns the noise budget of a BFV or BGV ciphertext in bits,
+// computed exactly with the secret key: how far the noise can still grow
+// before decryption fails. 0 means the ciphertext no longer decrypts
+// correctly. It is meant for debugging; use EstimatedNoiseBudget when the
+// secret key is not at hand.
+func (ct *Ciphertext) NoiseBudget(cc *CryptoContext, keys *KeyPair) (float64, error) {
+	if ct == nil || ct.sim != nil {
+		return 0, errors.New("NoiseBudget is not available for simulated ciphertexts")
+	}
+	if !cc.acquire() {
+		return 0, errors.New("CryptoContext is closed or invalid")
+	}
+	defer cc.release()
+	if !keys.acquire() {
+		return 0, errors.New("KeyPair is closed or invalid")
+	}
+	defer keys.release()
+	if !ct.acquire() {
+		return 0, errors.New("Input Ciphertext is closed or invalid")
+	}
+	defer ct.release()
+	var budget C.double
+	status := C.Ciphertext_NoiseBudget(cc.ptr, keys.ptr, ct.ptr, &budget)
+	if err := checkPKEErrorMsg(status); err != nil {
+		return 0, err
+	}
+	return float64(budget), nil
+}
+
+// EstimatedNoiseBudget returns a key-free estimate of the noise budget of a
+// BFV or BGV ciphertext in bits. The bound is tracked from encryption
+// through additions, multiplications, rotations and modulus switching with
+// average-case growth heuristics, so it is an estimate rather than a
+// guarantee. ok is false for CKKS ciphertexts and for ciphertexts produced
+// by operations that are not tracked.
+func (ct *Ciphertext) EstimatedNoiseBudget() (budget float64, ok bool) {
+	if ct == nil || ct.noise == nil {
+		return 0, false
+	}
+	return max(ct.noise.modBits-ct.noise.bits-1, 0), true
+}
+
+// Precision decrypts a CKKS ciphertext and returns the bits of precision of
+// its first len(want) slots, -log2 of the largest absolute error against
+// want. It returns +Inf when the values match exactly.
+func (ct *Ciphertext) Precision(ev Evaluator, keys *KeyPair, want []float64) (float64, error) {
+	if len(want) == 0 {
+		return 0, errors.New("Precision: empty reference")
+	}
+	pt, err := ev.Decrypt(keys, ct)
+	if err != nil {
+		return 0, err
+	}
+	defer pt.Close()
+	got, err := pt.GetRealPackedValue()
+	if err != nil {
+		return 0, err
+	}
+	if len(got) < len(want) {
+		return 0, fmt.Errorf("Precision: ciphertext holds %d slots, reference has %d", len(got), len(want))
+	}
+	var worst float64
+	for i, w := range want {
+		worst = max(worst, math.Abs(got[i]-w))
+	}
+	return -math.Log2(worst), nil
+}
+
+// --- noise tracking ---
+
+// noiseBound is the tracked noise of a BFV/BGV ciphertext.
+type noiseBound struct {
+	// bits is log2 of a bound on the noise numerator: t*e for BFV, whose
+	// invariant noise is t*e/q, and m + t*e for BGV.
+	bits float64
+	// modBits is log2 of the ciphertext modulus q.
+	modBits float64
+}
+
+type noiseOp int
+
+const (
+	noiseFresh noiseOp = iota
+	noiseAdd
+	noiseAddPlain
+	noiseMultPlain
+	noiseMult
+	noiseKeySwitch
+	noiseModReduce
+)
+
+const (
+	noiseSigma = 3.19 // OpenFHE's default error standard deviation
+	noiseTail  = 6    // error coefficients stay within noiseTail*sigma
+)
+
+// noiseModel holds the growth terms of a context, in bits.
+type noiseModel struct {
+	bgv       bool
+	t         float64 // log2 of the plaintext modulus
+	expansion float64 // growth of a ring product, 2*sqrt(N) on average
+	fresh     float64 // t*(e0 + e1*s + u*e)
+	keySwitch float64 // noise added by relinearization or rotation
+	rounding  float64 // BGV modulus switching rounding term
+}
+
+func (cc *CryptoContext) noiseModel() (noiseModel, bool) {
+	var m noiseModel
+	switch C.CryptoContext_GetSchemeKind(cc.ptr) {
+	case C.PKE_SCHEME_BFV:
+	case C.PKE_SCHEME_BGV:
+		m.bgv = true
+	default:
+		return m, false
+	}
+	m.t = math.Log2(float64(C.CryptoContext_GetPlaintextModulus(cc.ptr)))
+	m.expansion = math.Log2(2 * math.Sqrt(float64(C.CryptoContext_GetRingDimension(cc.ptr))))
+	e := math.Log2(noiseTail * noiseSigma)
+	m.fresh = m.t + e + m.expansion + 1
+	m.keySwitch = m.t + e + m.expansion + 2
+	m.rounding = m.t + m.expansion
+	return m, true
+}
+
+// logAdd returns log2(2^a + 2^b).
+func logAdd(a, b float64) float64 {
+	hi, lo := max(a, b), min(a, b)
+	return hi + math.Log2(1+math.Exp2(lo-hi))
+}
+
+// trackNoise sets the noise bound of out, produced by op from ins. The
+// result is left untracked when an input is.
+func (cc *CryptoContext) trackNoise(out *Ciphertext, op noiseOp, ins ...*Ciphertext) {
+	m, ok := cc.noiseModel()
+	if !ok || out == nil {
+		return
+	}
+	modBits := float64(C.Ciphertext_GetModulusLog2(out.ptr))
+	if modBits < 0 {
+		return
+	}
+	// Bring each input to the output modulus; a BGV modulus switch divides
+	// the noise and adds a rounding term.
+	bits := make([]float64, len(ins))
+	for i, in := range ins {
+		if in.noise == nil {
+			return
+		}
+		bits[i] = in.noise.bits
+		if drop := in.noise.modBits - modBits; drop > 0.5 {
+			bits[i] = logAdd(bits[i]-drop, m.rounding)
+		}
+	}
+
+	var b float64
+	switch op {
+	case noiseFresh:
+		b = m.fresh
+	case noiseAdd:
+		b = logAdd(bits[0], bits[1])
+	case noiseAddPlain:
+		b = logAdd(bits[0], m.t)
+	case noiseMultPlain:
+		b = bits[0] + m.expansion + m.t - 1
+	case noiseMult:
+		if m.bgv {
+			b = bits[0] + bits[1] + m.expansion
+		} else {
+			b = m.t + m.expansion + logAdd(bits[0], bits[1])
+		}
+		b = logAdd(b, m.keySwitch)
+	case noiseKeySwitch:
+		b = logAdd(bits[0], m.keySwitch)
+	case noiseModReduce:
+		b = bits[0]
+	}
+	out.noise = &noiseBound{bits: b, modBits: modBits}
+}
@@ -0,0 +1,146 @@
+package openfhe
+
+import (
+	"math"
+	"testing"
+)
+
+func testNoiseBudget(t *testing.T, cc *CryptoContext, keys *KeyPair) {
+	t.Helper()
+	vals := []int64{1, 2, 3, 4, 5, 6, 7, 8}
+	ct := encryptInts(t, cc, keys, vals)
+	defer ct.Close()
+
+	fresh := requireNoiseBudget(t, cc, keys, ct, 20)
+	freshEst, ok := ct.EstimatedNoiseBudget()
+	if !ok {
+		t.Fatal("fresh ciphertext has no estimated noise budget")
+	}
+	// The estimate is a heuristic; it should be in the right ballpark.
+	if math.Abs(freshEst-fresh) > 30 {
+		t.Errorf("estimated budget %.1f, measured %.1f", freshEst, fresh)
+	}
+
+	sq, err := cc.EvalMult(ct, ct)
+	mustT(t, err, "EvalMult")
+	defer sq.Close()
+	afterMult := requireNoiseBudget(t, cc, keys, sq, 1)
+	if afterMult >= fresh {
+		t.Errorf("budget after EvalMult %.1f, expected below fresh %.1f", afterMult, fresh)
+	}
+	multEst, ok := sq.EstimatedNoiseBudget()
+	if !ok || multEst >= freshEst {
+		t.Errorf("estimated budget after EvalMult %.1f (tracked %v), expected below %.1f", multEst, ok, freshEst)
+	}
+	want := make([]int64, len(vals))
+	for i, v := range vals {
+		want[i] = v * v
+	}
+	if got := decryptInts(t, cc, keys, sq, len(want)); !slicesEqual(got, want) {
+		t.Errorf("EvalMult = %v, expected %v", got, want)
+	}
+
+	sum, err := cc.EvalAddPlain(sq, mustPacked(t, cc, vals))
+	mustT(t, err, "EvalAddPlain")
+	defer sum.Close()
+	if _, ok := sum.EstimatedNoiseBudget(); !ok {
+		t.Error("EvalAddPlain result is not tracked")
+	}
+}
+
+func mustPacked(t *testing.T, cc *CryptoContext, vals []int64) *Plaintext {
+	t.Helper()
+	pt, err := cc.MakePackedPlaintext(vals)
+	mustT(t, err, "MakePackedPlaintext")
+	t.Cleanup(pt.Close)
+	return pt
+}
+
+func TestNoiseBudgetBFV(t *testing.T) {
+	cc, keys := setupBFVContextAndKeys(t)
+	defer cc.Close()
+	defer keys.Close()
+	testNoiseBudget(t, cc, keys)
+
+	// Squaring well past the configured depth exhausts the budget; once the
+	// noise wraps around the budget is a fraction of a bit.
+	acc := encryptInts(t, cc, keys, []int64{3, 5, 7})
+	for i := 0; i < 10; i++ {
+		budget, err := acc.NoiseBudget(cc, keys)
+		mustT(t, err, "NoiseBudget")
+		if budget < 1 {
+			break
+		}
+		next, err := cc.EvalMult(acc, acc)
+		mustT(t, err, "EvalMult")
+		acc.Close()
+		acc = next
+	}
+	defer acc.Close()
+	if budget, _ := acc.NoiseBudget(cc, keys); budget >= 1 {
+		t.Errorf("budget %.1f bits after repeated squaring, expected it exhausted", budget)
+	}
+}
+
+func TestNoiseBudgetBGV(t *testing.T) {
+	cc, keys := setupBGVContextAndKeys(t)
+	defer cc.Close()
+	defer keys.Close()
+	testNoiseBudget(t, cc, keys)
+}
+
+func TestNoiseBudgetCKKS(t *testing.T) {
+	cc, keys := setupCKKSContextAndKeys(t)
+	defer cc.Close()
+	defer keys.Close()
+
+	ct := encryptReals(t, cc, keys, []float64{0.5, 1})
+	defer ct.Close()
+	if _, err := ct.NoiseBudget(cc, keys); err == nil {
+		t.Error("NoiseBudget succeeded for a CKKS ciphertext")
+	}
+	if _, ok := ct.EstimatedNoiseBudget(); ok {
+		t.Error("CKKS ciphertext has an estimated noise budget")
+	}
+}
+
+func TestPrecision(t *testing.T) {
+	cc, keys := setupCKKSContextAndKeys(t)
+	defer cc.Close()
+	defer keys.Close()
+
+	in := []float64{0.5, -1.25, 2, 0.1}
+	ct := encryptReals(t, cc, keys, in)
+	defer ct.Close()
+	sq, err := cc.EvalMult(ct, ct)
+	mustT(t, err, "EvalMult")
+	defer sq.Close()
+
+	want := make([]float64, len(in))
+	for i, v := range in {
+		want[i] = v * v
+	}
+	fresh := requirePrecision(t, cc, keys, ct, in, 20)
+	prod := requirePrecision(t, cc, keys, sq, want, 15)
+	if math.IsInf(fresh, 1) || math.IsInf(prod, 1) {
+		t.Errorf("precision %v, %v: encrypted values should not be exact", fresh, prod)
+	}
+	if bits, _ := sq.Precision(cc, keys, in); bits > 1 {
+		t.Errorf("precision against the wrong reference is %.1f bits", bits)
+	}
+
+	sim, simKeys := newTestSimulator(t, SimParams{
+		Scheme:              SimCKKS,
+		RingDimension:       32,
+		MultiplicativeDepth: 1,
+		ScalingTechnique:    FLEXIBLEAUTO,
+		NoiseStdDev:         1e-6,
+	})
+	defer sim.Close()
+	noisy := encryptReals(t, sim, simKeys, in)
+	defer noisy.Close()
+	bits := requirePrecision(t, sim, simKeys, noisy, in, 12)
+	if bits > 30 {
+		t.Errorf("simulated precision %.1f bits, expected noise near 2^-20", bits)
+	}
+}
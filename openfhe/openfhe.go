@@ -52,11 +52,27 @@ const (
 	HYBRID          = 2
 )
 
+// --- Multiparty Modes ---
+const (
+	INVALID_MULTIPARTY_MODE   = 0
+	FIXED_NOISE_MULTIPARTY    = 1
+	NOISE_FLOODING_MULTIPARTY = 2
+)
+
+// --- Proxy Re-Encryption Modes ---
+const (
+	NOT_SET_PRE_MODE   = 0
+	INDCPA             = 1
+	FIXED_NOISE_HRA    = 2
+	NOISE_FLOODING_HRA = 3
+)
+
 // --- Common CryptoContext Methods ---
 func (cc *CryptoContext) Enable(feature int) error {
-	if cc.ptr == nil {
+	if !cc.acquire() {
 		return errors.New("CryptoContext is closed or invalid")
 	}
+	defer cc.release()
 	status := C.CryptoContext_Enable(cc.ptr, C.int(feature))
 	err := checkPKEErrorMsg(status)
 	if err != nil {
@@ -66,9 +82,10 @@ func (cc *CryptoContext) Enable(feature int) error {
 }
 
 func (cc *CryptoContext) KeyGen() (*KeyPair, error) {
-	if cc.ptr == nil {
+	if !cc.acquire() {
 		return nil, errors.New("CryptoContext is closed or invalid")
 	}
+	defer cc.release()
 	var kpH C.KeyPairPtr
 	status := C.CryptoContext_KeyGen(cc.ptr, &kpH)
 	err := checkPKEErrorMsg(status)
@@ -86,12 +103,14 @@ func (cc *CryptoContext) KeyGen() (*KeyPair, error) {
 }
 
 func (cc *CryptoContext) EvalMultKeyGen(keys *KeyPair) error {
-	if cc.ptr == nil {
+	if !cc.acquireExclusive() {
 		return errors.New("CryptoContext is closed or invalid")
 	}
-	if keys == nil || keys.ptr == nil {
+	defer cc.releaseExclusive()
+	if !keys.acquire() {
 		return errors.New("KeyPair is closed or invalid")
 	}
+	defer keys.release()
 	status := C.CryptoContext_EvalMultKeyGen(cc.ptr, keys.ptr)
 	err := checkPKEErrorMsg(status)
 	if err != nil {
@@ -101,12 +120,14 @@ func (cc *CryptoContext) EvalMultKeyGen(keys *KeyPair) error {
 }
 
 func (cc *CryptoContext) EvalRotateKeyGen(keys *KeyPair, indices []int32) error {
-	if cc.ptr == nil {
+	if !cc.acquireExclusive() {
 		return errors.New("CryptoContext is closed or invalid")
 	}
-	if keys == nil || keys.ptr == nil {
+	defer cc.releaseExclusive()
+	if !keys.acquire() {
 		return errors.New("KeyPair is closed or invalid")
 	}
+	defer keys.release()
 	if len(indices) == 0 {
 		return nil // Nothing to do
 	}
@@ -121,15 +142,18 @@ func (cc *CryptoContext) EvalRotateKeyGen(keys *KeyPair, indices []int32) error
 }
 
 func (cc *CryptoContext) Encrypt(keys *KeyPair, pt *Plaintext) (*Ciphertext, error) {
-	if cc.ptr == nil {
+	if !cc.acquire() {
 		return nil, errors.New("CryptoContext is closed or invalid")
 	}
-	if keys == nil || keys.ptr == nil {
+	defer cc.release()
+	if !keys.acquire() {
 		return nil, errors.New("KeyPair is closed or invalid")
 	}
-	if pt == nil || pt.ptr == nil {
+	defer keys.release()
+	if !pt.acquire() {
 		return nil, errors.New("Plaintext is closed or invalid")
 	}
+	defer pt.release()
 	var ctH C.CiphertextPtr
 	status := C.CryptoContext_Encrypt(cc.ptr, keys.ptr, pt.ptr, &ctH)
 	err := checkPKEErrorMsg(status)
@@ -140,19 +164,23 @@ func (cc *CryptoContext) Encrypt(keys *KeyPair, pt *Plaintext) (*Ciphertext, err
 		return nil, errors.New("Encrypt returned OK but null handle")
 	}
 	ct := &Ciphertext{ptr: ctH}
+	cc.trackNoise(ct, noiseFresh)
 	return ct, nil
 }
 
 func (cc *CryptoContext) Decrypt(keys *KeyPair, ct *Ciphertext) (*Plaintext, error) {
-	if cc.ptr == nil {
+	if !cc.acquire() {
 		return nil, errors.New("CryptoContext is closed or invalid")
 	}
-	if keys == nil || keys.ptr == nil {
+	defer cc.release()
+	if !keys.acquire() {
 		return nil, errors.New("KeyPair is closed or invalid")
 	}
-	if ct == nil || ct.ptr == nil {
+	defer keys.release()
+	if !ct.acquire() {
 		return nil, errors.New("Ciphertext is closed or invalid")
 	}
+	defer ct.release()
 	var ptH C.PlaintextPtr
 	status := C.CryptoContext_Decrypt(cc.ptr, keys.ptr, ct.ptr, &ptH)
 	err := checkPKEErrorMsg(status)
@@ -169,12 +197,18 @@ func (cc *CryptoContext) Decrypt(keys *KeyPair, ct *Ciphertext) (*Plaintext, err
 
 // --- Common Homomorphic Operations ---
 func (cc *CryptoContext) EvalAdd(ct1, ct2 *Ciphertext) (*Ciphertext, error) {
-	if cc.ptr == nil {
+	if !cc.acquire() {
 		return nil, errors.New("CryptoContext is closed or invalid")
 	}
-	if ct1 == nil || ct1.ptr == nil || ct2 == nil || ct2.ptr == nil {
+	defer cc.release()
+	if !ct1.acquire() {
+		return nil, errors.New("Input Ciphertext is closed or invalid")
+	}
+	defer ct1.release()
+	if !ct2.acquire() {
 		return nil, errors.New("Input Ciphertext is closed or invalid")
 	}
+	defer ct2.release()
 	var ctH C.CiphertextPtr
 	status := C.CryptoContext_EvalAdd(cc.ptr, ct1.ptr, ct2.ptr, &ctH)
 	err := checkPKEErrorMsg(status)
@@ -185,16 +219,23 @@ func (cc *CryptoContext) EvalAdd(ct1, ct2 *Ciphertext) (*Ciphertext, error) {
 		return nil, errors.New("EvalAdd returned OK but null handle")
 	}
 	ct := &Ciphertext{ptr: ctH}
+	cc.trackNoise(ct, noiseAdd, ct1, ct2)
 	return ct, nil
 }
 
 func (cc *CryptoContext) EvalSub(ct1, ct2 *Ciphertext) (*Ciphertext, error) {
-	if cc.ptr == nil {
+	if !cc.acquire() {
 		return nil, errors.New("CryptoContext is closed or invalid")
 	}
-	if ct1 == nil || ct1.ptr == nil || ct2 == nil || ct2.ptr == nil {
+	defer cc.release()
+	if !ct1.acquire() {
+		return nil, errors.New("Input Ciphertext is closed or invalid")
+	}
+	defer ct1.release()
+	if !ct2.acquire() {
 		return nil, errors.New("Input Ciphertext is closed or invalid")
 	}
+	defer ct2.release()
 	var ctH C.CiphertextPtr
 	status := C.CryptoContext_EvalSub(cc.ptr, ct1.ptr, ct2.ptr, &ctH)
 	err := checkPKEErrorMsg(status)
@@ -205,16 +246,31 @@ func (cc *CryptoContext) EvalSub(ct1, ct2 *Ciphertext) (*Ciphertext, error) {
 		return nil, errors.New("EvalSub returned OK but null handle")
 	}
 	ct := &Ciphertext{ptr: ctH}
+	cc.trackNoise(ct, noiseAdd, ct1, ct2)
 	return ct, nil
 }
 
 func (cc *CryptoContext) EvalMult(ct1, ct2 *Ciphertext) (*Ciphertext, error) {
-	if cc.ptr == nil {
+	if a := cc.autoBoot; a != nil {
+		cts, release, err := cc.ensureLevels(a, "EvalMult", 1, ct1, ct2)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+		ct1, ct2 = cts[0], cts[1]
+	}
+	if !cc.acquire() {
 		return nil, errors.New("CryptoContext is closed or invalid")
 	}
-	if ct1 == nil || ct1.ptr == nil || ct2 == nil || ct2.ptr == nil {
+	defer cc.release()
+	if !ct1.acquire() {
 		return nil, errors.New("Input Ciphertext is closed or invalid")
 	}
+	defer ct1.release()
+	if !ct2.acquire() {
+		return nil, errors.New("Input Ciphertext is closed or invalid")
+	}
+	defer ct2.release()
 	var ctH C.CiphertextPtr
 	status := C.CryptoContext_EvalMult(cc.ptr, ct1.ptr, ct2.ptr, &ctH)
 	err := checkPKEErrorMsg(status)
@@ -225,19 +281,56 @@ func (cc *CryptoContext) EvalMult(ct1, ct2 *Ciphertext) (*Ciphertext, error) {
 		return nil, errors.New("EvalMult returned OK but null handle")
 	}
 	ct := &Ciphertext{ptr: ctH}
+	cc.trackNoise(ct, noiseMult, ct1, ct2)
 	return ct, nil
 }
 
+// EvalSquare returns ct*ct, which OpenFHE computes faster than EvalMult of
+// a ciphertext with itself.
+func (cc *CryptoContext) EvalSquare(ct *Ciphertext) (*Ciphertext, error) {
+	if a := cc.autoBoot; a != nil {
+		cts, release, err := cc.ensureLevels(a, "EvalSquare", 1, ct)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+		ct = cts[0]
+	}
+	if !cc.acquire() {
+		return nil, errors.New("CryptoContext is closed or invalid")
+	}
+	defer cc.release()
+	if !ct.acquire() {
+		return nil, errors.New("Input Ciphertext is closed or invalid")
+	}
+	defer ct.release()
+	var ctH C.CiphertextPtr
+	status := C.CryptoContext_EvalSquare(cc.ptr, ct.ptr, &ctH)
+	err := checkPKEErrorMsg(status)
+	if err != nil {
+		return nil, err
+	}
+	if ctH == nil {
+		return nil, errors.New("EvalSquare returned OK but null handle")
+	}
+	out := &Ciphertext{ptr: ctH}
+	cc.trackNoise(out, noiseMult, ct, ct)
+	return out, nil
+}
+
 func (cc *CryptoContext) EvalAddPlain(ct *Ciphertext, pt *Plaintext) (*Ciphertext, error) {
-	if cc.ptr == nil {
+	if !cc.acquire() {
 		return nil, errors.New("CryptoContext is closed or invalid")
 	}
-	if ct == nil || ct.ptr == nil {
+	defer cc.release()
+	if !ct.acquire() {
 		return nil, errors.New("Input Ciphertext is closed or invalid")
 	}
-	if pt == nil || pt.ptr == nil {
+	defer ct.release()
+	if !pt.acquire() {
 		return nil, errors.New("Input Plaintext is closed or invalid")
 	}
+	defer pt.release()
 	var ctH C.CiphertextPtr
 	status := C.CryptoContext_EvalAddPlain(cc.ptr, ct.ptr, pt.ptr, &ctH)
 	err := checkPKEErrorMsg(status)
@@ -248,19 +341,23 @@ func (cc *CryptoContext) EvalAddPlain(ct *Ciphertext, pt *Plaintext) (*Ciphertex
 		return nil, errors.New("EvalAddPlain returned OK but null handle")
 	}
 	resCt := &Ciphertext{ptr: ctH}
+	cc.trackNoise(resCt, noiseAddPlain, ct)
 	return resCt, nil
 }
 
 func (cc *CryptoContext) EvalSubPlain(ct *Ciphertext, pt *Plaintext) (*Ciphertext, error) {
-	if cc.ptr == nil {
+	if !cc.acquire() {
 		return nil, errors.New("CryptoContext is closed or invalid")
 	}
-	if ct == nil || ct.ptr == nil {
+	defer cc.release()
+	if !ct.acquire() {
 		return nil, errors.New("Input Ciphertext is closed or invalid")
 	}
-	if pt == nil || pt.ptr == nil {
+	defer ct.release()
+	if !pt.acquire() {
 		return nil, errors.New("Input Plaintext is closed or invalid")
 	}
+	defer pt.release()
 	var ctH C.CiphertextPtr
 	status := C.CryptoContext_EvalSubPlain(cc.ptr, ct.ptr, pt.ptr, &ctH)
 	err := checkPKEErrorMsg(status)
@@ -271,19 +368,23 @@ func (cc *CryptoContext) EvalSubPlain(ct *Ciphertext, pt *Plaintext) (*Ciphertex
 		return nil, errors.New("EvalSubPlain returned OK but null handle")
 	}
 	resCt := &Ciphertext{ptr: ctH}
+	cc.trackNoise(resCt, noiseAddPlain, ct)
 	return resCt, nil
 }
 
 func (cc *CryptoContext) EvalMultPlain(ct *Ciphertext, pt *Plaintext) (*Ciphertext, error) {
-	if cc.ptr == nil {
+	if !cc.acquire() {
 		return nil, errors.New("CryptoContext is closed or invalid")
 	}
-	if ct == nil || ct.ptr == nil {
+	defer cc.release()
+	if !ct.acquire() {
 		return nil, errors.New("Input Ciphertext is closed or invalid")
 	}
-	if pt == nil || pt.ptr == nil {
+	defer ct.release()
+	if !pt.acquire() {
 		return nil, errors.New("Input Plaintext is closed or invalid")
 	}
+	defer pt.release()
 	var ctH C.CiphertextPtr
 	status := C.CryptoContext_EvalMultPlain(cc.ptr, ct.ptr, pt.ptr, &ctH)
 	err := checkPKEErrorMsg(status)
@@ -294,16 +395,28 @@ func (cc *CryptoContext) EvalMultPlain(ct *Ciphertext, pt *Plaintext) (*Cipherte
 		return nil, errors.New("EvalMultPlain returned OK but null handle")
 	}
 	resCt := &Ciphertext{ptr: ctH}
+	cc.trackNoise(resCt, noiseMultPlain, ct)
 	return resCt, nil
 }
 
 func (cc *CryptoContext) EvalRotate(ct *Ciphertext, index int32) (*Ciphertext, error) {
-	if cc.ptr == nil {
+	if cc.closed() {
 		return nil, errors.New("CryptoContext is closed or invalid")
 	}
-	if ct == nil || ct.ptr == nil {
+	if tr := cc.trace.Load(); tr != nil {
+		return tr.rotate(ct, index)
+	}
+	if cc.composeRotations && !isPow2Rotation(index) {
+		return cc.evalRotateComposed(ct, index)
+	}
+	if !cc.acquire() {
+		return nil, errors.New("CryptoContext is closed or invalid")
+	}
+	defer cc.release()
+	if !ct.acquire() {
 		return nil, errors.New("Input Ciphertext is closed or invalid")
 	}
+	defer ct.release()
 	var ctH C.CiphertextPtr
 	status := C.CryptoContext_EvalRotate(cc.ptr, ct.ptr, C.int32_t(index), &ctH)
 	err := checkPKEErrorMsg(status)
@@ -314,21 +427,25 @@ func (cc *CryptoContext) EvalRotate(ct *Ciphertext, index int32) (*Ciphertext, e
 		return nil, errors.New("EvalRotate returned OK but null handle")
 	}
 	resCt := &Ciphertext{ptr: ctH}
+	cc.trackNoise(resCt, noiseKeySwitch, ct)
 	return resCt, nil
 }
 
 // FastRotationPrecompute holds precomputed values for fast rotation
 type FastRotationPrecompute struct {
 	ptr unsafe.Pointer
+	ref ref
 }
 
 func (cc *CryptoContext) EvalFastRotationPrecompute(ct *Ciphertext) (*FastRotationPrecompute, error) {
-	if cc.ptr == nil {
+	if !cc.acquire() {
 		return nil, errors.New("CryptoContext is closed or invalid")
 	}
-	if ct == nil || ct.ptr == nil {
+	defer cc.release()
+	if !ct.acquire() {
 		return nil, errors.New("Input Ciphertext is closed or invalid")
 	}
+	defer ct.release()
 	var precompH unsafe.Pointer
 	status := C.CryptoContext_EvalFastRotationPrecompute(cc.ptr, ct.ptr, &precompH)
 	err := checkPKEErrorMsg(status)
@@ -343,15 +460,21 @@ func (cc *CryptoContext) EvalFastRotationPrecompute(ct *Ciphertext) (*FastRotati
 }
 
 func (cc *CryptoContext) EvalFastRotation(ct *Ciphertext, index int32, m uint32, precomp *FastRotationPrecompute) (*Ciphertext, error) {
-	if cc.ptr == nil {
+	if !cc.acquire() {
 		return nil, errors.New("CryptoContext is closed or invalid")
 	}
-	if ct == nil || ct.ptr == nil {
+	defer cc.release()
+	if !ct.acquire() {
 		return nil, errors.New("Input Ciphertext is closed or invalid")
 	}
-	if precomp == nil || precomp.ptr == nil {
+	defer ct.release()
+	if !precomp.acquire() {
 		return nil, errors.New("FastRotationPrecompute is closed or invalid")
 	}
+	defer precomp.release()
+	if tr := cc.trace.Load(); tr != nil {
+		return tr.fastRotate(ct, index)
+	}
 	var ctH C.CiphertextPtr
 	status := C.CryptoContext_EvalFastRotation(cc.ptr, ct.ptr, C.int32_t(index), C.uint32_t(m), precomp.ptr, &ctH)
 	err := checkPKEErrorMsg(status)
@@ -362,11 +485,12 @@ func (cc *CryptoContext) EvalFastRotation(ct *Ciphertext, index int32, m uint32,
 		return nil, errors.New("EvalFastRotation returned OK but null handle")
 	}
 	resCt := &Ciphertext{ptr: ctH}
+	cc.trackNoise(resCt, noiseKeySwitch, ct)
 	return resCt, nil
 }
 
 func (p *FastRotationPrecompute) Close() {
-	if p.ptr != nil {
+	if p.ref.close() && p.ptr != nil {
 		C.DestroyFastRotationPrecompute(p.ptr)
 		p.ptr = nil
 	}
@@ -379,12 +503,14 @@ func GetNativeInt() int {
 
 // --- CKKS Bootstrapping ---
 func (cc *CryptoContext) EvalBootstrapKeyGen(keys *KeyPair, slots uint32) error {
-	if cc.ptr == nil {
+	if !cc.acquireExclusive() {
 		return errors.New("CryptoContext is closed or invalid")
 	}
-	if keys == nil || keys.ptr == nil {
+	defer cc.releaseExclusive()
+	if !keys.acquire() {
 		return errors.New("KeyPair is closed or invalid")
 	}
+	defer keys.release()
 	status := C.CryptoContext_EvalBootstrapKeyGen(cc.ptr, keys.ptr, C.uint32_t(slots))
 	err := checkPKEErrorMsg(status)
 	if err != nil {
@@ -394,12 +520,14 @@ func (cc *CryptoContext) EvalBootstrapKeyGen(keys *KeyPair, slots uint32) error
 }
 
 func (cc *CryptoContext) EvalBootstrap(ct *Ciphertext) (*Ciphertext, error) {
-	if cc.ptr == nil {
+	if !cc.acquire() {
 		return nil, errors.New("CryptoContext is closed or invalid")
 	}
-	if ct == nil || ct.ptr == nil {
+	defer cc.release()
+	if !ct.acquire() {
 		return nil, errors.New("Input Ciphertext is closed or invalid")
 	}
+	defer ct.release()
 	var ctH C.CiphertextPtr
 	status := C.CryptoContext_EvalBootstrap(cc.ptr, ct.ptr, &ctH)
 	err := checkPKEErrorMsg(status)
@@ -414,57 +542,90 @@ func (cc *CryptoContext) EvalBootstrap(ct *Ciphertext) (*Ciphertext, error) {
 }
 
 func (cc *CryptoContext) EvalBootstrapSetupSimple(levelBudget []uint32) error {
-	if cc.ptr == nil {
+	if !cc.acquireExclusive() {
 		return errors.New("CryptoContext is closed or invalid")
 	}
-	var ptr *C.uint32_t
-	var n C.int
-	if len(levelBudget) > 0 {
-		ptr = (*C.uint32_t)(unsafe.Pointer(&levelBudget[0]))
-		n = C.int(len(levelBudget))
-	}
+	defer cc.releaseExclusive()
+	levelBudget = levelBudgetOrDefault(levelBudget)
 
-	status := C.CryptoContext_EvalBootstrapSetup_Simple(cc.ptr, ptr, n)
+	status := C.CryptoContext_EvalBootstrapSetup_Simple(cc.ptr,
+		(*C.uint32_t)(unsafe.Pointer(&levelBudget[0])), C.int(len(levelBudget)))
 	err := checkPKEErrorMsg(status)
 	if err != nil {
 		return err
 	}
+	cc.recordLevelBudget(levelBudget)
+	return nil
+}
+
+// EvalBootstrapSetup is the full form of EvalBootstrapSetupSimple. dim1 sets the
+// baby-step giant-step dimensions for the encoding/decoding transforms ({0, 0}
+// lets OpenFHE choose), slots selects sparse packing (0 means N/2), and
+// correctionFactor tunes the internal scaling (0 uses the library default).
+// An empty levelBudget means {4, 4}, as for EvalBootstrapSetupSimple.
+// When precompute is false, call EvalBootstrapPrecompute before bootstrapping.
+func (cc *CryptoContext) EvalBootstrapSetup(levelBudget, dim1 []uint32, slots, correctionFactor uint32, precompute bool) error {
+	if !cc.acquireExclusive() {
+		return errors.New("CryptoContext is closed or invalid")
+	}
+	defer cc.releaseExclusive()
+	levelBudget = levelBudgetOrDefault(levelBudget)
+	lbPtr, lbLen := (*C.uint32_t)(unsafe.Pointer(&levelBudget[0])), C.int(len(levelBudget))
+	var d1Ptr *C.uint32_t
+	var d1Len C.int
+	if len(dim1) > 0 {
+		d1Ptr = (*C.uint32_t)(unsafe.Pointer(&dim1[0]))
+		d1Len = C.int(len(dim1))
+	}
+	var cPrecompute C.int
+	if precompute {
+		cPrecompute = 1
+	}
+
+	status := C.CryptoContext_EvalBootstrapSetup(cc.ptr, lbPtr, lbLen, d1Ptr, d1Len,
+		C.uint32_t(slots), C.uint32_t(correctionFactor), cPrecompute)
+	if err := checkPKEErrorMsg(status); err != nil {
+		return err
+	}
+	cc.recordLevelBudget(levelBudget)
 	return nil
 }
 
-// func (cc *CryptoContext) EvalBootstrapSetup(slots uint32) error {
-// 	var cErr *C.char
-// 	ok := C.CryptoContext_EvalBootstrapSetup(cc.ptr, C.uint32_t(slots), &cErr)
-// 	if ok == 1 {
-// 		return nil
-// 	}
-// 	defer func() {
-// 		if cErr != nil {
-// 			C.free(unsafe.Pointer(cErr))
-// 		}
-// 	}()
-// 	if cErr != nil {
-// 		return fmt.Errorf("%s", C.GoString(cErr))
-// 	}
-// 	return fmt.Errorf("EvalBootstrapSetup failed")
-// }
-//
-// func (cc *CryptoContext) EvalBootstrapPrecompute(slots uint32) error {
-// 	var cErr *C.char
-// 	ok := C.CryptoContext_EvalBootstrapPrecompute(cc.ptr, C.uint32_t(slots), &cErr)
-// 	if ok == 1 {
-// 		return nil
-// 	}
-// 	defer func() {
-// 		if cErr != nil {
-// 			C.free(unsafe.Pointer(cErr))
-// 		}
-// 	}()
-// 	if cErr != nil {
-// 		return fmt.Errorf("%s", C.GoString(cErr))
-// 	}
-// 	return fmt.Errorf("EvalBootstrapPrecompute failed")
-// }
+// EvalBootstrapPrecompute computes the linear-transform plaintexts for the given
+// slot count. Only needed after EvalBootstrapSetup with precompute=false.
+func (cc *CryptoContext) EvalBootstrapPrecompute(slots uint32) error {
+	if !cc.acquireExclusive() {
+		return errors.New("CryptoContext is closed or invalid")
+	}
+	defer cc.releaseExclusive()
+	status := C.CryptoContext_EvalBootstrapPrecompute(cc.ptr, C.uint32_t(slots))
+	return checkPKEErrorMsg(status)
+}
+
+// EvalBootstrapWithIterations runs iterative (meta) bootstrapping. precision is
+// the measured precision in bits of a single bootstrap; each extra iteration
+// needs one more level than plain EvalBootstrap.
+func (cc *CryptoContext) EvalBootstrapWithIterations(ct *Ciphertext, numIterations, precision uint32) (*Ciphertext, error) {
+	if !cc.acquire() {
+		return nil, errors.New("CryptoContext is closed or invalid")
+	}
+	defer cc.release()
+	if !ct.acquire() {
+		return nil, errors.New("Input Ciphertext is closed or invalid")
+	}
+	defer ct.release()
+	var ctH C.CiphertextPtr
+	status := C.CryptoContext_EvalBootstrapIterations(cc.ptr, ct.ptr, C.uint32_t(numIterations), C.uint32_t(precision), &ctH)
+	err := checkPKEErrorMsg(status)
+	if err != nil {
+		return nil, err
+	}
+	if ctH == nil {
+		return nil, errors.New("EvalBootstrapWithIterations returned OK but null handle")
+	}
+	res := &Ciphertext{ptr: ctH}
+	return res, nil
+}
 
 // --- Global Cleanup ---
 
@@ -481,9 +642,13 @@ func Cleanup() {
 }
 
 func (ct *Ciphertext) GetLevel() (int, bool) {
-	if ct.ptr == nil {
+	if ct.sim != nil && !ct.closed() {
+		return ct.sim.level, true
+	}
+	if !ct.acquire() {
 		return -1, false // Indicate invalid state
 	}
+	defer ct.release()
 	level := C.Ciphertext_GetLevel(ct.ptr)
 	if level == -1 {
 		return -1, false
@@ -492,11 +657,30 @@ func (ct *Ciphertext) GetLevel() (int, bool) {
 	return int(level), true
 }
 
+// GetNoiseScaleDeg returns the CKKS noise scale degree: 1 for a fresh or
+// rescaled ciphertext, 2 after a multiplication that has not been rescaled.
+func (ct *Ciphertext) GetNoiseScaleDeg() (int, bool) {
+	if ct.sim != nil && !ct.closed() {
+		return ct.sim.scaleDeg, true
+	}
+	if !ct.acquire() {
+		return -1, false
+	}
+	defer ct.release()
+	deg := C.Ciphertext_GetNoiseScaleDeg(ct.ptr)
+	if deg == -1 {
+		return -1, false
+	}
+
+	return int(deg), true
+}
+
 func (cc *CryptoContext) GetParameterElementString() (string, error) {
 	fmt.Println("Go: Calling GetParameterElementString...")
-	if cc.ptr == nil {
+	if !cc.acquire() {
 		return "", errors.New("CryptoContext is closed or invalid")
 	}
+	defer cc.release()
 	var cStr *C.char
 	status := C.CryptoContext_GetParameterElementString(cc.ptr, &cStr)
 	err := checkPKEErrorMsg(status) // Check for errors returned by the C function
@@ -519,8 +703,9 @@ func (cc *CryptoContext) GetParameterElementString() (string, error) {
 // --- Release Methods for Go Wrappers ---
 
 // Close frees the underlying C++ CryptoContext object.
+// It waits for calls still running on cc to return.
 func (cc *CryptoContext) Close() {
-	if cc.ptr != nil {
+	if cc.ref.close() && cc.ptr != nil {
 		C.DestroyCryptoContext(cc.ptr)
 		cc.ptr = nil
 	}
@@ -528,15 +713,36 @@ func (cc *CryptoContext) Close() {
 
 // Close frees the underlying C++ KeyPair object.
 func (kp *KeyPair) Close() {
-	if kp.ptr != nil {
+	if kp.ref.close() && kp.ptr != nil {
 		C.DestroyKeyPair(kp.ptr)
 		kp.ptr = nil
 	}
 }
 
+// Clone returns a deep copy of ct.
+func (ct *Ciphertext) Clone() (*Ciphertext, error) {
+	if ct != nil && ct.sim != nil && !ct.closed() {
+		return &Ciphertext{sim: ct.sim.derive()}, nil
+	}
+	if !ct.acquire() {
+		return nil, errors.New("Ciphertext is closed or invalid")
+	}
+	defer ct.release()
+	var ctH C.CiphertextPtr
+	status := C.Ciphertext_Clone(ct.ptr, &ctH)
+	err := checkPKEErrorMsg(status)
+	if err != nil {
+		return nil, err
+	}
+	if ctH == nil {
+		return nil, errors.New("Clone returned OK but null handle")
+	}
+	return &Ciphertext{ptr: ctH, noise: ct.noise}, nil
+}
+
 // Close frees the underlying C++ Ciphertext object.
 func (ct *Ciphertext) Close() {
-	if ct.ptr != nil {
+	if ct.ref.close() && ct.ptr != nil {
 		// fmt.Println("Releasing Ciphertext:", ct.ptr) // Debug
 		C.DestroyCiphertext(ct.ptr)
 		ct.ptr = nil
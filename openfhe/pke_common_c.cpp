@@ -1,6 +1,8 @@
 #include "pke_common_c.h"
 #include "helpers_c.h"
 #include "pke_helpers_c.h"
+#include <algorithm>
+#include <cmath>
 
 using namespace lbcrypto;
 
@@ -119,10 +121,70 @@ uint64_t CryptoContext_GetRingDimension(CryptoContextPtr cc_ptr_to_sptr) {
   return cc->GetRingDimension();
 }
 
+uint32_t CryptoContext_GetBatchSize(CryptoContextPtr cc_ptr_to_sptr) {
+  if (!cc_ptr_to_sptr)
+    return 0;
+
+  auto &cc = GetCCSharedPtr(cc_ptr_to_sptr);
+
+  return cc->GetEncodingParams()->GetBatchSize();
+}
+
+int CryptoContext_GetScalingTechnique(CryptoContextPtr cc_ptr_to_sptr) {
+  if (!cc_ptr_to_sptr)
+    return -1;
+
+  auto &cc = GetCCSharedPtr(cc_ptr_to_sptr);
+  auto params = std::dynamic_pointer_cast<CryptoParametersRNS>(
+      cc->GetCryptoParameters());
+  if (!params)
+    return -1;
+
+  return static_cast<int>(params->GetScalingTechnique());
+}
+
+int CryptoContext_GetMultiplicativeDepth(CryptoContextPtr cc_ptr_to_sptr) {
+  if (!cc_ptr_to_sptr)
+    return -1;
+
+  auto &cc = GetCCSharedPtr(cc_ptr_to_sptr);
+  auto params = std::dynamic_pointer_cast<CryptoParametersRNS>(
+      cc->GetCryptoParameters());
+  if (!params)
+    return -1;
+
+  // One tower per level plus the base modulus; FLEXIBLEAUTOEXT adds one
+  // more that is not available to the computation.
+  int towers = static_cast<int>(params->GetElementParams()->GetParams().size());
+  if (params->GetScalingTechnique() == FLEXIBLEAUTOEXT)
+    towers--;
+  return towers - 1;
+}
+
+int CryptoContext_GetSecretKeyDist(CryptoContextPtr cc_ptr_to_sptr) {
+  if (!cc_ptr_to_sptr)
+    return -1;
+
+  auto &cc = GetCCSharedPtr(cc_ptr_to_sptr);
+  auto params = std::dynamic_pointer_cast<CryptoParametersRNS>(
+      cc->GetCryptoParameters());
+  if (!params)
+    return -1;
+
+  return static_cast<int>(params->GetSecretKeyDist());
+}
+
 void DestroyCryptoContext(CryptoContextPtr cc_ptr_to_sptr) {
   delete reinterpret_cast<CryptoContextSharedPtr *>(cc_ptr_to_sptr);
 }
 
+CryptoContextPtr CryptoContext_Share(CryptoContextPtr cc_ptr_to_sptr) {
+  if (!cc_ptr_to_sptr)
+    return nullptr;
+  return reinterpret_cast<CryptoContextPtr>(
+      new CryptoContextSharedPtr(GetCCSharedPtr(cc_ptr_to_sptr)));
+}
+
 // --- Common Operations ---
 PKEErr CryptoContext_Encrypt(CryptoContextPtr cc_ptr_to_sptr,
                              KeyPairPtr keys_raw_ptr,
@@ -271,6 +333,30 @@ PKEErr CryptoContext_EvalMult(CryptoContextPtr cc_ptr_to_sptr,
   PKE_CATCH_RETURN()
 }
 
+PKEErr CryptoContext_EvalSquare(CryptoContextPtr cc_ptr_to_sptr,
+                                CiphertextPtr ct_ptr_to_sptr,
+                                CiphertextPtr *out) {
+  try {
+    if (!cc_ptr_to_sptr) {
+      return MakePKEError("CryptoContext_EvalSquare: null context");
+    }
+    if (!ct_ptr_to_sptr) {
+      return MakePKEError("CryptoContext_EvalSquare: null input ciphertext");
+    }
+    if (!out) {
+      return MakePKEError("CryptoContext_EvalSquare: null output pointer");
+    }
+
+    auto &cc_sptr = GetCCSharedPtr(cc_ptr_to_sptr);
+    auto &ct_sptr = GetCTSharedPtr(ct_ptr_to_sptr);
+    Ciphertext<DCRTPoly> result_ct_sptr = cc_sptr->EvalSquare(ct_sptr);
+    *out = reinterpret_cast<CiphertextPtr>(
+        new CiphertextSharedPtr(result_ct_sptr));
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
 PKEErr CryptoContext_EvalAddPlain(CryptoContextPtr cc_ptr_to_sptr,
                                   CiphertextPtr ct_ptr_to_sptr,
                                   PlaintextPtr pt_ptr_to_sptr,
@@ -566,60 +652,154 @@ PKEErr Plaintext_GetPackedValueLength(PlaintextPtr pt_ptr_to_sptr,
   PKE_CATCH_RETURN()
 }
 
-PKEErr Plaintext_GetPackedValueAt(PlaintextPtr pt_ptr_to_sptr, int i,
-                                  int64_t *out_val) {
+PKEErr Plaintext_GetRealPackedValueLength(PlaintextPtr pt_ptr_to_sptr,
+                                          int *out_len) {
   try {
     if (!pt_ptr_to_sptr) {
-      return MakePKEError("Plaintext_GetPackedValueAt: null plaintext");
+      return MakePKEError("Plaintext_GetRealPackedValueLength: null plaintext");
     }
-    if (!out_val) {
-      return MakePKEError("Plaintext_GetPackedValueAt: null output pointer");
+    if (!out_len) {
+      return MakePKEError(
+          "Plaintext_GetRealPackedValueLength: null output pointer");
     }
     auto &pt_sptr = GetPTSharedPtr(pt_ptr_to_sptr);
-    // Add bounds check
-    if (i < 0 || (size_t)i >= pt_sptr->GetPackedValue().size()) {
-      return MakePKEError("Plaintext_GetPackedValueAt: index out of bounds");
+    *out_len = pt_sptr->GetRealPackedValue().size();
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
+PKEErr Plaintext_CopyPackedValue(PlaintextPtr pt_ptr_to_sptr, int64_t *dst,
+                                 int cap, int *out_len) {
+  try {
+    if (!pt_ptr_to_sptr) {
+      return MakePKEError("Plaintext_CopyPackedValue: null plaintext");
+    }
+    if (!out_len) {
+      return MakePKEError("Plaintext_CopyPackedValue: null output pointer");
     }
-    *out_val = pt_sptr->GetPackedValue()[i];
+    if (cap > 0 && !dst) {
+      return MakePKEError(
+          "Plaintext_CopyPackedValue: non-zero capacity with null buffer");
+    }
+    auto &pt_sptr = GetPTSharedPtr(pt_ptr_to_sptr);
+    const auto &values = pt_sptr->GetPackedValue();
+    size_t n = std::min(values.size(), static_cast<size_t>(cap > 0 ? cap : 0));
+    std::copy(values.begin(), values.begin() + n, dst);
+    *out_len = static_cast<int>(values.size());
     return MakePKEOk();
   }
   PKE_CATCH_RETURN()
 }
 
-PKEErr Plaintext_GetRealPackedValueLength(PlaintextPtr pt_ptr_to_sptr,
-                                          int *out_len) {
+PKEErr Plaintext_CopyRealPackedValue(PlaintextPtr pt_ptr_to_sptr, double *dst,
+                                     int cap, int *out_len) {
   try {
     if (!pt_ptr_to_sptr) {
-      return MakePKEError("Plaintext_GetRealPackedValueLength: null plaintext");
+      return MakePKEError("Plaintext_CopyRealPackedValue: null plaintext");
     }
     if (!out_len) {
+      return MakePKEError("Plaintext_CopyRealPackedValue: null output pointer");
+    }
+    if (cap > 0 && !dst) {
       return MakePKEError(
-          "Plaintext_GetRealPackedValueLength: null output pointer");
+          "Plaintext_CopyRealPackedValue: non-zero capacity with null buffer");
     }
     auto &pt_sptr = GetPTSharedPtr(pt_ptr_to_sptr);
-    *out_len = pt_sptr->GetRealPackedValue().size();
+    const auto &values = pt_sptr->GetRealPackedValue();
+    size_t n = std::min(values.size(), static_cast<size_t>(cap > 0 ? cap : 0));
+    std::copy(values.begin(), values.begin() + n, dst);
+    *out_len = static_cast<int>(values.size());
     return MakePKEOk();
   }
   PKE_CATCH_RETURN()
 }
 
-PKEErr Plaintext_GetRealPackedValueAt(PlaintextPtr pt_ptr_to_sptr, int i,
-                                      double *out_val) {
+PKEErr CryptoContext_MakeCoefPackedPlaintext(CryptoContextPtr cc_ptr_to_sptr,
+                                             int64_t *values, int len,
+                                             PlaintextPtr *out) {
+  try {
+    if (!cc_ptr_to_sptr) {
+      return MakePKEError(
+          "CryptoContext_MakeCoefPackedPlaintext: null context");
+    }
+    if (len > 0 && !values) {
+      return MakePKEError("CryptoContext_MakeCoefPackedPlaintext: non-zero "
+                          "length with null values");
+    }
+    if (!out) {
+      return MakePKEError(
+          "CryptoContext_MakeCoefPackedPlaintext: null output pointer");
+    }
+    auto &cc_sptr = GetCCSharedPtr(cc_ptr_to_sptr);
+    std::vector<int64_t> vec(values, values + len);
+    Plaintext pt_sptr = cc_sptr->MakeCoefPackedPlaintext(vec);
+    *out = reinterpret_cast<PlaintextPtr>(new PlaintextSharedPtr(pt_sptr));
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
+PKEErr Plaintext_CopyCoefPackedValue(PlaintextPtr pt_ptr_to_sptr, int64_t *dst,
+                                     int cap, int *out_len) {
   try {
     if (!pt_ptr_to_sptr) {
-      return MakePKEError("Plaintext_GetRealPackedValueAt: null plaintext");
+      return MakePKEError("Plaintext_CopyCoefPackedValue: null plaintext");
+    }
+    if (!out_len) {
+      return MakePKEError("Plaintext_CopyCoefPackedValue: null output pointer");
     }
-    if (!out_val) {
+    if (cap > 0 && !dst) {
       return MakePKEError(
-          "Plaintext_GetRealPackedValueAt: null output pointer");
+          "Plaintext_CopyCoefPackedValue: non-zero capacity with null buffer");
     }
     auto &pt_sptr = GetPTSharedPtr(pt_ptr_to_sptr);
-    // Add bounds check
-    if (i < 0 || (size_t)i >= pt_sptr->GetRealPackedValue().size()) {
+    const auto &values = pt_sptr->GetCoefPackedValue();
+    size_t n = std::min(values.size(), static_cast<size_t>(cap > 0 ? cap : 0));
+    std::copy(values.begin(), values.begin() + n, dst);
+    *out_len = static_cast<int>(values.size());
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
+PKEErr CryptoContext_MakeStringPlaintext(CryptoContextPtr cc_ptr_to_sptr,
+                                         const char *data, int len,
+                                         PlaintextPtr *out) {
+  try {
+    if (!cc_ptr_to_sptr) {
+      return MakePKEError("CryptoContext_MakeStringPlaintext: null context");
+    }
+    if (len > 0 && !data) {
+      return MakePKEError("CryptoContext_MakeStringPlaintext: non-zero length "
+                          "with null data");
+    }
+    if (!out) {
       return MakePKEError(
-          "Plaintext_GetRealPackedValueAt: index out of bounds");
+          "CryptoContext_MakeStringPlaintext: null output pointer");
     }
-    *out_val = pt_sptr->GetRealPackedValue()[i];
+    auto &cc_sptr = GetCCSharedPtr(cc_ptr_to_sptr);
+    std::string str(data, len);
+    Plaintext pt_sptr = cc_sptr->MakeStringPlaintext(str);
+    *out = reinterpret_cast<PlaintextPtr>(new PlaintextSharedPtr(pt_sptr));
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
+PKEErr Plaintext_GetStringValue(PlaintextPtr pt_ptr_to_sptr, char **outBytes,
+                                size_t *outLen) {
+  try {
+    if (!pt_ptr_to_sptr) {
+      return MakePKEError("Plaintext_GetStringValue: null plaintext");
+    }
+    if (!outBytes || !outLen) {
+      return MakePKEError("Plaintext_GetStringValue: null output pointer");
+    }
+    auto &pt_sptr = GetPTSharedPtr(pt_ptr_to_sptr);
+    const std::string &s = pt_sptr->GetStringValue();
+    *outBytes = CopyStringToC(s);
+    *outLen = s.size();
     return MakePKEOk();
   }
   PKE_CATCH_RETURN()
@@ -643,10 +823,49 @@ int Ciphertext_GetLevel(CiphertextPtr ct_ptr_to_sptr) {
   return static_cast<int>(ct_sptr->GetLevel()); // Cast size_t to int
 }
 
+int Ciphertext_GetNoiseScaleDeg(CiphertextPtr ct_ptr_to_sptr) {
+  if (!ct_ptr_to_sptr) {
+    return -1;
+  }
+
+  auto &ct_sptr = GetCTSharedPtr(ct_ptr_to_sptr);
+  if (!ct_sptr) {
+    return -1;
+  }
+
+  return static_cast<int>(ct_sptr->GetNoiseScaleDeg());
+}
+
+PKEErr Ciphertext_Clone(CiphertextPtr ct_ptr_to_sptr, CiphertextPtr *out) {
+  try {
+    if (!ct_ptr_to_sptr) {
+      return MakePKEError("Ciphertext_Clone: null ciphertext");
+    }
+    if (!out) {
+      return MakePKEError("Ciphertext_Clone: null output pointer");
+    }
+    auto &ct_sptr = GetCTSharedPtr(ct_ptr_to_sptr);
+    if (!ct_sptr) {
+      return MakePKEError("Ciphertext_Clone: empty ciphertext");
+    }
+    Ciphertext<DCRTPoly> clone = ct_sptr->Clone();
+    *out = reinterpret_cast<CiphertextPtr>(new CiphertextSharedPtr(clone));
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
 void DestroyCiphertext(CiphertextPtr ct_ptr_to_sptr) {
   delete reinterpret_cast<CiphertextSharedPtr *>(ct_ptr_to_sptr);
 }
 
+CiphertextPtr Ciphertext_Share(CiphertextPtr ct_ptr_to_sptr) {
+  if (!ct_ptr_to_sptr)
+    return nullptr;
+  return reinterpret_cast<CiphertextPtr>(
+      new CiphertextSharedPtr(GetCTSharedPtr(ct_ptr_to_sptr)));
+}
+
 // --- Serialization ---
 void FreeString(char *s) {
   if (s) {
@@ -790,6 +1009,38 @@ void DeserializeEvalMultKeyFromBytes(CryptoContextPtr cc_ptr_to_sptr,
   }
 }
 
+// EvalAutomorphismKey (Rotation Key) Serialization
+size_t SerializeEvalAutomorphismKeyToBytes(CryptoContextPtr cc_ptr_to_sptr,
+                                           const char *keyId, char **outBytes) {
+  try {
+    auto &cc = GetCCSharedPtr(cc_ptr_to_sptr);
+    std::stringstream ss;
+    if (!cc->SerializeEvalAutomorphismKey(ss, SerType::BINARY,
+                                          std::string(keyId)))
+      return 0;
+    std::string s = ss.str();
+    *outBytes = CopyStringToC(s);
+    if (!*outBytes)
+      return 0;
+    return s.length();
+  } catch (...) {
+    *outBytes = nullptr;
+    return 0;
+  }
+}
+
+int DeserializeEvalAutomorphismKeyFromBytes(CryptoContextPtr cc_ptr_to_sptr,
+                                            const char *inData, int inLen) {
+  try {
+    auto &cc = GetCCSharedPtr(cc_ptr_to_sptr);
+    std::string s(inData, inLen);
+    std::stringstream ss(s);
+    return cc->DeserializeEvalAutomorphismKey(ss, SerType::BINARY) ? 1 : 0;
+  } catch (...) {
+    return 0;
+  }
+}
+
 // Ciphertext Serialization
 size_t SerializeCiphertextToBytes(CiphertextPtr ct_ptr_to_sptr,
                                   char **outBytes) {
@@ -863,6 +1114,111 @@ PKEErr CryptoContext_GetParameterElementString(CryptoContextPtr cc_ptr_to_sptr,
   }
 }
 
+// --- Noise Estimation ---
+
+// Log2Big returns log2(x) for integers beyond the range of a double.
+static double Log2Big(const BigInteger &x) {
+  usint msb = x.GetMSB();
+  if (msb <= 53)
+    return std::log2(x.ConvertToDouble());
+  usint shift = msb - 53;
+  return std::log2((x >> shift).ConvertToDouble()) + shift;
+}
+
+int CryptoContext_GetSchemeKind(CryptoContextPtr cc_ptr_to_sptr) {
+  if (!cc_ptr_to_sptr)
+    return PKE_SCHEME_UNKNOWN;
+
+  auto &cc = GetCCSharedPtr(cc_ptr_to_sptr);
+  auto params = cc->GetCryptoParameters();
+  if (std::dynamic_pointer_cast<CryptoParametersBFVRNS>(params))
+    return PKE_SCHEME_BFV;
+  if (std::dynamic_pointer_cast<CryptoParametersBGVRNS>(params))
+    return PKE_SCHEME_BGV;
+  if (std::dynamic_pointer_cast<CryptoParametersCKKSRNS>(params))
+    return PKE_SCHEME_CKKS;
+  return PKE_SCHEME_UNKNOWN;
+}
+
+uint64_t CryptoContext_GetPlaintextModulus(CryptoContextPtr cc_ptr_to_sptr) {
+  if (!cc_ptr_to_sptr)
+    return 0;
+
+  auto &cc = GetCCSharedPtr(cc_ptr_to_sptr);
+  return cc->GetCryptoParameters()->GetPlaintextModulus();
+}
+
+double Ciphertext_GetModulusLog2(CiphertextPtr ct_ptr_to_sptr) {
+  if (!ct_ptr_to_sptr)
+    return -1;
+
+  auto &ct = GetCTSharedPtr(ct_ptr_to_sptr);
+  if (!ct || ct->GetElements().empty())
+    return -1;
+
+  return Log2Big(ct->GetElements()[0].GetModulus());
+}
+
+PKEErr Ciphertext_NoiseBudget(CryptoContextPtr cc_ptr_to_sptr,
+                              KeyPairPtr keys_raw_ptr,
+                              CiphertextPtr ct_ptr_to_sptr, double *out) {
+  try {
+    if (!cc_ptr_to_sptr || !keys_raw_ptr || !ct_ptr_to_sptr || !out) {
+      return MakePKEError("Ciphertext_NoiseBudget: null input");
+    }
+    auto &cc = GetCCSharedPtr(cc_ptr_to_sptr);
+    auto kp_raw = reinterpret_cast<KeyPairRawPtr>(keys_raw_ptr);
+    auto &ct = GetCTSharedPtr(ct_ptr_to_sptr);
+    if (!kp_raw->secretKey) {
+      return MakePKEError("Ciphertext_NoiseBudget: keypair has no secret key");
+    }
+    int kind = CryptoContext_GetSchemeKind(cc_ptr_to_sptr);
+    if (kind != PKE_SCHEME_BFV && kind != PKE_SCHEME_BGV) {
+      return MakePKEError(
+          "Ciphertext_NoiseBudget: only BFV and BGV are supported");
+    }
+
+    // b = c0 + c1*s + c2*s^2 + ... at the ciphertext's current modulus.
+    const auto &cv = ct->GetElements();
+    DCRTPoly s = kp_raw->secretKey->GetPrivateElement();
+    size_t towers = cv[0].GetNumOfElements();
+    if (s.GetNumOfElements() > towers)
+      s.DropLastElements(s.GetNumOfElements() - towers);
+    s.SetFormat(Format::EVALUATION);
+    DCRTPoly b = cv[0];
+    DCRTPoly sPow = s;
+    for (size_t i = 1; i < cv.size(); i++) {
+      b += cv[i] * sPow;
+      if (i + 1 < cv.size())
+        sPow *= s;
+    }
+    b.SetFormat(Format::COEFFICIENT);
+    auto big = b.CRTInterpolate();
+
+    // BFV: b = (q/t)*m + e and the invariant noise is [t*b]_q / q.
+    // BGV: b = m + t*e must stay below q/2 in absolute value.
+    const BigInteger &q = big.GetModulus();
+    BigInteger half = q >> 1;
+    BigInteger t(cc->GetCryptoParameters()->GetPlaintextModulus());
+    BigInteger worst(0);
+    for (usint i = 0; i < big.GetLength(); i++) {
+      BigInteger c = big[i];
+      if (kind == PKE_SCHEME_BFV)
+        c = c.ModMul(t, q);
+      if (c > half)
+        c = q - c;
+      if (c > worst)
+        worst = c;
+    }
+    double budget = Log2Big(q) - 1;
+    if (worst > BigInteger(0))
+      budget -= Log2Big(worst);
+    *out = std::max(budget, 0.0);
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
 int GetNativeInt() {
 // Return the native integer size in bits (64 or 128)
 // This is determined at compile time by OpenFHE's NATIVE_SIZE macro
@@ -60,8 +60,19 @@ PKEErr CryptoContext_EvalMultKeyGen(CryptoContextPtr cc, KeyPairPtr keys);
 PKEErr CryptoContext_EvalRotateKeyGen(CryptoContextPtr cc, KeyPairPtr keys,
                                        int32_t *indices, int len);
 uint64_t CryptoContext_GetRingDimension(CryptoContextPtr cc);
+uint32_t CryptoContext_GetBatchSize(CryptoContextPtr cc);
+// Returns -1 for a null context or a scheme without RNS parameters.
+int CryptoContext_GetScalingTechnique(CryptoContextPtr cc);
+// Returns the number of levels a fresh ciphertext can consume, or -1.
+int CryptoContext_GetMultiplicativeDepth(CryptoContextPtr cc);
+// Returns the SecretKeyDist of the context, or -1.
+int CryptoContext_GetSecretKeyDist(CryptoContextPtr cc);
 int Ciphertext_GetLevel(CiphertextPtr ct);
+int Ciphertext_GetNoiseScaleDeg(CiphertextPtr ct);
+PKEErr Ciphertext_Clone(CiphertextPtr ct, CiphertextPtr *out);
 void DestroyCryptoContext(CryptoContextPtr cc);
+// Returns a new handle sharing ownership of the same context.
+CryptoContextPtr CryptoContext_Share(CryptoContextPtr cc);
 int GetNativeInt();
 
 // --- Common Operations ---
@@ -75,6 +86,8 @@ PKEErr CryptoContext_EvalSub(CryptoContextPtr cc, CiphertextPtr ct1,
                               CiphertextPtr ct2, CiphertextPtr *out);
 PKEErr CryptoContext_EvalMult(CryptoContextPtr cc, CiphertextPtr ct1,
                                CiphertextPtr ct2, CiphertextPtr *out);
+PKEErr CryptoContext_EvalSquare(CryptoContextPtr cc, CiphertextPtr ct,
+                                CiphertextPtr *out);
 PKEErr CryptoContext_EvalRotate(CryptoContextPtr cc, CiphertextPtr ct,
                                  int32_t index, CiphertextPtr *out);
 PKEErr CryptoContext_EvalFastRotationPrecompute(CryptoContextPtr cc,
@@ -101,13 +114,30 @@ void DestroyKeyPair(KeyPairPtr kp);
 
 // --- Plaintext ---
 PKEErr Plaintext_GetPackedValueLength(PlaintextPtr pt, int *out_len);
-PKEErr Plaintext_GetPackedValueAt(PlaintextPtr pt, int i, int64_t *out_val);
 PKEErr Plaintext_GetRealPackedValueLength(PlaintextPtr pt, int *out_len);
-PKEErr Plaintext_GetRealPackedValueAt(PlaintextPtr pt, int i, double *out_val);
+// Bulk copies: write min(len, cap) values to dst in one call and report the
+// full length in *out_len, so a caller can retry with a larger buffer.
+PKEErr Plaintext_CopyPackedValue(PlaintextPtr pt, int64_t *dst, int cap,
+                                 int *out_len);
+PKEErr Plaintext_CopyRealPackedValue(PlaintextPtr pt, double *dst, int cap,
+                                     int *out_len);
+PKEErr CryptoContext_MakeCoefPackedPlaintext(CryptoContextPtr cc,
+                                             int64_t *values, int len,
+                                             PlaintextPtr *out);
+PKEErr Plaintext_CopyCoefPackedValue(PlaintextPtr pt, int64_t *dst, int cap,
+                                     int *out_len);
+// The string is encoded one byte per coefficient, so len must not exceed the
+// ring dimension. GetStringValue mallocs *outBytes; free it with FreeString.
+PKEErr CryptoContext_MakeStringPlaintext(CryptoContextPtr cc, const char *data,
+                                         int len, PlaintextPtr *out);
+PKEErr Plaintext_GetStringValue(PlaintextPtr pt, char **outBytes,
+                                size_t *outLen);
 void DestroyPlaintext(PlaintextPtr pt);
 
 // --- Ciphertext ---
 void DestroyCiphertext(CiphertextPtr ct);
+// Returns a new handle sharing ownership of the same ciphertext.
+CiphertextPtr Ciphertext_Share(CiphertextPtr ct);
 
 // --- Serialization ---
 // This helper must be defined here as it's used by serial.go
@@ -128,11 +158,33 @@ size_t SerializeEvalMultKeyToBytes(CryptoContextPtr cc, const char *keyId,
 void DeserializeEvalMultKeyFromBytes(CryptoContextPtr cc, const char *inData,
                                      int inLen);
 
+size_t SerializeEvalAutomorphismKeyToBytes(CryptoContextPtr cc,
+                                           const char *keyId, char **outBytes);
+// Returns 1 on success and 0 on failure.
+int DeserializeEvalAutomorphismKeyFromBytes(CryptoContextPtr cc,
+                                            const char *inData, int inLen);
+
 size_t SerializeCiphertextToBytes(CiphertextPtr ct, char **outBytes);
 CiphertextPtr DeserializeCiphertextFromBytes(const char *inData, int inLen);
 
 PKEErr CryptoContext_GetParameterElementString(CryptoContextPtr cc,
                                                 char **outString);
+
+// --- Noise Estimation ---
+// Scheme kinds returned by CryptoContext_GetSchemeKind.
+#define PKE_SCHEME_UNKNOWN 0
+#define PKE_SCHEME_BFV 1
+#define PKE_SCHEME_BGV 2
+#define PKE_SCHEME_CKKS 3
+int CryptoContext_GetSchemeKind(CryptoContextPtr cc);
+// Returns 0 for a null context.
+uint64_t CryptoContext_GetPlaintextModulus(CryptoContextPtr cc);
+// Returns log2 of the current ciphertext modulus, or -1.
+double Ciphertext_GetModulusLog2(CiphertextPtr ct);
+// Computes the noise budget of a BFV/BGV ciphertext in bits using the
+// secret key.
+PKEErr Ciphertext_NoiseBudget(CryptoContextPtr cc, KeyPairPtr keys,
+                              CiphertextPtr ct, double *out);
 #ifdef __cplusplus
 }
 #endif
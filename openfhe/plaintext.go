@@ -4,6 +4,7 @@ package openfhe
 #cgo CPPFLAGS: -I${SRCDIR}/../openfhe-install/include -I${SRCDIR}/../openfhe-install/include/openfhe -I${SRCDIR}/../openfhe-install/include/openfhe/core -I${SRCDIR}/../openfhe-install/include/openfhe/pke -I${SRCDIR}/../openfhe-install/include/openfhe/binfhe -I${SRCDIR}/../openfhe-install/include/openfhe/cereal
 #cgo CXXFLAGS: -std=c++17
 #include <stdint.h>
+#include <stdlib.h>
 #include "pke_common_c.h"
 #include "ckks_c.h"
 #include "bgv_c.h"
@@ -12,112 +13,226 @@ import "C"
 
 import (
 	"errors"
+	"unsafe"
 )
 
+// GetPackedValue returns the slot values of a BFV/BGV plaintext.
 func (pt *Plaintext) GetPackedValue() ([]int64, error) {
-	if pt.ptr == nil {
-		return nil, errors.New("Plaintext is closed or invalid")
-	}
+	return pt.DecodePackedInto(nil)
+}
 
-	var lengthC C.int
+// GetRealPackedValue returns the real parts of the slots of a CKKS
+// plaintext.
+func (pt *Plaintext) GetRealPackedValue() ([]float64, error) {
+	return pt.DecodeInto(nil)
+}
 
-	status := C.Plaintext_GetPackedValueLength(pt.ptr, &lengthC)
-	err := checkPKEErrorMsg(status)
-	if err != nil {
-		return nil, err
+// GetComplexPackedValue returns the slots of a CKKS plaintext.
+func (pt *Plaintext) GetComplexPackedValue() ([]complex128, error) {
+	return pt.DecodeComplexInto(nil)
+}
+
+// GetCoefPackedValue returns the coefficients of a coefficient-packed
+// plaintext.
+func (pt *Plaintext) GetCoefPackedValue() ([]int64, error) {
+	return pt.decodeInt64Into(nil, copyCoefPacked)
+}
+
+// DecodePackedInto writes the slot values of a BFV/BGV plaintext into dst,
+// reusing its capacity, and returns the filled slice. Values are copied in
+// a single cgo call; dst is grown only if it is too small.
+func (pt *Plaintext) DecodePackedInto(dst []int64) ([]int64, error) {
+	if pt.sim != nil && !pt.closed() {
+		return pt.sim.decodeInts(dst)
 	}
+	return pt.decodeInt64Into(dst, copyPacked)
+}
 
-	length := int(lengthC)
-	if length == 0 {
-		return nil, nil // Empty vector
+// DecodeInto writes the real slot values of a CKKS plaintext into dst,
+// reusing its capacity, and returns the filled slice.
+func (pt *Plaintext) DecodeInto(dst []float64) ([]float64, error) {
+	if pt.sim != nil && !pt.closed() {
+		return pt.sim.decodeReal(dst)
+	}
+	if !pt.acquire() {
+		return nil, errors.New("Plaintext is closed or invalid")
 	}
+	defer pt.release()
 
-	goSlice := make([]int64, length)
-	for i := 0; i < length; i++ {
-		var valC C.int64_t
-		status = C.Plaintext_GetPackedValueAt(pt.ptr, C.int(i), &valC)
-		err := checkPKEErrorMsg(status)
-		if err != nil {
+	dst = dst[:cap(dst)]
+	for {
+		var buf *C.double
+		if len(dst) > 0 {
+			buf = (*C.double)(unsafe.Pointer(&dst[0]))
+		}
+		var lengthC C.int
+		status := C.Plaintext_CopyRealPackedValue(pt.ptr, buf, C.int(len(dst)), &lengthC)
+		if err := checkPKEErrorMsg(status); err != nil {
 			return nil, err
 		}
-		goSlice[i] = int64(valC)
+		length := int(lengthC)
+		if length <= len(dst) {
+			if length == 0 {
+				return nil, nil // Empty vector
+			}
+			return dst[:length], nil
+		}
+		dst = make([]float64, length)
 	}
-
-	return goSlice, nil
 }
 
-func (pt *Plaintext) GetRealPackedValue() ([]float64, error) {
-	if pt.ptr == nil {
+// DecodeComplexInto writes the slots of a CKKS plaintext into dst, reusing
+// its capacity, and returns the filled slice.
+func (pt *Plaintext) DecodeComplexInto(dst []complex128) ([]complex128, error) {
+	if pt.sim != nil && !pt.closed() {
+		return pt.sim.decodeComplex(dst)
+	}
+	if !pt.acquire() {
 		return nil, errors.New("Plaintext is closed or invalid")
 	}
+	defer pt.release()
+
+	dst = dst[:cap(dst)]
+	for {
+		// complex128 has the same layout as complex_double_t.
+		var buf *C.complex_double_t
+		if len(dst) > 0 {
+			buf = (*C.complex_double_t)(unsafe.Pointer(&dst[0]))
+		}
+		var lengthC C.int
+		status := C.Plaintext_CopyComplexPackedValue(pt.ptr, buf, C.int(len(dst)), &lengthC)
+		if err := checkPKEErrorMsg(status); err != nil {
+			return nil, err
+		}
+		length := int(lengthC)
+		if length <= len(dst) {
+			if length == 0 {
+				return nil, nil // Empty vector
+			}
+			return dst[:length], nil
+		}
+		dst = make([]complex128, length)
+	}
+}
 
-	var lengthC C.int
+type int64Copier func(pt C.PlaintextPtr, dst *C.int64_t, cap C.int, outLen *C.int) C.PKEErr
 
-	status := C.Plaintext_GetRealPackedValueLength(pt.ptr, &lengthC)
-	err := checkPKEErrorMsg(status)
-	if err != nil {
-		return nil, err
-	}
+func copyPacked(pt C.PlaintextPtr, dst *C.int64_t, cap C.int, outLen *C.int) C.PKEErr {
+	return C.Plaintext_CopyPackedValue(pt, dst, cap, outLen)
+}
 
-	length := int(lengthC)
-	if length == 0 {
-		return nil, nil // Empty vector
-	}
+func copyCoefPacked(pt C.PlaintextPtr, dst *C.int64_t, cap C.int, outLen *C.int) C.PKEErr {
+	return C.Plaintext_CopyCoefPackedValue(pt, dst, cap, outLen)
+}
 
-	goSlice := make([]float64, length)
-	for i := 0; i < length; i++ {
-		var valC C.double
+func (pt *Plaintext) decodeInt64Into(dst []int64, copyFn int64Copier) ([]int64, error) {
+	if !pt.acquire() {
+		return nil, errors.New("Plaintext is closed or invalid")
+	}
+	defer pt.release()
 
-		status = C.Plaintext_GetRealPackedValueAt(pt.ptr, C.int(i), &valC)
-		err := checkPKEErrorMsg(status)
-		if err != nil {
+	dst = dst[:cap(dst)]
+	for {
+		var buf *C.int64_t
+		if len(dst) > 0 {
+			buf = (*C.int64_t)(unsafe.Pointer(&dst[0]))
+		}
+		var lengthC C.int
+		if err := checkPKEErrorMsg(copyFn(pt.ptr, buf, C.int(len(dst)), &lengthC)); err != nil {
 			return nil, err
 		}
-
-		goSlice[i] = float64(valC)
+		length := int(lengthC)
+		if length <= len(dst) {
+			if length == 0 {
+				return nil, nil // Empty vector
+			}
+			return dst[:length], nil
+		}
+		dst = make([]int64, length)
 	}
-
-	return goSlice, nil
 }
 
-func (pt *Plaintext) GetComplexPackedValue() ([]complex128, error) {
-	if pt.ptr == nil {
-		return nil, errors.New("Plaintext is closed or invalid")
+// MakeCoefPackedPlaintext encodes vec directly into the polynomial
+// coefficients rather than into slots. Rotations and slot-wise operations do
+// not apply; multiplication is polynomial multiplication mod X^N+1.
+func (cc *CryptoContext) MakeCoefPackedPlaintext(vec []int64) (*Plaintext, error) {
+	if !cc.acquire() {
+		return nil, errors.New("CryptoContext is closed or invalid")
 	}
-
-	var lengthC C.int
-
-	status := C.Plaintext_GetComplexPackedValueLength(pt.ptr, &lengthC)
+	defer cc.release()
+	if len(vec) == 0 {
+		return nil, errors.New("MakeCoefPackedPlaintext: input vector is empty")
+	}
+	cVec := (*C.int64_t)(unsafe.Pointer(&vec[0]))
+	cLen := C.int(len(vec))
+	var ptH C.PlaintextPtr
+	status := C.CryptoContext_MakeCoefPackedPlaintext(cc.ptr, cVec, cLen, &ptH)
 	err := checkPKEErrorMsg(status)
 	if err != nil {
 		return nil, err
 	}
-
-	length := int(lengthC)
-	if length == 0 {
-		return nil, nil // Empty vector
+	if ptH == nil {
+		return nil, errors.New("MakeCoefPackedPlaintext returned OK but null handle")
 	}
+	return &Plaintext{ptr: ptH}, nil
+}
 
-	goSlice := make([]complex128, length)
-	var valC C.complex_double_t
-
-	for i := 0; i < length; i++ {
-		status = C.Plaintext_GetComplexPackedValueAt(pt.ptr, C.int(i), &valC)
-		err := checkPKEErrorMsg(status)
-		if err != nil {
-			return nil, err
-		}
+// MakeStringPlaintext encodes s one byte per coefficient, so it must be
+// no longer than the ring dimension and the plaintext modulus must be at
+// least 256.
+func (cc *CryptoContext) MakeStringPlaintext(s string) (*Plaintext, error) {
+	if !cc.acquire() {
+		return nil, errors.New("CryptoContext is closed or invalid")
+	}
+	defer cc.release()
+	if len(s) == 0 {
+		return nil, errors.New("MakeStringPlaintext: input string is empty")
+	}
+	cStr := C.CString(s)
+	defer C.free(unsafe.Pointer(cStr))
+	var ptH C.PlaintextPtr
+	status := C.CryptoContext_MakeStringPlaintext(cc.ptr, cStr, C.int(len(s)), &ptH)
+	err := checkPKEErrorMsg(status)
+	if err != nil {
+		return nil, err
+	}
+	if ptH == nil {
+		return nil, errors.New("MakeStringPlaintext returned OK but null handle")
+	}
+	return &Plaintext{ptr: ptH}, nil
+}
 
-		goSlice[i] = complex(float64(valC.real), float64(valC.imag))
+// GetStringValue returns the string held by a plaintext created with
+// MakeStringPlaintext. Call SetLength first after decryption to drop the
+// trailing zero coefficients.
+func (pt *Plaintext) GetStringValue() (string, error) {
+	if !pt.acquire() {
+		return "", errors.New("Plaintext is closed or invalid")
 	}
+	defer pt.release()
 
-	return goSlice, nil
+	var cData *C.char
+	var cLen C.size_t
+	status := C.Plaintext_GetStringValue(pt.ptr, &cData, &cLen)
+	err := checkPKEErrorMsg(status)
+	if err != nil {
+		return "", err
+	}
+	if cData == nil {
+		return "", nil
+	}
+	defer C.FreeString(cData)
+	return C.GoStringN(cData, C.int(cLen)), nil
 }
 
 func (pt *Plaintext) SetLength(len int) error {
-	if pt.ptr == nil {
+	if pt.sim != nil && !pt.closed() {
+		return pt.sim.setLength(len)
+	}
+	if !pt.acquire() {
 		return errors.New("Plaintext is closed or invalid")
 	}
+	defer pt.release()
 
 	status := C.Plaintext_SetLength(pt.ptr, C.int(len))
 	err := checkPKEErrorMsg(status)
@@ -130,7 +245,7 @@ func (pt *Plaintext) SetLength(len int) error {
 
 // Close frees the underlying C++ Plaintext object.
 func (pt *Plaintext) Close() {
-	if pt.ptr != nil {
+	if pt.ref.close() && pt.ptr != nil {
 		C.DestroyPlaintext(pt.ptr)
 		pt.ptr = nil
 	}
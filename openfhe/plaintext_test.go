@@ -0,0 +1,216 @@
+package openfhe
+
+import (
+	"math/cmplx"
+	"testing"
+)
+
+func TestBFVCoefPackedMult(t *testing.T) {
+	cc, keys := setupBFVContextAndKeys(t)
+	defer cc.Close()
+	defer keys.Close()
+
+	// (1 + 2x + 3x^2) * (1 + x) = 1 + 3x + 5x^2 + 3x^3
+	pt1, err := cc.MakeCoefPackedPlaintext([]int64{1, 2, 3})
+	mustT(t, err, "MakeCoefPackedPlaintext")
+	defer pt1.Close()
+	pt2, err := cc.MakeCoefPackedPlaintext([]int64{1, 1})
+	mustT(t, err, "MakeCoefPackedPlaintext")
+	defer pt2.Close()
+
+	ct1, err := cc.Encrypt(keys, pt1)
+	mustT(t, err, "Encrypt")
+	defer ct1.Close()
+	ct2, err := cc.Encrypt(keys, pt2)
+	mustT(t, err, "Encrypt")
+	defer ct2.Close()
+
+	ctMult, err := cc.EvalMult(ct1, ct2)
+	mustT(t, err, "EvalMult")
+	defer ctMult.Close()
+
+	ptDec, err := cc.Decrypt(keys, ctMult)
+	mustT(t, err, "Decrypt")
+	defer ptDec.Close()
+
+	result, err := ptDec.GetCoefPackedValue()
+	mustT(t, err, "GetCoefPackedValue")
+
+	expected := []int64{1, 3, 5, 3}
+	if len(result) < len(expected) || !slicesEqual(result[:len(expected)], expected) {
+		t.Errorf("coefficient product mismatch. Expected %v, Got %v", expected, result)
+	}
+	for i := len(expected); i < len(result); i++ {
+		if result[i] != 0 {
+			t.Fatalf("coefficient %d = %d, expected 0", i, result[i])
+		}
+	}
+}
+
+func TestBFVStringPlaintext(t *testing.T) {
+	cc, keys := setupBFVContextAndKeys(t)
+	defer cc.Close()
+	defer keys.Close()
+
+	msg := "Hello, OpenFHE!"
+	pt, err := cc.MakeStringPlaintext(msg)
+	mustT(t, err, "MakeStringPlaintext")
+	defer pt.Close()
+
+	direct, err := pt.GetStringValue()
+	mustT(t, err, "GetStringValue")
+	if direct != msg {
+		t.Errorf("GetStringValue before encryption = %q, expected %q", direct, msg)
+	}
+
+	ct, err := cc.Encrypt(keys, pt)
+	mustT(t, err, "Encrypt")
+	defer ct.Close()
+
+	ptDec, err := cc.Decrypt(keys, ct)
+	mustT(t, err, "Decrypt")
+	defer ptDec.Close()
+	mustT(t, ptDec.SetLength(len(msg)), "SetLength")
+
+	got, err := ptDec.GetStringValue()
+	mustT(t, err, "GetStringValue")
+	if got != msg {
+		t.Errorf("string round trip = %q, expected %q", got, msg)
+	}
+
+	if _, err := cc.MakeStringPlaintext(""); err == nil {
+		t.Error("expected error for empty string")
+	}
+}
+
+func TestCKKSPlaintextAtLevel(t *testing.T) {
+	cc, keys := setupCKKSContextAndKeys(t)
+	defer cc.Close()
+	defer keys.Close()
+
+	vals := []float64{0.5, 1.0, 1.5, 2.0}
+	pt, err := cc.MakeCKKSPackedPlaintextWithParams(vals, 1, 1, 0)
+	mustT(t, err, "MakeCKKSPackedPlaintextWithParams")
+	defer pt.Close()
+
+	ct, err := cc.Encrypt(keys, pt)
+	mustT(t, err, "Encrypt")
+	defer ct.Close()
+
+	if level, ok := ct.GetLevel(); !ok || level != 1 {
+		t.Errorf("ciphertext level = %d, expected 1", level)
+	}
+
+	ptDec, err := cc.Decrypt(keys, ct)
+	mustT(t, err, "Decrypt")
+	defer ptDec.Close()
+
+	result, err := ptDec.GetRealPackedValue()
+	mustT(t, err, "GetRealPackedValue")
+	if !slicesApproxEqual(result[:len(vals)], vals, 0.0001) {
+		t.Errorf("level-1 round trip mismatch. Expected ~%v, Got %v", vals, result[:len(vals)])
+	}
+}
+
+func TestCKKSComplexPlaintextWithParams(t *testing.T) {
+	cc, keys := setupCKKSContextAndKeys(t)
+	defer cc.Close()
+	defer keys.Close()
+
+	vec := []complex128{complex(1, -1), complex(0.25, 2), complex(-3, 0.5), complex(0, 0)}
+	pt, err := cc.MakeCKKSComplexPackedPlaintextWithParams(vec, 1, 0, 4)
+	mustT(t, err, "MakeCKKSComplexPackedPlaintextWithParams")
+	defer pt.Close()
+
+	ct, err := cc.Encrypt(keys, pt)
+	mustT(t, err, "Encrypt")
+	defer ct.Close()
+
+	ptDec, err := cc.Decrypt(keys, ct)
+	mustT(t, err, "Decrypt")
+	defer ptDec.Close()
+
+	result, err := ptDec.GetComplexPackedValue()
+	mustT(t, err, "GetComplexPackedValue")
+	if len(result) < len(vec) {
+		t.Fatalf("decrypted %d slots, expected at least %d", len(result), len(vec))
+	}
+	for i, want := range vec {
+		if cmplx.Abs(result[i]-want) > 0.0001 {
+			t.Errorf("slot %d = %v, expected ~%v", i, result[i], want)
+		}
+	}
+
+	if _, err := cc.MakeCKKSComplexPackedPlaintextWithParams(nil, 1, 0, 0); err == nil {
+		t.Error("expected error for empty vector")
+	}
+}
+
+func TestDecodeIntoReusesBuffer(t *testing.T) {
+	cc, keys := setupBFVContextAndKeys(t)
+	defer cc.Close()
+	defer keys.Close()
+
+	vec := []int64{3, 1, 4, 1, 5, 9, 2, 6}
+	pt, err := cc.MakePackedPlaintext(vec)
+	mustT(t, err, "MakePackedPlaintext")
+	defer pt.Close()
+
+	want, err := pt.GetPackedValue()
+	mustT(t, err, "GetPackedValue")
+
+	// A buffer that is too small is replaced; a large enough one is reused.
+	small := make([]int64, 2)
+	got, err := pt.DecodePackedInto(small)
+	mustT(t, err, "DecodePackedInto")
+	if !slicesEqual(got, want) {
+		t.Fatalf("DecodePackedInto mismatch. Expected %v, Got %v", want, got)
+	}
+
+	buf := make([]int64, 0, len(want)+10)
+	got, err = pt.DecodePackedInto(buf)
+	mustT(t, err, "DecodePackedInto")
+	if !slicesEqual(got, want) {
+		t.Fatalf("DecodePackedInto mismatch. Expected %v, Got %v", want, got)
+	}
+	if &got[0] != &buf[:1][0] {
+		t.Error("DecodePackedInto did not reuse a large enough buffer")
+	}
+}
+
+func TestCKKSDecodeInto(t *testing.T) {
+	cc, keys := setupCKKSContextAndKeys(t)
+	defer cc.Close()
+	defer keys.Close()
+
+	vals := []float64{1.0, 2.0, 3.0, 4.0, 5.0, 6.0, 7.0, 8.0}
+	pt, err := cc.MakeCKKSPackedPlaintext(vals)
+	mustT(t, err, "MakeCKKSPackedPlaintext")
+	defer pt.Close()
+
+	ct, err := cc.Encrypt(keys, pt)
+	mustT(t, err, "Encrypt")
+	defer ct.Close()
+
+	ptDec, err := cc.Decrypt(keys, ct)
+	mustT(t, err, "Decrypt")
+	defer ptDec.Close()
+
+	buf := make([]float64, 64)
+	got, err := ptDec.DecodeInto(buf)
+	mustT(t, err, "DecodeInto")
+	if !slicesApproxEqual(got[:len(vals)], vals, 0.0001) {
+		t.Errorf("DecodeInto mismatch. Expected ~%v, Got %v", vals, got[:len(vals)])
+	}
+
+	cbuf, err := ptDec.DecodeComplexInto(nil)
+	mustT(t, err, "DecodeComplexInto")
+	if len(cbuf) != len(got) {
+		t.Fatalf("DecodeComplexInto returned %d slots, DecodeInto %d", len(cbuf), len(got))
+	}
+	for i := range got {
+		if real(cbuf[i]) != got[i] {
+			t.Errorf("slot %d: complex real part %v != %v", i, real(cbuf[i]), got[i])
+		}
+	}
+}
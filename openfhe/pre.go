@@ -11,7 +11,7 @@ import "C"
 
 import (
 	"errors"
-	"unsafe"
+	"fmt"
 )
 
 // EvalKey represents a re-encryption key used in Proxy Re-Encryption (PRE).
@@ -19,11 +19,13 @@ import (
 // under another key without decryption.
 type EvalKey struct {
 	ptr C.EvalKeyPtr
+	ref ref
 }
 
-// Close frees the underlying C++ EvalKey object.
+// Close frees the underlying C++ EvalKey object once the calls using it
+// have returned.
 func (ek *EvalKey) Close() {
-	if ek.ptr != nil {
+	if ek.ref.close() && ek.ptr != nil {
 		C.DestroyEvalKey(ek.ptr)
 		ek.ptr = nil
 	}
@@ -39,7 +41,8 @@ func (ek *EvalKey) Close() {
 //
 // Parameters:
 //   - oldKeys: KeyPair containing the old private key
-//   - newKeys: KeyPair containing the new public key
+//   - newKeys: KeyPair containing the new public key. Only the public key is
+//     used, so a KeyPair from DeserializePublicKeyFromBytes is sufficient.
 //
 // Returns:
 //   - *EvalKey: The re-encryption key
@@ -55,41 +58,22 @@ func (ek *EvalKey) Close() {
 //	reencryptionKey, _ := cc.ReKeyGen(aliceKeys, bobKeys)
 //	defer reencryptionKey.Close()
 func (cc *CryptoContext) ReKeyGen(oldKeys, newKeys *KeyPair) (*EvalKey, error) {
-	if cc.ptr == nil {
+	if !cc.acquire() {
 		return nil, errors.New("CryptoContext is closed or invalid")
 	}
-	if oldKeys == nil || oldKeys.ptr == nil {
+	defer cc.release()
+	if !oldKeys.acquire() {
 		return nil, errors.New("oldKeys KeyPair is closed or invalid")
 	}
-	if newKeys == nil || newKeys.ptr == nil {
+	defer oldKeys.release()
+	if !newKeys.acquire() {
 		return nil, errors.New("newKeys KeyPair is closed or invalid")
 	}
-
-	// Get the private key from oldKeys
-	var oldSK unsafe.Pointer
-	status := C.GetPrivateKey(oldKeys.ptr, &oldSK)
-	err := checkPKEErrorMsg(status)
-	if err != nil {
-		return nil, err
-	}
-	if oldSK == nil {
-		return nil, errors.New("oldKeys has no private key")
-	}
-
-	// Get the public key from newKeys
-	var newPK unsafe.Pointer
-	status = C.GetPublicKey(newKeys.ptr, &newPK)
-	err = checkPKEErrorMsg(status)
-	if err != nil {
-		return nil, err
-	}
-	if newPK == nil {
-		return nil, errors.New("newKeys has no public key")
-	}
+	defer newKeys.release()
 
 	var ekH C.EvalKeyPtr
-	status = C.CryptoContext_ReKeyGen(cc.ptr, oldSK, newPK, &ekH)
-	err = checkPKEErrorMsg(status)
+	status := C.CryptoContext_ReKeyGen(cc.ptr, oldKeys.ptr, newKeys.ptr, &ekH)
+	err := checkPKEErrorMsg(status)
 	if err != nil {
 		return nil, err
 	}
@@ -102,6 +86,19 @@ func (cc *CryptoContext) ReKeyGen(oldKeys, newKeys *KeyPair) (*EvalKey, error) {
 	return ek, nil
 }
 
+// ReKeyGenFromPublicKeyBytes generates a re-encryption key from the private
+// key in oldKeys to a recipient public key serialized with
+// SerializePublicKeyToBytes. This is the usual flow when the recipient only
+// ships their public key.
+func (cc *CryptoContext) ReKeyGenFromPublicKeyBytes(oldKeys *KeyPair, newPublicKey []byte) (*EvalKey, error) {
+	newKeys := DeserializePublicKeyFromBytes(newPublicKey)
+	if newKeys == nil {
+		return nil, errors.New("ReKeyGenFromPublicKeyBytes: public key deserialization failed")
+	}
+	defer newKeys.Close()
+	return cc.ReKeyGen(oldKeys, newKeys)
+}
+
 // ReEncrypt transforms a ciphertext encrypted under one key to be encrypted
 // under another key using the re-encryption key from ReKeyGen.
 //
@@ -112,6 +109,9 @@ func (cc *CryptoContext) ReKeyGen(oldKeys, newKeys *KeyPair) (*EvalKey, error) {
 // Parameters:
 //   - ct: The ciphertext to re-encrypt
 //   - evalKey: The re-encryption key generated by ReKeyGen
+//   - publicKey: Optional. The KeyPair holding the public key ct is currently
+//     encrypted under. Required by the HRA-secure modes (FIXED_NOISE_HRA,
+//     NOISE_FLOODING_HRA), which use it to re-randomize the result.
 //
 // Returns:
 //   - *Ciphertext: The re-encrypted ciphertext
@@ -125,19 +125,34 @@ func (cc *CryptoContext) ReKeyGen(oldKeys, newKeys *KeyPair) (*EvalKey, error) {
 //	reencryptedCt, _ := cc.ReEncrypt(ct, reencryptionKey)
 //	// Now Bob can decrypt with his private key
 //	result, _ := cc.Decrypt(bobKeys, reencryptedCt)
-func (cc *CryptoContext) ReEncrypt(ct *Ciphertext, evalKey *EvalKey) (*Ciphertext, error) {
-	if cc.ptr == nil {
+func (cc *CryptoContext) ReEncrypt(ct *Ciphertext, evalKey *EvalKey, publicKey ...*KeyPair) (*Ciphertext, error) {
+	if !cc.acquire() {
 		return nil, errors.New("CryptoContext is closed or invalid")
 	}
-	if ct == nil || ct.ptr == nil {
+	defer cc.release()
+	if !ct.acquire() {
 		return nil, errors.New("Ciphertext is closed or invalid")
 	}
-	if evalKey == nil || evalKey.ptr == nil {
+	defer ct.release()
+	if !evalKey.acquire() {
 		return nil, errors.New("EvalKey is closed or invalid")
 	}
+	defer evalKey.release()
+	if len(publicKey) > 1 {
+		return nil, errors.New("ReEncrypt takes at most one public key")
+	}
+
+	var senderKeys C.KeyPairPtr
+	if len(publicKey) == 1 {
+		if !publicKey[0].acquire() {
+			return nil, errors.New("public KeyPair is closed or invalid")
+		}
+		defer publicKey[0].release()
+		senderKeys = publicKey[0].ptr
+	}
 
 	var ctH C.CiphertextPtr
-	status := C.CryptoContext_ReEncrypt(cc.ptr, ct.ptr, evalKey.ptr, &ctH)
+	status := C.CryptoContext_ReEncrypt(cc.ptr, ct.ptr, evalKey.ptr, senderKeys, &ctH)
 	err := checkPKEErrorMsg(status)
 	if err != nil {
 		return nil, err
@@ -150,3 +165,72 @@ func (cc *CryptoContext) ReEncrypt(ct *Ciphertext, evalKey *EvalKey) (*Ciphertex
 	reencryptedCt := &Ciphertext{ptr: ctH}
 	return reencryptedCt, nil
 }
+
+// PREHop is one step of a re-encryption chain.
+type PREHop struct {
+	// EvalKey re-encrypts from the current recipient to the next one.
+	EvalKey *EvalKey
+	// PublicKey is the current recipient's key pair. It may be nil in INDCPA
+	// mode and is required in the HRA-secure modes.
+	PublicKey *KeyPair
+}
+
+// ReEncryptChain re-encrypts ct along a chain of recipients, e.g.
+// Alice -> Bob -> Carol, without decrypting in between.
+//
+// The chain is checked against the parameters before any work is done: in
+// the HRA-secure modes it may not be longer than SetPRENumHops allowed, and
+// in NOISE_FLOODING_HRA, where every hop consumes one RNS tower, each hop
+// verifies that modulus is left for it.
+//
+// Example:
+//
+//	ab, _ := cc.ReKeyGen(aliceKeys, bobKeys)
+//	bc, _ := cc.ReKeyGen(bobKeys, carolKeys)
+//	ctCarol, _ := cc.ReEncryptChain(ctAlice, []PREHop{
+//		{EvalKey: ab, PublicKey: aliceKeys},
+//		{EvalKey: bc, PublicKey: bobKeys},
+//	})
+func (cc *CryptoContext) ReEncryptChain(ct *Ciphertext, hops []PREHop) (*Ciphertext, error) {
+	if !cc.acquire() {
+		return nil, errors.New("CryptoContext is closed or invalid")
+	}
+	defer cc.release()
+	if !ct.acquire() {
+		return nil, errors.New("Ciphertext is closed or invalid")
+	}
+	defer ct.release()
+	if len(hops) == 0 {
+		return nil, errors.New("ReEncryptChain: no hops")
+	}
+
+	evalKeys := make([]C.EvalKeyPtr, len(hops))
+	senderKeys := make([]C.KeyPairPtr, len(hops))
+	for i, hop := range hops {
+		if !hop.EvalKey.acquire() {
+			return nil, fmt.Errorf("ReEncryptChain: EvalKey of hop %d is closed or invalid", i)
+		}
+		defer hop.EvalKey.release()
+		evalKeys[i] = hop.EvalKey.ptr
+		if hop.PublicKey != nil {
+			if !hop.PublicKey.acquire() {
+				return nil, fmt.Errorf("ReEncryptChain: PublicKey of hop %d is closed or invalid", i)
+			}
+			defer hop.PublicKey.release()
+			senderKeys[i] = hop.PublicKey.ptr
+		}
+	}
+
+	var ctH C.CiphertextPtr
+	status := C.CryptoContext_ReEncryptChain(cc.ptr, ct.ptr, &evalKeys[0], &senderKeys[0], C.int(len(hops)), &ctH)
+	err := checkPKEErrorMsg(status)
+	if err != nil {
+		return nil, err
+	}
+
+	if ctH == nil {
+		return nil, errors.New("ReEncryptChain returned OK but null handle")
+	}
+
+	return &Ciphertext{ptr: ctH}, nil
+}
@@ -15,16 +15,16 @@ extern "C" {
 // --- PRE (Proxy Re-Encryption) Functions ---
 
 PKEErr CryptoContext_ReKeyGen(CryptoContextPtr cc_ptr_to_sptr,
-                              void *oldPrivateKey, void *newPublicKey,
+                              KeyPairPtr oldKeys, KeyPairPtr newKeys,
                               EvalKeyPtr *out) {
   try {
     if (!cc_ptr_to_sptr) {
       return MakePKEError("CryptoContext_ReKeyGen: null context");
     }
-    if (!oldPrivateKey) {
+    if (!oldKeys || !reinterpret_cast<KeyPairRawPtr>(oldKeys)->secretKey) {
       return MakePKEError("CryptoContext_ReKeyGen: null old private key");
     }
-    if (!newPublicKey) {
+    if (!newKeys || !reinterpret_cast<KeyPairRawPtr>(newKeys)->publicKey) {
       return MakePKEError("CryptoContext_ReKeyGen: null new public key");
     }
     if (!out) {
@@ -32,8 +32,8 @@ PKEErr CryptoContext_ReKeyGen(CryptoContextPtr cc_ptr_to_sptr,
     }
 
     auto &cc_sptr = GetCCSharedPtr(cc_ptr_to_sptr);
-    auto &oldSK_sptr = GetSKSharedPtr(oldPrivateKey);
-    auto &newPK_sptr = GetPKSharedPtr(newPublicKey);
+    auto &oldSK_sptr = reinterpret_cast<KeyPairRawPtr>(oldKeys)->secretKey;
+    auto &newPK_sptr = reinterpret_cast<KeyPairRawPtr>(newKeys)->publicKey;
 
     // Generate re-encryption key
     EvalKey<DCRTPoly> reencryptionKey =
@@ -51,7 +51,7 @@ PKEErr CryptoContext_ReKeyGen(CryptoContextPtr cc_ptr_to_sptr,
 
 PKEErr CryptoContext_ReEncrypt(CryptoContextPtr cc_ptr_to_sptr,
                                CiphertextPtr ciphertext, EvalKeyPtr evalKey,
-                               CiphertextPtr *out) {
+                               KeyPairPtr senderKeys, CiphertextPtr *out) {
   try {
     if (!cc_ptr_to_sptr) {
       return MakePKEError("CryptoContext_ReEncrypt: null context");
@@ -70,8 +70,14 @@ PKEErr CryptoContext_ReEncrypt(CryptoContextPtr cc_ptr_to_sptr,
     auto &ct_sptr = GetCTSharedPtr(ciphertext);
     auto &ek_sptr = GetEKSharedPtr(evalKey);
 
+    PublicKey<DCRTPoly> pk_sptr = nullptr;
+    if (senderKeys) {
+      pk_sptr = reinterpret_cast<KeyPairRawPtr>(senderKeys)->publicKey;
+    }
+
     // Re-encrypt the ciphertext
-    Ciphertext<DCRTPoly> result_ct_sptr = cc_sptr->ReEncrypt(ct_sptr, ek_sptr);
+    Ciphertext<DCRTPoly> result_ct_sptr =
+        cc_sptr->ReEncrypt(ct_sptr, ek_sptr, pk_sptr);
 
     if (!result_ct_sptr) {
       return MakePKEError(
@@ -85,6 +91,127 @@ PKEErr CryptoContext_ReEncrypt(CryptoContextPtr cc_ptr_to_sptr,
   PKE_CATCH_RETURN()
 }
 
+PKEErr CryptoContext_ReEncryptChain(CryptoContextPtr cc_ptr_to_sptr,
+                                    CiphertextPtr ciphertext,
+                                    EvalKeyPtr *evalKeys, KeyPairPtr *senderKeys,
+                                    int numHops, CiphertextPtr *out) {
+  try {
+    if (!cc_ptr_to_sptr) {
+      return MakePKEError("CryptoContext_ReEncryptChain: null context");
+    }
+    if (!ciphertext) {
+      return MakePKEError("CryptoContext_ReEncryptChain: null ciphertext");
+    }
+    if (!evalKeys || numHops <= 0) {
+      return MakePKEError("CryptoContext_ReEncryptChain: no hops");
+    }
+    if (!out) {
+      return MakePKEError("CryptoContext_ReEncryptChain: null output pointer");
+    }
+
+    auto &cc_sptr = GetCCSharedPtr(cc_ptr_to_sptr);
+    auto params = std::dynamic_pointer_cast<CryptoParametersRNS>(
+        cc_sptr->GetCryptoParameters());
+    ProxyReEncryptionMode mode = params ? params->GetPREMode() : INDCPA;
+    bool hra = mode == FIXED_NOISE_HRA || mode == NOISE_FLOODING_HRA;
+
+    if (hra && params->GetPRENumHops() > 0 &&
+        static_cast<uint32_t>(numHops) > params->GetPRENumHops()) {
+      return MakePKEError("CryptoContext_ReEncryptChain: chain of " +
+                          std::to_string(numHops) + " hops exceeds the " +
+                          std::to_string(params->GetPRENumHops()) +
+                          " hops the parameters support");
+    }
+
+    Ciphertext<DCRTPoly> current = GetCTSharedPtr(ciphertext);
+    for (int i = 0; i < numHops; i++) {
+      std::string hop = "hop " + std::to_string(i);
+      if (!evalKeys[i]) {
+        return MakePKEError("CryptoContext_ReEncryptChain: null eval key at " +
+                            hop);
+      }
+      PublicKey<DCRTPoly> pk_sptr = nullptr;
+      if (senderKeys && senderKeys[i]) {
+        pk_sptr = reinterpret_cast<KeyPairRawPtr>(senderKeys[i])->publicKey;
+      }
+      if (hra && !pk_sptr) {
+        return MakePKEError("CryptoContext_ReEncryptChain: HRA mode needs the "
+                            "sender's public key at " +
+                            hop);
+      }
+      // Noise flooding spends one RNS tower per hop.
+      if (mode == NOISE_FLOODING_HRA &&
+          current->GetElements()[0].GetNumOfElements() < 2) {
+        return MakePKEError("CryptoContext_ReEncryptChain: no noise budget "
+                            "left for " +
+                            hop);
+      }
+
+      current = cc_sptr->ReEncrypt(current, GetEKSharedPtr(evalKeys[i]),
+                                   pk_sptr);
+      if (!current) {
+        return MakePKEError(
+            "CryptoContext_ReEncryptChain: ReEncrypt returned null at " + hop);
+      }
+      if (mode == NOISE_FLOODING_HRA) {
+        cc_sptr->ModReduceInPlace(current);
+      }
+    }
+
+    *out = reinterpret_cast<CiphertextPtr>(new CiphertextSharedPtr(current));
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
+// --- EvalKey Serialization ---
+PKEErr SerializeEvalKeyToBytes(EvalKeyPtr ek, char **outBytes,
+                               size_t *outLen) {
+  try {
+    if (!ek || !GetEKSharedPtr(ek)) {
+      return MakePKEError("SerializeEvalKeyToBytes: null eval key");
+    }
+    if (!outBytes || !outLen) {
+      return MakePKEError("SerializeEvalKeyToBytes: null output pointer");
+    }
+
+    std::stringstream ss;
+    Serial::Serialize(GetEKSharedPtr(ek), ss, SerType::BINARY);
+    std::string s = ss.str();
+    *outBytes = CopyStringToC(s);
+    if (!*outBytes) {
+      return MakePKEError("SerializeEvalKeyToBytes: allocation failed");
+    }
+    *outLen = s.length();
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
+PKEErr DeserializeEvalKeyFromBytes(const char *inData, int inLen,
+                                   EvalKeyPtr *out) {
+  try {
+    if (!inData || inLen <= 0) {
+      return MakePKEError("DeserializeEvalKeyFromBytes: no data");
+    }
+    if (!out) {
+      return MakePKEError("DeserializeEvalKeyFromBytes: null output pointer");
+    }
+
+    EvalKey<DCRTPoly> ek;
+    std::string s(inData, inLen);
+    std::stringstream ss(s);
+    Serial::Deserialize(ek, ss, SerType::BINARY);
+    if (!ek) {
+      return MakePKEError("DeserializeEvalKeyFromBytes: null eval key");
+    }
+
+    *out = reinterpret_cast<EvalKeyPtr>(new EvalKeySharedPtr(ek));
+    return MakePKEOk();
+  }
+  PKE_CATCH_RETURN()
+}
+
 // --- EvalKey Management ---
 void DestroyEvalKey(EvalKeyPtr ek) {
   delete reinterpret_cast<EvalKeySharedPtr *>(ek);
@@ -12,16 +12,36 @@ typedef void *EvalKeyPtr;
 
 // --- PRE (Proxy Re-Encryption) Functions ---
 
-// ReKeyGen generates a re-encryption key from oldPrivateKey to newPublicKey
+// ReKeyGen generates a re-encryption key from the private key in oldKeys to
+// the public key in newKeys. newKeys only needs to hold a public key.
 // This key allows transforming ciphertexts encrypted under oldPublicKey
 // to ciphertexts encrypted under newPublicKey without decryption.
-PKEErr CryptoContext_ReKeyGen(CryptoContextPtr cc, void *oldPrivateKey,
-                              void *newPublicKey, EvalKeyPtr *out);
+PKEErr CryptoContext_ReKeyGen(CryptoContextPtr cc, KeyPairPtr oldKeys,
+                              KeyPairPtr newKeys, EvalKeyPtr *out);
 
 // ReEncrypt transforms a ciphertext encrypted under one key to be encrypted
 // under another key using the re-encryption key from ReKeyGen.
+// senderKeys may be NULL; the HRA-secure modes require the key pair whose
+// public key the input ciphertext is encrypted under, to re-randomize it.
 PKEErr CryptoContext_ReEncrypt(CryptoContextPtr cc, CiphertextPtr ciphertext,
-                               EvalKeyPtr evalKey, CiphertextPtr *out);
+                               EvalKeyPtr evalKey, KeyPairPtr senderKeys,
+                               CiphertextPtr *out);
+
+// ReEncryptChain applies numHops re-encryptions in order. senderKeys may be
+// NULL, or hold NULL entries, in INDCPA mode. The chain is rejected up front
+// if it exceeds the hops the parameters were generated for, and each hop
+// checks that modulus is left for it.
+PKEErr CryptoContext_ReEncryptChain(CryptoContextPtr cc,
+                                    CiphertextPtr ciphertext,
+                                    EvalKeyPtr *evalKeys, KeyPairPtr *senderKeys,
+                                    int numHops, CiphertextPtr *out);
+
+// --- EvalKey Serialization ---
+// outBytes is malloc'd; release it with FreeString.
+PKEErr SerializeEvalKeyToBytes(EvalKeyPtr ek, char **outBytes,
+                               size_t *outLen);
+PKEErr DeserializeEvalKeyFromBytes(const char *inData, int inLen,
+                                   EvalKeyPtr *out);
 
 // --- EvalKey Management ---
 void DestroyEvalKey(EvalKeyPtr ek);
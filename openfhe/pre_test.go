@@ -1,6 +1,9 @@
 package openfhe
 
 import (
+	"bytes"
+	"encoding/binary"
+	"io"
 	"math"
 	"testing"
 )
@@ -368,3 +371,358 @@ func TestPRE_MultipleReencryptions(t *testing.T) {
 
 	t.Logf("PRE Multiple Reencryptions Test: Alice → Bob → Charlie successful")
 }
+
+// setupPREBGV creates a BGV context for proxy re-encryption in the given mode,
+// supporting numHops hops, with one key pair per party.
+func setupPREBGV(t *testing.T, mode int, numHops uint32, parties int) (*CryptoContext, []*KeyPair) {
+	t.Helper()
+
+	params, err := NewParamsBGVrns()
+	mustT(t, err, "NewParamsBGVrns")
+	defer params.Close()
+
+	mustT(t, params.SetPlaintextModulus(65537), "SetPlaintextModulus")
+	mustT(t, params.SetPREMode(mode), "SetPREMode")
+	mustT(t, params.SetKeySwitchTechnique(BV), "SetKeySwitchTechnique")
+	mustT(t, params.SetScalingTechnique(FIXEDMANUAL), "SetScalingTechnique")
+	mustT(t, params.SetMultiplicativeDepth(0), "SetMultiplicativeDepth")
+	if mode != INDCPA {
+		mustT(t, params.SetPRENumHops(numHops), "SetPRENumHops")
+	}
+	if mode == NOISE_FLOODING_HRA {
+		mustT(t, params.SetStatisticalSecurity(40), "SetStatisticalSecurity")
+		mustT(t, params.SetNumAdversarialQueries(1<<20), "SetNumAdversarialQueries")
+	}
+
+	cc, err := NewCryptoContextBGV(params)
+	mustT(t, err, "NewCryptoContextBGV")
+
+	mustT(t, cc.Enable(PKE), "Enable PKE")
+	mustT(t, cc.Enable(KEYSWITCH), "Enable KEYSWITCH")
+	mustT(t, cc.Enable(LEVELEDSHE), "Enable LEVELEDSHE")
+	mustT(t, cc.Enable(PRE), "Enable PRE")
+
+	keys := make([]*KeyPair, parties)
+	for i := range keys {
+		keys[i], err = cc.KeyGen()
+		mustT(t, err, "KeyGen")
+	}
+	return cc, keys
+}
+
+// preChain builds the re-encryption keys keys[0] -> keys[1] -> ... and the
+// matching hops.
+func preChain(t *testing.T, cc *CryptoContext, keys []*KeyPair) []PREHop {
+	t.Helper()
+	hops := make([]PREHop, len(keys)-1)
+	for i := range hops {
+		ek, err := cc.ReKeyGen(keys[i], keys[i+1])
+		mustT(t, err, "ReKeyGen")
+		t.Cleanup(ek.Close)
+		hops[i] = PREHop{EvalKey: ek, PublicKey: keys[i]}
+	}
+	return hops
+}
+
+func TestPRE_BGV_ChainModes(t *testing.T) {
+	modes := []struct {
+		name string
+		mode int
+	}{
+		{"INDCPA", INDCPA},
+		{"FIXED_NOISE_HRA", FIXED_NOISE_HRA},
+		{"NOISE_FLOODING_HRA", NOISE_FLOODING_HRA},
+	}
+	for _, m := range modes {
+		t.Run(m.name, func(t *testing.T) {
+			if m.mode == NOISE_FLOODING_HRA && testing.Short() {
+				t.Skip("skipping noise-flooding PRE in -short mode")
+			}
+
+			// Alice -> Bob -> Carol
+			cc, keys := setupPREBGV(t, m.mode, 2, 3)
+			defer cc.Close()
+			for _, kp := range keys {
+				defer kp.Close()
+			}
+			hops := preChain(t, cc, keys)
+
+			data := []int64{1, 2, 3, 4, 5, 6, 7, 8}
+			pt, err := cc.MakePackedPlaintext(data)
+			mustT(t, err, "MakePackedPlaintext")
+			defer pt.Close()
+			ct, err := cc.Encrypt(keys[0], pt)
+			mustT(t, err, "Encrypt")
+			defer ct.Close()
+
+			ctCarol, err := cc.ReEncryptChain(ct, hops)
+			mustT(t, err, "ReEncryptChain")
+			defer ctCarol.Close()
+
+			ptCarol, err := cc.Decrypt(keys[2], ctCarol)
+			mustT(t, err, "Decrypt by Carol")
+			defer ptCarol.Close()
+			got, err := ptCarol.GetPackedValue()
+			mustT(t, err, "GetPackedValue")
+			if !slicesEqual(got[:len(data)], data) {
+				t.Fatalf("Carol decryption mismatch.\nwant %v\ngot  %v", data, got[:len(data)])
+			}
+		})
+	}
+}
+
+func TestPRE_BGV_HRAReEncryptWithPublicKey(t *testing.T) {
+	cc, keys := setupPREBGV(t, FIXED_NOISE_HRA, 1, 2)
+	defer cc.Close()
+	defer keys[0].Close()
+	defer keys[1].Close()
+
+	ek, err := cc.ReKeyGen(keys[0], keys[1])
+	mustT(t, err, "ReKeyGen")
+	defer ek.Close()
+
+	data := []int64{42, 7, 0, 65536}
+	pt, err := cc.MakePackedPlaintext(data)
+	mustT(t, err, "MakePackedPlaintext")
+	defer pt.Close()
+	ct, err := cc.Encrypt(keys[0], pt)
+	mustT(t, err, "Encrypt")
+	defer ct.Close()
+
+	ctBob, err := cc.ReEncrypt(ct, ek, keys[0])
+	mustT(t, err, "ReEncrypt")
+	defer ctBob.Close()
+
+	ptBob, err := cc.Decrypt(keys[1], ctBob)
+	mustT(t, err, "Decrypt by Bob")
+	defer ptBob.Close()
+	got, err := ptBob.GetPackedValue()
+	mustT(t, err, "GetPackedValue")
+	if !slicesEqual(got[:len(data)], data) {
+		t.Fatalf("Bob decryption mismatch.\nwant %v\ngot  %v", data, got[:len(data)])
+	}
+
+	if _, err := cc.ReEncrypt(ct, ek, keys[0], keys[1]); err == nil {
+		t.Error("expected error for more than one public key")
+	}
+}
+
+func TestPRE_BGV_ChainChecks(t *testing.T) {
+	// Parameters for a single hop cannot carry Alice -> Bob -> Carol.
+	cc, keys := setupPREBGV(t, FIXED_NOISE_HRA, 1, 3)
+	defer cc.Close()
+	for _, kp := range keys {
+		defer kp.Close()
+	}
+	hops := preChain(t, cc, keys)
+
+	pt, err := cc.MakePackedPlaintext([]int64{1, 2, 3})
+	mustT(t, err, "MakePackedPlaintext")
+	defer pt.Close()
+	ct, err := cc.Encrypt(keys[0], pt)
+	mustT(t, err, "Encrypt")
+	defer ct.Close()
+
+	if _, err := cc.ReEncryptChain(ct, hops); err == nil {
+		t.Error("expected error for chain longer than the configured hops")
+	}
+
+	// HRA modes need the sender's public key at every hop.
+	noPK := []PREHop{{EvalKey: hops[0].EvalKey}}
+	if _, err := cc.ReEncryptChain(ct, noPK); err == nil {
+		t.Error("expected error for HRA hop without public key")
+	}
+
+	if _, err := cc.ReEncryptChain(ct, nil); err == nil {
+		t.Error("expected error for empty chain")
+	}
+	if _, err := cc.ReEncryptChain(ct, []PREHop{{EvalKey: &EvalKey{}}}); err == nil {
+		t.Error("expected error for closed eval key")
+	}
+}
+
+func TestPRE_CKKS_Chain(t *testing.T) {
+	params, err := NewParamsCKKSRNS()
+	mustT(t, err, "NewParamsCKKSRNS")
+	defer params.Close()
+
+	mustT(t, params.SetMultiplicativeDepth(1), "SetMultiplicativeDepth")
+	mustT(t, params.SetScalingModSize(50), "SetScalingModSize")
+	mustT(t, params.SetBatchSize(8), "SetBatchSize")
+	mustT(t, params.SetPREMode(INDCPA), "SetPREMode")
+
+	cc, err := NewCryptoContextCKKS(params)
+	mustT(t, err, "NewCryptoContextCKKS")
+	defer cc.Close()
+
+	mustT(t, cc.Enable(PKE), "Enable PKE")
+	mustT(t, cc.Enable(KEYSWITCH), "Enable KEYSWITCH")
+	mustT(t, cc.Enable(LEVELEDSHE), "Enable LEVELEDSHE")
+	mustT(t, cc.Enable(PRE), "Enable PRE")
+
+	keys := make([]*KeyPair, 3)
+	for i := range keys {
+		keys[i], err = cc.KeyGen()
+		mustT(t, err, "KeyGen")
+		defer keys[i].Close()
+	}
+	hops := preChain(t, cc, keys)
+
+	data := []float64{0.5, -1.25, 3.75, 2.0}
+	pt, err := cc.MakeCKKSPackedPlaintext(data)
+	mustT(t, err, "MakeCKKSPackedPlaintext")
+	defer pt.Close()
+	ct, err := cc.Encrypt(keys[0], pt)
+	mustT(t, err, "Encrypt")
+	defer ct.Close()
+
+	ctCarol, err := cc.ReEncryptChain(ct, hops)
+	mustT(t, err, "ReEncryptChain")
+	defer ctCarol.Close()
+
+	ptCarol, err := cc.Decrypt(keys[2], ctCarol)
+	mustT(t, err, "Decrypt by Carol")
+	defer ptCarol.Close()
+	mustT(t, ptCarol.SetLength(len(data)), "SetLength")
+	got, err := ptCarol.GetRealPackedValue()
+	mustT(t, err, "GetRealPackedValue")
+	if !slicesApproxEqual(got[:len(data)], data, 1e-4) {
+		t.Fatalf("Carol decryption mismatch.\nwant ~%v\ngot  %v", data, got[:len(data)])
+	}
+}
+
+// TestPRE_SeparateProxy walks through a deployment where Bob only publishes
+// his serialized public key and the proxy receives the serialized context,
+// re-encryption key and ciphertext.
+func TestPRE_SeparateProxy(t *testing.T) {
+	cc, keys := setupPREBGV(t, INDCPA, 1, 2)
+	defer cc.Close()
+	alice, bob := keys[0], keys[1]
+	defer alice.Close()
+	defer bob.Close()
+
+	// Bob -> Alice: public key only.
+	bobPK, err := SerializePublicKeyToBytes(bob)
+	mustT(t, err, "SerializePublicKeyToBytes")
+
+	ek, err := cc.ReKeyGenFromPublicKeyBytes(alice, bobPK)
+	mustT(t, err, "ReKeyGenFromPublicKeyBytes")
+	defer ek.Close()
+
+	data := []int64{3, 1, 4, 1, 5, 9, 2, 6}
+	pt, err := cc.MakePackedPlaintext(data)
+	mustT(t, err, "MakePackedPlaintext")
+	defer pt.Close()
+	ct, err := cc.Encrypt(alice, pt)
+	mustT(t, err, "Encrypt")
+	defer ct.Close()
+
+	// Alice -> proxy: re-encryption key and ciphertext over one stream.
+	var stream bytes.Buffer
+	mustT(t, SerializeEvalKey(&stream, ek), "SerializeEvalKey")
+	ctBytes, err := SerializeCiphertextToBytes(ct)
+	mustT(t, err, "SerializeCiphertextToBytes")
+	mustT(t, writeRecord(&stream, ctBytes), "writeRecord")
+
+	// Proxy.
+	ekProxy, err := DeserializeEvalKey(&stream)
+	mustT(t, err, "DeserializeEvalKey")
+	defer ekProxy.Close()
+	ctProxyBytes, err := readRecord(&stream)
+	mustT(t, err, "readRecord")
+	ctProxy := DeserializeCiphertextFromBytes(ctProxyBytes)
+	if ctProxy == nil {
+		t.Fatal("Ciphertext deserialization failed")
+	}
+	defer ctProxy.Close()
+
+	ctBob, err := cc.ReEncrypt(ctProxy, ekProxy)
+	mustT(t, err, "ReEncrypt")
+	defer ctBob.Close()
+
+	// Bob.
+	ptBob, err := cc.Decrypt(bob, ctBob)
+	mustT(t, err, "Decrypt by Bob")
+	defer ptBob.Close()
+	got, err := ptBob.GetPackedValue()
+	mustT(t, err, "GetPackedValue")
+	if !slicesEqual(got[:len(data)], data) {
+		t.Fatalf("Bob decryption mismatch.\nwant %v\ngot  %v", data, got[:len(data)])
+	}
+}
+
+func TestPRE_EvalKeySerializationRoundTrip(t *testing.T) {
+	cc, keys := setupPREBGV(t, INDCPA, 1, 2)
+	defer cc.Close()
+	defer keys[0].Close()
+	defer keys[1].Close()
+
+	ek, err := cc.ReKeyGen(keys[0], keys[1])
+	mustT(t, err, "ReKeyGen")
+	defer ek.Close()
+
+	data, err := SerializeEvalKeyToBytes(ek)
+	mustT(t, err, "SerializeEvalKeyToBytes")
+	loaded, err := DeserializeEvalKeyFromBytes(data)
+	mustT(t, err, "DeserializeEvalKeyFromBytes")
+	defer loaded.Close()
+
+	again, err := SerializeEvalKeyToBytes(loaded)
+	mustT(t, err, "SerializeEvalKeyToBytes loaded")
+	if !bytes.Equal(data, again) {
+		t.Error("re-serialized EvalKey differs from the original")
+	}
+}
+
+func TestPRE_EvalKeySerializationInvalidInputs(t *testing.T) {
+	if _, err := SerializeEvalKeyToBytes(&EvalKey{}); err == nil {
+		t.Error("expected error for closed EvalKey")
+	}
+	if _, err := DeserializeEvalKeyFromBytes(nil); err == nil {
+		t.Error("expected error for empty data")
+	}
+	if _, err := DeserializeEvalKey(bytes.NewReader([]byte{0, 0, 0})); err == nil {
+		t.Error("expected error for truncated stream")
+	}
+	if _, err := DeserializeEvalKey(bytes.NewReader(make([]byte, 8))); err == nil {
+		t.Error("expected error for zero-length record")
+	}
+
+	cc, keys := setupPREBGV(t, INDCPA, 1, 1)
+	defer cc.Close()
+	defer keys[0].Close()
+	if _, err := cc.ReKeyGenFromPublicKeyBytes(keys[0], []byte("not a key")); err == nil {
+		t.Error("expected error for garbage public key")
+	}
+}
+
+func TestReadRecordRejectsForgedLength(t *testing.T) {
+	header := func(n uint64) []byte {
+		var hdr [8]byte
+		binary.BigEndian.PutUint64(hdr[:], n)
+		return hdr[:]
+	}
+
+	// A length past the limit is refused before anything is read.
+	if _, err := readRecord(bytes.NewReader(header(maxRecordSize + 1))); err == nil {
+		t.Error("readRecord accepted a length past maxRecordSize")
+	}
+	if _, err := DeserializeEvalKey(bytes.NewReader(header(1 << 34))); err == nil {
+		t.Error("DeserializeEvalKey accepted a 16 GiB record")
+	}
+
+	// A length within the limit but longer than the stream fails once the
+	// stream ends instead of allocating the whole record up front.
+	stream := append(header(maxRecordSize), "short payload"...)
+	if _, err := readRecord(bytes.NewReader(stream)); err != io.ErrUnexpectedEOF {
+		t.Errorf("readRecord on a truncated record = %v, expected %v", err, io.ErrUnexpectedEOF)
+	}
+
+	var buf bytes.Buffer
+	mustT(t, writeRecord(&buf, []byte("payload")), "writeRecord")
+	got, err := readRecord(&buf)
+	mustT(t, err, "readRecord")
+	if string(got) != "payload" {
+		t.Errorf("readRecord = %q, expected %q", got, "payload")
+	}
+}
@@ -0,0 +1,441 @@
+package openfhe
+
+import "sync"
+
+// --- Concurrency ---
+//
+// Every handle to a native object (contexts, keys, plaintexts, ciphertexts
+// and the auxiliary objects of PRE, scheme switching, FBT and threshold
+// FHEW) may be shared between goroutines. Every method holds a reference on
+// the handles it is given for the duration of the native call, and Close
+// waits for those calls to return before freeing the C++ object; calls that
+// start after Close report the handle as closed.
+//
+// OpenFHE keeps evaluation keys in maps shared by every CryptoContext, so
+// key generation, bootstrapping and scheme-switching setup, and eval-key
+// deserialization run alone, while all other operations run in parallel.
+//
+// Configuration that lives on the Go handle (EnableAutoBootstrap,
+// DisableAutoBootstrap and KeyGenForPlan) is not synchronized and should be
+// done before the context is shared. PlanKeys may run its computation on
+// several goroutines but must not overlap any other use of the context.
+
+// ref counts the calls in flight on a native handle.
+type ref struct {
+	mu     sync.Mutex
+	idle   *sync.Cond // created by the first close that has to wait
+	users  int
+	closed bool
+}
+
+// acquire takes a reference, or reports false once close has been called.
+func (r *ref) acquire() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return false
+	}
+	r.users++
+	return true
+}
+
+func (r *ref) release() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.users--
+	if r.users == 0 && r.idle != nil {
+		r.idle.Broadcast()
+	}
+}
+
+// close marks the handle closed and waits for the references taken before
+// it to be released. Only the first call returns true; that caller frees
+// the native object.
+func (r *ref) close() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return false
+	}
+	r.closed = true
+	for r.users > 0 {
+		if r.idle == nil {
+			r.idle = sync.NewCond(&r.mu)
+		}
+		r.idle.Wait()
+	}
+	return true
+}
+
+func (r *ref) isClosed() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.closed
+}
+
+// evalKeys guards OpenFHE's eval-key maps and bootstrapping precomputations.
+// A writer waiting on it blocks new readers, so a method that has acquired
+// a CryptoContext must not call another method that acquires it again.
+var evalKeys sync.RWMutex
+
+// acquire takes a reference on cc and a read hold on the eval keys. It
+// reports false when cc is nil or closed.
+func (cc *CryptoContext) acquire() bool {
+	if cc == nil || !cc.ref.acquire() {
+		return false
+	}
+	if cc.ptr == nil {
+		cc.ref.release()
+		return false
+	}
+	evalKeys.RLock()
+	return true
+}
+
+func (cc *CryptoContext) release() {
+	evalKeys.RUnlock()
+	cc.ref.release()
+}
+
+// acquireExclusive is acquire for operations that add to the eval keys or
+// bootstrapping state: it waits until no other operation is running.
+func (cc *CryptoContext) acquireExclusive() bool {
+	if cc == nil || !cc.ref.acquire() {
+		return false
+	}
+	if cc.ptr == nil {
+		cc.ref.release()
+		return false
+	}
+	evalKeys.Lock()
+	return true
+}
+
+func (cc *CryptoContext) releaseExclusive() {
+	evalKeys.Unlock()
+	cc.ref.release()
+}
+
+// closed reports whether cc is nil or closed.
+func (cc *CryptoContext) closed() bool {
+	return cc == nil || cc.ref.isClosed()
+}
+
+func (kp *KeyPair) acquire() bool {
+	if kp == nil || !kp.ref.acquire() {
+		return false
+	}
+	if kp.ptr == nil {
+		kp.ref.release()
+		return false
+	}
+	return true
+}
+
+func (kp *KeyPair) release() { kp.ref.release() }
+
+// closed reports whether kp is nil or closed.
+func (kp *KeyPair) closed() bool {
+	return kp == nil || kp.ref.isClosed()
+}
+
+func (pt *Plaintext) acquire() bool {
+	if pt == nil || !pt.ref.acquire() {
+		return false
+	}
+	if pt.ptr == nil {
+		pt.ref.release()
+		return false
+	}
+	return true
+}
+
+func (pt *Plaintext) release() { pt.ref.release() }
+
+// closed reports whether pt is nil or closed.
+func (pt *Plaintext) closed() bool {
+	return pt == nil || pt.ref.isClosed()
+}
+
+func (ct *Ciphertext) acquire() bool {
+	if ct == nil || !ct.ref.acquire() {
+		return false
+	}
+	if ct.ptr == nil {
+		ct.ref.release()
+		return false
+	}
+	return true
+}
+
+func (ct *Ciphertext) release() { ct.ref.release() }
+
+// closed reports whether ct is nil or closed.
+func (ct *Ciphertext) closed() bool {
+	return ct == nil || ct.ref.isClosed()
+}
+
+// The remaining handles only count references; none of them guards shared
+// native state.
+
+func (p *ParamsBFV) acquire() bool {
+	if p == nil || !p.ref.acquire() {
+		return false
+	}
+	if p.ptr == nil {
+		p.ref.release()
+		return false
+	}
+	return true
+}
+
+func (p *ParamsBFV) release() { p.ref.release() }
+
+func (p *ParamsBGV) acquire() bool {
+	if p == nil || !p.ref.acquire() {
+		return false
+	}
+	if p.ptr == nil {
+		p.ref.release()
+		return false
+	}
+	return true
+}
+
+func (p *ParamsBGV) release() { p.ref.release() }
+
+func (p *ParamsCKKS) acquire() bool {
+	if p == nil || !p.ref.acquire() {
+		return false
+	}
+	if p.ptr == nil {
+		p.ref.release()
+		return false
+	}
+	return true
+}
+
+func (p *ParamsCKKS) release() { p.ref.release() }
+
+func (ek *EvalKey) acquire() bool {
+	if ek == nil || !ek.ref.acquire() {
+		return false
+	}
+	if ek.ptr == nil {
+		ek.ref.release()
+		return false
+	}
+	return true
+}
+
+func (ek *EvalKey) release() { ek.ref.release() }
+
+func (p *FastRotationPrecompute) acquire() bool {
+	if p == nil || !p.ref.acquire() {
+		return false
+	}
+	if p.ptr == nil {
+		p.ref.release()
+		return false
+	}
+	return true
+}
+
+func (p *FastRotationPrecompute) release() { p.ref.release() }
+
+// acquire takes a reference on both shares.
+func (s *IntMPBootShares) acquire() bool {
+	if s == nil || !s.MaskedDecryption.acquire() {
+		return false
+	}
+	if !s.ReEncryption.acquire() {
+		s.MaskedDecryption.release()
+		return false
+	}
+	return true
+}
+
+func (s *IntMPBootShares) release() {
+	s.ReEncryption.release()
+	s.MaskedDecryption.release()
+}
+
+func (p *SchSwchParams) acquire() bool {
+	if p == nil || !p.ref.acquire() {
+		return false
+	}
+	if p.ptr == nil {
+		p.ref.release()
+		return false
+	}
+	return true
+}
+
+func (p *SchSwchParams) release() { p.ref.release() }
+
+func (k *LWEPrivateKey) acquire() bool {
+	if k == nil || !k.ref.acquire() {
+		return false
+	}
+	if k.ptr == nil {
+		k.ref.release()
+		return false
+	}
+	return true
+}
+
+func (k *LWEPrivateKey) release() { k.ref.release() }
+
+func (s *FBTSeries) acquire() bool {
+	if s == nil || !s.ref.acquire() {
+		return false
+	}
+	if s.ptr == nil {
+		s.ref.release()
+		return false
+	}
+	return true
+}
+
+func (s *FBTSeries) release() { s.ref.release() }
+
+// acquire takes a reference on cc and, for a scheme-switching context, on
+// the CryptoContext that owns it, so the owner is not closed or given new
+// scheme-switching keys while cc is in use.
+func (cc *BinFHEContext) acquire() bool {
+	if cc == nil || !cc.ref.acquire() {
+		return false
+	}
+	if cc.owner != nil && !cc.owner.acquire() {
+		cc.ref.release()
+		return false
+	}
+	if cc.h == nil {
+		cc.release()
+		return false
+	}
+	return true
+}
+
+func (cc *BinFHEContext) release() {
+	if cc.owner != nil {
+		cc.owner.release()
+	}
+	cc.ref.release()
+}
+
+// acquireExclusive is acquire for a caller that holds the eval keys
+// exclusively: it takes a reference on the owner but no read hold, which
+// would wait for the caller itself.
+func (cc *BinFHEContext) acquireExclusive() bool {
+	if cc == nil || !cc.ref.acquire() {
+		return false
+	}
+	if cc.owner != nil && !cc.owner.ref.acquire() {
+		cc.ref.release()
+		return false
+	}
+	if cc.h == nil {
+		cc.releaseExclusive()
+		return false
+	}
+	return true
+}
+
+func (cc *BinFHEContext) releaseExclusive() {
+	if cc.owner != nil {
+		cc.owner.ref.release()
+	}
+	cc.ref.release()
+}
+
+func (sk *BinFHESecretKey) acquire() bool {
+	if sk == nil || !sk.ref.acquire() {
+		return false
+	}
+	if sk.h == nil {
+		sk.ref.release()
+		return false
+	}
+	return true
+}
+
+func (sk *BinFHESecretKey) release() { sk.ref.release() }
+
+func (ct *BinFHECiphertext) acquire() bool {
+	if ct == nil || !ct.ref.acquire() {
+		return false
+	}
+	if ct.h == nil {
+		ct.ref.release()
+		return false
+	}
+	return true
+}
+
+func (ct *BinFHECiphertext) release() { ct.ref.release() }
+
+func (kp *BinFHEKeyPair) acquire() bool {
+	if kp == nil || !kp.ref.acquire() {
+		return false
+	}
+	if kp.h == nil {
+		kp.ref.release()
+		return false
+	}
+	return true
+}
+
+func (kp *BinFHEKeyPair) release() { kp.ref.release() }
+
+func (z *BinFHERingKey) acquire() bool {
+	if z == nil || !z.ref.acquire() {
+		return false
+	}
+	if z.h == nil {
+		z.ref.release()
+		return false
+	}
+	return true
+}
+
+func (z *BinFHERingKey) release() { z.ref.release() }
+
+func (crs *BinFHEMultipartyCRS) acquire() bool {
+	if crs == nil || !crs.ref.acquire() {
+		return false
+	}
+	if crs.h == nil {
+		crs.ref.release()
+		return false
+	}
+	return true
+}
+
+func (crs *BinFHEMultipartyCRS) release() { crs.ref.release() }
+
+func (s *BinFHERGSWShares) acquire() bool {
+	if s == nil || !s.ref.acquire() {
+		return false
+	}
+	if s.h == nil {
+		s.ref.release()
+		return false
+	}
+	return true
+}
+
+func (s *BinFHERGSWShares) release() { s.ref.release() }
+
+func (s *BinFHEBTKeyShare) acquire() bool {
+	if s == nil || !s.ref.acquire() {
+		return false
+	}
+	if s.h == nil {
+		s.ref.release()
+		return false
+	}
+	return true
+}
+
+func (s *BinFHEBTKeyShare) release() { s.ref.release() }
@@ -10,7 +10,11 @@ package openfhe
 import "C"
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"runtime"
+	"sync"
 	"unsafe"
 )
 
@@ -44,11 +48,13 @@ const (
 // SchSwchParams holds parameters for scheme switching
 type SchSwchParams struct {
 	ptr C.SchSwchParamsPtr
+	ref ref
 }
 
 // LWEPrivateKey represents a private key for LWE/BinFHE operations
 type LWEPrivateKey struct {
 	ptr C.LWEPrivateKeyPtr
+	ref ref
 }
 
 // LWECiphertext is an alias for BinFHECiphertext for scheme switching
@@ -72,54 +78,60 @@ func NewSchSwchParams() (*SchSwchParams, error) {
 
 // SetSecurityLevelCKKS sets the security level for the CKKS cryptocontext
 func (p *SchSwchParams) SetSecurityLevelCKKS(level SecurityLevel) error {
-	if p.ptr == nil {
+	if !p.acquire() {
 		return errors.New("SchSwchParams is closed or invalid")
 	}
+	defer p.release()
 	status := C.SchSwchParams_SetSecurityLevelCKKS(p.ptr, C.OFHESecurityLevel(level))
 	return checkPKEErrorMsg(status)
 }
 
 // SetSecurityLevelFHEW sets the security level for the FHEW cryptocontext
 func (p *SchSwchParams) SetSecurityLevelFHEW(level BinFHEParamSet) error {
-	if p.ptr == nil {
+	if !p.acquire() {
 		return errors.New("SchSwchParams is closed or invalid")
 	}
+	defer p.release()
 	status := C.SchSwchParams_SetSecurityLevelFHEW(p.ptr, C.BinFHEParamSet(level))
 	return checkPKEErrorMsg(status)
 }
 
 // SetNumSlotsCKKS sets the number of slots in CKKS encryption
 func (p *SchSwchParams) SetNumSlotsCKKS(numSlots uint32) error {
-	if p.ptr == nil {
+	if !p.acquire() {
 		return errors.New("SchSwchParams is closed or invalid")
 	}
+	defer p.release()
 	status := C.SchSwchParams_SetNumSlotsCKKS(p.ptr, C.uint32_t(numSlots))
 	return checkPKEErrorMsg(status)
 }
 
 // SetNumValues sets the number of values to switch
 func (p *SchSwchParams) SetNumValues(numValues uint32) error {
-	if p.ptr == nil {
+	if !p.acquire() {
 		return errors.New("SchSwchParams is closed or invalid")
 	}
+	defer p.release()
 	status := C.SchSwchParams_SetNumValues(p.ptr, C.uint32_t(numValues))
 	return checkPKEErrorMsg(status)
 }
 
 // SetCtxtModSizeFHEWLargePrec sets the ciphertext modulus size for FHEW in large precision
 func (p *SchSwchParams) SetCtxtModSizeFHEWLargePrec(ctxtModSize uint32) error {
-	if p.ptr == nil {
+	if !p.acquire() {
 		return errors.New("SchSwchParams is closed or invalid")
 	}
+	defer p.release()
 	status := C.SchSwchParams_SetCtxtModSizeFHEWLargePrec(p.ptr, C.uint32_t(ctxtModSize))
 	return checkPKEErrorMsg(status)
 }
 
 // SetComputeArgmin enables/disables argmin computation
 func (p *SchSwchParams) SetComputeArgmin(flag bool) error {
-	if p.ptr == nil {
+	if !p.acquire() {
 		return errors.New("SchSwchParams is closed or invalid")
 	}
+	defer p.release()
 	cFlag := C.int(0)
 	if flag {
 		cFlag = C.int(1)
@@ -130,9 +142,10 @@ func (p *SchSwchParams) SetComputeArgmin(flag bool) error {
 
 // SetUseAltArgmin enables/disables alternative argmin mode
 func (p *SchSwchParams) SetUseAltArgmin(flag bool) error {
-	if p.ptr == nil {
+	if !p.acquire() {
 		return errors.New("SchSwchParams is closed or invalid")
 	}
+	defer p.release()
 	cFlag := C.int(0)
 	if flag {
 		cFlag = C.int(1)
@@ -143,9 +156,10 @@ func (p *SchSwchParams) SetUseAltArgmin(flag bool) error {
 
 // SetArbitraryFunctionEvaluation enables/disables arbitrary function evaluation
 func (p *SchSwchParams) SetArbitraryFunctionEvaluation(flag bool) error {
-	if p.ptr == nil {
+	if !p.acquire() {
 		return errors.New("SchSwchParams is closed or invalid")
 	}
+	defer p.release()
 	cFlag := C.int(0)
 	if flag {
 		cFlag = C.int(1)
@@ -156,9 +170,10 @@ func (p *SchSwchParams) SetArbitraryFunctionEvaluation(flag bool) error {
 
 // SetOneHotEncoding enables/disables one-hot encoding for argmin output
 func (p *SchSwchParams) SetOneHotEncoding(flag bool) error {
-	if p.ptr == nil {
+	if !p.acquire() {
 		return errors.New("SchSwchParams is closed or invalid")
 	}
+	defer p.release()
 	cFlag := C.int(0)
 	if flag {
 		cFlag = C.int(1)
@@ -169,9 +184,10 @@ func (p *SchSwchParams) SetOneHotEncoding(flag bool) error {
 
 // GetSecurityLevelCKKS returns the security level for CKKS
 func (p *SchSwchParams) GetSecurityLevelCKKS() (SecurityLevel, error) {
-	if p.ptr == nil {
+	if !p.acquire() {
 		return 0, errors.New("SchSwchParams is closed or invalid")
 	}
+	defer p.release()
 	var level C.OFHESecurityLevel
 	status := C.SchSwchParams_GetSecurityLevelCKKS(p.ptr, &level)
 	err := checkPKEErrorMsg(status)
@@ -183,9 +199,10 @@ func (p *SchSwchParams) GetSecurityLevelCKKS() (SecurityLevel, error) {
 
 // GetSecurityLevelFHEW returns the security level for FHEW
 func (p *SchSwchParams) GetSecurityLevelFHEW() (BinFHEParamSet, error) {
-	if p.ptr == nil {
+	if !p.acquire() {
 		return 0, errors.New("SchSwchParams is closed or invalid")
 	}
+	defer p.release()
 	var level C.BinFHEParamSet
 	status := C.SchSwchParams_GetSecurityLevelFHEW(p.ptr, &level)
 	err := checkPKEErrorMsg(status)
@@ -197,9 +214,10 @@ func (p *SchSwchParams) GetSecurityLevelFHEW() (BinFHEParamSet, error) {
 
 // GetNumSlotsCKKS returns the number of slots in CKKS
 func (p *SchSwchParams) GetNumSlotsCKKS() (uint32, error) {
-	if p.ptr == nil {
+	if !p.acquire() {
 		return 0, errors.New("SchSwchParams is closed or invalid")
 	}
+	defer p.release()
 	var numSlots C.uint32_t
 	status := C.SchSwchParams_GetNumSlotsCKKS(p.ptr, &numSlots)
 	err := checkPKEErrorMsg(status)
@@ -211,9 +229,10 @@ func (p *SchSwchParams) GetNumSlotsCKKS() (uint32, error) {
 
 // GetNumValues returns the number of values
 func (p *SchSwchParams) GetNumValues() (uint32, error) {
-	if p.ptr == nil {
+	if !p.acquire() {
 		return 0, errors.New("SchSwchParams is closed or invalid")
 	}
+	defer p.release()
 	var numValues C.uint32_t
 	status := C.SchSwchParams_GetNumValues(p.ptr, &numValues)
 	err := checkPKEErrorMsg(status)
@@ -225,7 +244,7 @@ func (p *SchSwchParams) GetNumValues() (uint32, error) {
 
 // Close frees the underlying C++ SchSwchParams object
 func (p *SchSwchParams) Close() {
-	if p.ptr != nil {
+	if p.ref.close() && p.ptr != nil {
 		C.DestroySchSwchParams(p.ptr)
 		p.ptr = nil
 	}
@@ -235,7 +254,7 @@ func (p *SchSwchParams) Close() {
 
 // Close frees the underlying C++ LWEPrivateKey object
 func (k *LWEPrivateKey) Close() {
-	if k.ptr != nil {
+	if k.ref.close() && k.ptr != nil {
 		C.DestroyLWEPrivateKey(k.ptr)
 		k.ptr = nil
 	}
@@ -245,12 +264,14 @@ func (k *LWEPrivateKey) Close() {
 
 // EvalCKKStoFHEWSetup performs setup for CKKS to FHEW scheme switching
 func (cc *CryptoContext) EvalCKKStoFHEWSetup(params *SchSwchParams) (*LWEPrivateKey, error) {
-	if cc.ptr == nil {
+	if !cc.acquireExclusive() {
 		return nil, errors.New("CryptoContext is closed or invalid")
 	}
-	if params == nil || params.ptr == nil {
+	defer cc.releaseExclusive()
+	if !params.acquire() {
 		return nil, errors.New("SchSwchParams is closed or invalid")
 	}
+	defer params.release()
 
 	var keyH C.LWEPrivateKeyPtr
 	status := C.CryptoContext_EvalCKKStoFHEWSetup(cc.ptr, params.ptr, &keyH)
@@ -267,15 +288,18 @@ func (cc *CryptoContext) EvalCKKStoFHEWSetup(params *SchSwchParams) (*LWEPrivate
 
 // EvalCKKStoFHEWKeyGen generates keys for CKKS to FHEW scheme switching
 func (cc *CryptoContext) EvalCKKStoFHEWKeyGen(keys *KeyPair, lwesk *LWEPrivateKey) error {
-	if cc.ptr == nil {
+	if !cc.acquireExclusive() {
 		return errors.New("CryptoContext is closed or invalid")
 	}
-	if keys == nil || keys.ptr == nil {
+	defer cc.releaseExclusive()
+	if !keys.acquire() {
 		return errors.New("KeyPair is closed or invalid")
 	}
-	if lwesk == nil || lwesk.ptr == nil {
+	defer keys.release()
+	if !lwesk.acquire() {
 		return errors.New("LWEPrivateKey is closed or invalid")
 	}
+	defer lwesk.release()
 
 	status := C.CryptoContext_EvalCKKStoFHEWKeyGen(cc.ptr, keys.ptr, lwesk.ptr)
 	return checkPKEErrorMsg(status)
@@ -283,9 +307,10 @@ func (cc *CryptoContext) EvalCKKStoFHEWKeyGen(keys *KeyPair, lwesk *LWEPrivateKe
 
 // EvalCKKStoFHEWPrecompute performs precomputation for CKKS to FHEW switching
 func (cc *CryptoContext) EvalCKKStoFHEWPrecompute(scale float64) error {
-	if cc.ptr == nil {
+	if !cc.acquireExclusive() {
 		return errors.New("CryptoContext is closed or invalid")
 	}
+	defer cc.releaseExclusive()
 
 	status := C.CryptoContext_EvalCKKStoFHEWPrecompute(cc.ptr, C.double(scale))
 	return checkPKEErrorMsg(status)
@@ -293,12 +318,19 @@ func (cc *CryptoContext) EvalCKKStoFHEWPrecompute(scale float64) error {
 
 // EvalCKKStoFHEW transforms a CKKS ciphertext to FHEW ciphertexts
 func (cc *CryptoContext) EvalCKKStoFHEW(ct *Ciphertext, numValues uint32) ([]*LWECiphertext, error) {
-	if cc.ptr == nil {
+	if !cc.acquire() {
 		return nil, errors.New("CryptoContext is closed or invalid")
 	}
-	if ct == nil || ct.ptr == nil {
+	defer cc.release()
+	return cc.evalCKKStoFHEW(ct, numValues)
+}
+
+// evalCKKStoFHEW is EvalCKKStoFHEW for a caller that has acquired cc.
+func (cc *CryptoContext) evalCKKStoFHEW(ct *Ciphertext, numValues uint32) ([]*LWECiphertext, error) {
+	if !ct.acquire() {
 		return nil, errors.New("Ciphertext is closed or invalid")
 	}
+	defer ct.release()
 
 	var outArray *C.LWECiphertextH
 	var outLen C.int
@@ -330,12 +362,14 @@ func (cc *CryptoContext) EvalCKKStoFHEW(ct *Ciphertext, numValues uint32) ([]*LW
 
 // EvalFHEWtoCKKSSetup performs setup for FHEW to CKKS scheme switching
 func (cc *CryptoContext) EvalFHEWtoCKKSSetup(ccLWE *BinFHEContext, numSlots, logQ uint32) error {
-	if cc.ptr == nil {
+	if !cc.acquireExclusive() {
 		return errors.New("CryptoContext is closed or invalid")
 	}
-	if ccLWE == nil || ccLWE.h == nil {
+	defer cc.releaseExclusive()
+	if !ccLWE.acquireExclusive() {
 		return errors.New("BinFHEContext is closed or invalid")
 	}
+	defer ccLWE.releaseExclusive()
 
 	status := C.CryptoContext_EvalFHEWtoCKKSSetup(cc.ptr, ccLWE.h, C.uint32_t(numSlots), C.uint32_t(logQ))
 	return checkPKEErrorMsg(status)
@@ -343,15 +377,18 @@ func (cc *CryptoContext) EvalFHEWtoCKKSSetup(ccLWE *BinFHEContext, numSlots, log
 
 // EvalFHEWtoCKKSKeyGen generates keys for FHEW to CKKS scheme switching
 func (cc *CryptoContext) EvalFHEWtoCKKSKeyGen(keys *KeyPair, lwesk *LWEPrivateKey) error {
-	if cc.ptr == nil {
+	if !cc.acquireExclusive() {
 		return errors.New("CryptoContext is closed or invalid")
 	}
-	if keys == nil || keys.ptr == nil {
+	defer cc.releaseExclusive()
+	if !keys.acquire() {
 		return errors.New("KeyPair is closed or invalid")
 	}
-	if lwesk == nil || lwesk.ptr == nil {
+	defer keys.release()
+	if !lwesk.acquire() {
 		return errors.New("LWEPrivateKey is closed or invalid")
 	}
+	defer lwesk.release()
 
 	status := C.CryptoContext_EvalFHEWtoCKKSKeyGen(cc.ptr, keys.ptr, lwesk.ptr)
 	return checkPKEErrorMsg(status)
@@ -359,9 +396,10 @@ func (cc *CryptoContext) EvalFHEWtoCKKSKeyGen(keys *KeyPair, lwesk *LWEPrivateKe
 
 // EvalFHEWtoCKKS transforms FHEW ciphertexts to a CKKS ciphertext
 func (cc *CryptoContext) EvalFHEWtoCKKS(lweCts []*LWECiphertext, numSlots, p uint32) (*Ciphertext, error) {
-	if cc.ptr == nil {
+	if !cc.acquire() {
 		return nil, errors.New("CryptoContext is closed or invalid")
 	}
+	defer cc.release()
 	if len(lweCts) == 0 {
 		return nil, errors.New("LWE ciphertext array is empty")
 	}
@@ -369,9 +407,10 @@ func (cc *CryptoContext) EvalFHEWtoCKKS(lweCts []*LWECiphertext, numSlots, p uin
 	// Convert Go slice to C array
 	cArray := make([]C.LWECiphertextH, len(lweCts))
 	for i, ct := range lweCts {
-		if ct == nil || ct.h == nil {
+		if !ct.acquire() {
 			return nil, errors.New("LWE ciphertext is closed or invalid")
 		}
+		defer ct.release()
 		cArray[i] = ct.h
 	}
 
@@ -393,9 +432,15 @@ func (cc *CryptoContext) EvalFHEWtoCKKS(lweCts []*LWECiphertext, numSlots, p uin
 func (cc *CryptoContext) EvalFHEWtoCKKSExt(lweCts []*LWECiphertext, numSlots, p uint32,
 	pmin, pmax float64,
 ) (*Ciphertext, error) {
-	if cc.ptr == nil {
+	if !cc.acquire() {
 		return nil, errors.New("CryptoContext is closed or invalid")
 	}
+	defer cc.release()
+	return cc.evalFHEWtoCKKSExt(lweCts, numSlots, p, pmin, pmax)
+}
+
+// evalFHEWtoCKKSExt is EvalFHEWtoCKKSExt for a caller that has acquired cc.
+func (cc *CryptoContext) evalFHEWtoCKKSExt(lweCts []*LWECiphertext, numSlots, p uint32, pmin, pmax float64) (*Ciphertext, error) {
 	if len(lweCts) == 0 {
 		return nil, errors.New("LWE ciphertext array is empty")
 	}
@@ -403,9 +448,10 @@ func (cc *CryptoContext) EvalFHEWtoCKKSExt(lweCts []*LWECiphertext, numSlots, p
 	// Convert Go slice to C array
 	cArray := make([]C.LWECiphertextH, len(lweCts))
 	for i, ct := range lweCts {
-		if ct == nil || ct.h == nil {
+		if !ct.acquire() {
 			return nil, errors.New("LWE ciphertext is closed or invalid")
 		}
+		defer ct.release()
 		cArray[i] = ct.h
 	}
 
@@ -425,12 +471,14 @@ func (cc *CryptoContext) EvalFHEWtoCKKSExt(lweCts []*LWECiphertext, numSlots, p
 
 // EvalSchemeSwitchingSetup performs setup for bidirectional scheme switching
 func (cc *CryptoContext) EvalSchemeSwitchingSetup(params *SchSwchParams) (*LWEPrivateKey, error) {
-	if cc.ptr == nil {
+	if !cc.acquireExclusive() {
 		return nil, errors.New("CryptoContext is closed or invalid")
 	}
-	if params == nil || params.ptr == nil {
+	defer cc.releaseExclusive()
+	if !params.acquire() {
 		return nil, errors.New("SchSwchParams is closed or invalid")
 	}
+	defer params.release()
 
 	var keyH C.LWEPrivateKeyPtr
 	status := C.CryptoContext_EvalSchemeSwitchingSetup(cc.ptr, params.ptr, &keyH)
@@ -447,28 +495,46 @@ func (cc *CryptoContext) EvalSchemeSwitchingSetup(params *SchSwchParams) (*LWEPr
 
 // EvalSchemeSwitchingKeyGen generates keys for bidirectional scheme switching
 func (cc *CryptoContext) EvalSchemeSwitchingKeyGen(keys *KeyPair, lwesk *LWEPrivateKey) error {
-	if cc.ptr == nil {
+	if !cc.acquireExclusive() {
 		return errors.New("CryptoContext is closed or invalid")
 	}
-	if keys == nil || keys.ptr == nil {
+	defer cc.releaseExclusive()
+	if !keys.acquire() {
 		return errors.New("KeyPair is closed or invalid")
 	}
-	if lwesk == nil || lwesk.ptr == nil {
+	defer keys.release()
+	if !lwesk.acquire() {
 		return errors.New("LWEPrivateKey is closed or invalid")
 	}
+	defer lwesk.release()
 
 	status := C.CryptoContext_EvalSchemeSwitchingKeyGen(cc.ptr, keys.ptr, lwesk.ptr)
 	return checkPKEErrorMsg(status)
 }
 
 // GetBinCCForSchemeSwitch retrieves the BinFHE context used for scheme switching
-// Note: The returned BinFHEContext is owned by the CKKS CryptoContext and should NOT be closed.
-// It will be automatically cleaned up when the CryptoContext is closed.
+// Note: The returned BinFHEContext is owned by the CKKS CryptoContext. Its
+// Close only invalidates the handle; the context is freed when the
+// CryptoContext is closed, after which calls on the handle fail. Loading
+// scheme switching keys with DeserializeSchemeSwitchingKeysFromBytes replaces
+// the context, so handles retrieved before must not be used afterwards.
 func (cc *CryptoContext) GetBinCCForSchemeSwitch() (*BinFHEContext, error) {
-	if cc.ptr == nil {
+	if !cc.acquire() {
 		return nil, errors.New("CryptoContext is closed or invalid")
 	}
+	defer cc.release()
+	binCC, err := cc.binCCForSchemeSwitch()
+	if err != nil {
+		return nil, err
+	}
+	binCC.owner = cc
+	return binCC, nil
+}
 
+// binCCForSchemeSwitch is GetBinCCForSchemeSwitch for a caller that has
+// acquired cc. The handle does not reference cc, so it is only valid while
+// the caller holds cc, and it must not be closed.
+func (cc *CryptoContext) binCCForSchemeSwitch() (*BinFHEContext, error) {
 	var binCCH C.BinFHEContextH
 	status := C.CryptoContext_GetBinCCForSchemeSwitch(cc.ptr, &binCCH)
 	err := checkPKEErrorMsg(status)
@@ -482,16 +548,221 @@ func (cc *CryptoContext) GetBinCCForSchemeSwitch() (*BinFHEContext, error) {
 	return &BinFHEContext{h: binCCH}, nil
 }
 
+// EvalFunctionViaSchemeSwitching evaluates a lookup table on the first
+// numValues slots of ct. The slots are switched to FHEW, lut is applied to
+// each LWE ciphertext with EvalFunc in parallel, and the results are packed
+// back into the first numValues slots of a CKKS ciphertext.
+//
+// lut[m] is the function value for input m, so len(lut) is the plaintext
+// modulus p: a power of two, at most GetMaxPlaintextSpace of the
+// scheme-switching BinFHE context. Slot values must be integers in [0, p).
+//
+// The context must be set up with EvalSchemeSwitchingSetup using
+// SetArbitraryFunctionEvaluation(true), followed by EvalSchemeSwitchingKeyGen,
+// LWEPrivateKey.BTKeyGen and EvalCKKStoFHEWPrecompute(1/p).
+func (cc *CryptoContext) EvalFunctionViaSchemeSwitching(ct *Ciphertext, lut []uint64, numValues uint32) (*Ciphertext, error) {
+	return cc.evalFunctionViaSchemeSwitching(context.Background(), ct, lut, numValues)
+}
+
+// The whole pipeline, including the EvalFunc goroutines, holds cc: they use
+// the BinFHE context cc owns, which Close or a scheme-switching key
+// deserialization would otherwise free under them.
+func (cc *CryptoContext) evalFunctionViaSchemeSwitching(ctx context.Context, ct *Ciphertext, lut []uint64, numValues uint32) (*Ciphertext, error) {
+	if !cc.acquire() {
+		return nil, errors.New("CryptoContext is closed or invalid")
+	}
+	defer cc.release()
+	if numValues == 0 {
+		return nil, errors.New("EvalFunctionViaSchemeSwitching: numValues must be positive")
+	}
+	p := uint64(len(lut))
+	if p < 2 || p&(p-1) != 0 {
+		return nil, fmt.Errorf("EvalFunctionViaSchemeSwitching: lookup table size %d is not a power of two", p)
+	}
+
+	ccLWE, err := cc.binCCForSchemeSwitch()
+	if err != nil {
+		return nil, err
+	}
+	table, err := ccLWE.GenerateLUTViaFunction(func(m, _ uint64) uint64 { return lut[m] }, p)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	lweCts, err := cc.evalCKKStoFHEW(ct, numValues)
+	if err != nil {
+		return nil, err
+	}
+	defer closeLWECiphertexts(lweCts)
+
+	results := make([]*LWECiphertext, len(lweCts))
+	defer closeLWECiphertexts(results)
+	errs := make([]error, len(lweCts))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, runtime.GOMAXPROCS(0))
+	for i := range lweCts {
+		sem <- struct{}{}
+		if ctx.Err() != nil {
+			<-sem
+			break
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i], errs[i] = ccLWE.EvalFunc(lweCts[i], table)
+		}(i)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("EvalFunctionViaSchemeSwitching: slot %d: %w", i, err)
+		}
+	}
+
+	return cc.evalFHEWtoCKKSExt(results, numValues, uint32(p), 0, float64(p))
+}
+
+func closeLWECiphertexts(cts []*LWECiphertext) {
+	for _, ct := range cts {
+		if ct != nil {
+			ct.Close()
+		}
+	}
+}
+
 // EvalCompareSwitchPrecompute performs precomputation for comparison via scheme switching
 func (cc *CryptoContext) EvalCompareSwitchPrecompute(pLWE uint32, scaleSign float64) error {
-	if cc.ptr == nil {
+	if !cc.acquireExclusive() {
 		return errors.New("CryptoContext is closed or invalid")
 	}
+	defer cc.releaseExclusive()
 
 	status := C.CryptoContext_EvalCompareSwitchPrecompute(cc.ptr, C.uint32_t(pLWE), C.double(scaleSign))
 	return checkPKEErrorMsg(status)
 }
 
+// EvalCompareSchemeSwitching compares ct1 and ct2 slot by slot by switching
+// their difference to FHEW. The result holds 1 where ct1 < ct2 and 0
+// elsewhere. EvalCompareSwitchPrecompute must have been called with the same
+// pLWE and scaleSign; unit indicates the inputs are already in [-0.5, 0.5).
+func (cc *CryptoContext) EvalCompareSchemeSwitching(ct1, ct2 *Ciphertext, numCtxts, numSlots, pLWE uint32,
+	scaleSign float64, unit bool) (*Ciphertext, error) {
+	if !cc.acquire() {
+		return nil, errors.New("CryptoContext is closed or invalid")
+	}
+	defer cc.release()
+	if !ct1.acquire() {
+		return nil, errors.New("Input Ciphertext is closed or invalid")
+	}
+	defer ct1.release()
+	if !ct2.acquire() {
+		return nil, errors.New("Input Ciphertext is closed or invalid")
+	}
+	defer ct2.release()
+
+	var cUnit C.int
+	if unit {
+		cUnit = 1
+	}
+	var ctH C.CiphertextPtr
+	status := C.CryptoContext_EvalCompareSchemeSwitching(cc.ptr, ct1.ptr, ct2.ptr, C.uint32_t(numCtxts),
+		C.uint32_t(numSlots), C.uint32_t(pLWE), C.double(scaleSign), cUnit, &ctH)
+	if err := checkPKEErrorMsg(status); err != nil {
+		return nil, err
+	}
+	if ctH == nil {
+		return nil, errors.New("EvalCompareSchemeSwitching returned OK but null handle")
+	}
+	return &Ciphertext{ptr: ctH}, nil
+}
+
+type extremumKind int
+
+const (
+	extremumMin extremumKind = iota
+	extremumMinAlt
+	extremumMax
+	extremumMaxAlt
+)
+
+func (cc *CryptoContext) evalExtremumSchemeSwitching(kind extremumKind, ct *Ciphertext, keys *KeyPair,
+	numValues, numSlots, pLWE uint32, scaleSign float64) (*Ciphertext, *Ciphertext, error) {
+	if !cc.acquire() {
+		return nil, nil, errors.New("CryptoContext is closed or invalid")
+	}
+	defer cc.release()
+	if !ct.acquire() {
+		return nil, nil, errors.New("Input Ciphertext is closed or invalid")
+	}
+	defer ct.release()
+	if !keys.acquire() {
+		return nil, nil, errors.New("KeyPair is closed or invalid")
+	}
+	defer keys.release()
+
+	var valH, argH C.CiphertextPtr
+	var status C.PKEErr
+	switch kind {
+	case extremumMin:
+		status = C.CryptoContext_EvalMinSchemeSwitching(cc.ptr, ct.ptr, keys.ptr, C.uint32_t(numValues),
+			C.uint32_t(numSlots), C.uint32_t(pLWE), C.double(scaleSign), &valH, &argH)
+	case extremumMinAlt:
+		status = C.CryptoContext_EvalMinSchemeSwitchingAlt(cc.ptr, ct.ptr, keys.ptr, C.uint32_t(numValues),
+			C.uint32_t(numSlots), C.uint32_t(pLWE), C.double(scaleSign), &valH, &argH)
+	case extremumMax:
+		status = C.CryptoContext_EvalMaxSchemeSwitching(cc.ptr, ct.ptr, keys.ptr, C.uint32_t(numValues),
+			C.uint32_t(numSlots), C.uint32_t(pLWE), C.double(scaleSign), &valH, &argH)
+	case extremumMaxAlt:
+		status = C.CryptoContext_EvalMaxSchemeSwitchingAlt(cc.ptr, ct.ptr, keys.ptr, C.uint32_t(numValues),
+			C.uint32_t(numSlots), C.uint32_t(pLWE), C.double(scaleSign), &valH, &argH)
+	}
+	if err := checkPKEErrorMsg(status); err != nil {
+		return nil, nil, err
+	}
+	if valH == nil || argH == nil {
+		return nil, nil, errors.New("scheme-switching min/max returned OK but null handle")
+	}
+	return &Ciphertext{ptr: valH}, &Ciphertext{ptr: argH}, nil
+}
+
+// EvalMinSchemeSwitching computes the minimum of the first numValues slots of
+// ct and its position. The position is a one-hot mask over numValues slots if
+// SetOneHotEncoding was enabled, otherwise the index in the first slot.
+// Requires SetComputeArgmin on the SchSwchParams used for setup, and
+// EvalCompareSwitchPrecompute with the same pLWE and scaleSign.
+func (cc *CryptoContext) EvalMinSchemeSwitching(ct *Ciphertext, keys *KeyPair, numValues, numSlots, pLWE uint32,
+	scaleSign float64) (value, argmin *Ciphertext, err error) {
+	return cc.evalExtremumSchemeSwitching(extremumMin, ct, keys, numValues, numSlots, pLWE, scaleSign)
+}
+
+// EvalMinSchemeSwitchingAlt is EvalMinSchemeSwitching with more FHEW
+// bootstrapping and fewer CKKS levels. Requires SetUseAltArgmin.
+func (cc *CryptoContext) EvalMinSchemeSwitchingAlt(ct *Ciphertext, keys *KeyPair, numValues, numSlots, pLWE uint32,
+	scaleSign float64) (value, argmin *Ciphertext, err error) {
+	return cc.evalExtremumSchemeSwitching(extremumMinAlt, ct, keys, numValues, numSlots, pLWE, scaleSign)
+}
+
+// EvalMaxSchemeSwitching is the maximum counterpart of EvalMinSchemeSwitching.
+func (cc *CryptoContext) EvalMaxSchemeSwitching(ct *Ciphertext, keys *KeyPair, numValues, numSlots, pLWE uint32,
+	scaleSign float64) (value, argmax *Ciphertext, err error) {
+	return cc.evalExtremumSchemeSwitching(extremumMax, ct, keys, numValues, numSlots, pLWE, scaleSign)
+}
+
+// EvalMaxSchemeSwitchingAlt is the maximum counterpart of
+// EvalMinSchemeSwitchingAlt.
+func (cc *CryptoContext) EvalMaxSchemeSwitchingAlt(ct *Ciphertext, keys *KeyPair, numValues, numSlots, pLWE uint32,
+	scaleSign float64) (value, argmax *Ciphertext, err error) {
+	return cc.evalExtremumSchemeSwitching(extremumMaxAlt, ct, keys, numValues, numSlots, pLWE, scaleSign)
+}
+
 // --- Helper Methods for LWEPrivateKey ---
 
 // DecryptLWECiphertext decrypts an LWE ciphertext using the LWEPrivateKey from scheme switching
@@ -499,15 +770,18 @@ func (cc *CryptoContext) EvalCompareSwitchPrecompute(pLWE uint32, scaleSign floa
 // Returns int64 to match OpenFHE's LWEPlaintext type
 // Note: returned values are always in range [0, p-1] despite being signed
 func (lwesk *LWEPrivateKey) DecryptLWECiphertext(ccLWE *BinFHEContext, ct *LWECiphertext, p uint64) (int64, error) {
-	if lwesk == nil || lwesk.ptr == nil {
+	if !lwesk.acquire() {
 		return 0, errors.New("LWEPrivateKey is closed or invalid")
 	}
-	if ccLWE == nil || ccLWE.h == nil {
+	defer lwesk.release()
+	if !ccLWE.acquire() {
 		return 0, errors.New("BinFHEContext is closed or invalid")
 	}
-	if ct == nil || ct.h == nil {
+	defer ccLWE.release()
+	if !ct.acquire() {
 		return 0, errors.New("LWECiphertext is closed or invalid")
 	}
+	defer ct.release()
 
 	var result C.int64_t
 	// Convert LWEPrivateKeyPtr to unsafe.Pointer for void* parameter
@@ -519,3 +793,19 @@ func (lwesk *LWEPrivateKey) DecryptLWECiphertext(ccLWE *BinFHEContext, ct *LWECi
 
 	return int64(result), nil
 }
+
+// BTKeyGen generates the FHEW bootstrapping keys for lwesk in ccLWE, which
+// EvalFunc needs on the scheme-switching BinFHE context.
+func (lwesk *LWEPrivateKey) BTKeyGen(ccLWE *BinFHEContext) error {
+	if !lwesk.acquire() {
+		return errors.New("LWEPrivateKey is closed or invalid")
+	}
+	defer lwesk.release()
+	if !ccLWE.acquire() {
+		return errors.New("BinFHEContext is closed or invalid")
+	}
+	defer ccLWE.release()
+
+	status := C.BinFHEContext_BTKeyGenLWEKey(ccLWE.h, unsafe.Pointer(lwesk.ptr))
+	return checkBinFHEErrorMsg(status)
+}
@@ -1,5 +1,7 @@
 #include "schemeswitch_c.h"
+#include "binfhecontext-ser.h"
 #include "binfhecontext.h"
+#include "helpers_c.h"
 #include "openfhe.h"
 #include "scheme/ckksrns/ckksrns-schemeswitching.h"
 #include "scheme/scheme-swch-params.h"
@@ -601,3 +603,235 @@ PKEErr CryptoContext_EvalCompareSwitchPrecompute(CryptoContextPtr cc,
 
   TRY_CATCH_END_RETURN_PKERR
 }
+
+PKEErr CryptoContext_EvalCompareSchemeSwitching(
+    CryptoContextPtr cc, CiphertextPtr ct1, CiphertextPtr ct2,
+    uint32_t numCtxts, uint32_t numSlots, uint32_t pLWE, double scaleSign,
+    int unit, CiphertextPtr *out) {
+  TRY_CATCH_BEGIN
+  if (!cc) {
+    throw std::invalid_argument("CryptoContext pointer is null");
+  }
+  if (!ct1 || !ct2) {
+    throw std::invalid_argument("Ciphertext pointer is null");
+  }
+  if (!out) {
+    throw std::invalid_argument("Output pointer is null");
+  }
+
+  auto result = (*unwrapCC(cc))
+                    ->EvalCompareSchemeSwitching(
+                        *unwrapCiphertext(ct1), *unwrapCiphertext(ct2),
+                        numCtxts, numSlots, pLWE, scaleSign, unit != 0);
+  *out = new Ciphertext<DCRTPoly>(result);
+
+  TRY_CATCH_END_RETURN_PKERR
+}
+
+// Shared body of the min/max variants; op selects the OpenFHE method.
+template <typename Op>
+static void evalExtremumSchemeSwitching(CryptoContextPtr cc, CiphertextPtr ct,
+                                        KeyPairPtr keyPair,
+                                        CiphertextPtr *outValue,
+                                        CiphertextPtr *outArg, Op op) {
+  if (!cc) {
+    throw std::invalid_argument("CryptoContext pointer is null");
+  }
+  if (!ct) {
+    throw std::invalid_argument("Ciphertext pointer is null");
+  }
+  if (!keyPair || !unwrapKeyPair(keyPair)->publicKey) {
+    throw std::invalid_argument("KeyPair has no public key");
+  }
+  if (!outValue || !outArg) {
+    throw std::invalid_argument("Output pointer is null");
+  }
+
+  auto result = op(*unwrapCC(cc), *unwrapCiphertext(ct),
+                   unwrapKeyPair(keyPair)->publicKey);
+  if (result.size() != 2) {
+    throw std::runtime_error("unexpected number of results");
+  }
+  *outValue = new Ciphertext<DCRTPoly>(result[0]);
+  *outArg = new Ciphertext<DCRTPoly>(result[1]);
+}
+
+PKEErr CryptoContext_EvalMinSchemeSwitching(CryptoContextPtr cc,
+                                            CiphertextPtr ct,
+                                            KeyPairPtr keyPair,
+                                            uint32_t numValues,
+                                            uint32_t numSlots, uint32_t pLWE,
+                                            double scaleSign,
+                                            CiphertextPtr *outValue,
+                                            CiphertextPtr *outArg) {
+  TRY_CATCH_BEGIN
+  evalExtremumSchemeSwitching(
+      cc, ct, keyPair, outValue, outArg,
+      [&](CryptoContext<DCRTPoly> &c, const Ciphertext<DCRTPoly> &x,
+          const PublicKey<DCRTPoly> &pk) {
+        return c->EvalMinSchemeSwitching(x, pk, numValues, numSlots, pLWE,
+                                         scaleSign);
+      });
+  TRY_CATCH_END_RETURN_PKERR
+}
+
+PKEErr CryptoContext_EvalMinSchemeSwitchingAlt(
+    CryptoContextPtr cc, CiphertextPtr ct, KeyPairPtr keyPair,
+    uint32_t numValues, uint32_t numSlots, uint32_t pLWE, double scaleSign,
+    CiphertextPtr *outValue, CiphertextPtr *outArg) {
+  TRY_CATCH_BEGIN
+  evalExtremumSchemeSwitching(
+      cc, ct, keyPair, outValue, outArg,
+      [&](CryptoContext<DCRTPoly> &c, const Ciphertext<DCRTPoly> &x,
+          const PublicKey<DCRTPoly> &pk) {
+        return c->EvalMinSchemeSwitchingAlt(x, pk, numValues, numSlots, pLWE,
+                                            scaleSign);
+      });
+  TRY_CATCH_END_RETURN_PKERR
+}
+
+PKEErr CryptoContext_EvalMaxSchemeSwitching(CryptoContextPtr cc,
+                                            CiphertextPtr ct,
+                                            KeyPairPtr keyPair,
+                                            uint32_t numValues,
+                                            uint32_t numSlots, uint32_t pLWE,
+                                            double scaleSign,
+                                            CiphertextPtr *outValue,
+                                            CiphertextPtr *outArg) {
+  TRY_CATCH_BEGIN
+  evalExtremumSchemeSwitching(
+      cc, ct, keyPair, outValue, outArg,
+      [&](CryptoContext<DCRTPoly> &c, const Ciphertext<DCRTPoly> &x,
+          const PublicKey<DCRTPoly> &pk) {
+        return c->EvalMaxSchemeSwitching(x, pk, numValues, numSlots, pLWE,
+                                         scaleSign);
+      });
+  TRY_CATCH_END_RETURN_PKERR
+}
+
+PKEErr CryptoContext_EvalMaxSchemeSwitchingAlt(
+    CryptoContextPtr cc, CiphertextPtr ct, KeyPairPtr keyPair,
+    uint32_t numValues, uint32_t numSlots, uint32_t pLWE, double scaleSign,
+    CiphertextPtr *outValue, CiphertextPtr *outArg) {
+  TRY_CATCH_BEGIN
+  evalExtremumSchemeSwitching(
+      cc, ct, keyPair, outValue, outArg,
+      [&](CryptoContext<DCRTPoly> &c, const Ciphertext<DCRTPoly> &x,
+          const PublicKey<DCRTPoly> &pk) {
+        return c->EvalMaxSchemeSwitchingAlt(x, pk, numValues, numSlots, pLWE,
+                                            scaleSign);
+      });
+  TRY_CATCH_END_RETURN_PKERR
+}
+
+// --- Serialization ---
+
+static void copyStreamOut(const std::stringstream &ss, char **outBytes,
+                          size_t *outLen) {
+  std::string s = ss.str();
+  *outBytes = CopyStringToC(s);
+  if (!*outBytes) {
+    throw std::runtime_error("failed to allocate serialization buffer");
+  }
+  *outLen = s.size();
+}
+
+PKEErr SerializeLWEPrivateKeyToBytes(LWEPrivateKeyPtr key, char **outBytes,
+                                     size_t *outLen) {
+  TRY_CATCH_BEGIN
+  if (!key) {
+    throw std::invalid_argument("LWEPrivateKey pointer is null");
+  }
+  if (!outBytes || !outLen) {
+    throw std::invalid_argument("Output pointer is null");
+  }
+
+  std::stringstream ss;
+  Serial::Serialize(*unwrapLWEPrivateKey(key), ss, SerType::BINARY);
+  copyStreamOut(ss, outBytes, outLen);
+
+  TRY_CATCH_END_RETURN_PKERR
+}
+
+PKEErr DeserializeLWEPrivateKeyFromBytes(const char *inData, int inLen,
+                                         LWEPrivateKeyPtr *out) {
+  TRY_CATCH_BEGIN
+  if (!inData || inLen <= 0) {
+    throw std::invalid_argument("No data to deserialize");
+  }
+  if (!out) {
+    throw std::invalid_argument("Output pointer is null");
+  }
+
+  LWEPrivateKey key;
+  std::stringstream ss(std::string(inData, inLen));
+  Serial::Deserialize(key, ss, SerType::BINARY);
+  if (!key) {
+    throw std::runtime_error("deserialized LWEPrivateKey is null");
+  }
+  *out = new LWEPrivateKey(key);
+
+  TRY_CATCH_END_RETURN_PKERR
+}
+
+PKEErr CryptoContext_SerializeSchemeSwitchingKeys(CryptoContextPtr cc,
+                                                  char **outBytes,
+                                                  size_t *outLen) {
+  TRY_CATCH_BEGIN
+  if (!cc) {
+    throw std::invalid_argument("CryptoContext pointer is null");
+  }
+  if (!outBytes || !outLen) {
+    throw std::invalid_argument("Output pointer is null");
+  }
+
+  auto &c = *unwrapCC(cc);
+  auto ccLWE = c->GetBinCCForSchemeSwitch();
+  if (!ccLWE) {
+    throw std::runtime_error("scheme switching has not been set up");
+  }
+
+  std::stringstream ss;
+  Serial::Serialize(*ccLWE, ss, SerType::BINARY);
+  Serial::Serialize(ccLWE->GetRefreshKey(), ss, SerType::BINARY);
+  Serial::Serialize(ccLWE->GetSwitchKey(), ss, SerType::BINARY);
+  Serial::Serialize(c->GetSwkFC(), ss, SerType::BINARY);
+  copyStreamOut(ss, outBytes, outLen);
+
+  TRY_CATCH_END_RETURN_PKERR
+}
+
+PKEErr CryptoContext_DeserializeSchemeSwitchingKeys(CryptoContextPtr cc,
+                                                    const char *inData,
+                                                    int inLen) {
+  TRY_CATCH_BEGIN
+  if (!cc) {
+    throw std::invalid_argument("CryptoContext pointer is null");
+  }
+  if (!inData || inLen <= 0) {
+    throw std::invalid_argument("No data to deserialize");
+  }
+
+  std::stringstream ss(std::string(inData, inLen));
+
+  auto ccLWE = std::make_shared<BinFHEContext>();
+  Serial::Deserialize(*ccLWE, ss, SerType::BINARY);
+  RingGSWACCKey refreshKey;
+  Serial::Deserialize(refreshKey, ss, SerType::BINARY);
+  LWESwitchingKey switchKey;
+  Serial::Deserialize(switchKey, ss, SerType::BINARY);
+  EvalKey<DCRTPoly> swkFC;
+  Serial::Deserialize(swkFC, ss, SerType::BINARY);
+
+  if (refreshKey && switchKey) {
+    ccLWE->BTKeyLoad({refreshKey, switchKey});
+  }
+
+  auto &c = *unwrapCC(cc);
+  c->SetBinCCForSchemeSwitch(ccLWE);
+  if (swkFC) {
+    c->SetSwkFC(swkFC);
+  }
+
+  TRY_CATCH_END_RETURN_PKERR
+}
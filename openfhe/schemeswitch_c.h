@@ -121,8 +121,55 @@ PKEErr CryptoContext_EvalCompareSwitchPrecompute(CryptoContextPtr cc,
                                                  uint32_t pLWE,
                                                  double scaleSign);
 
-// Note: Comparison and Argmin functions will be added in a future phase
-// as they require additional BinFHE operations to be implemented first
+// Slot-wise comparison: the result holds 1 where ct1 < ct2 and 0 otherwise.
+PKEErr CryptoContext_EvalCompareSchemeSwitching(
+    CryptoContextPtr cc, CiphertextPtr ct1, CiphertextPtr ct2,
+    uint32_t numCtxts, uint32_t numSlots, uint32_t pLWE, double scaleSign,
+    int unit, CiphertextPtr *out);
+
+// Min/max over the first numValues slots. outValue holds the extremum and
+// outArg its index (or a one-hot mask when SetOneHotEncoding is enabled).
+PKEErr CryptoContext_EvalMinSchemeSwitching(CryptoContextPtr cc,
+                                            CiphertextPtr ct,
+                                            KeyPairPtr keyPair,
+                                            uint32_t numValues,
+                                            uint32_t numSlots, uint32_t pLWE,
+                                            double scaleSign,
+                                            CiphertextPtr *outValue,
+                                            CiphertextPtr *outArg);
+PKEErr CryptoContext_EvalMinSchemeSwitchingAlt(
+    CryptoContextPtr cc, CiphertextPtr ct, KeyPairPtr keyPair,
+    uint32_t numValues, uint32_t numSlots, uint32_t pLWE, double scaleSign,
+    CiphertextPtr *outValue, CiphertextPtr *outArg);
+PKEErr CryptoContext_EvalMaxSchemeSwitching(CryptoContextPtr cc,
+                                            CiphertextPtr ct,
+                                            KeyPairPtr keyPair,
+                                            uint32_t numValues,
+                                            uint32_t numSlots, uint32_t pLWE,
+                                            double scaleSign,
+                                            CiphertextPtr *outValue,
+                                            CiphertextPtr *outArg);
+PKEErr CryptoContext_EvalMaxSchemeSwitchingAlt(
+    CryptoContextPtr cc, CiphertextPtr ct, KeyPairPtr keyPair,
+    uint32_t numValues, uint32_t numSlots, uint32_t pLWE, double scaleSign,
+    CiphertextPtr *outValue, CiphertextPtr *outArg);
+
+// --- Serialization ---
+// Byte buffers returned through outBytes are malloc'd; release with FreeString.
+PKEErr SerializeLWEPrivateKeyToBytes(LWEPrivateKeyPtr key, char **outBytes,
+                                     size_t *outLen);
+PKEErr DeserializeLWEPrivateKeyFromBytes(const char *inData, int inLen,
+                                         LWEPrivateKeyPtr *out);
+
+// Serializes the scheme-switching BinFHE context, its bootstrapping keys and
+// the FHEW-to-CKKS switching key held by cc.
+PKEErr CryptoContext_SerializeSchemeSwitchingKeys(CryptoContextPtr cc,
+                                                  char **outBytes,
+                                                  size_t *outLen);
+// Loads data written by CryptoContext_SerializeSchemeSwitchingKeys into cc.
+PKEErr CryptoContext_DeserializeSchemeSwitchingKeys(CryptoContextPtr cc,
+                                                    const char *inData,
+                                                    int inLen);
 
 #ifdef __cplusplus
 }
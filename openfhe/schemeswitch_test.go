@@ -217,3 +217,374 @@ func TestBinFHEParameterGetters(t *testing.T) {
 
 	t.Log("BinFHE parameter getters test completed successfully!")
 }
+
+// setupSchemeSwitchingEval builds a CKKS context with bidirectional scheme
+// switching; configure sets the argmin/one-hot flags before setup.
+func setupSchemeSwitchingEval(t *testing.T, multDepth int, slots uint32,
+	configure func(p *SchSwchParams)) (*CryptoContext, *KeyPair, *LWEPrivateKey, uint32) {
+	t.Helper()
+
+	params, err := NewParamsCKKSRNS()
+	mustT(t, err, "NewParamsCKKSRNS")
+	defer params.Close()
+
+	mustT(t, params.SetMultiplicativeDepth(multDepth), "SetMultiplicativeDepth")
+	mustT(t, params.SetFirstModSize(60), "SetFirstModSize")
+	mustT(t, params.SetScalingModSize(50), "SetScalingModSize")
+	mustT(t, params.SetScalingTechnique(FLEXIBLEAUTO), "SetScalingTechnique")
+	mustT(t, params.SetSecurityLevel(HEStdNotSet), "SetSecurityLevel")
+	mustT(t, params.SetRingDim(8192), "SetRingDim")
+	mustT(t, params.SetBatchSize(int(slots)), "SetBatchSize")
+
+	cc, err := NewCryptoContextCKKS(params)
+	mustT(t, err, "NewCryptoContextCKKS")
+
+	mustT(t, cc.Enable(PKE), "Enable PKE")
+	mustT(t, cc.Enable(KEYSWITCH), "Enable KEYSWITCH")
+	mustT(t, cc.Enable(LEVELEDSHE), "Enable LEVELEDSHE")
+	mustT(t, cc.Enable(ADVANCEDSHE), "Enable ADVANCEDSHE")
+	mustT(t, cc.Enable(SCHEMESWITCH), "Enable SCHEMESWITCH")
+
+	keys, err := cc.KeyGen()
+	mustT(t, err, "KeyGen")
+
+	swParams, err := NewSchSwchParams()
+	mustT(t, err, "NewSchSwchParams")
+	defer swParams.Close()
+
+	mustT(t, swParams.SetSecurityLevelCKKS(HEStdNotSet), "SetSecurityLevelCKKS")
+	mustT(t, swParams.SetSecurityLevelFHEW(BinFHETOY), "SetSecurityLevelFHEW")
+	mustT(t, swParams.SetCtxtModSizeFHEWLargePrec(25), "SetCtxtModSizeFHEWLargePrec")
+	mustT(t, swParams.SetNumSlotsCKKS(slots), "SetNumSlotsCKKS")
+	mustT(t, swParams.SetNumValues(slots), "SetNumValues")
+	if configure != nil {
+		configure(swParams)
+	}
+
+	lwesk, err := cc.EvalSchemeSwitchingSetup(swParams)
+	mustT(t, err, "EvalSchemeSwitchingSetup")
+	mustT(t, cc.EvalSchemeSwitchingKeyGen(keys, lwesk), "EvalSchemeSwitchingKeyGen")
+
+	ccLWE, err := cc.GetBinCCForSchemeSwitch()
+	mustT(t, err, "GetBinCCForSchemeSwitch")
+	pLWE, err := ccLWE.GetMaxPlaintextSpace()
+	mustT(t, err, "GetMaxPlaintextSpace")
+
+	return cc, keys, lwesk, pLWE
+}
+
+func decryptReal(t *testing.T, cc *CryptoContext, keys *KeyPair, ct *Ciphertext, n int) []float64 {
+	t.Helper()
+	pt, err := cc.Decrypt(keys, ct)
+	mustT(t, err, "Decrypt")
+	defer pt.Close()
+	mustT(t, pt.SetLength(n), "SetLength")
+	v, err := pt.GetRealPackedValue()
+	mustT(t, err, "GetRealPackedValue")
+	return v[:n]
+}
+
+func TestSchemeSwitchingCompare(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping scheme-switching comparison test in -short mode")
+	}
+
+	const slots = 16
+	cc, keys, lwesk, pLWE := setupSchemeSwitchingEval(t, 17, slots, nil)
+	defer cc.Close()
+	defer keys.Close()
+	defer lwesk.Close()
+
+	scaleSign := 1.0
+	mustT(t, cc.EvalCompareSwitchPrecompute(pLWE, scaleSign), "EvalCompareSwitchPrecompute")
+
+	x1 := make([]float64, slots)
+	x2 := make([]float64, slots)
+	for i := range x1 {
+		x1[i] = float64(i)
+		x2[i] = 5.25
+	}
+	pt1, err := cc.MakeCKKSPackedPlaintext(x1)
+	mustT(t, err, "MakeCKKSPackedPlaintext x1")
+	defer pt1.Close()
+	pt2, err := cc.MakeCKKSPackedPlaintext(x2)
+	mustT(t, err, "MakeCKKSPackedPlaintext x2")
+	defer pt2.Close()
+
+	c1, err := cc.Encrypt(keys, pt1)
+	mustT(t, err, "Encrypt x1")
+	defer c1.Close()
+	c2, err := cc.Encrypt(keys, pt2)
+	mustT(t, err, "Encrypt x2")
+	defer c2.Close()
+
+	res, err := cc.EvalCompareSchemeSwitching(c1, c2, slots, slots, pLWE, scaleSign, false)
+	mustT(t, err, "EvalCompareSchemeSwitching")
+	defer res.Close()
+
+	got := decryptReal(t, cc, keys, res, slots)
+	want := make([]float64, slots)
+	for i := range x1 {
+		if x1[i] < x2[i] {
+			want[i] = 1
+		}
+	}
+	if !slicesApproxEqual(got, want, 0.01) {
+		t.Fatalf("comparison mismatch.\nwant %v\ngot  %v", want, got)
+	}
+}
+
+func TestSchemeSwitchingArgminArgmax(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping scheme-switching argmin test in -short mode")
+	}
+
+	const numValues = 8
+	x := []float64{-1.125, 4.5, 2.0, 3.75, 0.0, -0.5, 1.25, 3.0}
+	const minIdx, maxIdx = 0, 1
+
+	cases := []struct {
+		name   string
+		alt    bool
+		oneHot bool
+	}{
+		{"index", false, false},
+		{"one-hot", false, true},
+		{"alt one-hot", true, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			depth := 9 + 3 + 1 + 3 // + log2(numValues)
+			cc, keys, lwesk, pLWE := setupSchemeSwitchingEval(t, depth, numValues, func(p *SchSwchParams) {
+				mustT(t, p.SetComputeArgmin(true), "SetComputeArgmin")
+				mustT(t, p.SetUseAltArgmin(tc.alt), "SetUseAltArgmin")
+				mustT(t, p.SetOneHotEncoding(tc.oneHot), "SetOneHotEncoding")
+			})
+			defer cc.Close()
+			defer keys.Close()
+			defer lwesk.Close()
+
+			scaleSign := 512.0
+			mustT(t, cc.EvalCompareSwitchPrecompute(pLWE, scaleSign), "EvalCompareSwitchPrecompute")
+
+			pt, err := cc.MakeCKKSPackedPlaintext(x)
+			mustT(t, err, "MakeCKKSPackedPlaintext")
+			defer pt.Close()
+			ct, err := cc.Encrypt(keys, pt)
+			mustT(t, err, "Encrypt")
+			defer ct.Close()
+
+			minFn, maxFn := cc.EvalMinSchemeSwitching, cc.EvalMaxSchemeSwitching
+			if tc.alt {
+				minFn, maxFn = cc.EvalMinSchemeSwitchingAlt, cc.EvalMaxSchemeSwitchingAlt
+			}
+
+			check := func(what string, fn func(*Ciphertext, *KeyPair, uint32, uint32, uint32, float64) (*Ciphertext, *Ciphertext, error),
+				wantVal float64, wantIdx int) {
+				val, arg, err := fn(ct, keys, numValues, numValues, pLWE, scaleSign)
+				mustT(t, err, what)
+				defer val.Close()
+				defer arg.Close()
+
+				gotVal := decryptReal(t, cc, keys, val, 1)
+				if math.Abs(gotVal[0]-wantVal) > 0.01 {
+					t.Errorf("%s value: want %v, got %v", what, wantVal, gotVal[0])
+				}
+				if tc.oneHot {
+					gotArg := decryptReal(t, cc, keys, arg, numValues)
+					want := make([]float64, numValues)
+					want[wantIdx] = 1
+					if !slicesApproxEqual(gotArg, want, 0.01) {
+						t.Errorf("%s one-hot: want %v, got %v", what, want, gotArg)
+					}
+				} else {
+					gotArg := decryptReal(t, cc, keys, arg, 1)
+					if math.Abs(gotArg[0]-float64(wantIdx)) > 0.01 {
+						t.Errorf("%s index: want %d, got %v", what, wantIdx, gotArg[0])
+					}
+				}
+			}
+			check("min", minFn, x[minIdx], minIdx)
+			check("max", maxFn, x[maxIdx], maxIdx)
+		})
+	}
+}
+
+func TestSchemeSwitchingExtremumClosedInputs(t *testing.T) {
+	cc := &CryptoContext{}
+	if _, _, err := cc.EvalMinSchemeSwitching(nil, nil, 8, 8, 8, 1); err == nil {
+		t.Fatal("expected error for closed context")
+	}
+	if _, err := cc.EvalCompareSchemeSwitching(nil, nil, 8, 8, 8, 1, false); err == nil {
+		t.Fatal("expected error for closed context")
+	}
+}
+
+func TestSchemeSwitchingEvalFunction(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping scheme-switching function evaluation test in -short mode")
+	}
+
+	const slots = 8
+	cc, keys, lwesk, pLWE := setupSchemeSwitchingEval(t, 14, slots, func(p *SchSwchParams) {
+		mustT(t, p.SetArbitraryFunctionEvaluation(true), "SetArbitraryFunctionEvaluation")
+	})
+	defer cc.Close()
+	defer keys.Close()
+	defer lwesk.Close()
+
+	ccLWE, err := cc.GetBinCCForSchemeSwitch()
+	mustT(t, err, "GetBinCCForSchemeSwitch")
+	mustT(t, lwesk.BTKeyGen(ccLWE), "BTKeyGen")
+	mustT(t, cc.EvalCKKStoFHEWPrecompute(1.0/float64(pLWE)), "EvalCKKStoFHEWPrecompute")
+
+	p := uint64(pLWE)
+	lut := make([]uint64, p)
+	for m := range lut {
+		lut[m] = (uint64(m) * uint64(m) * uint64(m)) % p
+	}
+
+	in := make([]float64, slots)
+	want := make([]float64, slots)
+	for i := range in {
+		m := uint64(i) % p
+		in[i] = float64(m)
+		want[i] = float64(lut[m])
+	}
+	pt, err := cc.MakeCKKSPackedPlaintext(in)
+	mustT(t, err, "MakeCKKSPackedPlaintext")
+	defer pt.Close()
+	ct, err := cc.Encrypt(keys, pt)
+	mustT(t, err, "Encrypt")
+	defer ct.Close()
+
+	res, err := cc.EvalFunctionViaSchemeSwitching(ct, lut, slots)
+	mustT(t, err, "EvalFunctionViaSchemeSwitching")
+	defer res.Close()
+
+	got := decryptReal(t, cc, keys, res, slots)
+	for i := range got {
+		got[i] = math.Round(got[i])
+	}
+	if !slicesApproxEqual(got, want, 1e-9) {
+		t.Fatalf("function evaluation mismatch.\nwant %v\ngot  %v", want, got)
+	}
+}
+
+func TestSchemeSwitchingEvalFunctionRejectsBadTable(t *testing.T) {
+	cc, keys, lwesk, _ := setupSchemeSwitchingEval(t, 14, 8, func(p *SchSwchParams) {
+		mustT(t, p.SetArbitraryFunctionEvaluation(true), "SetArbitraryFunctionEvaluation")
+	})
+	defer cc.Close()
+	defer keys.Close()
+	defer lwesk.Close()
+
+	pt, err := cc.MakeCKKSPackedPlaintext([]float64{0, 1, 2})
+	mustT(t, err, "MakeCKKSPackedPlaintext")
+	defer pt.Close()
+	ct, err := cc.Encrypt(keys, pt)
+	mustT(t, err, "Encrypt")
+	defer ct.Close()
+
+	if _, err := cc.EvalFunctionViaSchemeSwitching(ct, []uint64{0, 1, 2}, 3); err == nil {
+		t.Fatal("expected error for lookup table whose size is not a power of two")
+	}
+	if _, err := cc.EvalFunctionViaSchemeSwitching(ct, nil, 3); err == nil {
+		t.Fatal("expected error for empty lookup table")
+	}
+	if _, err := cc.EvalFunctionViaSchemeSwitching(ct, []uint64{0, 1}, 0); err == nil {
+		t.Fatal("expected error for zero numValues")
+	}
+}
+
+func TestSchemeSwitchingSerialization(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping scheme-switching serialization test in -short mode")
+	}
+
+	const slots = 8
+	ccOrig, keysOrig, lweskOrig, pLWE := setupSchemeSwitchingEval(t, 17, slots, nil)
+
+	x := []float64{0, 1, 2, 3, 4, 5, 6, 7}
+	pt, err := ccOrig.MakeCKKSPackedPlaintext(x)
+	mustT(t, err, "MakeCKKSPackedPlaintext")
+	ctOrig, err := ccOrig.Encrypt(keysOrig, pt)
+	mustT(t, err, "Encrypt")
+	pt.Close()
+
+	// Client side: serialize everything the server needs, plus the LWE key.
+	ccSerial, err := SerializeCryptoContextToBytes(ccOrig)
+	mustT(t, err, "SerializeCryptoContextToBytes")
+	multSerial, err := SerializeEvalMultKeyToBytes(ccOrig, "")
+	mustT(t, err, "SerializeEvalMultKeyToBytes")
+	rotSerial, err := SerializeEvalAutomorphismKeyToBytes(ccOrig, "")
+	mustT(t, err, "SerializeEvalAutomorphismKeyToBytes")
+	swSerial, err := SerializeSchemeSwitchingKeysToBytes(ccOrig)
+	mustT(t, err, "SerializeSchemeSwitchingKeysToBytes")
+	lweSerial, err := SerializeLWEPrivateKeyToBytes(lweskOrig)
+	mustT(t, err, "SerializeLWEPrivateKeyToBytes")
+	ctSerial, err := SerializeCiphertextToBytes(ctOrig)
+	mustT(t, err, "SerializeCiphertextToBytes")
+
+	ctOrig.Close()
+	lweskOrig.Close()
+	keysOrig.Close()
+	ccOrig.Close()
+
+	// Server side.
+	cc := DeserializeCryptoContextFromBytes(ccSerial)
+	if cc == nil {
+		t.Fatal("CryptoContext deserialization failed")
+	}
+	defer cc.Close()
+	mustT(t, cc.Enable(PKE), "Enable PKE")
+	mustT(t, cc.Enable(KEYSWITCH), "Enable KEYSWITCH")
+	mustT(t, cc.Enable(LEVELEDSHE), "Enable LEVELEDSHE")
+	mustT(t, cc.Enable(ADVANCEDSHE), "Enable ADVANCEDSHE")
+	mustT(t, cc.Enable(SCHEMESWITCH), "Enable SCHEMESWITCH")
+	mustT(t, DeserializeEvalMultKeyFromBytes(cc, multSerial), "DeserializeEvalMultKeyFromBytes")
+	mustT(t, DeserializeEvalAutomorphismKeyFromBytes(cc, rotSerial), "DeserializeEvalAutomorphismKeyFromBytes")
+	mustT(t, DeserializeSchemeSwitchingKeysFromBytes(cc, swSerial), "DeserializeSchemeSwitchingKeysFromBytes")
+
+	ct := DeserializeCiphertextFromBytes(ctSerial)
+	if ct == nil {
+		t.Fatal("Ciphertext deserialization failed")
+	}
+	defer ct.Close()
+
+	mustT(t, cc.EvalCKKStoFHEWPrecompute(1.0/float64(pLWE)), "EvalCKKStoFHEWPrecompute")
+	lweCts, err := cc.EvalCKKStoFHEW(ct, slots)
+	mustT(t, err, "EvalCKKStoFHEW")
+	defer closeLWECiphertexts(lweCts)
+
+	// Client side again: decrypt the LWE ciphertexts with the restored key.
+	lwesk, err := DeserializeLWEPrivateKeyFromBytes(lweSerial)
+	mustT(t, err, "DeserializeLWEPrivateKeyFromBytes")
+	defer lwesk.Close()
+	ccLWE, err := cc.GetBinCCForSchemeSwitch()
+	mustT(t, err, "GetBinCCForSchemeSwitch")
+
+	for i, lweCt := range lweCts {
+		got, err := lwesk.DecryptLWECiphertext(ccLWE, lweCt, uint64(pLWE))
+		mustT(t, err, "DecryptLWECiphertext")
+		if want := int64(x[i]) % int64(pLWE); got != want {
+			t.Errorf("slot %d: got %d, expected %d", i, got, want)
+		}
+	}
+}
+
+func TestSchemeSwitchingSerializationInvalidInputs(t *testing.T) {
+	if _, err := SerializeLWEPrivateKeyToBytes(&LWEPrivateKey{}); err == nil {
+		t.Error("expected error for closed LWEPrivateKey")
+	}
+	if _, err := DeserializeLWEPrivateKeyFromBytes(nil); err == nil {
+		t.Error("expected error for empty LWEPrivateKey data")
+	}
+	if _, err := SerializeSchemeSwitchingKeysToBytes(&CryptoContext{}); err == nil {
+		t.Error("expected error for closed CryptoContext")
+	}
+	if err := DeserializeSchemeSwitchingKeysFromBytes(&CryptoContext{}, []byte{1}); err == nil {
+		t.Error("expected error for closed CryptoContext")
+	}
+}
@@ -5,18 +5,29 @@ package openfhe
 #cgo CXXFLAGS: -std=c++17
 #include <stdlib.h>
 #include "pke_common_c.h"
+#include "binfhe_c.h"
+#include "schemeswitch_c.h"
+#include "pre_c.h"
 */
 import "C"
 
 import (
+	"bytes"
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"io"
+	"math"
 	"unsafe"
 )
 
 // --- CryptoContext Serialization ---
 
 func SerializeCryptoContextToBytes(cc *CryptoContext) ([]byte, error) {
+	if !cc.acquire() {
+		return nil, errors.New("CryptoContext is closed or invalid")
+	}
+	defer cc.release()
 	var cBytes *C.char
 	size := C.SerializeCryptoContextToBytes(cc.ptr, &cBytes)
 	if size == 0 || cBytes == nil {
@@ -46,6 +57,10 @@ func DeserializeCryptoContextFromBytes(data []byte) *CryptoContext {
 // --- PublicKey Serialization ---
 
 func SerializePublicKeyToBytes(kp *KeyPair) ([]byte, error) {
+	if !kp.acquire() {
+		return nil, errors.New("KeyPair is closed or invalid")
+	}
+	defer kp.release()
 	var cBytes *C.char
 	size := C.SerializePublicKeyToBytes(kp.ptr, &cBytes)
 	if size == 0 || cBytes == nil {
@@ -76,6 +91,10 @@ func DeserializePublicKeyFromBytes(data []byte) *KeyPair {
 // --- PrivateKey Serialization ---
 
 func SerializePrivateKeyToBytes(kp *KeyPair) ([]byte, error) {
+	if !kp.acquire() {
+		return nil, errors.New("KeyPair is closed or invalid")
+	}
+	defer kp.release()
 	var cBytes *C.char
 	size := C.SerializePrivateKeyToBytes(kp.ptr, &cBytes)
 	if size == 0 || cBytes == nil {
@@ -105,6 +124,10 @@ func DeserializePrivateKeyFromBytes(data []byte) *KeyPair {
 
 // SerializeEvalMultKeyToBytes serializes the relin/evalmult keys stored *within* the CryptoContext.
 func SerializeEvalMultKeyToBytes(cc *CryptoContext, keyId string) ([]byte, error) {
+	if !cc.acquire() {
+		return nil, errors.New("CryptoContext is closed or invalid")
+	}
+	defer cc.release()
 	cKeyId := C.CString(keyId)
 	defer C.free(unsafe.Pointer(cKeyId))
 
@@ -120,6 +143,10 @@ func SerializeEvalMultKeyToBytes(cc *CryptoContext, keyId string) ([]byte, error
 
 // DeserializeEvalMultKeyFromBytes loads the relin/evalmult keys *into* the provided CryptoContext.
 func DeserializeEvalMultKeyFromBytes(cc *CryptoContext, data []byte) error {
+	if !cc.acquireExclusive() {
+		return errors.New("CryptoContext is closed or invalid")
+	}
+	defer cc.releaseExclusive()
 	if len(data) == 0 {
 		return fmt.Errorf("cannot deserialize eval mult key from empty data")
 	}
@@ -132,9 +159,53 @@ func DeserializeEvalMultKeyFromBytes(cc *CryptoContext, data []byte) error {
 	return nil
 }
 
+// --- EvalAutomorphismKey Serialization ---
+
+// SerializeEvalAutomorphismKeyToBytes serializes the rotation keys stored *within* the CryptoContext.
+// Scheme switching from CKKS to FHEW stores its switching keys here as well.
+func SerializeEvalAutomorphismKeyToBytes(cc *CryptoContext, keyId string) ([]byte, error) {
+	if !cc.acquire() {
+		return nil, errors.New("CryptoContext is closed or invalid")
+	}
+	defer cc.release()
+	cKeyId := C.CString(keyId)
+	defer C.free(unsafe.Pointer(cKeyId))
+
+	var cBytes *C.char
+	size := C.SerializeEvalAutomorphismKeyToBytes(cc.ptr, cKeyId, &cBytes)
+	if size == 0 || cBytes == nil {
+		return nil, fmt.Errorf("eval automorphism key serialization failed (keyId: %s)", keyId)
+	}
+	goBytes := C.GoBytes(unsafe.Pointer(cBytes), C.int(size))
+	C.FreeString(cBytes)
+	return goBytes, nil
+}
+
+// DeserializeEvalAutomorphismKeyFromBytes loads the rotation keys *into* the provided CryptoContext.
+func DeserializeEvalAutomorphismKeyFromBytes(cc *CryptoContext, data []byte) error {
+	if !cc.acquireExclusive() {
+		return errors.New("CryptoContext is closed or invalid")
+	}
+	defer cc.releaseExclusive()
+	if len(data) == 0 {
+		return fmt.Errorf("cannot deserialize eval automorphism key from empty data")
+	}
+	cData := (*C.char)(unsafe.Pointer(&data[0]))
+	cLen := C.int(len(data))
+
+	if C.DeserializeEvalAutomorphismKeyFromBytes(cc.ptr, cData, cLen) == 0 {
+		return fmt.Errorf("eval automorphism key deserialization failed")
+	}
+	return nil
+}
+
 // --- Ciphertext Serialization ---
 
 func SerializeCiphertextToBytes(ct *Ciphertext) ([]byte, error) {
+	if !ct.acquire() {
+		return nil, errors.New("Ciphertext is closed or invalid")
+	}
+	defer ct.release()
 	var cBytes *C.char
 	size := C.SerializeCiphertextToBytes(ct.ptr, &cBytes)
 	if size == 0 || cBytes == nil {
@@ -161,6 +232,226 @@ func DeserializeCiphertextFromBytes(data []byte) *Ciphertext {
 	return ct
 }
 
+// --- EvalKey (Re-Encryption Key) Serialization ---
+
+// SerializeEvalKeyToBytes serializes a re-encryption key from ReKeyGen so it
+// can be shipped to a proxy.
+func SerializeEvalKeyToBytes(ek *EvalKey) ([]byte, error) {
+	if !ek.acquire() {
+		return nil, errors.New("EvalKey is closed or invalid")
+	}
+	defer ek.release()
+	var cBytes *C.char
+	var size C.size_t
+	status := C.SerializeEvalKeyToBytes(ek.ptr, &cBytes, &size)
+	err := checkPKEErrorMsg(status)
+	if err != nil {
+		return nil, err
+	}
+	goBytes := C.GoBytes(unsafe.Pointer(cBytes), C.int(size))
+	C.FreeString(cBytes)
+	return goBytes, nil
+}
+
+// DeserializeEvalKeyFromBytes restores a key written by SerializeEvalKeyToBytes.
+func DeserializeEvalKeyFromBytes(data []byte) (*EvalKey, error) {
+	if len(data) == 0 {
+		return nil, errors.New("cannot deserialize EvalKey from empty data")
+	}
+	var ekH C.EvalKeyPtr
+	status := C.DeserializeEvalKeyFromBytes((*C.char)(unsafe.Pointer(&data[0])), C.int(len(data)), &ekH)
+	err := checkPKEErrorMsg(status)
+	if err != nil {
+		return nil, err
+	}
+	if ekH == nil {
+		return nil, errors.New("DeserializeEvalKeyFromBytes returned OK but null handle")
+	}
+	return &EvalKey{ptr: ekH}, nil
+}
+
+// SerializeEvalKey writes ek to w as a length-prefixed record, so several
+// keys or other records can share one stream.
+func SerializeEvalKey(w io.Writer, ek *EvalKey) error {
+	data, err := SerializeEvalKeyToBytes(ek)
+	if err != nil {
+		return err
+	}
+	return writeRecord(w, data)
+}
+
+// DeserializeEvalKey reads one record written by SerializeEvalKey from r.
+func DeserializeEvalKey(r io.Reader) (*EvalKey, error) {
+	data, err := readRecord(r)
+	if err != nil {
+		return nil, err
+	}
+	return DeserializeEvalKeyFromBytes(data)
+}
+
+// maxRecordSize bounds the length prefix accepted by readRecord. The
+// deserializers take the length as a C int.
+const maxRecordSize = math.MaxInt32
+
+// recordChunk is how much of a record readRecord reads at a time, so a
+// forged length costs no more memory than the bytes actually sent.
+const recordChunk = 1 << 20
+
+// writeRecord writes data prefixed with its length as a big-endian uint64.
+func writeRecord(w io.Writer, data []byte) error {
+	if len(data) > maxRecordSize {
+		return fmt.Errorf("record of %d bytes exceeds the limit of %d", len(data), maxRecordSize)
+	}
+	var hdr [8]byte
+	binary.BigEndian.PutUint64(hdr[:], uint64(len(data)))
+	if _, err := w.Write(hdr[:]); err != nil {
+		return err
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+// readRecord reads one record written by writeRecord.
+func readRecord(r io.Reader) ([]byte, error) {
+	var hdr [8]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		return nil, err
+	}
+	n := binary.BigEndian.Uint64(hdr[:])
+	if n == 0 || n > maxRecordSize {
+		return nil, fmt.Errorf("invalid record length %d", n)
+	}
+	var buf bytes.Buffer
+	for remaining := int64(n); remaining > 0; {
+		chunk := min(remaining, recordChunk)
+		if _, err := io.CopyN(&buf, r, chunk); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+		remaining -= chunk
+	}
+	return buf.Bytes(), nil
+}
+
+// --- Scheme Switching Serialization ---
+//
+// A server evaluating EvalCKKStoFHEW/EvalFHEWtoCKKS on client data needs the
+// CryptoContext (serialized after scheme-switching setup), the EvalMult and
+// EvalAutomorphism keys, and the scheme-switching keys below. The
+// LWEPrivateKey stays with the client.
+
+// SerializeLWEPrivateKeyToBytes serializes the FHEW secret key returned by
+// EvalCKKStoFHEWSetup or EvalSchemeSwitchingSetup.
+func SerializeLWEPrivateKeyToBytes(key *LWEPrivateKey) ([]byte, error) {
+	if !key.acquire() {
+		return nil, errors.New("LWEPrivateKey is closed or invalid")
+	}
+	defer key.release()
+	var cBytes *C.char
+	var size C.size_t
+	status := C.SerializeLWEPrivateKeyToBytes(key.ptr, &cBytes, &size)
+	err := checkPKEErrorMsg(status)
+	if err != nil {
+		return nil, err
+	}
+	goBytes := C.GoBytes(unsafe.Pointer(cBytes), C.int(size))
+	C.FreeString(cBytes)
+	return goBytes, nil
+}
+
+// DeserializeLWEPrivateKeyFromBytes restores a key written by SerializeLWEPrivateKeyToBytes.
+func DeserializeLWEPrivateKeyFromBytes(data []byte) (*LWEPrivateKey, error) {
+	if len(data) == 0 {
+		return nil, errors.New("cannot deserialize LWEPrivateKey from empty data")
+	}
+	var keyH C.LWEPrivateKeyPtr
+	status := C.DeserializeLWEPrivateKeyFromBytes((*C.char)(unsafe.Pointer(&data[0])), C.int(len(data)), &keyH)
+	err := checkPKEErrorMsg(status)
+	if err != nil {
+		return nil, err
+	}
+	if keyH == nil {
+		return nil, errors.New("DeserializeLWEPrivateKeyFromBytes returned OK but null handle")
+	}
+	return &LWEPrivateKey{ptr: keyH}, nil
+}
+
+// SerializeSchemeSwitchingKeysToBytes serializes the BinFHE context derived
+// for scheme switching, its bootstrapping keys and the FHEW-to-CKKS switching
+// key held by cc.
+func SerializeSchemeSwitchingKeysToBytes(cc *CryptoContext) ([]byte, error) {
+	if !cc.acquire() {
+		return nil, errors.New("CryptoContext is closed or invalid")
+	}
+	defer cc.release()
+	var cBytes *C.char
+	var size C.size_t
+	status := C.CryptoContext_SerializeSchemeSwitchingKeys(cc.ptr, &cBytes, &size)
+	err := checkPKEErrorMsg(status)
+	if err != nil {
+		return nil, err
+	}
+	goBytes := C.GoBytes(unsafe.Pointer(cBytes), C.int(size))
+	C.FreeString(cBytes)
+	return goBytes, nil
+}
+
+// DeserializeSchemeSwitchingKeysFromBytes loads data written by
+// SerializeSchemeSwitchingKeysToBytes *into* the provided CryptoContext.
+// GetBinCCForSchemeSwitch returns the restored BinFHE context afterwards.
+func DeserializeSchemeSwitchingKeysFromBytes(cc *CryptoContext, data []byte) error {
+	if !cc.acquireExclusive() {
+		return errors.New("CryptoContext is closed or invalid")
+	}
+	defer cc.releaseExclusive()
+	if len(data) == 0 {
+		return errors.New("cannot deserialize scheme switching keys from empty data")
+	}
+	status := C.CryptoContext_DeserializeSchemeSwitchingKeys(cc.ptr, (*C.char)(unsafe.Pointer(&data[0])), C.int(len(data)))
+	return checkPKEErrorMsg(status)
+}
+
+// --- BinFHEContext Serialization ---
+
+// SerializeBinFHEContextToBytes serializes a BinFHE context together with its
+// bootstrapping keys, if they have been generated.
+func SerializeBinFHEContextToBytes(cc *BinFHEContext) ([]byte, error) {
+	if !cc.acquire() {
+		return nil, errors.New("BinFHEContext is closed or invalid")
+	}
+	defer cc.release()
+	var cBytes *C.char
+	var size C.size_t
+	status := C.BinFHEContext_SerializeToBytes(cc.h, &cBytes, &size)
+	err := checkBinFHEErrorMsg(status)
+	if err != nil {
+		return nil, err
+	}
+	goBytes := C.GoBytes(unsafe.Pointer(cBytes), C.int(size))
+	C.free(unsafe.Pointer(cBytes))
+	return goBytes, nil
+}
+
+// DeserializeBinFHEContextFromBytes returns a *new* BinFHEContext, owned by
+// the caller, restored from SerializeBinFHEContextToBytes.
+func DeserializeBinFHEContextFromBytes(data []byte) (*BinFHEContext, error) {
+	if len(data) == 0 {
+		return nil, errors.New("cannot deserialize BinFHEContext from empty data")
+	}
+	var h C.BinFHEContextH
+	status := C.BinFHEContext_DeserializeFromBytes((*C.char)(unsafe.Pointer(&data[0])), C.int(len(data)), &h)
+	err := checkBinFHEErrorMsg(status)
+	if err != nil {
+		return nil, err
+	}
+	if h == nil {
+		return nil, errors.New("DeserializeBinFHEContextFromBytes returned OK but null handle")
+	}
+	return &BinFHEContext{h: h}, nil
+}
+
 // --- Helper for KeyPair Reconstruction ---
 
 // NewKeyPair creates an empty KeyPair struct. Useful for combining deserialized keys.
@@ -180,9 +471,10 @@ func NewKeyPair() (*KeyPair, error) {
 
 // GetPublicKey extracts the public key part from a KeyPair.
 func (kp *KeyPair) GetPublicKey() (unsafe.Pointer, error) {
-	if kp.ptr == nil {
+	if !kp.acquire() {
 		return nil, errors.New("KeyPair is closed or invalid")
 	}
+	defer kp.release()
 	var pkH unsafe.Pointer
 	status := C.GetPublicKey(kp.ptr, &pkH)
 	err := checkPKEErrorMsg(status)
@@ -197,9 +489,10 @@ func (kp *KeyPair) GetPublicKey() (unsafe.Pointer, error) {
 
 // GetPrivateKey extracts the private key part from a KeyPair.
 func (kp *KeyPair) GetPrivateKey() (unsafe.Pointer, error) {
-	if kp.ptr == nil {
+	if !kp.acquire() {
 		return nil, errors.New("KeyPair is closed or invalid")
 	}
+	defer kp.release()
 	var skH unsafe.Pointer
 	status := C.GetPrivateKey(kp.ptr, &skH)
 	err := checkPKEErrorMsg(status)
@@ -215,9 +508,10 @@ func (kp *KeyPair) GetPrivateKey() (unsafe.Pointer, error) {
 // SetPublicKey sets the public key part of an existing KeyPair.
 // It takes the temporary pointer returned by GetPublicKey or a deserialization.
 func (kp *KeyPair) SetPublicKey(pkPtr unsafe.Pointer) error { // ADDED error
-	if kp.ptr == nil {
+	if !kp.acquire() {
 		return errors.New("KeyPair is closed or invalid")
 	}
+	defer kp.release()
 	if pkPtr == nil {
 		return errors.New("Input public key pointer is nil")
 	}
@@ -232,9 +526,10 @@ func (kp *KeyPair) SetPublicKey(pkPtr unsafe.Pointer) error { // ADDED error
 // SetPrivateKey sets the private key part of an existing KeyPair.
 // It takes the temporary pointer returned by GetPrivateKey or a deserialization.
 func (kp *KeyPair) SetPrivateKey(skPtr unsafe.Pointer) error { // ADDED error
-	if kp.ptr == nil {
+	if !kp.acquire() {
 		return errors.New("KeyPair is closed or invalid")
 	}
+	defer kp.release()
 	if skPtr == nil {
 		return errors.New("Input private key pointer is nil")
 	}
@@ -0,0 +1,1016 @@
+package openfhe
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+)
+
+// SimScheme selects the scheme a Simulator emulates.
+type SimScheme int
+
+const (
+	SimBFV SimScheme = iota
+	SimBGV
+	SimCKKS
+)
+
+func (s SimScheme) String() string {
+	switch s {
+	case SimBFV:
+		return "BFV"
+	case SimBGV:
+		return "BGV"
+	case SimCKKS:
+		return "CKKS"
+	}
+	return fmt.Sprintf("SimScheme(%d)", int(s))
+}
+
+// SimParams configures a Simulator. Zero values select defaults where one
+// exists.
+type SimParams struct {
+	Scheme SimScheme
+
+	// RingDimension defaults to 16384.
+	RingDimension uint64
+	// BatchSize is the number of slots. It defaults to RingDimension for
+	// BFV/BGV and RingDimension/2 for CKKS.
+	BatchSize uint32
+
+	// PlaintextModulus is required for BFV/BGV and must be 1 mod
+	// 2*RingDimension, as for real packed encoding.
+	PlaintextModulus uint64
+
+	// MultiplicativeDepth is the number of multiplications (CKKS: rescales)
+	// a ciphertext can go through.
+	MultiplicativeDepth int
+
+	// ScalingTechnique is the CKKS rescaling mode. With FIXEDMANUAL (the
+	// zero value) every product must be rescaled explicitly; any other
+	// technique rescales automatically before the next multiplication.
+	ScalingTechnique int
+
+	// NoiseStdDev is the standard deviation of the Gaussian noise a CKKS
+	// simulation adds to each slot on encryption and after every
+	// multiplication, rotation and rescale. Zero gives exact results.
+	NoiseStdDev float64
+	// Seed seeds the noise generator.
+	Seed int64
+}
+
+// Simulator is a pure-Go, cleartext implementation of Evaluator. It keeps
+// the slot values in the clear and reproduces the library's semantics:
+// BFV/BGV arithmetic modulo the plaintext modulus, cyclic slot rotation,
+// CKKS level and scaling-degree accounting, and the errors raised for a
+// missing feature, a missing evaluation key or an exhausted depth.
+//
+// A Simulator provides no security whatsoever; use it for tests only.
+type Simulator struct {
+	params SimParams
+	slots  int
+	rngMu  sync.Mutex // operations may run on several goroutines
+	rng    *rand.Rand
+	closed atomic.Bool
+
+	// mu guards the enabled features and the key state, which setup calls
+	// change while operations read them, as evalKeys does for OpenFHE's
+	// key maps.
+	mu        sync.RWMutex
+	features  int
+	nextKeyID int
+	multKeys  map[int]bool
+	sumKeys   map[int]bool
+	rotKeys   map[int]map[int32]bool
+}
+
+type simKeyPair struct {
+	owner *Simulator
+	id    int
+}
+
+type simPlaintext struct {
+	ckks     bool
+	ints     []int64 // BFV/BGV, centered
+	vals     []complex128
+	length   int
+	level    int
+	scaleDeg int
+}
+
+type simCiphertext struct {
+	owner *Simulator
+	keyID int
+	ints  []int64 // BFV/BGV, reduced to [0, t)
+	vals  []complex128
+	level int
+	// scaleDeg is the CKKS noise scale degree: 2 after a multiplication
+	// that has not been rescaled yet.
+	scaleDeg int
+	// depth counts the BFV/BGV multiplications behind this ciphertext.
+	depth int
+}
+
+// NewSimulator creates a Simulator. As with a real CryptoContext, features
+// have to be enabled and keys generated before use.
+func NewSimulator(p SimParams) (*Simulator, error) {
+	if p.Scheme != SimBFV && p.Scheme != SimBGV && p.Scheme != SimCKKS {
+		return nil, fmt.Errorf("NewSimulator: unknown scheme %v", p.Scheme)
+	}
+	if p.RingDimension == 0 {
+		p.RingDimension = 1 << 14
+	}
+	if p.RingDimension&(p.RingDimension-1) != 0 {
+		return nil, fmt.Errorf("NewSimulator: ring dimension %d is not a power of two", p.RingDimension)
+	}
+	maxSlots := p.RingDimension
+	if p.Scheme == SimCKKS {
+		maxSlots /= 2
+	}
+	if p.BatchSize == 0 {
+		p.BatchSize = uint32(maxSlots)
+	}
+	if uint64(p.BatchSize) > maxSlots || p.BatchSize&(p.BatchSize-1) != 0 {
+		return nil, fmt.Errorf("NewSimulator: batch size %d must be a power of two no larger than %d", p.BatchSize, maxSlots)
+	}
+	if p.MultiplicativeDepth < 0 {
+		return nil, errors.New("NewSimulator: negative multiplicative depth")
+	}
+	if p.Scheme != SimCKKS {
+		if p.PlaintextModulus < 2 {
+			return nil, errors.New("NewSimulator: PlaintextModulus is required for BFV/BGV")
+		}
+		if p.PlaintextModulus%(2*p.RingDimension) != 1 {
+			return nil, fmt.Errorf("NewSimulator: plaintext modulus %d is not 1 mod %d and does not support packing",
+				p.PlaintextModulus, 2*p.RingDimension)
+		}
+	}
+	if p.NoiseStdDev < 0 {
+		return nil, errors.New("NewSimulator: negative noise standard deviation")
+	}
+	return &Simulator{
+		params:   p,
+		slots:    int(p.BatchSize),
+		rng:      rand.New(rand.NewSource(p.Seed)),
+		multKeys: make(map[int]bool),
+		sumKeys:  make(map[int]bool),
+		rotKeys:  make(map[int]map[int32]bool),
+	}, nil
+}
+
+// Close releases the simulator. Later calls fail like on a closed
+// CryptoContext.
+func (s *Simulator) Close() {
+	s.closed.Store(true)
+}
+
+// --- setup ---
+
+func (s *Simulator) live() error {
+	if s == nil || s.closed.Load() {
+		return errors.New("Simulator is closed or invalid")
+	}
+	return nil
+}
+
+func (s *Simulator) need(feature int, op string) error {
+	if err := s.live(); err != nil {
+		return err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.features&feature == 0 {
+		return fmt.Errorf("%s operation has not been enabled", op)
+	}
+	return nil
+}
+
+func (s *Simulator) Enable(feature int) error {
+	if err := s.live(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.features |= feature
+	return nil
+}
+
+func (s *Simulator) KeyGen() (*KeyPair, error) {
+	if err := s.need(PKE, "KeyGen"); err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextKeyID++
+	return &KeyPair{sim: &simKeyPair{owner: s, id: s.nextKeyID}}, nil
+}
+
+func (s *Simulator) keyPair(keys *KeyPair) (*simKeyPair, error) {
+	if keys == nil || keys.sim == nil || keys.closed() {
+		return nil, errors.New("KeyPair is closed or invalid")
+	}
+	if keys.sim.owner != s {
+		return nil, errors.New("KeyPair belongs to a different Simulator")
+	}
+	return keys.sim, nil
+}
+
+func (s *Simulator) EvalMultKeyGen(keys *KeyPair) error {
+	if err := s.need(LEVELEDSHE, "EvalMultKeyGen"); err != nil {
+		return err
+	}
+	kp, err := s.keyPair(keys)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.multKeys[kp.id] = true
+	return nil
+}
+
+func (s *Simulator) EvalRotateKeyGen(keys *KeyPair, indices []int32) error {
+	if err := s.need(KEYSWITCH, "EvalRotateKeyGen"); err != nil {
+		return err
+	}
+	kp, err := s.keyPair(keys)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.rotKeys[kp.id] == nil {
+		s.rotKeys[kp.id] = make(map[int32]bool)
+	}
+	for _, k := range indices {
+		s.rotKeys[kp.id][k] = true
+	}
+	return nil
+}
+
+func (s *Simulator) EvalSumKeyGen(keys *KeyPair) error {
+	if err := s.need(ADVANCEDSHE, "EvalSumKeyGen"); err != nil {
+		return err
+	}
+	kp, err := s.keyPair(keys)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sumKeys[kp.id] = true
+	return nil
+}
+
+func (s *Simulator) hasMultKey(keyID int) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.multKeys[keyID]
+}
+
+func (s *Simulator) hasSumKey(keyID int) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.sumKeys[keyID]
+}
+
+func (s *Simulator) hasRotKey(keyID int, index int32) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.rotKeys[keyID][index]
+}
+
+func (s *Simulator) GetRingDimension() uint64 {
+	if s.live() != nil {
+		return 0
+	}
+	return s.params.RingDimension
+}
+
+func (s *Simulator) GetBatchSize() uint32 {
+	if s.live() != nil {
+		return 0
+	}
+	return s.params.BatchSize
+}
+
+func (s *Simulator) GetScalingTechnique() int {
+	if s.live() != nil {
+		return -1
+	}
+	switch s.params.Scheme {
+	case SimCKKS:
+		return s.params.ScalingTechnique
+	case SimBGV:
+		return FLEXIBLEAUTO
+	}
+	return NORESCALE
+}
+
+// --- encoding ---
+
+func (s *Simulator) ckks() bool { return s.params.Scheme == SimCKKS }
+
+func (s *Simulator) manual() bool { return s.ckks() && s.params.ScalingTechnique == FIXEDMANUAL }
+
+func (s *Simulator) MakePackedPlaintext(vec []int64) (*Plaintext, error) {
+	if err := s.live(); err != nil {
+		return nil, err
+	}
+	if s.ckks() {
+		return nil, errors.New("MakePackedPlaintext is not available for CKKS")
+	}
+	if len(vec) == 0 {
+		return nil, errors.New("MakePackedPlaintext: empty vector")
+	}
+	if len(vec) > s.slots {
+		return nil, fmt.Errorf("MakePackedPlaintext: %d values exceed %d slots", len(vec), s.slots)
+	}
+	t := int64(s.params.PlaintextModulus)
+	ints := make([]int64, s.slots)
+	for i, v := range vec {
+		if v >= t || v <= -t {
+			return nil, fmt.Errorf("Cannot encode integer %d at position %d that is > plaintext modulus %d", v, i, t)
+		}
+		ints[i] = v
+	}
+	return &Plaintext{sim: &simPlaintext{ints: ints, length: len(vec), scaleDeg: 1}}, nil
+}
+
+func (s *Simulator) MakeCKKSPackedPlaintext(vec []float64) (*Plaintext, error) {
+	return s.MakeCKKSPackedPlaintextWithParams(vec, 1, 0, 0)
+}
+
+func (s *Simulator) MakeCKKSPackedPlaintextWithParams(vec []float64, noiseScaleDeg, level, slots uint32) (*Plaintext, error) {
+	vals := make([]complex128, len(vec))
+	for i, v := range vec {
+		vals[i] = complex(v, 0)
+	}
+	return s.makeCKKS(vals, noiseScaleDeg, level, slots)
+}
+
+func (s *Simulator) MakeCKKSComplexPackedPlaintext(vec []complex128) (*Plaintext, error) {
+	return s.MakeCKKSComplexPackedPlaintextWithParams(vec, 1, 0, 0)
+}
+
+func (s *Simulator) MakeCKKSComplexPackedPlaintextWithParams(vec []complex128, noiseScaleDeg, level, slots uint32) (*Plaintext, error) {
+	return s.makeCKKS(vec, noiseScaleDeg, level, slots)
+}
+
+func (s *Simulator) makeCKKS(vec []complex128, noiseScaleDeg, level, slots uint32) (*Plaintext, error) {
+	if err := s.live(); err != nil {
+		return nil, err
+	}
+	if !s.ckks() {
+		return nil, fmt.Errorf("CKKS encoding is not available for %v", s.params.Scheme)
+	}
+	if len(vec) == 0 {
+		return nil, errors.New("MakeCKKSPackedPlaintext: empty vector")
+	}
+	period := s.slots
+	if slots != 0 {
+		if int(slots) > s.slots || slots&(slots-1) != 0 {
+			return nil, fmt.Errorf("MakeCKKSPackedPlaintext: invalid slot count %d", slots)
+		}
+		period = int(slots)
+	}
+	if len(vec) > period {
+		return nil, fmt.Errorf("MakeCKKSPackedPlaintext: %d values exceed %d slots", len(vec), period)
+	}
+	if noiseScaleDeg < 1 {
+		return nil, errors.New("MakeCKKSPackedPlaintext: noise scale degree must be at least 1")
+	}
+	if int(level)+int(noiseScaleDeg)-1 > s.params.MultiplicativeDepth {
+		return nil, fmt.Errorf("MakeCKKSPackedPlaintext: level %d is beyond the multiplicative depth %d",
+			level, s.params.MultiplicativeDepth)
+	}
+	// A plaintext with fewer slots is replicated across the full batch.
+	vals := make([]complex128, s.slots)
+	for i := range vals {
+		if j := i % period; j < len(vec) {
+			vals[i] = vec[j]
+		}
+	}
+	return &Plaintext{sim: &simPlaintext{
+		ckks:     true,
+		vals:     vals,
+		length:   len(vec),
+		level:    int(level),
+		scaleDeg: int(noiseScaleDeg),
+	}}, nil
+}
+
+func (s *Simulator) plaintext(pt *Plaintext) (*simPlaintext, error) {
+	if pt == nil || pt.sim == nil || pt.closed() {
+		return nil, errors.New("Input Plaintext is closed or invalid")
+	}
+	if pt.sim.ckks != s.ckks() {
+		return nil, errors.New("Plaintext encoding does not match the Simulator scheme")
+	}
+	return pt.sim, nil
+}
+
+// --- encryption ---
+
+func (s *Simulator) Encrypt(keys *KeyPair, pt *Plaintext) (*Ciphertext, error) {
+	if err := s.need(PKE, "Encrypt"); err != nil {
+		return nil, err
+	}
+	kp, err := s.keyPair(keys)
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.plaintext(pt)
+	if err != nil {
+		return nil, err
+	}
+	c := &simCiphertext{owner: s, keyID: kp.id, level: p.level, scaleDeg: p.scaleDeg}
+	if p.ckks {
+		c.vals = append([]complex128(nil), p.vals...)
+		s.addNoise(c)
+	} else {
+		c.ints = make([]int64, len(p.ints))
+		for i, v := range p.ints {
+			c.ints[i] = s.reduce(v)
+		}
+	}
+	return &Ciphertext{sim: c}, nil
+}
+
+func (s *Simulator) Decrypt(keys *KeyPair, ct *Ciphertext) (*Plaintext, error) {
+	if err := s.need(PKE, "Decrypt"); err != nil {
+		return nil, err
+	}
+	kp, err := s.keyPair(keys)
+	if err != nil {
+		return nil, err
+	}
+	c, err := s.ciphertext(ct)
+	if err != nil {
+		return nil, err
+	}
+	if c.keyID != kp.id {
+		return nil, errors.New("Decrypt: ciphertext was not encrypted under this key")
+	}
+	p := &simPlaintext{ckks: s.ckks(), length: s.slots, level: c.level, scaleDeg: c.scaleDeg}
+	if p.ckks {
+		p.vals = append([]complex128(nil), c.vals...)
+	} else {
+		p.ints = make([]int64, len(c.ints))
+		for i, v := range c.ints {
+			p.ints[i] = s.center(v)
+		}
+	}
+	return &Plaintext{sim: p}, nil
+}
+
+// --- arithmetic ---
+
+func (s *Simulator) ciphertext(ct *Ciphertext) (*simCiphertext, error) {
+	if err := s.live(); err != nil {
+		return nil, err
+	}
+	if ct == nil || ct.sim == nil || ct.closed() {
+		return nil, errors.New("Input Ciphertext is closed or invalid")
+	}
+	if ct.sim.owner != s {
+		return nil, errors.New("Ciphertext belongs to a different Simulator")
+	}
+	return ct.sim, nil
+}
+
+func (s *Simulator) pair(ct1, ct2 *Ciphertext) (*simCiphertext, *simCiphertext, error) {
+	a, err := s.ciphertext(ct1)
+	if err != nil {
+		return nil, nil, err
+	}
+	b, err := s.ciphertext(ct2)
+	if err != nil {
+		return nil, nil, err
+	}
+	if a.keyID != b.keyID {
+		return nil, nil, errors.New("Ciphertexts were not encrypted under the same key")
+	}
+	return a, b, nil
+}
+
+func (s *Simulator) EvalAdd(ct1, ct2 *Ciphertext) (*Ciphertext, error) {
+	return s.addSub(ct1, ct2, 1, "EvalAdd")
+}
+
+func (s *Simulator) EvalSub(ct1, ct2 *Ciphertext) (*Ciphertext, error) {
+	return s.addSub(ct1, ct2, -1, "EvalSub")
+}
+
+func (s *Simulator) addSub(ct1, ct2 *Ciphertext, sign int64, op string) (*Ciphertext, error) {
+	if err := s.need(LEVELEDSHE, op); err != nil {
+		return nil, err
+	}
+	a, b, err := s.pair(ct1, ct2)
+	if err != nil {
+		return nil, err
+	}
+	a, b, err = s.align(a, b, op)
+	if err != nil {
+		return nil, err
+	}
+	r := a.derive()
+	r.depth = max(a.depth, b.depth)
+	if s.ckks() {
+		for i := range r.vals {
+			r.vals[i] += complex(float64(sign), 0) * b.vals[i]
+		}
+	} else {
+		for i := range r.ints {
+			r.ints[i] = s.addMod(r.ints[i], s.reduce(sign*s.center(b.ints[i])))
+		}
+	}
+	return &Ciphertext{sim: r}, nil
+}
+
+func (s *Simulator) EvalMult(ct1, ct2 *Ciphertext) (*Ciphertext, error) {
+	if err := s.need(LEVELEDSHE, "EvalMult"); err != nil {
+		return nil, err
+	}
+	a, b, err := s.pair(ct1, ct2)
+	if err != nil {
+		return nil, err
+	}
+	if !s.hasMultKey(a.keyID) {
+		return nil, errors.New("EvalMult: relinearization key has not been generated; call EvalMultKeyGen")
+	}
+	if s.ckks() && !s.manual() {
+		a, b = s.autoRescale(a), s.autoRescale(b)
+	}
+	a, b, err = s.align(a, b, "EvalMult")
+	if err != nil {
+		return nil, err
+	}
+	r := a.derive()
+	if s.ckks() {
+		r.scaleDeg = a.scaleDeg + b.scaleDeg
+		if err := s.checkBudget(r, "EvalMult"); err != nil {
+			return nil, err
+		}
+		for i := range r.vals {
+			r.vals[i] *= b.vals[i]
+		}
+		s.addNoise(r)
+		return &Ciphertext{sim: r}, nil
+	}
+
+	r.depth = max(a.depth, b.depth) + 1
+	if r.depth > s.params.MultiplicativeDepth {
+		return nil, fmt.Errorf("EvalMult: multiplicative depth %d exceeded", s.params.MultiplicativeDepth)
+	}
+	if s.params.Scheme == SimBGV {
+		r.level = r.depth
+	}
+	for i := range r.ints {
+		r.ints[i] = s.mulMod(r.ints[i], b.ints[i])
+	}
+	return &Ciphertext{sim: r}, nil
+}
+
+// EvalSquare is EvalMult of ct with itself.
+func (s *Simulator) EvalSquare(ct *Ciphertext) (*Ciphertext, error) {
+	return s.EvalMult(ct, ct)
+}
+
+func (s *Simulator) EvalAddPlain(ct *Ciphertext, pt *Plaintext) (*Ciphertext, error) {
+	return s.plainOp(ct, pt, "EvalAddPlain")
+}
+
+func (s *Simulator) EvalSubPlain(ct *Ciphertext, pt *Plaintext) (*Ciphertext, error) {
+	return s.plainOp(ct, pt, "EvalSubPlain")
+}
+
+func (s *Simulator) EvalMultPlain(ct *Ciphertext, pt *Plaintext) (*Ciphertext, error) {
+	return s.plainOp(ct, pt, "EvalMultPlain")
+}
+
+func (s *Simulator) plainOp(ct *Ciphertext, pt *Plaintext, op string) (*Ciphertext, error) {
+	if err := s.need(LEVELEDSHE, op); err != nil {
+		return nil, err
+	}
+	c, err := s.ciphertext(ct)
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.plaintext(pt)
+	if err != nil {
+		return nil, err
+	}
+	mult := op == "EvalMultPlain"
+	if s.ckks() {
+		if mult && !s.manual() {
+			c = s.autoRescale(c)
+		}
+		if p.level > c.level {
+			return nil, fmt.Errorf("%s: plaintext level %d is above ciphertext level %d", op, p.level, c.level)
+		}
+		if !mult && s.manual() && p.scaleDeg != c.scaleDeg {
+			return nil, fmt.Errorf("%s: noise scale degree mismatch (%d vs %d)", op, c.scaleDeg, p.scaleDeg)
+		}
+	}
+
+	r := c.derive()
+	switch {
+	case s.ckks() && mult:
+		r.scaleDeg = c.scaleDeg + p.scaleDeg
+		if err := s.checkBudget(r, op); err != nil {
+			return nil, err
+		}
+		for i := range r.vals {
+			r.vals[i] *= p.vals[i]
+		}
+		s.addNoise(r)
+	case s.ckks():
+		sign := complex(1, 0)
+		if op == "EvalSubPlain" {
+			sign = -1
+		}
+		for i := range r.vals {
+			r.vals[i] += sign * p.vals[i]
+		}
+	default:
+		for i := range r.ints {
+			v := s.reduce(p.ints[i])
+			switch op {
+			case "EvalAddPlain":
+				r.ints[i] = s.addMod(r.ints[i], v)
+			case "EvalSubPlain":
+				r.ints[i] = s.addMod(r.ints[i], s.reduce(-p.ints[i]))
+			default:
+				r.ints[i] = s.mulMod(r.ints[i], v)
+			}
+		}
+	}
+	return &Ciphertext{sim: r}, nil
+}
+
+func (s *Simulator) EvalRotate(ct *Ciphertext, index int32) (*Ciphertext, error) {
+	if err := s.need(KEYSWITCH, "EvalRotate"); err != nil {
+		return nil, err
+	}
+	c, err := s.ciphertext(ct)
+	if err != nil {
+		return nil, err
+	}
+	if index != 0 && !s.hasRotKey(c.keyID, index) {
+		return nil, fmt.Errorf("EvalRotate: rotation key for index %d has not been generated", index)
+	}
+	r := s.rotate(c, int(index))
+	if index != 0 {
+		s.addNoise(r)
+	}
+	return &Ciphertext{sim: r}, nil
+}
+
+func (s *Simulator) Rescale(ct *Ciphertext) (*Ciphertext, error) {
+	return s.modReduce(ct, "Rescale")
+}
+
+func (s *Simulator) ModReduce(ct *Ciphertext) (*Ciphertext, error) {
+	return s.modReduce(ct, "ModReduce")
+}
+
+func (s *Simulator) modReduce(ct *Ciphertext, op string) (*Ciphertext, error) {
+	if err := s.need(LEVELEDSHE, op); err != nil {
+		return nil, err
+	}
+	c, err := s.ciphertext(ct)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case s.params.Scheme == SimBFV:
+		return nil, fmt.Errorf("%s is not supported for BFV", op)
+	case !s.manual():
+		// Automatic techniques rescale on their own; the call is a no-op.
+		return &Ciphertext{sim: c.derive()}, nil
+	case c.scaleDeg < 2:
+		return nil, fmt.Errorf("%s: ciphertext has no pending scaling factor to remove", op)
+	}
+	r := c.derive()
+	r.level++
+	r.scaleDeg--
+	s.addNoise(r)
+	return &Ciphertext{sim: r}, nil
+}
+
+func (s *Simulator) EvalSum(ct *Ciphertext, batchSize uint32) (*Ciphertext, error) {
+	if err := s.need(ADVANCEDSHE, "EvalSum"); err != nil {
+		return nil, err
+	}
+	c, err := s.ciphertext(ct)
+	if err != nil {
+		return nil, err
+	}
+	if !s.hasSumKey(c.keyID) {
+		return nil, errors.New("EvalSum: summation keys have not been generated; call EvalSumKeyGen")
+	}
+	return &Ciphertext{sim: s.sum(c, int(batchSize))}, nil
+}
+
+func (s *Simulator) EvalInnerProduct(ct1, ct2 *Ciphertext, batchSize uint32) (*Ciphertext, error) {
+	if err := s.need(ADVANCEDSHE, "EvalInnerProduct"); err != nil {
+		return nil, err
+	}
+	prod, err := s.EvalMult(ct1, ct2)
+	if err != nil {
+		return nil, err
+	}
+	defer prod.Close()
+	if !s.hasSumKey(prod.sim.keyID) {
+		return nil, errors.New("EvalInnerProduct: summation keys have not been generated; call EvalSumKeyGen")
+	}
+	return &Ciphertext{sim: s.sum(prod.sim, int(batchSize))}, nil
+}
+
+// EvalPoly evaluates the polynomial on every slot. It consumes
+// ceil(log2(degree+1)) levels, the depth of OpenFHE's evaluation.
+func (s *Simulator) EvalPoly(ct *Ciphertext, coefficients []float64) (*Ciphertext, error) {
+	if err := s.need(ADVANCEDSHE, "EvalPoly"); err != nil {
+		return nil, err
+	}
+	c, err := s.ciphertext(ct)
+	if err != nil {
+		return nil, err
+	}
+	if len(coefficients) == 0 {
+		return nil, errors.New("EvalPoly requires at least one coefficient")
+	}
+	if !s.ckks() {
+		return nil, fmt.Errorf("EvalPoly is not supported for %v", s.params.Scheme)
+	}
+	if !s.hasMultKey(c.keyID) {
+		return nil, errors.New("EvalPoly: relinearization key has not been generated; call EvalMultKeyGen")
+	}
+	if s.manual() && c.scaleDeg != 1 {
+		return nil, errors.New("EvalPoly: input must be rescaled first")
+	}
+	c = s.autoRescale(c)
+
+	r := c.derive()
+	r.level += PolyDepth(len(coefficients) - 1)
+	if err := s.checkBudget(r, "EvalPoly"); err != nil {
+		return nil, err
+	}
+	for i, x := range r.vals {
+		var acc complex128
+		for k := len(coefficients) - 1; k >= 0; k-- {
+			acc = acc*x + complex(coefficients[k], 0)
+		}
+		r.vals[i] = acc
+	}
+	s.addNoise(r)
+	return &Ciphertext{sim: r}, nil
+}
+
+// EvalChebyshevSeries evaluates the series on every slot. It consumes
+// ChebyshevDepth(degree) levels.
+func (s *Simulator) EvalChebyshevSeries(ct *Ciphertext, coefficients []float64, a, b float64) (*Ciphertext, error) {
+	if err := s.need(ADVANCEDSHE, "EvalChebyshevSeries"); err != nil {
+		return nil, err
+	}
+	c, err := s.ciphertext(ct)
+	if err != nil {
+		return nil, err
+	}
+	if len(coefficients) == 0 {
+		return nil, errors.New("EvalChebyshevSeries requires at least one coefficient")
+	}
+	if !(a < b) {
+		return nil, fmt.Errorf("EvalChebyshevSeries: interval [%g, %g] is empty", a, b)
+	}
+	if !s.ckks() {
+		return nil, fmt.Errorf("EvalChebyshevSeries is not supported for %v", s.params.Scheme)
+	}
+	if !s.hasMultKey(c.keyID) {
+		return nil, errors.New("EvalChebyshevSeries: relinearization key has not been generated; call EvalMultKeyGen")
+	}
+	if s.manual() && c.scaleDeg != 1 {
+		return nil, errors.New("EvalChebyshevSeries: input must be rescaled first")
+	}
+	depth := ChebyshevDepth(len(coefficients) - 1)
+	if depth < 0 {
+		return nil, fmt.Errorf("EvalChebyshevSeries: degree %d is not supported", len(coefficients)-1)
+	}
+	c = s.autoRescale(c)
+
+	r := c.derive()
+	r.level += depth
+	if err := s.checkBudget(r, "EvalChebyshevSeries"); err != nil {
+		return nil, err
+	}
+	for i, x := range r.vals {
+		// Clenshaw's recurrence.
+		y := (2*x - complex(a+b, 0)) / complex(b-a, 0)
+		var b1, b2 complex128
+		for k := len(coefficients) - 1; k >= 1; k-- {
+			b1, b2 = 2*y*b1-b2+complex(coefficients[k], 0), b1
+		}
+		r.vals[i] = y*b1 - b2 + complex(coefficients[0]/2, 0)
+	}
+	s.addNoise(r)
+	return &Ciphertext{sim: r}, nil
+}
+
+// --- helpers ---
+
+func (c *simCiphertext) derive() *simCiphertext {
+	r := *c
+	r.ints = append([]int64(nil), c.ints...)
+	r.vals = append([]complex128(nil), c.vals...)
+	return &r
+}
+
+func (s *Simulator) checkBudget(c *simCiphertext, op string) error {
+	if c.level+c.scaleDeg-1 > s.params.MultiplicativeDepth {
+		return fmt.Errorf("%s: multiplicative depth %d exceeded", op, s.params.MultiplicativeDepth)
+	}
+	return nil
+}
+
+// autoRescale returns c rescaled if it carries a pending scaling factor.
+func (s *Simulator) autoRescale(c *simCiphertext) *simCiphertext {
+	if c.scaleDeg < 2 {
+		return c
+	}
+	r := c.derive()
+	r.level += r.scaleDeg - 1
+	r.scaleDeg = 1
+	return r
+}
+
+// align brings a and b to the same level, as the library does before
+// combining two ciphertexts.
+func (s *Simulator) align(a, b *simCiphertext, op string) (*simCiphertext, *simCiphertext, error) {
+	if !s.ckks() {
+		return a, b, nil
+	}
+	if a.scaleDeg != b.scaleDeg {
+		if s.manual() {
+			return nil, nil, fmt.Errorf("%s: noise scale degree mismatch (%d vs %d); Rescale first", op, a.scaleDeg, b.scaleDeg)
+		}
+		a, b = s.autoRescale(a), s.autoRescale(b)
+	}
+	if a.level < b.level {
+		a = a.derive()
+		a.level = b.level
+	} else if b.level < a.level {
+		b = b.derive()
+		b.level = a.level
+	}
+	return a, b, nil
+}
+
+// source returns the slot that rotation by k moves into slot i. BFV/BGV
+// slots with a full batch form two rows that rotate independently.
+func (s *Simulator) source(i, k int) int {
+	n := s.slots
+	if !s.ckks() && uint64(n) == s.params.RingDimension {
+		half := n / 2
+		row, col := i/half, i%half
+		return row*half + ((col+k)%half+half)%half
+	}
+	return ((i+k)%n + n) % n
+}
+
+func (s *Simulator) rotate(c *simCiphertext, k int) *simCiphertext {
+	r := c.derive()
+	for i := range r.vals {
+		r.vals[i] = c.vals[s.source(i, k)]
+	}
+	for i := range r.ints {
+		r.ints[i] = c.ints[s.source(i, k)]
+	}
+	return r
+}
+
+// swapRows exchanges the two rows of a full BFV/BGV batch, like the
+// conjugation automorphism.
+func (s *Simulator) swapRows(c *simCiphertext) *simCiphertext {
+	r := c.derive()
+	half := len(r.ints) / 2
+	for i := range r.ints {
+		r.ints[i] = c.ints[(i+half)%len(c.ints)]
+	}
+	return r
+}
+
+// sum follows the library's EvalSum: rotations by powers of two below
+// batchSize, except that for a BFV/BGV batch wider than one row they stop
+// at the row length and the conjugation automorphism adds the other row.
+func (s *Simulator) sum(c *simCiphertext, batchSize int) *simCiphertext {
+	acc := c.derive()
+	add := func(o *simCiphertext) {
+		for i := range acc.vals {
+			acc.vals[i] += o.vals[i]
+		}
+		for i := range acc.ints {
+			acc.ints[i] = s.addMod(acc.ints[i], o.ints[i])
+		}
+	}
+	half := int(s.params.RingDimension / 2)
+	bothRows := !s.ckks() && batchSize > half
+	if bothRows {
+		batchSize = half
+	}
+	for step := 1; step < batchSize; step <<= 1 {
+		add(s.rotate(acc, step))
+	}
+	if bothRows {
+		add(s.swapRows(acc))
+	}
+	s.addNoise(acc)
+	return acc
+}
+
+func (s *Simulator) addNoise(c *simCiphertext) {
+	sigma := s.params.NoiseStdDev
+	if sigma == 0 {
+		return
+	}
+	s.rngMu.Lock()
+	defer s.rngMu.Unlock()
+	for i := range c.vals {
+		c.vals[i] += complex(s.rng.NormFloat64()*sigma, s.rng.NormFloat64()*sigma)
+	}
+}
+
+// reduce maps v to [0, t).
+func (s *Simulator) reduce(v int64) int64 {
+	t := int64(s.params.PlaintextModulus)
+	v %= t
+	if v < 0 {
+		v += t
+	}
+	return v
+}
+
+// center maps v in [0, t) to the signed range the library decodes to.
+func (s *Simulator) center(v int64) int64 {
+	t := int64(s.params.PlaintextModulus)
+	if v > t/2 {
+		return v - t
+	}
+	return v
+}
+
+func (s *Simulator) addMod(a, b int64) int64 {
+	t := s.params.PlaintextModulus
+	sum := uint64(a) + uint64(b)
+	if sum >= t {
+		sum -= t
+	}
+	return int64(sum)
+}
+
+func (s *Simulator) mulMod(a, b int64) int64 {
+	hi, lo := bits.Mul64(uint64(a), uint64(b))
+	return int64(bits.Rem64(hi, lo, s.params.PlaintextModulus))
+}
+
+// --- plaintext and ciphertext accessors ---
+
+func (p *simPlaintext) decodeInts(dst []int64) ([]int64, error) {
+	if p.ckks {
+		return nil, errors.New("Plaintext is not a packed BFV/BGV plaintext")
+	}
+	return append(dst[:0], p.ints[:p.length]...), nil
+}
+
+func (p *simPlaintext) decodeReal(dst []float64) ([]float64, error) {
+	if !p.ckks {
+		return nil, errors.New("Plaintext is not a CKKS plaintext")
+	}
+	dst = dst[:0]
+	for _, v := range p.vals[:p.length] {
+		dst = append(dst, real(v))
+	}
+	return dst, nil
+}
+
+func (p *simPlaintext) decodeComplex(dst []complex128) ([]complex128, error) {
+	if !p.ckks {
+		return nil, errors.New("Plaintext is not a CKKS plaintext")
+	}
+	return append(dst[:0], p.vals[:p.length]...), nil
+}
+
+func (p *simPlaintext) setLength(n int) error {
+	size := len(p.ints)
+	if p.ckks {
+		size = len(p.vals)
+	}
+	if n < 0 || n > size {
+		return fmt.Errorf("SetLength: length %d out of range [0, %d]", n, size)
+	}
+	p.length = n
+	return nil
+}
@@ -0,0 +1,472 @@
+package openfhe
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+func newTestSimulator(t *testing.T, p SimParams) (*Simulator, *KeyPair) {
+	t.Helper()
+	sim, err := NewSimulator(p)
+	mustT(t, err, "NewSimulator")
+	for _, f := range []int{PKE, KEYSWITCH, LEVELEDSHE, ADVANCEDSHE} {
+		mustT(t, sim.Enable(f), "Enable")
+	}
+	keys, err := sim.KeyGen()
+	mustT(t, err, "KeyGen")
+	mustT(t, sim.EvalMultKeyGen(keys), "EvalMultKeyGen")
+	return sim, keys
+}
+
+func encryptInts(t *testing.T, ev Evaluator, keys *KeyPair, vals []int64) *Ciphertext {
+	t.Helper()
+	pt, err := ev.MakePackedPlaintext(vals)
+	mustT(t, err, "MakePackedPlaintext")
+	defer pt.Close()
+	ct, err := ev.Encrypt(keys, pt)
+	mustT(t, err, "Encrypt")
+	return ct
+}
+
+func decryptInts(t *testing.T, ev Evaluator, keys *KeyPair, ct *Ciphertext, n int) []int64 {
+	t.Helper()
+	pt, err := ev.Decrypt(keys, ct)
+	mustT(t, err, "Decrypt")
+	defer pt.Close()
+	mustT(t, pt.SetLength(n), "SetLength")
+	vals, err := pt.GetPackedValue()
+	mustT(t, err, "GetPackedValue")
+	return vals
+}
+
+func encryptReals(t *testing.T, ev Evaluator, keys *KeyPair, vals []float64) *Ciphertext {
+	t.Helper()
+	pt, err := ev.MakeCKKSPackedPlaintext(vals)
+	mustT(t, err, "MakeCKKSPackedPlaintext")
+	defer pt.Close()
+	ct, err := ev.Encrypt(keys, pt)
+	mustT(t, err, "Encrypt")
+	return ct
+}
+
+func decryptReals(t *testing.T, ev Evaluator, keys *KeyPair, ct *Ciphertext, n int) []float64 {
+	t.Helper()
+	pt, err := ev.Decrypt(keys, ct)
+	mustT(t, err, "Decrypt")
+	defer pt.Close()
+	vals, err := pt.GetRealPackedValue()
+	mustT(t, err, "GetRealPackedValue")
+	if len(vals) < n {
+		t.Fatalf("decrypted %d slots, expected at least %d", len(vals), n)
+	}
+	return vals[:n]
+}
+
+func TestSimulatorBFV(t *testing.T) {
+	sim, keys := newTestSimulator(t, SimParams{
+		Scheme:              SimBFV,
+		RingDimension:       32,
+		PlaintextModulus:    65537,
+		MultiplicativeDepth: 2,
+	})
+	defer sim.Close()
+	mustT(t, sim.EvalRotateKeyGen(keys, []int32{1, -2}), "EvalRotateKeyGen")
+
+	a := encryptInts(t, sim, keys, []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12})
+	defer a.Close()
+	b := encryptInts(t, sim, keys, []int64{300, -5, 0, 1})
+	defer b.Close()
+
+	prod, err := sim.EvalMult(a, b)
+	mustT(t, err, "EvalMult")
+	defer prod.Close()
+	sq, err := sim.EvalMult(b, b)
+	mustT(t, err, "EvalMult square")
+	defer sq.Close()
+	if got, want := decryptInts(t, sim, keys, prod, 4), []int64{300, -10, 0, 4}; !slicesEqual(got, want) {
+		t.Errorf("EvalMult = %v, expected %v", got, want)
+	}
+	// 300^2 = 90000 = 24463 mod 65537
+	if got, want := decryptInts(t, sim, keys, sq, 2), []int64{24463, 25}; !slicesEqual(got, want) {
+		t.Errorf("EvalMult square = %v, expected %v", got, want)
+	}
+
+	rot1, err := sim.EvalRotate(a, 1)
+	mustT(t, err, "EvalRotate 1")
+	defer rot1.Close()
+	rotNeg2, err := sim.EvalRotate(a, -2)
+	mustT(t, err, "EvalRotate -2")
+	defer rotNeg2.Close()
+	if got, want := decryptInts(t, sim, keys, rot1, 12), []int64{2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 0}; !slicesEqual(got, want) {
+		t.Errorf("EvalRotate(1) = %v, expected %v", got, want)
+	}
+	if got, want := decryptInts(t, sim, keys, rotNeg2, 12), []int64{0, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}; !slicesEqual(got, want) {
+		t.Errorf("EvalRotate(-2) = %v, expected %v", got, want)
+	}
+
+	mustT(t, sim.EvalSumKeyGen(keys), "EvalSumKeyGen")
+	sum, err := sim.EvalSum(a, 16)
+	mustT(t, err, "EvalSum")
+	defer sum.Close()
+	if got := decryptInts(t, sim, keys, sum, 1); got[0] != 78 {
+		t.Errorf("EvalSum = %v, expected 78", got[0])
+	}
+}
+
+// With batchSize == RingDimension the slots form two rows: EvalSum over
+// one row stays in it, while a wider sum adds the other row through the
+// conjugation automorphism, as the library does.
+func TestSimulatorEvalSumFullBatch(t *testing.T) {
+	sim, keys := newTestSimulator(t, SimParams{
+		Scheme:              SimBFV,
+		RingDimension:       32,
+		BatchSize:           32,
+		PlaintextModulus:    65537,
+		MultiplicativeDepth: 1,
+	})
+	defer sim.Close()
+	mustT(t, sim.EvalSumKeyGen(keys), "EvalSumKeyGen")
+
+	vals := make([]int64, 32)
+	for i := range vals {
+		vals[i] = int64(i + 1)
+	}
+	ct := encryptInts(t, sim, keys, vals)
+	defer ct.Close()
+
+	row, err := sim.EvalSum(ct, 16)
+	mustT(t, err, "EvalSum row")
+	defer row.Close()
+	got := decryptInts(t, sim, keys, row, 32)
+	if got[0] != 136 || got[16] != 392 {
+		t.Errorf("EvalSum(16) = %d and %d in the two rows, expected 136 and 392", got[0], got[16])
+	}
+
+	all, err := sim.EvalSum(ct, 32)
+	mustT(t, err, "EvalSum all")
+	defer all.Close()
+	for i, v := range decryptInts(t, sim, keys, all, 32) {
+		if v != 528 {
+			t.Fatalf("EvalSum(32) slot %d = %d, expected 528", i, v)
+		}
+	}
+}
+
+func TestSimulatorErrors(t *testing.T) {
+	sim, keys := newTestSimulator(t, SimParams{
+		Scheme:              SimBFV,
+		RingDimension:       32,
+		PlaintextModulus:    65537,
+		MultiplicativeDepth: 1,
+	})
+	defer sim.Close()
+
+	ct := encryptInts(t, sim, keys, []int64{2, 3})
+	defer ct.Close()
+
+	expectErr := func(err error, substr string) {
+		t.Helper()
+		if err == nil || !strings.Contains(err.Error(), substr) {
+			t.Errorf("error = %v, expected it to mention %q", err, substr)
+		}
+	}
+
+	_, err := sim.EvalRotate(ct, 3)
+	expectErr(err, "rotation key for index 3")
+	_, err = sim.EvalSum(ct, 4)
+	expectErr(err, "EvalSumKeyGen")
+
+	sq, err := sim.EvalMult(ct, ct)
+	mustT(t, err, "EvalMult")
+	defer sq.Close()
+	_, err = sim.EvalMult(sq, ct)
+	expectErr(err, "depth")
+
+	_, err = sim.MakePackedPlaintext([]int64{65537})
+	expectErr(err, "plaintext modulus")
+
+	other, err := sim.KeyGen()
+	mustT(t, err, "KeyGen")
+	_, err = sim.Decrypt(other, ct)
+	expectErr(err, "key")
+
+	bare, err := NewSimulator(SimParams{Scheme: SimCKKS, RingDimension: 32})
+	mustT(t, err, "NewSimulator")
+	_, err = bare.KeyGen()
+	expectErr(err, "not been enabled")
+
+	if _, err := NewSimulator(SimParams{Scheme: SimBFV, RingDimension: 32, PlaintextModulus: 65539}); err == nil {
+		t.Error("expected error for a plaintext modulus without packing support")
+	}
+
+	sim.Close()
+	_, err = sim.EvalAdd(ct, ct)
+	expectErr(err, "closed")
+}
+
+func TestSimulatorConcurrentKeyGen(t *testing.T) {
+	sim, err := NewSimulator(SimParams{
+		Scheme:              SimBFV,
+		RingDimension:       64,
+		PlaintextModulus:    65537,
+		MultiplicativeDepth: 1,
+	})
+	mustT(t, err, "NewSimulator")
+	defer sim.Close()
+
+	// Setup and evaluation on several goroutines at once, as under the
+	// race detector; each goroutine uses its own key pair.
+	ids := make([]int, stressGoroutines)
+	var wg sync.WaitGroup
+	for g := range stressGoroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, f := range []int{PKE, KEYSWITCH, LEVELEDSHE} {
+				if err := sim.Enable(f); err != nil {
+					t.Errorf("Enable: %v", err)
+					return
+				}
+			}
+			keys, err := sim.KeyGen()
+			if err != nil {
+				t.Errorf("KeyGen: %v", err)
+				return
+			}
+			defer keys.Close()
+			ids[g] = keys.sim.id
+			index := int32(g%7 + 1)
+			if err := sim.EvalMultKeyGen(keys); err != nil {
+				t.Errorf("EvalMultKeyGen: %v", err)
+				return
+			}
+			if err := sim.EvalRotateKeyGen(keys, []int32{index}); err != nil {
+				t.Errorf("EvalRotateKeyGen: %v", err)
+				return
+			}
+			pt, err := sim.MakePackedPlaintext([]int64{int64(g), 1})
+			if err != nil {
+				t.Errorf("MakePackedPlaintext: %v", err)
+				return
+			}
+			defer pt.Close()
+			ct, err := sim.Encrypt(keys, pt)
+			if err != nil {
+				t.Errorf("Encrypt: %v", err)
+				return
+			}
+			defer ct.Close()
+			rot, err := sim.EvalRotate(ct, index)
+			if err != nil {
+				t.Errorf("EvalRotate: %v", err)
+				return
+			}
+			defer rot.Close()
+			sq, err := sim.EvalMult(rot, rot)
+			if err != nil {
+				t.Errorf("EvalMult: %v", err)
+				return
+			}
+			sq.Close()
+		}()
+	}
+	wg.Wait()
+
+	seen := make(map[int]bool)
+	for _, id := range ids {
+		if seen[id] {
+			t.Fatalf("KeyGen handed out key id %d twice", id)
+		}
+		seen[id] = true
+	}
+}
+
+func TestSimulatorCKKSManualLevels(t *testing.T) {
+	sim, keys := newTestSimulator(t, SimParams{
+		Scheme:              SimCKKS,
+		RingDimension:       64,
+		BatchSize:           8,
+		MultiplicativeDepth: 2,
+		ScalingTechnique:    FIXEDMANUAL,
+	})
+	defer sim.Close()
+
+	x := encryptReals(t, sim, keys, []float64{1, 2, 3, 4})
+	defer x.Close()
+
+	sq, err := sim.EvalMult(x, x)
+	mustT(t, err, "EvalMult")
+	defer sq.Close()
+	if _, err := sim.EvalAdd(sq, x); err == nil {
+		t.Error("expected error adding ciphertexts with different scaling degrees")
+	}
+
+	sqR, err := sim.Rescale(sq)
+	mustT(t, err, "Rescale")
+	defer sqR.Close()
+	if level, _ := sqR.GetLevel(); level != 1 {
+		t.Errorf("level after Rescale = %d, expected 1", level)
+	}
+	if _, err := sim.Rescale(sqR); err == nil {
+		t.Error("expected error rescaling a rescaled ciphertext")
+	}
+
+	// x is brought to level 1 automatically.
+	sum, err := sim.EvalAdd(sqR, x)
+	mustT(t, err, "EvalAdd")
+	defer sum.Close()
+	if got, want := decryptReals(t, sim, keys, sum, 4), []float64{2, 6, 12, 20}; !slicesApproxEqual(got, want, 1e-9) {
+		t.Errorf("x^2 + x = %v, expected %v", got, want)
+	}
+
+	cube, err := sim.EvalMult(sqR, x)
+	mustT(t, err, "EvalMult")
+	defer cube.Close()
+	cubeR, err := sim.Rescale(cube)
+	mustT(t, err, "Rescale")
+	defer cubeR.Close()
+	if _, err := sim.EvalMult(cubeR, x); err == nil {
+		t.Error("expected error once the multiplicative depth is exhausted")
+	}
+}
+
+func TestSimulatorCKKSNoise(t *testing.T) {
+	sim, keys := newTestSimulator(t, SimParams{
+		Scheme:              SimCKKS,
+		RingDimension:       64,
+		BatchSize:           8,
+		MultiplicativeDepth: 3,
+		ScalingTechnique:    FLEXIBLEAUTO,
+		NoiseStdDev:         1e-6,
+		Seed:                1,
+	})
+	defer sim.Close()
+	mustT(t, sim.EvalSumKeyGen(keys), "EvalSumKeyGen")
+
+	vals := []float64{0.5, -1, 2, 0.25, 0, 0, 0, 0}
+	x := encryptReals(t, sim, keys, vals)
+	defer x.Close()
+
+	noisy := decryptReals(t, sim, keys, x, 8)
+	if slicesApproxEqual(noisy, vals, 0) {
+		t.Error("expected encryption noise in the decrypted values")
+	}
+	if !slicesApproxEqual(noisy, vals, 1e-4) {
+		t.Errorf("noisy decryption %v too far from %v", noisy, vals)
+	}
+
+	poly, err := sim.EvalPoly(x, []float64{1, 0, 1}) // 1 + x^2
+	mustT(t, err, "EvalPoly")
+	defer poly.Close()
+	if got, want := decryptReals(t, sim, keys, poly, 4), []float64{1.25, 2, 5, 1.0625}; !slicesApproxEqual(got, want, 1e-4) {
+		t.Errorf("EvalPoly = %v, expected %v", got, want)
+	}
+
+	// A degree-13 series needs 5 levels, more than the simulator has; a
+	// degree-2 one interpolates x^2 exactly in 3.
+	square := func(v float64) float64 { return v * v }
+	if _, err := sim.EvalChebyshevSeries(x, ChebyshevCoefficients(square, -1, 2, 13), -1, 2); err == nil || !strings.Contains(err.Error(), "depth") {
+		t.Errorf("EvalChebyshevSeries beyond the depth = %v, expected a depth error", err)
+	}
+	cheb, err := sim.EvalChebyshevSeries(x, ChebyshevCoefficients(square, -1, 2, 2), -1, 2)
+	mustT(t, err, "EvalChebyshevSeries")
+	defer cheb.Close()
+	if got, want := decryptReals(t, sim, keys, cheb, 4), []float64{0.25, 1, 4, 0.0625}; !slicesApproxEqual(got, want, 1e-4) {
+		t.Errorf("EvalChebyshevSeries = %v, expected %v", got, want)
+	}
+
+	ip, err := sim.EvalInnerProduct(x, x, 8)
+	mustT(t, err, "EvalInnerProduct")
+	defer ip.Close()
+	if got := decryptReals(t, sim, keys, ip, 1); !slicesApproxEqual(got, []float64{5.3125}, 1e-4) {
+		t.Errorf("EvalInnerProduct = %v, expected [5.3125]", got)
+	}
+}
+
+// affineSum computes sum(a*x + b) over the first n slots. It only uses the
+// Evaluator interface so it runs unchanged on a Simulator and on OpenFHE.
+func affineSum(ev Evaluator, ct *Ciphertext, a, b float64, n uint32) (*Ciphertext, error) {
+	scale, err := ev.MakeCKKSPackedPlaintext(repeatFloat(a, int(n)))
+	if err != nil {
+		return nil, err
+	}
+	defer scale.Close()
+	shift, err := ev.MakeCKKSPackedPlaintext(repeatFloat(b, int(n)))
+	if err != nil {
+		return nil, err
+	}
+	defer shift.Close()
+
+	ax, err := ev.EvalMultPlain(ct, scale)
+	if err != nil {
+		return nil, err
+	}
+	defer ax.Close()
+	if ev.GetScalingTechnique() == FIXEDMANUAL {
+		rescaled, err := ev.Rescale(ax)
+		if err != nil {
+			return nil, err
+		}
+		defer rescaled.Close()
+		ax = rescaled
+	}
+	axb, err := ev.EvalAddPlain(ax, shift)
+	if err != nil {
+		return nil, err
+	}
+	defer axb.Close()
+	return ev.EvalSum(axb, n)
+}
+
+func repeatFloat(v float64, n int) []float64 {
+	out := make([]float64, n)
+	for i := range out {
+		out[i] = v
+	}
+	return out
+}
+
+func TestSimulatorMatchesCryptoContext(t *testing.T) {
+	cc, ccKeys := setupCKKSContextAndKeys(t)
+	defer cc.Close()
+	defer ccKeys.Close()
+	mustT(t, cc.Enable(ADVANCEDSHE), "Enable ADVANCEDSHE")
+	mustT(t, cc.EvalSumKeyGen(ccKeys), "EvalSumKeyGen")
+
+	sim, simKeys := newTestSimulator(t, SimParams{
+		Scheme:              SimCKKS,
+		RingDimension:       cc.GetRingDimension(),
+		BatchSize:           cc.GetBatchSize(),
+		MultiplicativeDepth: 1,
+		ScalingTechnique:    cc.GetScalingTechnique(),
+	})
+	defer sim.Close()
+	mustT(t, sim.EvalSumKeyGen(simKeys), "EvalSumKeyGen")
+
+	vals := []float64{1, 2, 3, 4, 5, 6, 7, 8}
+	for name, run := range map[string]struct {
+		ev   Evaluator
+		keys *KeyPair
+	}{
+		"openfhe":   {cc, ccKeys},
+		"simulator": {sim, simKeys},
+	} {
+		ct := encryptReals(t, run.ev, run.keys, vals)
+		res, err := affineSum(run.ev, ct, 0.5, 1, 8)
+		mustT(t, err, name+" affineSum")
+		got := decryptReals(t, run.ev, run.keys, res, 1)
+		if !slicesApproxEqual(got, []float64{26}, 1e-3) {
+			t.Errorf("%s: affineSum = %v, expected [26]", name, got)
+		}
+		res.Close()
+		ct.Close()
+	}
+
+	// Objects do not cross implementations.
+	simCt := encryptReals(t, sim, simKeys, vals)
+	defer simCt.Close()
+	if _, err := cc.EvalAdd(simCt, simCt); err == nil {
+		t.Error("expected error passing a simulated ciphertext to a CryptoContext")
+	}
+}
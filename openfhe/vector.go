@@ -0,0 +1,433 @@
+package openfhe
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Slot is the element type of an EncryptedVector: int64 for BFV/BGV,
+// float64 or complex128 for CKKS.
+type Slot interface {
+	int64 | float64 | complex128
+}
+
+// VectorContext binds a CryptoContext and a key pair for use by
+// EncryptedVector. The evaluation keys the vector operations need must
+// already be generated in the context: EvalMultKeyGen for Mul and Dot,
+// EvalSumKeyGen for Sum and Dot, and EvalRotateKeyGen for the indices used
+// by Rotate, Slice (start) and Concat (minus the length of the left operand).
+//
+// The VectorContext does not own the context or the keys; close them after
+// all vectors created from it.
+type VectorContext struct {
+	cc            *CryptoContext
+	keys          *KeyPair
+	slots         int
+	manualRescale bool
+}
+
+// NewVectorContext creates a VectorContext. Rescaling after CKKS
+// multiplications is done automatically when the context uses FIXEDMANUAL.
+func NewVectorContext(cc *CryptoContext, keys *KeyPair) (*VectorContext, error) {
+	if cc.closed() {
+		return nil, errors.New("CryptoContext is closed or invalid")
+	}
+	if keys.closed() {
+		return nil, errors.New("KeyPair is closed or invalid")
+	}
+
+	slots := SlotCount(cc)
+	if slots == 0 {
+		return nil, errors.New("NewVectorContext: could not determine slot count")
+	}
+
+	return &VectorContext{
+		cc:            cc,
+		keys:          keys,
+		slots:         slots,
+		manualRescale: cc.GetScalingTechnique() == FIXEDMANUAL,
+	}, nil
+}
+
+// Slots returns the number of slots available to a vector: the batch size,
+// capped at half the ring dimension for BFV and BGV.
+func (vc *VectorContext) Slots() int { return vc.slots }
+
+// EncryptedVector is an encrypted vector of up to Slots() values. Every
+// operation returns a new vector and closes the intermediates it creates;
+// the operands are left untouched and must still be closed by the caller.
+type EncryptedVector[T Slot] struct {
+	vc *VectorContext
+	ct *Ciphertext
+	n  int
+	// padded records that the slots from n onwards are known to hold zero.
+	// Sum and Concat rely on it and mask the vector first when it is unset.
+	padded bool
+}
+
+// EncryptVector encrypts values under the VectorContext's key pair.
+func EncryptVector[T Slot](vc *VectorContext, values []T) (*EncryptedVector[T], error) {
+	if vc == nil {
+		return nil, errors.New("VectorContext is nil")
+	}
+	if len(values) == 0 {
+		return nil, errors.New("EncryptVector: input vector is empty")
+	}
+	if len(values) > vc.slots {
+		return nil, fmt.Errorf("EncryptVector: %d values do not fit in %d slots", len(values), vc.slots)
+	}
+
+	pt, err := encodeSlots(vc.cc, values, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer pt.Close()
+
+	ct, err := vc.cc.Encrypt(vc.keys, pt)
+	if err != nil {
+		return nil, err
+	}
+	return &EncryptedVector[T]{vc: vc, ct: ct, n: len(values), padded: true}, nil
+}
+
+// Decrypt decrypts the first Len() slots.
+func (v *EncryptedVector[T]) Decrypt() ([]T, error) {
+	if err := v.check(); err != nil {
+		return nil, err
+	}
+
+	pt, err := v.vc.cc.Decrypt(v.vc.keys, v.ct)
+	if err != nil {
+		return nil, err
+	}
+	defer pt.Close()
+	if err := pt.SetLength(v.n); err != nil {
+		return nil, err
+	}
+
+	out, err := decodeSlots[T](pt)
+	if err != nil {
+		return nil, err
+	}
+	if len(out) > v.n {
+		out = out[:v.n]
+	}
+	return out, nil
+}
+
+// Len returns the number of values in the vector.
+func (v *EncryptedVector[T]) Len() int { return v.n }
+
+// Level returns the level of the underlying ciphertext, or -1 if the vector
+// is closed.
+func (v *EncryptedVector[T]) Level() int {
+	if v.ct == nil {
+		return -1
+	}
+	level, _ := v.ct.GetLevel()
+	return level
+}
+
+// Ciphertext returns the underlying ciphertext. It remains owned by the
+// vector.
+func (v *EncryptedVector[T]) Ciphertext() *Ciphertext { return v.ct }
+
+// Close frees the underlying ciphertext.
+func (v *EncryptedVector[T]) Close() {
+	if v.ct != nil {
+		v.ct.Close()
+		v.ct = nil
+	}
+}
+
+// Add returns v + o element-wise.
+func (v *EncryptedVector[T]) Add(o *EncryptedVector[T]) (*EncryptedVector[T], error) {
+	if err := v.checkPair(o); err != nil {
+		return nil, err
+	}
+	ct, err := v.vc.cc.EvalAdd(v.ct, o.ct)
+	if err != nil {
+		return nil, err
+	}
+	return v.derive(ct, v.n, v.padded && o.padded), nil
+}
+
+// Sub returns v - o element-wise.
+func (v *EncryptedVector[T]) Sub(o *EncryptedVector[T]) (*EncryptedVector[T], error) {
+	if err := v.checkPair(o); err != nil {
+		return nil, err
+	}
+	ct, err := v.vc.cc.EvalSub(v.ct, o.ct)
+	if err != nil {
+		return nil, err
+	}
+	return v.derive(ct, v.n, v.padded && o.padded), nil
+}
+
+// Mul returns v * o element-wise.
+func (v *EncryptedVector[T]) Mul(o *EncryptedVector[T]) (*EncryptedVector[T], error) {
+	if err := v.checkPair(o); err != nil {
+		return nil, err
+	}
+	ct, err := v.vc.cc.EvalMult(v.ct, o.ct)
+	if err != nil {
+		return nil, err
+	}
+	if ct, err = v.rescale(ct); err != nil {
+		return nil, err
+	}
+	return v.derive(ct, v.n, v.padded || o.padded), nil
+}
+
+// Scale returns c * v.
+func (v *EncryptedVector[T]) Scale(c T) (*EncryptedVector[T], error) {
+	if err := v.check(); err != nil {
+		return nil, err
+	}
+	consts := make([]T, v.n)
+	for i := range consts {
+		consts[i] = c
+	}
+	ct, err := v.multPlain(v.ct, consts)
+	if err != nil {
+		return nil, err
+	}
+	return v.derive(ct, v.n, true), nil
+}
+
+// Rotate rotates all slots left by k (right for negative k), like
+// EvalRotate. The length is unchanged, so for a vector shorter than Slots()
+// the slots beyond Len() are rotated in; on a freshly encrypted vector these
+// are zero and Rotate acts as a shift.
+func (v *EncryptedVector[T]) Rotate(k int) (*EncryptedVector[T], error) {
+	if err := v.check(); err != nil {
+		return nil, err
+	}
+	ct, err := v.vc.cc.EvalRotate(v.ct, int32(k))
+	if err != nil {
+		return nil, err
+	}
+	return v.derive(ct, v.n, false), nil
+}
+
+// Sum returns a vector of length one holding the sum of the elements of v.
+func (v *EncryptedVector[T]) Sum() (*EncryptedVector[T], error) {
+	if err := v.check(); err != nil {
+		return nil, err
+	}
+	in, done, err := v.zeroPadded()
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	ct, err := v.vc.cc.EvalSum(in, uint32(v.vc.slots))
+	if err != nil {
+		return nil, err
+	}
+	return v.derive(ct, 1, false), nil
+}
+
+// Dot returns a vector of length one holding the inner product of v and o.
+func (v *EncryptedVector[T]) Dot(o *EncryptedVector[T]) (*EncryptedVector[T], error) {
+	prod, err := v.Mul(o)
+	if err != nil {
+		return nil, err
+	}
+	defer prod.Close()
+	return prod.Sum()
+}
+
+// Slice returns the elements [start, end) of v.
+func (v *EncryptedVector[T]) Slice(start, end int) (*EncryptedVector[T], error) {
+	if err := v.check(); err != nil {
+		return nil, err
+	}
+	if start < 0 || end > v.n || start >= end {
+		return nil, fmt.Errorf("Slice: invalid range [%d, %d) for length %d", start, end, v.n)
+	}
+
+	in := v.ct
+	if start > 0 {
+		rot, err := v.vc.cc.EvalRotate(v.ct, int32(start))
+		if err != nil {
+			return nil, err
+		}
+		defer rot.Close()
+		in = rot
+	}
+	ct, err := v.mask(in, end-start)
+	if err != nil {
+		return nil, err
+	}
+	return v.derive(ct, end-start, true), nil
+}
+
+// Concat returns v followed by o.
+func (v *EncryptedVector[T]) Concat(o *EncryptedVector[T]) (*EncryptedVector[T], error) {
+	if err := v.check(); err != nil {
+		return nil, err
+	}
+	if err := o.check(); err != nil {
+		return nil, err
+	}
+	if v.vc != o.vc {
+		return nil, errors.New("vectors belong to different VectorContexts")
+	}
+	if v.n+o.n > v.vc.slots {
+		return nil, fmt.Errorf("Concat: %d values do not fit in %d slots", v.n+o.n, v.vc.slots)
+	}
+
+	left, doneLeft, err := v.zeroPadded()
+	if err != nil {
+		return nil, err
+	}
+	defer doneLeft()
+	right, doneRight, err := o.zeroPadded()
+	if err != nil {
+		return nil, err
+	}
+	defer doneRight()
+
+	shifted, err := v.vc.cc.EvalRotate(right, int32(-v.n))
+	if err != nil {
+		return nil, err
+	}
+	defer shifted.Close()
+
+	ct, err := v.vc.cc.EvalAdd(left, shifted)
+	if err != nil {
+		return nil, err
+	}
+	return v.derive(ct, v.n+o.n, true), nil
+}
+
+// --- internals ---
+
+func (v *EncryptedVector[T]) check() error {
+	if v == nil || v.ct.closed() {
+		return errors.New("EncryptedVector is closed or invalid")
+	}
+	return nil
+}
+
+func (v *EncryptedVector[T]) checkPair(o *EncryptedVector[T]) error {
+	if err := v.check(); err != nil {
+		return err
+	}
+	if err := o.check(); err != nil {
+		return err
+	}
+	if v.vc != o.vc {
+		return errors.New("vectors belong to different VectorContexts")
+	}
+	if v.n != o.n {
+		return fmt.Errorf("length mismatch: %d vs %d", v.n, o.n)
+	}
+	return nil
+}
+
+func (v *EncryptedVector[T]) derive(ct *Ciphertext, n int, padded bool) *EncryptedVector[T] {
+	return &EncryptedVector[T]{vc: v.vc, ct: ct, n: n, padded: padded}
+}
+
+// rescale consumes ct and returns its rescaled replacement when the context
+// leaves rescaling to the caller.
+func (v *EncryptedVector[T]) rescale(ct *Ciphertext) (*Ciphertext, error) {
+	if !v.vc.manualRescale || !isCKKSSlot[T]() {
+		return ct, nil
+	}
+	res, err := v.vc.cc.Rescale(ct)
+	ct.Close()
+	return res, err
+}
+
+// multPlain multiplies ct by values encoded at the ciphertext's level.
+func (v *EncryptedVector[T]) multPlain(ct *Ciphertext, values []T) (*Ciphertext, error) {
+	level, _ := ct.GetLevel()
+	if level < 0 {
+		level = 0
+	}
+	pt, err := encodeSlots(v.vc.cc, values, uint32(level))
+	if err != nil {
+		return nil, err
+	}
+	defer pt.Close()
+
+	res, err := v.vc.cc.EvalMultPlain(ct, pt)
+	if err != nil {
+		return nil, err
+	}
+	return v.rescale(res)
+}
+
+// mask keeps the first n slots of ct and zeroes the rest.
+func (v *EncryptedVector[T]) mask(ct *Ciphertext, n int) (*Ciphertext, error) {
+	ones := make([]T, n)
+	for i := range ones {
+		ones[i] = 1
+	}
+	return v.multPlain(ct, ones)
+}
+
+// zeroPadded returns a ciphertext of v whose slots beyond Len() are zero,
+// and a function releasing it.
+func (v *EncryptedVector[T]) zeroPadded() (*Ciphertext, func(), error) {
+	if v.padded {
+		return v.ct, func() {}, nil
+	}
+	ct, err := v.mask(v.ct, v.n)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ct, ct.Close, nil
+}
+
+func isCKKSSlot[T Slot]() bool {
+	var zero T
+	_, isInt := any(zero).(int64)
+	return !isInt
+}
+
+func encodeSlots[T Slot](ev Evaluator, values []T, level uint32) (*Plaintext, error) {
+	switch vals := any(values).(type) {
+	case []int64:
+		return ev.MakePackedPlaintext(vals)
+	case []float64:
+		return ev.MakeCKKSPackedPlaintextWithParams(vals, 1, level, 0)
+	case []complex128:
+		return ev.MakeCKKSComplexPackedPlaintextWithParams(vals, 1, level, 0)
+	}
+	return nil, errors.New("unsupported slot type")
+}
+
+func decodeSlots[T Slot](pt *Plaintext) ([]T, error) {
+	var out []T
+	var err error
+	switch dst := any(&out).(type) {
+	case *[]int64:
+		*dst, err = pt.GetPackedValue()
+	case *[]float64:
+		*dst, err = pt.GetRealPackedValue()
+	case *[]complex128:
+		*dst, err = pt.GetComplexPackedValue()
+	}
+	return out, err
+}
+
+// slotCount returns the number of slots a packed plaintext of ev holds.
+func slotCount(ev Evaluator) int {
+	if slots := int(ev.GetBatchSize()); slots != 0 {
+		return slots
+	}
+	return int(ev.GetRingDimension() / 2)
+}
+
+// SlotCount returns the number of slots a packed computation on ev can
+// rotate through: the batch size, capped at half the ring dimension. A full
+// BFV/BGV batch is two rows of N/2 slots that EvalRotate turns separately,
+// so rotations, replication and sums only work within one row. It returns
+// 0 when ev is closed.
+func SlotCount(ev Evaluator) int {
+	return min(slotCount(ev), int(ev.GetRingDimension()/2))
+}
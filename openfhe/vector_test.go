@@ -0,0 +1,285 @@
+package openfhe
+
+import (
+	"math/cmplx"
+	"testing"
+)
+
+func setupCKKSManualVectorContext(t *testing.T) (*CryptoContext, *KeyPair, *VectorContext) {
+	t.Helper()
+
+	params, err := NewParamsCKKSRNS()
+	mustT(t, err, "NewParamsCKKSRNS")
+	defer params.Close()
+	mustT(t, params.SetMultiplicativeDepth(3), "SetMultiplicativeDepth")
+	mustT(t, params.SetScalingModSize(50), "SetScalingModSize")
+	mustT(t, params.SetBatchSize(8), "SetBatchSize")
+	mustT(t, params.SetScalingTechnique(FIXEDMANUAL), "SetScalingTechnique")
+
+	cc, err := NewCryptoContextCKKS(params)
+	mustT(t, err, "NewCryptoContextCKKS")
+	mustT(t, cc.Enable(PKE), "Enable PKE")
+	mustT(t, cc.Enable(KEYSWITCH), "Enable KEYSWITCH")
+	mustT(t, cc.Enable(LEVELEDSHE), "Enable LEVELEDSHE")
+	mustT(t, cc.Enable(ADVANCEDSHE), "Enable ADVANCEDSHE")
+
+	keys, err := cc.KeyGen()
+	mustT(t, err, "KeyGen")
+	mustT(t, cc.EvalMultKeyGen(keys), "EvalMultKeyGen")
+	mustT(t, cc.EvalSumKeyGen(keys), "EvalSumKeyGen")
+	mustT(t, cc.EvalRotateKeyGen(keys, []int32{1, 2, -2}), "EvalRotateKeyGen")
+
+	vc, err := NewVectorContext(cc, keys)
+	mustT(t, err, "NewVectorContext")
+	return cc, keys, vc
+}
+
+func TestEncryptedVectorCKKSArithmetic(t *testing.T) {
+	cc, keys, vc := setupCKKSManualVectorContext(t)
+	defer cc.Close()
+	defer keys.Close()
+
+	if vc.Slots() != 8 {
+		t.Fatalf("Slots() = %d, expected 8", vc.Slots())
+	}
+
+	a, err := EncryptVector(vc, []float64{1, 2, 3, 4})
+	mustT(t, err, "EncryptVector a")
+	defer a.Close()
+	b, err := EncryptVector(vc, []float64{0.5, -1, 2, 0.25})
+	mustT(t, err, "EncryptVector b")
+	defer b.Close()
+
+	sum, err := a.Add(b)
+	mustT(t, err, "Add")
+	defer sum.Close()
+	got, err := sum.Decrypt()
+	mustT(t, err, "Decrypt sum")
+	if want := []float64{1.5, 1, 5, 4.25}; !slicesApproxEqual(got, want, 1e-4) {
+		t.Errorf("Add = %v, expected %v", got, want)
+	}
+
+	prod, err := a.Mul(b)
+	mustT(t, err, "Mul")
+	defer prod.Close()
+	if prod.Level() != a.Level()+1 {
+		t.Errorf("Mul level = %d, expected %d (automatic rescale)", prod.Level(), a.Level()+1)
+	}
+	got, err = prod.Decrypt()
+	mustT(t, err, "Decrypt product")
+	if want := []float64{0.5, -2, 6, 1}; !slicesApproxEqual(got, want, 1e-4) {
+		t.Errorf("Mul = %v, expected %v", got, want)
+	}
+
+	scaled, err := a.Scale(-2)
+	mustT(t, err, "Scale")
+	defer scaled.Close()
+	got, err = scaled.Decrypt()
+	mustT(t, err, "Decrypt scaled")
+	if want := []float64{-2, -4, -6, -8}; !slicesApproxEqual(got, want, 1e-4) {
+		t.Errorf("Scale = %v, expected %v", got, want)
+	}
+
+	dot, err := a.Dot(b)
+	mustT(t, err, "Dot")
+	defer dot.Close()
+	if dot.Len() != 1 {
+		t.Errorf("Dot length = %d, expected 1", dot.Len())
+	}
+	got, err = dot.Decrypt()
+	mustT(t, err, "Decrypt dot")
+	if !slicesApproxEqual(got, []float64{5.5}, 1e-4) {
+		t.Errorf("Dot = %v, expected [5.5]", got)
+	}
+}
+
+func TestEncryptedVectorCKKSLayout(t *testing.T) {
+	cc, keys, vc := setupCKKSManualVectorContext(t)
+	defer cc.Close()
+	defer keys.Close()
+
+	a, err := EncryptVector(vc, []float64{1, 2, 3, 4})
+	mustT(t, err, "EncryptVector")
+	defer a.Close()
+
+	rot, err := a.Rotate(1)
+	mustT(t, err, "Rotate")
+	defer rot.Close()
+	got, err := rot.Decrypt()
+	mustT(t, err, "Decrypt rotated")
+	if want := []float64{2, 3, 4, 0}; !slicesApproxEqual(got, want, 1e-4) {
+		t.Errorf("Rotate = %v, expected %v", got, want)
+	}
+
+	// Sum after a rotation has to mask the slots wrapped in from the end.
+	rotSum, err := rot.Sum()
+	mustT(t, err, "Sum")
+	defer rotSum.Close()
+	got, err = rotSum.Decrypt()
+	mustT(t, err, "Decrypt sum")
+	if !slicesApproxEqual(got, []float64{9}, 1e-4) {
+		t.Errorf("Sum = %v, expected [9]", got)
+	}
+
+	mid, err := a.Slice(1, 3)
+	mustT(t, err, "Slice")
+	defer mid.Close()
+	got, err = mid.Decrypt()
+	mustT(t, err, "Decrypt slice")
+	if want := []float64{2, 3}; !slicesApproxEqual(got, want, 1e-4) {
+		t.Errorf("Slice = %v, expected %v", got, want)
+	}
+
+	tail, err := a.Slice(2, 4)
+	mustT(t, err, "Slice")
+	defer tail.Close()
+	joined, err := tail.Concat(a)
+	mustT(t, err, "Concat")
+	defer joined.Close()
+	got, err = joined.Decrypt()
+	mustT(t, err, "Decrypt concat")
+	if want := []float64{3, 4, 1, 2, 3, 4}; !slicesApproxEqual(got, want, 1e-4) {
+		t.Errorf("Concat = %v, expected %v", got, want)
+	}
+
+	if _, err := a.Slice(3, 2); err == nil {
+		t.Error("expected error for empty slice range")
+	}
+	if _, err := a.Concat(joined); err == nil {
+		t.Error("expected error for concatenation beyond slot count")
+	}
+}
+
+func TestEncryptedVectorBFV(t *testing.T) {
+	cc, keys := setupBFVContextAndKeys(t)
+	defer cc.Close()
+	defer keys.Close()
+	mustT(t, cc.EvalSumKeyGen(keys), "EvalSumKeyGen")
+
+	vc, err := NewVectorContext(cc, keys)
+	mustT(t, err, "NewVectorContext")
+
+	a, err := EncryptVector(vc, []int64{1, 2, 3, 4, 5})
+	mustT(t, err, "EncryptVector a")
+	defer a.Close()
+	b, err := EncryptVector(vc, []int64{5, 4, 3, 2, 1})
+	mustT(t, err, "EncryptVector b")
+	defer b.Close()
+
+	diff, err := a.Sub(b)
+	mustT(t, err, "Sub")
+	defer diff.Close()
+	got, err := diff.Decrypt()
+	mustT(t, err, "Decrypt diff")
+	if want := []int64{-4, -2, 0, 2, 4}; !slicesEqual(got, want) {
+		t.Errorf("Sub = %v, expected %v", got, want)
+	}
+
+	dot, err := a.Dot(b)
+	mustT(t, err, "Dot")
+	defer dot.Close()
+	got, err = dot.Decrypt()
+	mustT(t, err, "Decrypt dot")
+	if want := []int64{35}; !slicesEqual(got, want) {
+		t.Errorf("Dot = %v, expected %v", got, want)
+	}
+
+	if _, err := a.Add(dot); err == nil {
+		t.Error("expected error for length mismatch")
+	}
+}
+
+// A full BFV batch is two rows that rotate separately, so vectors are
+// confined to the first N/2 slots.
+func TestEncryptedVectorBFVSliceConcat(t *testing.T) {
+	cc, keys := setupBFVContextAndKeys(t)
+	defer cc.Close()
+	defer keys.Close()
+
+	vc, err := NewVectorContext(cc, keys)
+	mustT(t, err, "NewVectorContext")
+	row := int(cc.GetRingDimension() / 2)
+	if int(cc.GetBatchSize()) <= row {
+		t.Fatalf("batch size %d is not a full batch for ring dimension %d", cc.GetBatchSize(), 2*row)
+	}
+	if vc.Slots() != row {
+		t.Fatalf("Slots = %d, expected %d", vc.Slots(), row)
+	}
+	mustT(t, cc.EvalRotateKeyGen(keys, []int32{1, -3, int32(row - 2)}), "EvalRotateKeyGen")
+
+	a, err := EncryptVector(vc, []int64{1, 2, 3})
+	mustT(t, err, "EncryptVector a")
+	defer a.Close()
+	b, err := EncryptVector(vc, []int64{7, 8})
+	mustT(t, err, "EncryptVector b")
+	defer b.Close()
+
+	slice, err := a.Slice(1, 3)
+	mustT(t, err, "Slice")
+	defer slice.Close()
+	got, err := slice.Decrypt()
+	mustT(t, err, "Decrypt slice")
+	if want := []int64{2, 3}; !slicesEqual(got, want) {
+		t.Errorf("Slice = %v, expected %v", got, want)
+	}
+
+	cat, err := a.Concat(b)
+	mustT(t, err, "Concat")
+	defer cat.Close()
+	got, err = cat.Decrypt()
+	mustT(t, err, "Decrypt concat")
+	if want := []int64{1, 2, 3, 7, 8}; !slicesEqual(got, want) {
+		t.Errorf("Concat = %v, expected %v", got, want)
+	}
+
+	// The end of a vector filling the row.
+	values := make([]int64, row)
+	for i := range values {
+		values[i] = int64(i % 1000)
+	}
+	long, err := EncryptVector(vc, values)
+	mustT(t, err, "EncryptVector long")
+	defer long.Close()
+	tail, err := long.Slice(row-2, row)
+	mustT(t, err, "Slice tail")
+	defer tail.Close()
+	got, err = tail.Decrypt()
+	mustT(t, err, "Decrypt tail")
+	if want := values[row-2:]; !slicesEqual(got, want) {
+		t.Errorf("Slice(%d, %d) = %v, expected %v", row-2, row, got, want)
+	}
+
+	if _, err := EncryptVector(vc, make([]int64, row+1)); err == nil {
+		t.Error("EncryptVector accepted a vector spanning both rows")
+	}
+	if _, err := long.Concat(a); err == nil {
+		t.Error("Concat accepted a result spanning both rows")
+	}
+}
+
+func TestEncryptedVectorComplex(t *testing.T) {
+	cc, keys, vc := setupCKKSManualVectorContext(t)
+	defer cc.Close()
+	defer keys.Close()
+
+	in := []complex128{complex(1, 1), complex(0, -2)}
+	a, err := EncryptVector(vc, in)
+	mustT(t, err, "EncryptVector")
+	defer a.Close()
+
+	scaled, err := a.Scale(complex(0, 1))
+	mustT(t, err, "Scale")
+	defer scaled.Close()
+
+	got, err := scaled.Decrypt()
+	mustT(t, err, "Decrypt")
+	want := []complex128{complex(-1, 1), complex(2, 0)}
+	if len(got) != len(want) {
+		t.Fatalf("Decrypt returned %d values, expected %d", len(got), len(want))
+	}
+	for i := range want {
+		if cmplx.Abs(got[i]-want[i]) > 1e-4 {
+			t.Errorf("slot %d = %v, expected ~%v", i, got[i], want[i])
+		}
+	}
+}
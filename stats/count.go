@@ -0,0 +1,61 @@
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/dozyio/openfhe-go/openfhe"
+)
+
+// Indicators bins values by edges, which must be increasing, and returns
+// one 0/1 column per bin: column i marks the values in
+// [edges[i], edges[i+1]). Values outside [edges[0], edges[len-1]) are in no
+// bin. The columns are meant to be encrypted with BFV/BGV and passed to
+// Histogram.
+func Indicators(values, edges []float64) ([][]int64, error) {
+	if len(edges) < 2 {
+		return nil, errors.New("stats: at least two bin edges are required")
+	}
+	if !sort.Float64sAreSorted(edges) {
+		return nil, errors.New("stats: bin edges must be increasing")
+	}
+	bins := make([][]int64, len(edges)-1)
+	for i := range bins {
+		bins[i] = make([]int64, len(values))
+	}
+	for j, v := range values {
+		i := sort.SearchFloat64s(edges, v)
+		if i < len(edges) && edges[i] == v {
+			i++
+		}
+		if i > 0 && i < len(edges) {
+			bins[i-1][j] = 1
+		}
+	}
+	return bins, nil
+}
+
+// Count returns the number of ones in an encrypted indicator column, which
+// is its sum. Depth 0.
+func (a *Analyzer) Count(ind *Column) (*openfhe.Ciphertext, error) {
+	return a.Sum(ind)
+}
+
+// Histogram returns the count of each indicator column, as produced by
+// Indicators. Depth 0.
+func (a *Analyzer) Histogram(bins []*Column) ([]*openfhe.Ciphertext, error) {
+	if len(bins) == 0 {
+		return nil, errors.New("stats: no bins")
+	}
+	out := make([]*openfhe.Ciphertext, 0, len(bins))
+	for i, b := range bins {
+		c, err := a.Count(b)
+		if err != nil {
+			closeAll(out)
+			return nil, fmt.Errorf("stats: bin %d: %w", i, err)
+		}
+		out = append(out, c)
+	}
+	return out, nil
+}
@@ -0,0 +1,488 @@
+// Package stats computes statistics over encrypted columns on top of the
+// openfhe package: sums, means, variances and standard deviations,
+// covariance and correlation, and weighted means with CKKS, and counts and
+// histograms over indicator columns with BFV/BGV.
+//
+// A Column is a sequence of ciphertexts, each packing consecutive values
+// from slot 0 with zeros in the unused slots, as produced by EncryptColumn
+// or openfhe.EncryptBatch. Every statistic is returned as a ciphertext
+// holding the result in slot 0; the other slots are unspecified.
+//
+// The statistics consume the following multiplicative depth, where c is
+// openfhe.ChebyshevDepth(d.Degree) for the Domain d they are given:
+//
+//	Sum, Count, Histogram            0
+//	Mean, WeightedMean               1
+//	Variance, Covariance             2
+//	WeightedMeanEncrypted            c + 1
+//	StdDev                           c + 2
+//	Correlation                      c + 4
+//
+// All of them need the keys from EvalSumKeyGen and the ADVANCEDSHE feature;
+// those of depth 2 and above also need EvalMultKeyGen. Square roots and
+// inverses are approximated by Chebyshev series, so the Domain must bound
+// the value they are applied to, and precision drops towards zero.
+// BFV/BGV counts are exact as long as they stay below the plaintext
+// modulus.
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/dozyio/openfhe-go/openfhe"
+)
+
+// DefaultDegree is the Chebyshev degree used when Domain.Degree is zero;
+// it costs openfhe.ChebyshevDepth(DefaultDegree) = 7 levels.
+const DefaultDegree = 59
+
+// Domain is the interval a square root or inverse is approximated on, and
+// the degree of the approximation.
+type Domain struct {
+	Min, Max float64
+	Degree   int
+}
+
+// Analyzer computes statistics in one context.
+type Analyzer struct {
+	ev            openfhe.Evaluator
+	slots         int
+	manualRescale bool
+}
+
+// New creates an Analyzer for ev. With CKKS in FIXEDMANUAL mode every
+// product is rescaled.
+func New(ev openfhe.Evaluator) (*Analyzer, error) {
+	if ev == nil {
+		return nil, errors.New("Evaluator is nil")
+	}
+	if ev.GetRingDimension() == 0 {
+		return nil, errors.New("CryptoContext is closed or invalid")
+	}
+	return &Analyzer{
+		ev:            ev,
+		slots:         openfhe.SlotCount(ev),
+		manualRescale: ev.GetScalingTechnique() == openfhe.FIXEDMANUAL,
+	}, nil
+}
+
+// Column is an encrypted column of Len values, Chunk per ciphertext; the
+// last ciphertext may hold fewer. A zero Chunk means the slot count.
+type Column struct {
+	Cts   []*openfhe.Ciphertext
+	Len   int
+	Chunk int
+}
+
+// Close frees the ciphertexts.
+func (c *Column) Close() {
+	for _, ct := range c.Cts {
+		ct.Close()
+	}
+	c.Cts = nil
+}
+
+// EncryptColumn encrypts values with openfhe.EncryptBatch.
+func EncryptColumn[T openfhe.Slot](a *Analyzer, keys *openfhe.KeyPair, values []T, opts *openfhe.BatchOptions) (*Column, error) {
+	cts, err := openfhe.EncryptBatch(a.ev, keys, values, opts)
+	if err != nil {
+		return nil, err
+	}
+	col := &Column{Cts: cts, Len: len(values)}
+	if opts != nil {
+		col.Chunk = opts.ChunkSize
+	}
+	return col, nil
+}
+
+// DecryptReal decrypts a CKKS statistic.
+func (a *Analyzer) DecryptReal(keys *openfhe.KeyPair, ct *openfhe.Ciphertext) (float64, error) {
+	pt, err := a.ev.Decrypt(keys, ct)
+	if err != nil {
+		return 0, err
+	}
+	defer pt.Close()
+	vals, err := pt.GetRealPackedValue()
+	if err != nil {
+		return 0, err
+	}
+	if len(vals) == 0 {
+		return 0, errors.New("stats: decrypted no values")
+	}
+	return vals[0], nil
+}
+
+// DecryptInt decrypts a BFV/BGV statistic.
+func (a *Analyzer) DecryptInt(keys *openfhe.KeyPair, ct *openfhe.Ciphertext) (int64, error) {
+	pt, err := a.ev.Decrypt(keys, ct)
+	if err != nil {
+		return 0, err
+	}
+	defer pt.Close()
+	vals, err := pt.GetPackedValue()
+	if err != nil {
+		return 0, err
+	}
+	if len(vals) == 0 {
+		return 0, errors.New("stats: decrypted no values")
+	}
+	return vals[0], nil
+}
+
+// --- Statistics ---
+
+// Sum returns the sum of the column. Depth 0.
+func (a *Analyzer) Sum(x *Column) (*openfhe.Ciphertext, error) {
+	if err := a.check(x); err != nil {
+		return nil, err
+	}
+	return a.total(x.Cts, a.used(x))
+}
+
+// Mean returns the mean of the column. Depth 1.
+func (a *Analyzer) Mean(x *Column) (*openfhe.Ciphertext, error) {
+	sum, err := a.Sum(x)
+	if err != nil {
+		return nil, err
+	}
+	defer sum.Close()
+	return a.scale(sum, 1/float64(x.Len))
+}
+
+// Variance returns the population variance E[x²] − E[x]². Depth 2.
+func (a *Analyzer) Variance(x *Column) (*openfhe.Ciphertext, error) {
+	return a.covariance(x, x, 0)
+}
+
+// SampleVariance returns the variance with Bessel's correction, dividing
+// by Len−1. Depth 2.
+func (a *Analyzer) SampleVariance(x *Column) (*openfhe.Ciphertext, error) {
+	return a.covariance(x, x, 1)
+}
+
+// StdDev returns the population standard deviation; d must bound the
+// variance. Depth ChebyshevDepth(d.Degree) + 2.
+func (a *Analyzer) StdDev(x *Column, d Domain) (*openfhe.Ciphertext, error) {
+	if err := d.check(false); err != nil {
+		return nil, err
+	}
+	v, err := a.Variance(x)
+	if err != nil {
+		return nil, err
+	}
+	defer v.Close()
+	return a.approx(v, math.Sqrt, d)
+}
+
+// Covariance returns the population covariance E[xy] − E[x]E[y] of two
+// columns with the same layout. Depth 2.
+func (a *Analyzer) Covariance(x, y *Column) (*openfhe.Ciphertext, error) {
+	return a.covariance(x, y, 0)
+}
+
+// Correlation returns Pearson's correlation coefficient of two columns
+// with the same layout; d must bound Var(x)·Var(y). Depth
+// ChebyshevDepth(d.Degree) + 4.
+func (a *Analyzer) Correlation(x, y *Column, d Domain) (*openfhe.Ciphertext, error) {
+	if err := d.check(true); err != nil {
+		return nil, err
+	}
+	cov, err := a.covariance(x, y, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer cov.Close()
+	vx, err := a.Variance(x)
+	if err != nil {
+		return nil, err
+	}
+	defer vx.Close()
+	vy, err := a.Variance(y)
+	if err != nil {
+		return nil, err
+	}
+	defer vy.Close()
+
+	prod, err := a.mult(vx, vy)
+	if err != nil {
+		return nil, err
+	}
+	defer prod.Close()
+	inv, err := a.approx(prod, func(t float64) float64 { return 1 / math.Sqrt(t) }, d)
+	if err != nil {
+		return nil, err
+	}
+	defer inv.Close()
+	return a.mult(cov, inv)
+}
+
+// WeightedMean returns Σ wᵢxᵢ / Σ wᵢ for plaintext weights, one per value.
+// Depth 1.
+func (a *Analyzer) WeightedMean(x *Column, weights []float64) (*openfhe.Ciphertext, error) {
+	if err := a.check(x); err != nil {
+		return nil, err
+	}
+	if len(weights) != x.Len {
+		return nil, fmt.Errorf("stats: %d weights for %d values", len(weights), x.Len)
+	}
+	var total float64
+	for _, w := range weights {
+		total += w
+	}
+	if total == 0 {
+		return nil, errors.New("stats: weights sum to zero")
+	}
+
+	chunk := a.chunk(x)
+	terms := make([]*openfhe.Ciphertext, 0, len(x.Cts))
+	defer func() { closeAll(terms) }()
+	for i, ct := range x.Cts {
+		w := weights[i*chunk : min((i+1)*chunk, len(weights))]
+		scaled := make([]float64, len(w))
+		for j, v := range w {
+			scaled[j] = v / total
+		}
+		pt, err := a.ev.MakeCKKSPackedPlaintext(scaled)
+		if err != nil {
+			return nil, err
+		}
+		term, err := a.rescale(a.ev.EvalMultPlain(ct, pt))
+		pt.Close()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, term)
+	}
+	return a.total(terms, a.used(x))
+}
+
+// WeightedMeanEncrypted returns Σ wᵢxᵢ / Σ wᵢ for encrypted weights w with
+// the same layout as x; d must bound Σ wᵢ and have a positive Min. Depth
+// ChebyshevDepth(d.Degree) + 1.
+func (a *Analyzer) WeightedMeanEncrypted(x, w *Column, d Domain) (*openfhe.Ciphertext, error) {
+	if err := d.check(true); err != nil {
+		return nil, err
+	}
+	if err := a.checkPair(x, w); err != nil {
+		return nil, err
+	}
+	sw, err := a.total(w.Cts, a.used(w))
+	if err != nil {
+		return nil, err
+	}
+	defer sw.Close()
+	inv, err := a.approx(sw, func(t float64) float64 { return 1 / t }, d)
+	if err != nil {
+		return nil, err
+	}
+	defer inv.Close()
+	swx, err := a.dot(x, w)
+	if err != nil {
+		return nil, err
+	}
+	defer swx.Close()
+	return a.mult(swx, inv)
+}
+
+// covariance returns Σxy/(n−ddof) − ΣxΣy/(n(n−ddof)).
+func (a *Analyzer) covariance(x, y *Column, ddof int) (*openfhe.Ciphertext, error) {
+	if err := a.checkPair(x, y); err != nil {
+		return nil, err
+	}
+	n := float64(x.Len)
+	if x.Len <= ddof {
+		return nil, fmt.Errorf("stats: %d values are too few", x.Len)
+	}
+
+	sxy, err := a.dot(x, y)
+	if err != nil {
+		return nil, err
+	}
+	defer sxy.Close()
+	exy, err := a.scale(sxy, 1/(n-float64(ddof)))
+	if err != nil {
+		return nil, err
+	}
+	defer exy.Close()
+
+	sx, err := a.Sum(x)
+	if err != nil {
+		return nil, err
+	}
+	defer sx.Close()
+	var sxsy *openfhe.Ciphertext
+	if x == y {
+		sxsy, err = a.square(sx)
+	} else {
+		var sy *openfhe.Ciphertext
+		if sy, err = a.Sum(y); err != nil {
+			return nil, err
+		}
+		defer sy.Close()
+		sxsy, err = a.mult(sx, sy)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer sxsy.Close()
+	exey, err := a.scale(sxsy, 1/(n*(n-float64(ddof))))
+	if err != nil {
+		return nil, err
+	}
+	defer exey.Close()
+	return a.ev.EvalSub(exy, exey)
+}
+
+// dot returns Σ xᵢyᵢ, squaring when x and y are the same column.
+func (a *Analyzer) dot(x, y *Column) (*openfhe.Ciphertext, error) {
+	prods := make([]*openfhe.Ciphertext, 0, len(x.Cts))
+	defer func() { closeAll(prods) }()
+	for i, ct := range x.Cts {
+		var p *openfhe.Ciphertext
+		var err error
+		if x == y {
+			p, err = a.square(ct)
+		} else {
+			p, err = a.mult(ct, y.Cts[i])
+		}
+		if err != nil {
+			return nil, err
+		}
+		prods = append(prods, p)
+	}
+	return a.total(prods, a.used(x))
+}
+
+// --- helpers ---
+
+func (a *Analyzer) chunk(c *Column) int {
+	if c.Chunk == 0 {
+		return a.slots
+	}
+	return c.Chunk
+}
+
+// used is the number of leading slots that can be nonzero.
+func (a *Analyzer) used(c *Column) int {
+	return min(c.Len, a.chunk(c))
+}
+
+func (a *Analyzer) check(c *Column) error {
+	if c == nil || c.Len <= 0 {
+		return errors.New("stats: Column is empty")
+	}
+	chunk := a.chunk(c)
+	if chunk < 1 || chunk > a.slots {
+		return fmt.Errorf("stats: chunk size %d is not in [1, %d]", chunk, a.slots)
+	}
+	if want := (c.Len + chunk - 1) / chunk; len(c.Cts) != want {
+		return fmt.Errorf("stats: %d values need %d ciphertexts of %d, got %d", c.Len, want, chunk, len(c.Cts))
+	}
+	return nil
+}
+
+func (a *Analyzer) checkPair(x, y *Column) error {
+	if err := a.check(x); err != nil {
+		return err
+	}
+	if err := a.check(y); err != nil {
+		return err
+	}
+	if x.Len != y.Len || a.chunk(x) != a.chunk(y) {
+		return fmt.Errorf("stats: columns of %d and %d values are not laid out alike", x.Len, y.Len)
+	}
+	return nil
+}
+
+// check validates d for a function defined on [0, ∞), or on (0, ∞) when
+// positive is set.
+func (d Domain) check(positive bool) error {
+	switch {
+	case !(d.Min < d.Max):
+		return fmt.Errorf("stats: domain [%g, %g] is empty", d.Min, d.Max)
+	case positive && d.Min <= 0:
+		return fmt.Errorf("stats: domain [%g, %g] must be positive", d.Min, d.Max)
+	case d.Min < 0:
+		return fmt.Errorf("stats: domain [%g, %g] must be non-negative", d.Min, d.Max)
+	}
+	if d.Degree < 0 || openfhe.ChebyshevDepth(d.degree()) < 0 {
+		return fmt.Errorf("stats: Chebyshev degree %d is not supported", d.Degree)
+	}
+	return nil
+}
+
+func (d Domain) degree() int {
+	if d.Degree == 0 {
+		return DefaultDegree
+	}
+	return d.Degree
+}
+
+// total returns the sum of every slot of cts, whose nonzero slots lie in
+// the first n. The inputs are left open.
+func (a *Analyzer) total(cts []*openfhe.Ciphertext, n int) (*openfhe.Ciphertext, error) {
+	acc := cts[0]
+	for i, ct := range cts[1:] {
+		next, err := a.ev.EvalAdd(acc, ct)
+		if i > 0 {
+			acc.Close()
+		}
+		if err != nil {
+			return nil, err
+		}
+		acc = next
+	}
+	if len(cts) > 1 {
+		defer acc.Close()
+	}
+	return a.ev.EvalSum(acc, uint32(nextPow2(n)))
+}
+
+// approx applies fn to ct with a Chebyshev series on d.
+func (a *Analyzer) approx(ct *openfhe.Ciphertext, fn func(float64) float64, d Domain) (*openfhe.Ciphertext, error) {
+	coeffs := openfhe.ChebyshevCoefficients(fn, d.Min, d.Max, d.degree())
+	return a.ev.EvalChebyshevSeries(ct, coeffs, d.Min, d.Max)
+}
+
+func (a *Analyzer) mult(x, y *openfhe.Ciphertext) (*openfhe.Ciphertext, error) {
+	return a.rescale(a.ev.EvalMult(x, y))
+}
+
+func (a *Analyzer) square(x *openfhe.Ciphertext) (*openfhe.Ciphertext, error) {
+	return a.rescale(a.ev.EvalSquare(x))
+}
+
+// scale multiplies slot 0 of ct by c and clears the other slots.
+func (a *Analyzer) scale(ct *openfhe.Ciphertext, c float64) (*openfhe.Ciphertext, error) {
+	pt, err := a.ev.MakeCKKSPackedPlaintext([]float64{c})
+	if err != nil {
+		return nil, err
+	}
+	defer pt.Close()
+	return a.rescale(a.ev.EvalMultPlain(ct, pt))
+}
+
+// rescale rescales the product ct in FIXEDMANUAL mode, closing it.
+func (a *Analyzer) rescale(ct *openfhe.Ciphertext, err error) (*openfhe.Ciphertext, error) {
+	if err != nil || !a.manualRescale {
+		return ct, err
+	}
+	defer ct.Close()
+	return a.ev.Rescale(ct)
+}
+
+func closeAll(cts []*openfhe.Ciphertext) {
+	for _, ct := range cts {
+		ct.Close()
+	}
+}
+
+func nextPow2(n int) int {
+	p := 1
+	for p < n {
+		p <<= 1
+	}
+	return p
+}
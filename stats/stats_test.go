@@ -0,0 +1,265 @@
+package stats
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dozyio/openfhe-go/internal/testutil"
+	"github.com/dozyio/openfhe-go/openfhe"
+)
+
+// plain statistics for the expectations.
+
+func mean(x []float64) float64 {
+	var s float64
+	for _, v := range x {
+		s += v
+	}
+	return s / float64(len(x))
+}
+
+func cov(x, y []float64) float64 {
+	mx, my := mean(x), mean(y)
+	var s float64
+	for i := range x {
+		s += (x[i] - mx) * (y[i] - my)
+	}
+	return s / float64(len(x))
+}
+
+var (
+	colX = []float64{0.5, 1.2, -0.3, 0.8, 1.5, 0.1, -0.7, 0.9, 1.1, 0.4, -0.2, 0.6}
+	colY = []float64{0.7, 1.0, -0.1, 0.5, 1.6, 0.3, -0.4, 1.2, 0.8, 0.2, 0.1, 0.9}
+)
+
+func setupCKKSContext(t *testing.T) (*openfhe.CryptoContext, *openfhe.KeyPair) {
+	t.Helper()
+
+	params, err := openfhe.NewParamsCKKSRNS()
+	testutil.MustT(t, err, "NewParamsCKKSRNS")
+	defer params.Close()
+	testutil.MustT(t, params.SetMultiplicativeDepth(9), "SetMultiplicativeDepth")
+	testutil.MustT(t, params.SetScalingModSize(50), "SetScalingModSize")
+	testutil.MustT(t, params.SetBatchSize(8), "SetBatchSize")
+	testutil.MustT(t, params.SetScalingTechnique(openfhe.FIXEDMANUAL), "SetScalingTechnique")
+
+	cc, err := openfhe.NewCryptoContextCKKS(params)
+	testutil.MustT(t, err, "NewCryptoContextCKKS")
+	for _, f := range []int{openfhe.PKE, openfhe.KEYSWITCH, openfhe.LEVELEDSHE, openfhe.ADVANCEDSHE} {
+		testutil.MustT(t, cc.Enable(f), "Enable")
+	}
+	keys, err := cc.KeyGen()
+	testutil.MustT(t, err, "KeyGen")
+	testutil.MustT(t, cc.EvalMultKeyGen(keys), "EvalMultKeyGen")
+	testutil.MustT(t, cc.EvalSumKeyGen(keys), "EvalSumKeyGen")
+	return cc, keys
+}
+
+// checkReal decrypts the statistic ct, closing it, and compares it with
+// want.
+func checkReal(t *testing.T, a *Analyzer, keys *openfhe.KeyPair, name string, ct *openfhe.Ciphertext, err error, want, tolerance float64) {
+	t.Helper()
+	testutil.MustT(t, err, name)
+	defer ct.Close()
+	got, err := a.DecryptReal(keys, ct)
+	testutil.MustT(t, err, "DecryptReal")
+	if math.Abs(got-want) > tolerance {
+		t.Errorf("%s = %v, expected %v", name, got, want)
+	}
+}
+
+func TestStatsCKKS(t *testing.T) {
+	cc, keys := setupCKKSContext(t)
+	defer cc.Close()
+	defer keys.Close()
+	a, err := New(cc)
+	testutil.MustT(t, err, "New")
+
+	// 12 values span two ciphertexts of 8 slots.
+	x, err := EncryptColumn(a, keys, colX, nil)
+	testutil.MustT(t, err, "EncryptColumn")
+	defer x.Close()
+	y, err := EncryptColumn(a, keys, colY, nil)
+	testutil.MustT(t, err, "EncryptColumn")
+	defer y.Close()
+
+	sum, err := a.Sum(x)
+	checkReal(t, a, keys, "Sum", sum, err, mean(colX)*12, 1e-3)
+	m, err := a.Mean(x)
+	checkReal(t, a, keys, "Mean", m, err, mean(colX), 1e-3)
+	v, err := a.Variance(x)
+	checkReal(t, a, keys, "Variance", v, err, cov(colX, colX), 1e-3)
+	c, err := a.Covariance(x, y)
+	checkReal(t, a, keys, "Covariance", c, err, cov(colX, colY), 1e-3)
+
+	sd, err := a.StdDev(x, Domain{Min: 0, Max: 1, Degree: 27})
+	checkReal(t, a, keys, "StdDev", sd, err, math.Sqrt(cov(colX, colX)), 0.01)
+	r, err := a.Correlation(x, y, Domain{Min: 0.05, Max: 1, Degree: 13})
+	want := cov(colX, colY) / math.Sqrt(cov(colX, colX)*cov(colY, colY))
+	checkReal(t, a, keys, "Correlation", r, err, want, 0.01)
+}
+
+func TestStatsSimulator(t *testing.T) {
+	sim, keys := testutil.NewSimulator(t, openfhe.SimParams{
+		Scheme:              openfhe.SimCKKS,
+		RingDimension:       32,
+		MultiplicativeDepth: 11,
+		ScalingTechnique:    openfhe.FLEXIBLEAUTO,
+		NoiseStdDev:         1e-9,
+	})
+	defer sim.Close()
+	a, err := New(sim)
+	testutil.MustT(t, err, "New")
+
+	// Chunks of 5 spread 12 values over three ciphertexts.
+	opts := &openfhe.BatchOptions{ChunkSize: 5}
+	x, err := EncryptColumn(a, keys, colX, opts)
+	testutil.MustT(t, err, "EncryptColumn")
+	defer x.Close()
+	y, err := EncryptColumn(a, keys, colY, opts)
+	testutil.MustT(t, err, "EncryptColumn")
+	defer y.Close()
+
+	n := float64(len(colX))
+	v, err := a.SampleVariance(x)
+	checkReal(t, a, keys, "SampleVariance", v, err, cov(colX, colX)*n/(n-1), 1e-6)
+
+	weights := []float64{1, 2, 3, 4, 5, 6, 1, 2, 3, 4, 5, 6}
+	var wx, ws float64
+	for i, w := range weights {
+		wx += w * colX[i]
+		ws += w
+	}
+	wm, err := a.WeightedMean(x, weights)
+	checkReal(t, a, keys, "WeightedMean", wm, err, wx/ws, 1e-6)
+
+	w, err := EncryptColumn(a, keys, weights, opts)
+	testutil.MustT(t, err, "EncryptColumn")
+	defer w.Close()
+	wme, err := a.WeightedMeanEncrypted(x, w, Domain{Min: 10, Max: 100})
+	checkReal(t, a, keys, "WeightedMeanEncrypted", wme, err, wx/ws, 1e-3)
+
+	r, err := a.Correlation(x, y, Domain{Min: 0.05, Max: 1})
+	want := cov(colX, colY) / math.Sqrt(cov(colX, colX)*cov(colY, colY))
+	checkReal(t, a, keys, "Correlation", r, err, want, 1e-3)
+
+	// Correlation needs 4 + ChebyshevDepth(59) = 11 levels; one more degree
+	// step does not fit.
+	if _, err := a.Correlation(x, y, Domain{Min: 0.05, Max: 1, Degree: 119}); err == nil {
+		t.Error("Correlation exceeded the multiplicative depth without an error")
+	}
+	if _, err := a.StdDev(x, Domain{Min: -1, Max: 1}); err == nil {
+		t.Error("StdDev accepted a negative domain")
+	}
+	if _, err := a.WeightedMeanEncrypted(x, w, Domain{Min: 0, Max: 100}); err == nil {
+		t.Error("WeightedMeanEncrypted accepted a domain containing zero")
+	}
+	if _, err := a.Covariance(x, &Column{Cts: y.Cts[:2], Len: 10, Chunk: 5}); err == nil {
+		t.Error("Covariance accepted columns of different lengths")
+	}
+	if _, err := a.Sum(&Column{Cts: x.Cts, Len: len(colX)}); err == nil {
+		t.Error("Sum accepted a column whose chunk size does not match its ciphertexts")
+	}
+}
+
+func TestStatsManualRescale(t *testing.T) {
+	sim, keys := testutil.NewSimulator(t, openfhe.SimParams{
+		Scheme:              openfhe.SimCKKS,
+		RingDimension:       32,
+		MultiplicativeDepth: 9,
+		ScalingTechnique:    openfhe.FIXEDMANUAL,
+	})
+	defer sim.Close()
+	a, err := New(sim)
+	testutil.MustT(t, err, "New")
+
+	x, err := EncryptColumn(a, keys, colX, nil)
+	testutil.MustT(t, err, "EncryptColumn")
+	defer x.Close()
+	sd, err := a.StdDev(x, Domain{Min: 0, Max: 1})
+	checkReal(t, a, keys, "StdDev", sd, err, math.Sqrt(cov(colX, colX)), 0.01)
+}
+
+func TestIndicators(t *testing.T) {
+	bins, err := Indicators([]float64{0.5, 1, 2.5, -1, 3, 1.9}, []float64{0, 1, 2, 3})
+	testutil.MustT(t, err, "Indicators")
+	want := [][]int64{
+		{1, 0, 0, 0, 0, 0},
+		{0, 1, 0, 0, 0, 1},
+		{0, 0, 1, 0, 0, 0},
+	}
+	for i := range want {
+		for j := range want[i] {
+			if bins[i][j] != want[i][j] {
+				t.Fatalf("Indicators = %v, expected %v", bins, want)
+			}
+		}
+	}
+	if _, err := Indicators([]float64{1}, []float64{2, 1}); err == nil {
+		t.Error("Indicators accepted decreasing edges")
+	}
+}
+
+func testHistogram(t *testing.T, ev openfhe.Evaluator, keys *openfhe.KeyPair) {
+	t.Helper()
+	a, err := New(ev)
+	testutil.MustT(t, err, "New")
+
+	ages := []float64{23, 35, 47, 51, 29, 33, 62, 18, 44, 38, 27, 55, 70, 31}
+	edges := []float64{0, 30, 45, 60, 100}
+	ind, err := Indicators(ages, edges)
+	testutil.MustT(t, err, "Indicators")
+	var cols []*Column
+	for _, b := range ind {
+		col, err := EncryptColumn(a, keys, b, &openfhe.BatchOptions{ChunkSize: 8})
+		testutil.MustT(t, err, "EncryptColumn")
+		defer col.Close()
+		cols = append(cols, col)
+	}
+
+	counts, err := a.Histogram(cols)
+	testutil.MustT(t, err, "Histogram")
+	for i, ct := range counts {
+		got, err := a.DecryptInt(keys, ct)
+		ct.Close()
+		testutil.MustT(t, err, "DecryptInt")
+		var want int64
+		for _, v := range ind[i] {
+			want += v
+		}
+		if got != want {
+			t.Errorf("bin [%v, %v) count = %d, expected %d", edges[i], edges[i+1], got, want)
+		}
+	}
+}
+
+func TestHistogramBFV(t *testing.T) {
+	params, err := openfhe.NewParamsBFVrns()
+	testutil.MustT(t, err, "NewParamsBFVrns")
+	defer params.Close()
+	testutil.MustT(t, params.SetPlaintextModulus(65537), "SetPlaintextModulus")
+	testutil.MustT(t, params.SetMultiplicativeDepth(1), "SetMultiplicativeDepth")
+	cc, err := openfhe.NewCryptoContextBFV(params)
+	testutil.MustT(t, err, "NewCryptoContextBFV")
+	defer cc.Close()
+	for _, f := range []int{openfhe.PKE, openfhe.KEYSWITCH, openfhe.LEVELEDSHE, openfhe.ADVANCEDSHE} {
+		testutil.MustT(t, cc.Enable(f), "Enable")
+	}
+	keys, err := cc.KeyGen()
+	testutil.MustT(t, err, "KeyGen")
+	defer keys.Close()
+	testutil.MustT(t, cc.EvalSumKeyGen(keys), "EvalSumKeyGen")
+
+	testHistogram(t, cc, keys)
+}
+
+func TestHistogramSimulator(t *testing.T) {
+	sim, keys := testutil.NewSimulator(t, openfhe.SimParams{
+		Scheme:           openfhe.SimBGV,
+		RingDimension:    32,
+		PlaintextModulus: 65537,
+	})
+	defer sim.Close()
+
+	testHistogram(t, sim, keys)
+}